@@ -0,0 +1,190 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DefaultAbortConditionInterval is the default period between two evaluations of an abort condition
+	DefaultAbortConditionInterval = 10 * time.Second
+	// DefaultHTTPAbortConditionTimeout is the default timeout of the http probe
+	DefaultHTTPAbortConditionTimeout = 5 * time.Second
+)
+
+// AbortCondition describes a steady-state check which is evaluated while the experiment is running.
+// Once the check is breached, the experiment will be stopped and all targets will be recovered.
+// Exactly one of `prometheus`, `http` and `podReadiness` should be set.
+type AbortCondition struct {
+	// Name is used to identify the condition in the events and the status
+	Name string `json:"name"`
+
+	// Interval is the period between two evaluations, e.g. "30s". Default to "10s".
+	// +optional
+	Interval *string `json:"interval,omitempty"`
+
+	// FailureThreshold is the count of consecutive breaches needed to abort the experiment. Default to 1.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+
+	// Prometheus checks the result of a prometheus query
+	// +optional
+	Prometheus *PrometheusAbortCondition `json:"prometheus,omitempty"`
+
+	// HTTP checks the response of a http request
+	// +optional
+	HTTP *HTTPAbortCondition `json:"http,omitempty"`
+
+	// PodReadiness checks the ratio of ready pods
+	// +optional
+	PodReadiness *PodReadinessAbortCondition `json:"podReadiness,omitempty"`
+}
+
+// AbortStatus records the abort condition which has stopped the experiment
+type AbortStatus struct {
+	// Condition is the name of the breached abort condition
+	Condition string `json:"condition"`
+
+	// Reason is the detail of the breach
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Time is when the experiment is aborted
+	Time metav1.Time `json:"time"`
+}
+
+// AbortOperator is the operator used to compare the result of a query with the threshold
+type AbortOperator string
+
+const (
+	GreaterThanOperator        AbortOperator = ">"
+	GreaterThanOrEqualOperator AbortOperator = ">="
+	LessThanOperator           AbortOperator = "<"
+	LessThanOrEqualOperator    AbortOperator = "<="
+	EqualOperator              AbortOperator = "=="
+	NotEqualOperator           AbortOperator = "!="
+)
+
+// PrometheusAbortCondition is breached when the result of the query compared with the threshold is true,
+// e.g. the error rate of a service ("query") is greater than ("operator") 0.05 ("threshold").
+// The query is sent to the prometheus server configured for the controller manager.
+type PrometheusAbortCondition struct {
+	// Query is an instant PromQL query, which should return a scalar or a vector with exactly one element
+	Query string `json:"query"`
+
+	// Operator is used to compare the result of the query with the threshold
+	// +kubebuilder:validation:Enum=">";">=";"<";"<=";"==";"!="
+	Operator AbortOperator `json:"operator"`
+
+	// Threshold is a float number, e.g. "0.05"
+	Threshold string `json:"threshold"`
+}
+
+// HTTPAbortCondition is breached when the request fails or the status code of the response is unexpected.
+// The host of the url should be one of the hosts allowed by the controller manager.
+type HTTPAbortCondition struct {
+	// URL is the address to request
+	URL string `json:"url"`
+
+	// Method is the method of the request. Default to GET.
+	// +optional
+	// +kubebuilder:validation:Enum=GET;HEAD
+	Method string `json:"method,omitempty"`
+
+	// Timeout is the timeout of the request, e.g. "3s". Default to "5s".
+	// +optional
+	Timeout *string `json:"timeout,omitempty"`
+
+	// ExpectedStatusCodes is the list of expected status codes. Any 2xx code is expected if it's empty.
+	// +optional
+	ExpectedStatusCodes []int32 `json:"expectedStatusCodes,omitempty"`
+}
+
+// PodReadinessAbortCondition is breached when the percent of ready pods drops below the minimum.
+// It can't be evaluated if no pod is selected.
+type PodReadinessAbortCondition struct {
+	// Namespace is the namespace of the pods to check. Default to the namespace of the chaos.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// LabelSelectors is used to select the pods to check by their labels.
+	// All pods in the namespace are checked if it's empty.
+	// +optional
+	LabelSelectors map[string]string `json:"labelSelectors,omitempty"`
+
+	// MinReadyPercent is the minimum percent of the ready pods among the selected pods
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	MinReadyPercent int32 `json:"minReadyPercent"`
+}
+
+// GetInterval returns the period between two evaluations of the condition
+func (in *AbortCondition) GetInterval() (time.Duration, error) {
+	if in.Interval == nil {
+		return DefaultAbortConditionInterval, nil
+	}
+
+	return time.ParseDuration(*in.Interval)
+}
+
+// GetFailureThreshold returns the count of consecutive breaches needed to abort the experiment
+func (in *AbortCondition) GetFailureThreshold() int {
+	if in.FailureThreshold == nil || *in.FailureThreshold < 1 {
+		return 1
+	}
+
+	return int(*in.FailureThreshold)
+}
+
+// GetTimeout returns the timeout of the http probe
+func (in *HTTPAbortCondition) GetTimeout() (time.Duration, error) {
+	if in.Timeout == nil {
+		return DefaultHTTPAbortConditionTimeout, nil
+	}
+
+	return time.ParseDuration(*in.Timeout)
+}
+
+// GetNamespace returns the namespace of the pods to check
+func (in *PodReadinessAbortCondition) GetNamespace(chaosNamespace string) string {
+	if len(in.Namespace) == 0 {
+		return chaosNamespace
+	}
+
+	return in.Namespace
+}
+
+// Compare returns the result of `value <operator> threshold`
+func (in AbortOperator) Compare(value float64, threshold float64) bool {
+	switch in {
+	case GreaterThanOperator:
+		return value > threshold
+	case GreaterThanOrEqualOperator:
+		return value >= threshold
+	case LessThanOperator:
+		return value < threshold
+	case LessThanOrEqualOperator:
+		return value <= threshold
+	case EqualOperator:
+		return value == threshold
+	case NotEqualOperator:
+		return value != threshold
+	}
+
+	return false
+}
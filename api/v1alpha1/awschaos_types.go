@@ -56,6 +56,11 @@ type AwsChaosSpec struct {
 	// +optional
 	Duration *string `json:"duration,omitempty"`
 
+	// AbortConditions are the steady-state checks evaluated while the experiment is running.
+	// The experiment will be stopped and recovered once any of them is breached.
+	// +optional
+	AbortConditions []AbortCondition `json:"abortConditions,omitempty"`
+
 	// SecretName defines the name of kubernetes secret.
 	// +optional
 	SecretName *string `json:"secretName,omitempty"`
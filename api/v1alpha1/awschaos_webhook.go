@@ -83,6 +83,7 @@ func (in *AwsChaosSpec) Validate() field.ErrorList {
 	allErrs := in.validateEbsVolume(specField.Child("volumeID"))
 	allErrs = append(allErrs, in.validateAction(specField)...)
 	allErrs = append(allErrs, validateDuration(in, specField)...)
+	allErrs = append(allErrs, validateAbortConditions(in.AbortConditions, specField.Child("abortConditions"))...)
 	allErrs = append(allErrs, in.validateDeviceName(specField.Child("deviceName"))...)
 	return allErrs
 }
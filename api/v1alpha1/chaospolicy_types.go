@@ -0,0 +1,68 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const KindChaosPolicy = "ChaosPolicy"
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+
+// ChaosPolicy limits the blast radius of the experiments in the cluster.
+// It's enforced by the admission webhook when an experiment is created or updated.
+// All policies in the cluster are enforced at the same time.
+type ChaosPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ChaosPolicySpec `json:"spec"`
+}
+
+// ChaosPolicySpec defines the limits of a ChaosPolicy
+type ChaosPolicySpec struct {
+	// MaxPodPercentPerNamespace is the maximum percent of the pods which could be under chaos
+	// in every namespace, counting the experiments already running. The experiments choosing pods randomly
+	// are checked by the max count of the pods they could choose.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	MaxPodPercentPerNamespace *int32 `json:"maxPodPercentPerNamespace,omitempty"`
+
+	// MaxConcurrent is the maximum count of the running experiments of every kind,
+	// e.g. {"NetworkChaos": 2}
+	// +optional
+	MaxConcurrent map[string]int32 `json:"maxConcurrent,omitempty"`
+
+	// ProtectedNamespaces is a list of namespaces in which the experiments with mode "all",
+	// or with mode "ramp" reaching 100% of the pods, are forbidden
+	// +optional
+	ProtectedNamespaces []string `json:"protectedNamespaces,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ChaosPolicyList contains a list of ChaosPolicy
+type ChaosPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ChaosPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ChaosPolicy{})
+	SchemeBuilder.Register(&ChaosPolicyList{})
+}
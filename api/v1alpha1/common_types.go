@@ -25,6 +25,12 @@ import (
 const (
 	// PauseAnnotationKey defines the annotation used to pause a chaos
 	PauseAnnotationKey = "experiment.chaos-mesh.org/pause"
+	// DryRunAnnotationKey defines the annotation used to run a chaos without injecting it,
+	// the commands which would be executed on the targets are rendered into the records
+	DryRunAnnotationKey = "experiment.chaos-mesh.org/dry-run"
+	// ProtectedAnnotationKey defines the annotation used to protect a pod from all experiments,
+	// a pod with the value "true" is never selected by any selector
+	ProtectedAnnotationKey = "chaos-mesh.org/protected"
 )
 
 type ChaosStatus struct {
@@ -34,6 +40,27 @@ type ChaosStatus struct {
 
 	// Experiment records the last experiment state.
 	Experiment ExperimentStatus `json:"experiment"`
+
+	// Abort records the abort condition which has stopped the experiment
+	// +optional
+	Abort *AbortStatus `json:"abort,omitempty"`
+
+	// Ramp records the current stage of every selector in ramp mode
+	// +optional
+	Ramp []RampStatus `json:"ramp,omitempty"`
+}
+
+// RampStatus is the progress of a selector in ramp mode
+type RampStatus struct {
+	SelectorKey string `json:"selectorKey"`
+
+	// Stage is the index of the current step of the ramp policy
+	Stage int32 `json:"stage"`
+
+	// NextStageTime is the time to go to the next stage. It's empty if the ramp has reached the last stage,
+	// or it's halted because the experiment is paused or aborted.
+	// +optional
+	NextStageTime *metav1.Time `json:"nextStageTime,omitempty"`
 }
 
 type ChaosConditionType string
@@ -43,6 +70,8 @@ const (
 	ConditionAllInjected  ChaosConditionType = "AllInjected"
 	ConditionAllRecovered ChaosConditionType = "AllRecovered"
 	ConditionPaused       ChaosConditionType = "Paused"
+	// ConditionAborted means the experiment has been stopped as one of the abort conditions is breached
+	ConditionAborted ChaosConditionType = "Aborted"
 )
 
 type ChaosCondition struct {
@@ -52,6 +81,21 @@ type ChaosCondition struct {
 	Reason string `json:"reason"`
 }
 
+// IsAborted returns whether the experiment has been aborted by an abort condition
+func (in *ChaosStatus) IsAborted() bool {
+	return in.Abort != nil
+}
+
+// GetRampStage returns the current stage of the selector in ramp mode, the first stage is 0
+func (in *ChaosStatus) GetRampStage(selectorKey string) int32 {
+	for _, ramp := range in.Ramp {
+		if ramp.SelectorKey == selectorKey {
+			return ramp.Stage
+		}
+	}
+	return 0
+}
+
 type DesiredPhase string
 
 const (
@@ -73,6 +117,32 @@ type Record struct {
 	Id          string `json:"id"`
 	SelectorKey string `json:"selectorKey"`
 	Phase       Phase  `json:"phase"`
+
+	// Deselected means the target doesn't match the selector anymore, but it still exists. The target is recovered
+	// before the record turns into "Gone".
+	// +optional
+	Deselected bool `json:"deselected,omitempty"`
+
+	// Commands are the commands which would be executed on the target, they are only rendered in dry-run mode
+	// +optional
+	Commands []string `json:"commands,omitempty"`
+
+	// LastError is the error of the last failed attempt to apply or recover the target.
+	// It's cleared once an attempt succeeds.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+
+	// InjectedAt is the last time the chaos was injected into the target
+	// +optional
+	InjectedAt *metav1.Time `json:"injectedAt,omitempty"`
+
+	// RecoveredAt is the last time the target was recovered
+	// +optional
+	RecoveredAt *metav1.Time `json:"recoveredAt,omitempty"`
+
+	// FailedAttempts is the count of the failed attempts to apply or recover the target
+	// +optional
+	FailedAttempts int32 `json:"failedAttempts,omitempty"`
 }
 
 type Phase string
@@ -82,6 +152,15 @@ const (
 	NotInjected Phase = "Not Injected"
 	// Injected means the target is injected. It's safe to recover it.
 	Injected Phase = "Injected"
+	// Failed means the controller has given up on the target after too many failed attempts to apply it, and nothing
+	// has been injected on it. It's a terminal phase, the controller will not operate on the target anymore.
+	Failed Phase = "Failed"
+	// Gone means the target has been deleted during the experiment, or it doesn't match the selector anymore and has
+	// been recovered. It's a terminal phase, the controller will not operate on the target anymore.
+	Gone Phase = "Gone"
+	// WouldInject means the target has been selected in dry-run mode, and nothing has been injected.
+	// It's safe to turn it back into "Not Injected" without recovering it.
+	WouldInject Phase = "Would Inject"
 )
 
 var log = ctrl.Log.WithName("api")
@@ -92,9 +171,11 @@ var log = ctrl.Log.WithName("api")
 type InnerObject interface {
 	IsDeleted() bool
 	IsPaused() bool
+	IsDryRun() bool
 	GetChaos() *ChaosInstance
 	DurationExceeded(time.Time) (bool, time.Duration, error)
 	IsOneShot() bool
+	GetAbortConditions() []AbortCondition
 	StatefulObject
 }
 
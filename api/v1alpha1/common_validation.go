@@ -15,6 +15,7 @@ package v1alpha1
 
 import (
 	"fmt"
+	"net/url"
 	"strconv"
 	"time"
 
@@ -81,3 +82,216 @@ func validatePodSelector(value string, mode PodMode, valueField *field.Path) fie
 
 	return allErrs
 }
+
+// validateReselectPolicy validates the interval of the reselect policy
+func (in *PodSelector) validateReselectPolicy(policyField *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if in == nil || in.ReselectPolicy == nil {
+		return allErrs
+	}
+
+	interval, err := in.GetReselectInterval()
+	if err != nil {
+		allErrs = append(allErrs, field.Invalid(policyField.Child("interval"), in.ReselectPolicy.Interval,
+			fmt.Sprintf("parse interval field error:%s", err)))
+	} else if interval <= 0 {
+		allErrs = append(allErrs, field.Invalid(policyField.Child("interval"), in.ReselectPolicy.Interval,
+			"interval must be greater than 0"))
+	}
+
+	return allErrs
+}
+
+// validateRampPolicy validates that the ramp policy is set with the ramp mode, and its steps are increasing percents
+func (in *PodSelector) validateRampPolicy(policyField *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if in == nil {
+		return allErrs
+	}
+	if in.Mode != RampPodMode {
+		if in.RampPolicy != nil {
+			allErrs = append(allErrs, field.Invalid(policyField, in.RampPolicy,
+				fmt.Sprintf("ramp policy is only supported with mode:%s", RampPodMode)))
+		}
+		return allErrs
+	}
+	if in.RampPolicy == nil {
+		return append(allErrs, field.Required(policyField, fmt.Sprintf("ramp policy is required with mode:%s", RampPodMode)))
+	}
+
+	if len(in.RampPolicy.Steps) == 0 {
+		allErrs = append(allErrs, field.Required(policyField.Child("steps"), "steps of the ramp policy are required"))
+	}
+	var previous int32
+	for i, step := range in.RampPolicy.Steps {
+		if step <= 0 || step > 100 {
+			allErrs = append(allErrs, field.Invalid(policyField.Child("steps").Index(i), step,
+				fmt.Sprintf("step of %d is invalid, Must be (0,100]", step)))
+		} else if step < previous {
+			allErrs = append(allErrs, field.Invalid(policyField.Child("steps").Index(i), step,
+				"steps must be in increasing order"))
+		}
+		previous = step
+	}
+
+	interval, err := in.RampPolicy.GetInterval()
+	if err != nil {
+		allErrs = append(allErrs, field.Invalid(policyField.Child("interval"), in.RampPolicy.Interval,
+			fmt.Sprintf("parse interval field error:%s", err)))
+	} else if interval <= 0 {
+		allErrs = append(allErrs, field.Invalid(policyField.Child("interval"), in.RampPolicy.Interval,
+			"interval must be greater than 0"))
+	}
+
+	return allErrs
+}
+
+// validateGroupBy validates the key to group the pods, and the groups cannot be evaluated again with reselect policy
+func (in *PodSelector) validateGroupBy(groupByField *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if in == nil || in.GroupBy == nil {
+		return allErrs
+	}
+
+	switch in.GroupBy.Type {
+	case GroupByNode, GroupByOwner:
+	case GroupByTopology:
+		if len(in.GroupBy.TopologyKey) == 0 {
+			allErrs = append(allErrs, field.Required(groupByField.Child("topologyKey"),
+				fmt.Sprintf("topology key is required with type:%s", GroupByTopology)))
+		}
+	default:
+		allErrs = append(allErrs, field.Invalid(groupByField.Child("type"), in.GroupBy.Type,
+			fmt.Sprintf("type %s not supported", in.GroupBy.Type)))
+	}
+
+	switch in.GroupBy.Groups {
+	case "", AllGroupMode, OneGroupMode:
+	default:
+		allErrs = append(allErrs, field.Invalid(groupByField.Child("groups"), in.GroupBy.Groups,
+			fmt.Sprintf("groups %s not supported", in.GroupBy.Groups)))
+	}
+
+	if in.ReselectPolicy != nil {
+		allErrs = append(allErrs, field.Invalid(groupByField, in.GroupBy,
+			"group by is not supported with reselect policy"))
+	}
+
+	return allErrs
+}
+
+// validateSelector validates that the selector doesn't ask for the protected pods, which are never selected
+func (in *PodSelector) validateSelector(selectorField *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if in == nil {
+		return allErrs
+	}
+
+	if value, ok := in.Selector.AnnotationSelectors[ProtectedAnnotationKey]; ok && value == "true" {
+		allErrs = append(allErrs, field.Invalid(selectorField.Child("annotationSelectors"), in.Selector.AnnotationSelectors,
+			fmt.Sprintf("the pods with annotation %s cannot be selected", ProtectedAnnotationKey)))
+	}
+
+	return allErrs
+}
+
+// validateAbortConditions validates that every abort condition has a unique name and exactly one valid check
+func validateAbortConditions(conditions []AbortCondition, conditionsField *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	names := make(map[string]struct{})
+	for i, condition := range conditions {
+		conditionField := conditionsField.Index(i)
+
+		if len(condition.Name) == 0 {
+			allErrs = append(allErrs, field.Required(conditionField.Child("name"), "name of the abort condition is required"))
+		} else if _, ok := names[condition.Name]; ok {
+			allErrs = append(allErrs, field.Duplicate(conditionField.Child("name"), condition.Name))
+		}
+		names[condition.Name] = struct{}{}
+
+		if interval, err := condition.GetInterval(); err != nil {
+			allErrs = append(allErrs, field.Invalid(conditionField.Child("interval"), condition.Interval,
+				fmt.Sprintf("parse interval field error:%s", err)))
+		} else if interval <= 0 {
+			allErrs = append(allErrs, field.Invalid(conditionField.Child("interval"), condition.Interval,
+				"interval must be greater than 0"))
+		}
+
+		count := 0
+		if condition.Prometheus != nil {
+			count++
+			allErrs = append(allErrs, condition.Prometheus.validate(conditionField.Child("prometheus"))...)
+		}
+		if condition.HTTP != nil {
+			count++
+			allErrs = append(allErrs, condition.HTTP.validate(conditionField.Child("http"))...)
+		}
+		if condition.PodReadiness != nil {
+			count++
+			allErrs = append(allErrs, condition.PodReadiness.validate(conditionField.Child("podReadiness"))...)
+		}
+		if count != 1 {
+			allErrs = append(allErrs, field.Invalid(conditionField, condition.Name,
+				"exactly one of prometheus, http and podReadiness should be set"))
+		}
+	}
+
+	return allErrs
+}
+
+func (in *PrometheusAbortCondition) validate(path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(in.Query) == 0 {
+		allErrs = append(allErrs, field.Required(path.Child("query"), "query is required"))
+	}
+
+	switch in.Operator {
+	case GreaterThanOperator, GreaterThanOrEqualOperator, LessThanOperator, LessThanOrEqualOperator, EqualOperator, NotEqualOperator:
+	default:
+		allErrs = append(allErrs, field.NotSupported(path.Child("operator"), in.Operator, []string{
+			string(GreaterThanOperator), string(GreaterThanOrEqualOperator), string(LessThanOperator),
+			string(LessThanOrEqualOperator), string(EqualOperator), string(NotEqualOperator),
+		}))
+	}
+
+	if _, err := strconv.ParseFloat(in.Threshold, 64); err != nil {
+		allErrs = append(allErrs, field.Invalid(path.Child("threshold"), in.Threshold,
+			fmt.Sprintf(ValidateValueParseError, err)))
+	}
+
+	return allErrs
+}
+
+func (in *HTTPAbortCondition) validate(path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if u, err := url.Parse(in.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("url"), in.URL, "url should be an absolute http(s) url"))
+	}
+
+	if timeout, err := in.GetTimeout(); err != nil {
+		allErrs = append(allErrs, field.Invalid(path.Child("timeout"), in.Timeout,
+			fmt.Sprintf("parse timeout field error:%s", err)))
+	} else if timeout <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("timeout"), in.Timeout,
+			"timeout must be greater than 0"))
+	}
+
+	return allErrs
+}
+
+func (in *PodReadinessAbortCondition) validate(path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if in.MinReadyPercent < 0 || in.MinReadyPercent > 100 {
+		allErrs = append(allErrs, field.Invalid(path.Child("minReadyPercent"), in.MinReadyPercent, "value must be in [0,100]"))
+	}
+
+	return allErrs
+}
@@ -17,6 +17,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
 var _ = Describe("common_webhook", func() {
@@ -27,4 +28,91 @@ var _ = Describe("common_webhook", func() {
 			Expect(selector.Namespaces[0]).To(Equal(metav1.NamespaceDefault))
 		})
 	})
+
+	Context("validateAbortConditions", func() {
+		It("validate the abort conditions", func() {
+			interval := "1"
+			type TestCase struct {
+				name       string
+				conditions []AbortCondition
+				errCount   int
+			}
+			tcs := []TestCase{
+				{
+					name: "valid conditions",
+					conditions: []AbortCondition{
+						{
+							Name: "error-rate",
+							Prometheus: &PrometheusAbortCondition{
+								Query:     "sum(rate(http_requests_total{code=~\"5..\"}[1m]))",
+								Operator:  GreaterThanOperator,
+								Threshold: "0.05",
+							},
+						},
+						{
+							Name: "health",
+							HTTP: &HTTPAbortCondition{
+								URL: "http://example.default.svc/healthz",
+							},
+						},
+						{
+							Name: "readiness",
+							PodReadiness: &PodReadinessAbortCondition{
+								MinReadyPercent: 50,
+							},
+						},
+					},
+					errCount: 0,
+				},
+				{
+					name: "duplicated name and invalid interval",
+					conditions: []AbortCondition{
+						{
+							Name:         "readiness",
+							PodReadiness: &PodReadinessAbortCondition{},
+						},
+						{
+							Name:         "readiness",
+							Interval:     &interval,
+							PodReadiness: &PodReadinessAbortCondition{},
+						},
+					},
+					errCount: 2,
+				},
+				{
+					name: "no check",
+					conditions: []AbortCondition{
+						{
+							Name: "empty",
+						},
+					},
+					errCount: 1,
+				},
+				{
+					name: "invalid prometheus and http checks",
+					conditions: []AbortCondition{
+						{
+							Name: "error-rate",
+							Prometheus: &PrometheusAbortCondition{
+								Operator:  "~",
+								Threshold: "a",
+							},
+						},
+						{
+							Name: "health",
+							HTTP: &HTTPAbortCondition{
+								URL: "/healthz",
+							},
+						},
+					},
+					errCount: 4,
+				},
+			}
+
+			for _, tc := range tcs {
+				errs := validateAbortConditions(tc.conditions, field.NewPath("spec", "abortConditions"))
+				Expect(errs).To(HaveLen(tc.errCount), tc.name)
+			}
+		})
+	})
 })
@@ -29,6 +29,35 @@ const (
 
 	// RandomAction represents get random IP when send DNS request.
 	RandomAction DNSChaosAction = "random"
+
+	// DNSDelayAction represents the added latency before the DNS request is resolved.
+	DNSDelayAction DNSChaosAction = "delay"
+
+	// DNSRcodeAction represents get the specified response code when send DNS request.
+	DNSRcodeAction DNSChaosAction = "rcode"
+
+	// DNSTruncateAction represents get a truncated response over UDP, which makes the client retry over TCP.
+	DNSTruncateAction DNSChaosAction = "truncate"
+
+	// DNSDropAction represents get no response when send DNS request, which times out.
+	DNSDropAction DNSChaosAction = "drop"
+
+	// DNSStaticAction represents get the specified records when send DNS request.
+	DNSStaticAction DNSChaosAction = "static"
+)
+
+// DNSRcode is the response code of the rcode action.
+type DNSRcode string
+
+const (
+	// NXDomainRcode represents the domain name doesn't exist.
+	NXDomainRcode DNSRcode = "NXDOMAIN"
+
+	// ServFailRcode represents the server fails to resolve the domain name.
+	ServFailRcode DNSRcode = "SERVFAIL"
+
+	// RefusedRcode represents the server refuses to resolve the domain name.
+	RefusedRcode DNSRcode = "REFUSED"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -52,17 +81,23 @@ type DNSChaos struct {
 
 // DNSChaosSpec defines the desired state of DNSChaos
 type DNSChaosSpec struct {
-	// Action defines the specific DNS chaos action.
-	// Supported action: error, random
-	// Default action: error
-	// +kubebuilder:validation:Enum=error;random
-	Action DNSChaosAction `json:"action"`
+	// Action defines the specific DNS chaos action on the domain names matched by the patterns.
+	// Supported action: error, random, delay, rcode, truncate, drop, static
+	// It's optional if the rules are set.
+	// +kubebuilder:validation:Enum=error;random;delay;rcode;truncate;drop;static
+	// +optional
+	Action DNSChaosAction `json:"action,omitempty"`
 
 	ContainerSelector `json:",inline"`
 
 	// Duration represents the duration of the chaos action
 	Duration *string `json:"duration,omitempty"`
 
+	// AbortConditions are the steady-state checks evaluated while the experiment is running.
+	// The experiment will be stopped and recovered once any of them is breached.
+	// +optional
+	AbortConditions []AbortCondition `json:"abortConditions,omitempty"`
+
 	// Choose which domain names to take effect, support the placeholder ? and wildcard *, or the Specified domain name.
 	// Note:
 	//      1. The wildcard * must be at the end of the string. For example, chaos-*.org is invalid.
@@ -72,6 +107,60 @@ type DNSChaosSpec struct {
 	// 		will take effect on "google.com", "github.com" and "chaos-mesh.org"
 	// +optional
 	DomainNamePatterns []string `json:"patterns"`
+
+	DNSChaosActionParams `json:",inline"`
+
+	// Rules select the actions per pattern. The domain names are matched by the rules in order,
+	// and the ones not matched by any rule are matched by the action and the patterns above.
+	// +optional
+	Rules []DNSChaosRule `json:"rules,omitempty"`
+}
+
+// DNSChaosRule is a DNS chaos action on the domain names matched by the patterns
+type DNSChaosRule struct {
+	// Action defines the specific DNS chaos action.
+	// Supported action: error, random, delay, rcode, truncate, drop, static
+	// +kubebuilder:validation:Enum=error;random;delay;rcode;truncate;drop;static
+	Action DNSChaosAction `json:"action"`
+
+	// Patterns choose which domain names to take effect, in the same way as the patterns of the spec.
+	// +optional
+	Patterns []string `json:"patterns,omitempty"`
+
+	DNSChaosActionParams `json:",inline"`
+}
+
+// DNSChaosActionParams are the parameters of the DNS chaos actions
+type DNSChaosActionParams struct {
+	// Delay is the added latency of the delay action, e.g. "100ms", "2s"
+	// +optional
+	Delay string `json:"delay,omitempty"`
+
+	// Rcode is the response code of the rcode action.
+	// Supported rcode: NXDOMAIN, SERVFAIL, REFUSED
+	// +kubebuilder:validation:Enum=NXDOMAIN;SERVFAIL;REFUSED
+	// +optional
+	Rcode DNSRcode `json:"rcode,omitempty"`
+
+	// Records are the answers of the static action
+	// +optional
+	Records *DNSStaticRecords `json:"records,omitempty"`
+}
+
+// DNSStaticRecords are the records answering the DNS requests
+type DNSStaticRecords struct {
+	// A are the IPv4 addresses answering the requests of type A
+	// +optional
+	A []string `json:"a,omitempty"`
+
+	// AAAA are the IPv6 addresses answering the requests of type AAAA
+	// +optional
+	AAAA []string `json:"aaaa,omitempty"`
+
+	// CNAME is the canonical name answering the requests of any type. The addresses are
+	// the records of the canonical name if it's set.
+	// +optional
+	CNAME string `json:"cname,omitempty"`
 }
 
 // DNSChaosStatus defines the observed state of DNSChaos
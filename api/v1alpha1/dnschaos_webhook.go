@@ -15,7 +15,10 @@ package v1alpha1
 
 import (
 	"fmt"
+	"net"
 	"reflect"
+	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -82,5 +85,79 @@ func (in *DNSChaosSpec) Validate() field.ErrorList {
 	specField := field.NewPath("spec")
 	allErrs := validatePodSelector(in.PodSelector.Value, in.PodSelector.Mode, specField.Child("value"))
 	allErrs = append(allErrs, validateDuration(in, specField)...)
+	allErrs = append(allErrs, validateAbortConditions(in.AbortConditions, specField.Child("abortConditions"))...)
+	allErrs = append(allErrs, in.PodSelector.validateReselectPolicy(specField.Child("reselectPolicy"))...)
+	allErrs = append(allErrs, in.PodSelector.validateRampPolicy(specField.Child("rampPolicy"))...)
+	allErrs = append(allErrs, in.PodSelector.validateGroupBy(specField.Child("groupBy"))...)
+	allErrs = append(allErrs, in.PodSelector.validateSelector(specField.Child("selector"))...)
+	allErrs = append(allErrs, in.validateActions(specField)...)
+	return allErrs
+}
+
+// validateActions validates the action of the spec and the actions of the rules
+func (in *DNSChaosSpec) validateActions(specField *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if in.Action == "" && len(in.Rules) == 0 {
+		allErrs = append(allErrs, field.Required(specField.Child("action"), "either the action or the rules should be set"))
+	}
+
+	if in.Action != "" {
+		allErrs = append(allErrs, validateDNSAction(in.Action, in.DomainNamePatterns, &in.DNSChaosActionParams, specField)...)
+	}
+	for i := range in.Rules {
+		rule := &in.Rules[i]
+		allErrs = append(allErrs, validateDNSAction(rule.Action, rule.Patterns, &rule.DNSChaosActionParams, specField.Child("rules").Index(i))...)
+	}
+
+	return allErrs
+}
+
+func validateDNSAction(action DNSChaosAction, patterns []string, params *DNSChaosActionParams, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, pattern := range patterns {
+		if strings.Contains(strings.TrimSuffix(pattern, "*"), "*") {
+			allErrs = append(allErrs, field.Invalid(path.Child("patterns").Index(i), pattern,
+				"the wildcard * must be at the end of the pattern"))
+		}
+	}
+
+	switch action {
+	case DNSDelayAction:
+		delay, err := time.ParseDuration(params.Delay)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("delay"), params.Delay,
+				fmt.Sprintf("parse delay field error:%s for action:%s", err, action)))
+		} else if delay <= 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("delay"), params.Delay,
+				fmt.Sprintf("action %s: delay should be positive", action)))
+		}
+	case DNSRcodeAction:
+		if params.Rcode == "" {
+			allErrs = append(allErrs, field.Required(path.Child("rcode"), fmt.Sprintf("rcode is required for action:%s", action)))
+		}
+	case DNSStaticAction:
+		allErrs = append(allErrs, params.Records.validate(path.Child("records"), action)...)
+	}
+
+	return allErrs
+}
+
+func (in *DNSStaticRecords) validate(recordsField *field.Path, action DNSChaosAction) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if in == nil || (len(in.A) == 0 && len(in.AAAA) == 0 && in.CNAME == "") {
+		return append(allErrs, field.Required(recordsField, fmt.Sprintf("records are required for action:%s", action)))
+	}
+
+	for i, a := range in.A {
+		if ip := net.ParseIP(a); ip == nil || ip.To4() == nil {
+			allErrs = append(allErrs, field.Invalid(recordsField.Child("a").Index(i), a, "not a valid IPv4 address"))
+		}
+	}
+	for i, aaaa := range in.AAAA {
+		if ip := net.ParseIP(aaaa); ip == nil || ip.To4() != nil {
+			allErrs = append(allErrs, field.Invalid(recordsField.Child("aaaa").Index(i), aaaa, "not a valid IPv6 address"))
+		}
+	}
+
 	return allErrs
 }
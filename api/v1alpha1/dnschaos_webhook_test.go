@@ -0,0 +1,121 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("dnschaos_webhook", func() {
+	Context("webhook.Validator of dnschaos", func() {
+		It("Validate", func() {
+
+			type TestCase struct {
+				name   string
+				spec   DNSChaosSpec
+				expect string
+			}
+
+			tcs := []TestCase{
+				{
+					name:   "simple action",
+					spec:   DNSChaosSpec{Action: ErrorAction},
+					expect: "",
+				},
+				{
+					name:   "no action nor rules",
+					spec:   DNSChaosSpec{},
+					expect: "error",
+				},
+				{
+					name:   "invalid pattern",
+					spec:   DNSChaosSpec{Action: ErrorAction, DomainNamePatterns: []string{"chaos-*.org"}},
+					expect: "error",
+				},
+				{
+					name: "rules without action",
+					spec: DNSChaosSpec{
+						Rules: []DNSChaosRule{
+							{
+								Action:               DNSDelayAction,
+								Patterns:             []string{"chaos-mesh.*"},
+								DNSChaosActionParams: DNSChaosActionParams{Delay: "100ms"},
+							},
+							{
+								Action:               DNSRcodeAction,
+								DNSChaosActionParams: DNSChaosActionParams{Rcode: NXDomainRcode},
+							},
+						},
+					},
+					expect: "",
+				},
+				{
+					name: "delay without delay",
+					spec: DNSChaosSpec{
+						Rules: []DNSChaosRule{{Action: DNSDelayAction}},
+					},
+					expect: "error",
+				},
+				{
+					name:   "rcode without rcode",
+					spec:   DNSChaosSpec{Action: DNSRcodeAction},
+					expect: "error",
+				},
+				{
+					name: "static records",
+					spec: DNSChaosSpec{
+						Action: DNSStaticAction,
+						DNSChaosActionParams: DNSChaosActionParams{
+							Records: &DNSStaticRecords{A: []string{"10.0.0.1"}, AAAA: []string{"fd00::1"}, CNAME: "chaos-mesh.org"},
+						},
+					},
+					expect: "",
+				},
+				{
+					name:   "static without records",
+					spec:   DNSChaosSpec{Action: DNSStaticAction},
+					expect: "error",
+				},
+				{
+					name: "static with invalid records",
+					spec: DNSChaosSpec{
+						Action: DNSStaticAction,
+						DNSChaosActionParams: DNSChaosActionParams{
+							Records: &DNSStaticRecords{A: []string{"fd00::1"}},
+						},
+					},
+					expect: "error",
+				},
+			}
+
+			for _, tc := range tcs {
+				chaos := &DNSChaos{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: metav1.NamespaceDefault,
+						Name:      "foo",
+					},
+					Spec: tc.spec,
+				}
+				err := chaos.ValidateCreate()
+				if tc.expect == "error" {
+					Expect(err).To(HaveOccurred(), tc.name)
+				} else {
+					Expect(err).NotTo(HaveOccurred(), tc.name)
+				}
+			}
+		})
+	})
+})
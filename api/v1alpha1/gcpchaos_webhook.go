@@ -80,6 +80,7 @@ func (in *GcpChaosSpec) Validate() field.ErrorList {
 	specField := field.NewPath("spec")
 	allErrs := in.validateDeviceName(specField.Child("deviceName"))
 	allErrs = append(allErrs, validateDuration(in, specField)...)
+	allErrs = append(allErrs, validateAbortConditions(in.AbortConditions, specField.Child("abortConditions"))...)
 	allErrs = append(allErrs, in.validateAction(specField)...)
 	return allErrs
 }
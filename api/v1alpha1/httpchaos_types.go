@@ -66,6 +66,11 @@ type HTTPChaosSpec struct {
 	// Duration represents the duration of the chaos action.
 	// +optional
 	Duration *string `json:"duration,omitempty"`
+
+	// AbortConditions are the steady-state checks evaluated while the experiment is running.
+	// The experiment will be stopped and recovered once any of them is breached.
+	// +optional
+	AbortConditions []AbortCondition `json:"abortConditions,omitempty"`
 }
 
 type HTTPChaosStatus struct {
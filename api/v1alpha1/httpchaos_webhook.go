@@ -85,6 +85,11 @@ func (in *HTTPChaosSpec) Validate() field.ErrorList {
 
 	allErrs := validatePodSelector(in.PodSelector.Value, in.PodSelector.Mode, specField.Child("value"))
 	allErrs = append(allErrs, validateDuration(in, specField)...)
+	allErrs = append(allErrs, validateAbortConditions(in.AbortConditions, specField.Child("abortConditions"))...)
+	allErrs = append(allErrs, in.PodSelector.validateReselectPolicy(specField.Child("reselectPolicy"))...)
+	allErrs = append(allErrs, in.PodSelector.validateRampPolicy(specField.Child("rampPolicy"))...)
+	allErrs = append(allErrs, in.PodSelector.validateGroupBy(specField.Child("groupBy"))...)
+	allErrs = append(allErrs, in.PodSelector.validateSelector(specField.Child("selector"))...)
 	return allErrs
 
 }
@@ -87,6 +87,11 @@ type IOChaosSpec struct {
 	// Valid time units are "ns", "us" (or "µs"), "ms", "s", "m", "h".
 	// +optional
 	Duration *string `json:"duration,omitempty"`
+
+	// AbortConditions are the steady-state checks evaluated while the experiment is running.
+	// The experiment will be stopped and recovered once any of them is breached.
+	// +optional
+	AbortConditions []AbortCondition `json:"abortConditions,omitempty"`
 }
 
 // IOChaosStatus defines the observed state of IOChaos
@@ -92,6 +92,11 @@ func (in *IOChaosSpec) Validate() field.ErrorList {
 	specField := field.NewPath("spec")
 	allErrs := in.validateDelay(specField.Child("delay"))
 	allErrs = append(allErrs, validateDuration(in, specField)...)
+	allErrs = append(allErrs, validateAbortConditions(in.AbortConditions, specField.Child("abortConditions"))...)
+	allErrs = append(allErrs, in.PodSelector.validateReselectPolicy(specField.Child("reselectPolicy"))...)
+	allErrs = append(allErrs, in.PodSelector.validateRampPolicy(specField.Child("rampPolicy"))...)
+	allErrs = append(allErrs, in.PodSelector.validateGroupBy(specField.Child("groupBy"))...)
+	allErrs = append(allErrs, in.PodSelector.validateSelector(specField.Child("selector"))...)
 	allErrs = append(allErrs, validatePodSelector(in.PodSelector.Value, in.PodSelector.Mode, specField.Child("value"))...)
 	allErrs = append(allErrs, in.validateErrno(specField.Child("errno"))...)
 	allErrs = append(allErrs, in.validatePercent(specField.Child("percent"))...)
@@ -25,6 +25,11 @@ type JVMChaosSpec struct {
 	// +optional
 	Duration *string `json:"duration,omitempty"`
 
+	// AbortConditions are the steady-state checks evaluated while the experiment is running.
+	// The experiment will be stopped and recovered once any of them is breached.
+	// +optional
+	AbortConditions []AbortCondition `json:"abortConditions,omitempty"`
+
 	// Action defines the specific jvm chaos action.
 	// Supported action: delay;return;script;cfl;oom;ccf;tce;cpf;tde;tpf
 	// +kubebuilder:validation:Enum=delay;return;script;cfl;oom;ccf;tce;cpf;tde;tpf
@@ -83,6 +83,11 @@ func (in *KernelChaosSpec) Validate() field.ErrorList {
 	specField := field.NewPath("spec")
 	allErrs := validatePodSelector(in.PodSelector.Value, in.PodSelector.Mode, specField.Child("value"))
 	allErrs = append(allErrs, validateDuration(in, specField)...)
+	allErrs = append(allErrs, validateAbortConditions(in.AbortConditions, specField.Child("abortConditions"))...)
+	allErrs = append(allErrs, in.PodSelector.validateReselectPolicy(specField.Child("reselectPolicy"))...)
+	allErrs = append(allErrs, in.PodSelector.validateRampPolicy(specField.Child("rampPolicy"))...)
+	allErrs = append(allErrs, in.PodSelector.validateGroupBy(specField.Child("groupBy"))...)
+	allErrs = append(allErrs, in.PodSelector.validateSelector(specField.Child("selector"))...)
 
 	return allErrs
 }
@@ -14,6 +14,8 @@
 package v1alpha1
 
 import (
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -61,6 +63,10 @@ const (
 
 	// BandwidthAction represents the chaos action of network bandwidth of pods.
 	BandwidthAction NetworkChaosAction = "bandwidth"
+
+	// PacketAction represents the chaos action of injecting faults into specific packets of pods
+	// through an eBPF program.
+	PacketAction NetworkChaosAction = "packet"
 )
 
 // Direction represents traffic direction from source to target,
@@ -84,14 +90,19 @@ type NetworkChaosSpec struct {
 	PodSelector `json:",inline"`
 
 	// Action defines the specific network chaos action.
-	// Supported action: partition, netem, delay, loss, duplicate, corrupt
+	// Supported action: partition, netem, delay, loss, duplicate, corrupt, bandwidth, packet
 	// Default action: delay
-	// +kubebuilder:validation:Enum=netem;delay;loss;duplicate;corrupt;partition;bandwidth
+	// +kubebuilder:validation:Enum=netem;delay;loss;duplicate;corrupt;partition;bandwidth;packet
 	Action NetworkChaosAction `json:"action"`
 
 	// Duration represents the duration of the chaos action
 	Duration *string `json:"duration,omitempty"`
 
+	// AbortConditions are the steady-state checks evaluated while the experiment is running.
+	// The experiment will be stopped and recovered once any of them is breached.
+	// +optional
+	AbortConditions []AbortCondition `json:"abortConditions,omitempty"`
+
 	// TcParameter represents the traffic control definition
 	TcParameter `json:",inline"`
 
@@ -104,9 +115,47 @@ type NetworkChaosSpec struct {
 	// +optional
 	Target *PodSelector `json:"target,omitempty"`
 
-	// ExternalTargets represents network targets outside k8s
+	// ExternalTargets represents network targets outside k8s, the domains are resolved into both IPv4 and IPv6 addresses
 	// +optional
 	ExternalTargets []string `json:"externalTargets,omitempty"`
+
+	// ResolvePolicy makes the controller resolve the domains in the external targets again periodically
+	// during the experiment, and update the addresses of them in the ipsets on the pods.
+	// If not set, the domains are resolved only once when the chaos is applied.
+	// +optional
+	ResolvePolicy *ResolvePolicy `json:"resolvePolicy,omitempty"`
+
+	// Device is the network interface of the selected pods to inject the chaos into, e.g. the interface of
+	// a secondary network attached by Multus. The interface with the same name is used on the target pods.
+	// The default interface eth0 is used for traffic control if it's empty, and the network partition
+	// applies on all interfaces.
+	// +optional
+	Device string `json:"device,omitempty"`
+
+	// Ingress shapes the inbound traffic of the selected pods themselves through an IFB device with
+	// direction "from" and "both", instead of the outbound traffic of the targets. It allows the
+	// traffic from the external targets or from outside the cluster to be shaped, and requires the
+	// ifb kernel module on the nodes. This applies on netem and bandwidth action.
+	// +optional
+	Ingress bool `json:"ingress,omitempty"`
+
+	// PacketFault represents the detail about packet action
+	// +optional
+	PacketFault *PacketFaultSpec `json:"packetFault,omitempty"`
+
+	// NodeNetwork injects the chaos into the host network of the nodes selected by the nodes and
+	// node selectors of the selector, instead of the network of the pods. The other selectors are
+	// ignored, and the mode applies on the nodes. The grpc port of chaos-daemon and the port of
+	// kubelet are never affected, and the rules are recovered by chaos-daemon once it loses contact
+	// with the controller. This applies on netem, bandwidth and network partition action.
+	// +optional
+	NodeNetwork bool `json:"nodeNetwork,omitempty"`
+
+	// PacketFilter limits the chaos to the packets with the protocol and ports, this applies on netem,
+	// bandwidth, packet and network partition action.
+	// The ports are matched against the packets flowing in the direction, e.g. the port of the target is
+	// the destination port with direction "to", and the source port with direction "from".
+	PacketFilter `json:",inline"`
 }
 
 // NetworkChaosStatus defines the observed state of NetworkChaos
@@ -115,24 +164,63 @@ type NetworkChaosStatus struct {
 	// Instances always specifies podnetworkchaos generation or empty
 	// +optional
 	Instances map[string]int64 `json:"instances,omitempty"`
+	// ResolvedTargets records the addresses which the external targets are resolved into by the resolve policy
+	// +optional
+	ResolvedTargets []ResolvedTarget `json:"resolvedTargets,omitempty"`
+}
+
+// ResolvePolicy defines how to resolve the domains in the external targets during an experiment
+type ResolvePolicy struct {
+	// Interval is the interval to resolve the domains again, e.g. "30s", "5m"
+	Interval string `json:"interval"`
+}
+
+// GetInterval returns the interval to resolve the domains again.
+// It returns zero if the domains shouldn't be resolved again.
+func (in *ResolvePolicy) GetInterval() (time.Duration, error) {
+	if in == nil {
+		return 0, nil
+	}
+
+	return time.ParseDuration(in.Interval)
+}
+
+// ResolvedTarget represents the addresses of an external target
+type ResolvedTarget struct {
+	// Name is the external target, which is a domain, an ip or a cidr
+	Name string `json:"name"`
+
+	// Cidrs are the addresses of the external target in the ipsets
+	// +optional
+	Cidrs []string `json:"cidrs,omitempty"`
 }
 
 // DelaySpec defines detail of a delay action
 type DelaySpec struct {
-	Latency string `json:"latency"`
+	// Latency is required unless the profile is set
+	// +optional
+	Latency string `json:"latency,omitempty"`
 	// +optional
 	Correlation string `json:"correlation,omitempty"`
 	// +optional
 	Jitter string `json:"jitter,omitempty"`
 	// +optional
 	Reorder *ReorderSpec `json:"reorder,omitempty"`
+	// Profile varies the latency over time, which cannot be set together with the latency
+	// +optional
+	Profile *ProfileSpec `json:"profile,omitempty"`
 }
 
 // LossSpec defines detail of a loss action
 type LossSpec struct {
-	Loss string `json:"loss"`
+	// Loss is required unless the profile is set
+	// +optional
+	Loss string `json:"loss,omitempty"`
 	// +optional
 	Correlation string `json:"correlation,omitempty"`
+	// Profile varies the loss over time, which cannot be set together with the loss
+	// +optional
+	Profile *ProfileSpec `json:"profile,omitempty"`
 }
 
 // DuplicateSpec defines detail of a duplicate action
@@ -152,7 +240,9 @@ type CorruptSpec struct {
 // BandwidthSpec defines detail of bandwidth limit.
 type BandwidthSpec struct {
 	// Rate is the speed knob. Allows bps, kbps, mbps, gbps, tbps unit. bps means bytes per second.
-	Rate string `json:"rate"`
+	// It's required unless the profile is set.
+	// +optional
+	Rate string `json:"rate,omitempty"`
 	// Limit is the number of bytes that can be queued waiting for tokens to become available.
 	// +kubebuilder:validation:Minimum=1
 	Limit uint32 `json:"limit"`
@@ -173,6 +263,69 @@ type BandwidthSpec struct {
 	// +optional
 	// +kubebuilder:validation:Minimum=0
 	Minburst *uint32 `json:"minburst,omitempty"`
+	// Profile varies the rate over time, which cannot be set together with the rate
+	// +optional
+	Profile *ProfileSpec `json:"profile,omitempty"`
+}
+
+// PacketFaultType represents the fault injected into the matched packets
+type PacketFaultType string
+
+const (
+	// DropPacketFault drops the matched packets
+	DropPacketFault PacketFaultType = "drop"
+
+	// ResetPacketFault turns the matched tcp segments into RST segments, which resets the connections
+	ResetPacketFault PacketFaultType = "reset"
+
+	// DelayPacketFault delays the matched packets. It can't be used together with the traffic control, e.g. delay,
+	// loss or bandwidth, on the outbound traffic of the same device.
+	DelayPacketFault PacketFaultType = "delay"
+
+	// CorruptPacketFault overwrites specific bytes of the payload of the matched packets
+	CorruptPacketFault PacketFaultType = "corrupt"
+)
+
+// PacketFaultSpec defines detail of a packet action. The fault is injected into the outbound packets
+// matched by the ipset of the targets, the packet filter, the tcp flags and the payload prefix.
+type PacketFaultSpec struct {
+	// Fault is the fault injected into the matched packets.
+	// Supported fault: drop, reset, delay, corrupt
+	// +kubebuilder:validation:Enum=drop;reset;delay;corrupt
+	Fault PacketFaultType `json:"fault"`
+
+	// TCPFlags matches the tcp segments by the flags, which requires tcp protocol.
+	// The flags are separated by commas, and a flag prefixed with "!" must be unset, e.g. "SYN,!ACK".
+	// Supported flag: FIN, SYN, RST, PSH, ACK, URG
+	// +optional
+	TCPFlags string `json:"tcpFlags,omitempty"`
+
+	// PayloadPrefix matches the packets whose payload starts with the bytes, which requires tcp or udp
+	// protocol. The bytes are in hex, e.g. "474554" for "GET", and at most 16 bytes are allowed.
+	// +optional
+	PayloadPrefix string `json:"payloadPrefix,omitempty"`
+
+	// Percent is the percentage of the matched packets to inject the fault into, defaults to 100
+	// +optional
+	Percent string `json:"percent,omitempty"`
+
+	// Latency is the delay of the packets, which is required by the delay fault and at most 10s
+	// +optional
+	Latency string `json:"latency,omitempty"`
+
+	// Corrupt represents the bytes to overwrite, which is required by the corrupt fault
+	// +optional
+	Corrupt *PacketCorruptSpec `json:"corrupt,omitempty"`
+}
+
+// PacketCorruptSpec represents the bytes to overwrite in the payload of the packets
+type PacketCorruptSpec struct {
+	// Offset is the offset of the bytes in the payload, which must be even
+	// +kubebuilder:validation:Minimum=0
+	Offset int32 `json:"offset"`
+
+	// Value is the bytes in hex to overwrite with, and at most 16 bytes are allowed
+	Value string `json:"value"`
 }
 
 // ReorderSpec defines details of packet reorder.
@@ -183,7 +336,76 @@ type ReorderSpec struct {
 	Gap         int    `json:"gap"`
 }
 
+// ProfileSpec varies a parameter of the traffic control over time, by either a sequence
+// of steps or a waveform. Chaos daemon updates the qdisc whenever the value changes.
+type ProfileSpec struct {
+	// Steps are the values applied one after another, each of them lasts for its duration
+	// +optional
+	Steps []ProfileStep `json:"steps,omitempty"`
+
+	// Waveform varies the value between its min and max value periodically
+	// +optional
+	Waveform *WaveformSpec `json:"waveform,omitempty"`
+
+	// Repeat restarts the steps after the last one ends, otherwise the value of the last
+	// step is kept until the chaos is recovered. The waveform always repeats.
+	// +optional
+	Repeat bool `json:"repeat,omitempty"`
+}
+
+// ProfileStep is a value of the parameter lasting for a duration
+type ProfileStep struct {
+	// Value is in the format of the parameter varied by the profile,
+	// like "100ms" of the latency, "25" of the loss, and "1mbps" of the rate
+	Value string `json:"value"`
+
+	// Duration is how long the value lasts
+	Duration string `json:"duration"`
+}
+
+// WaveformType represents the shape of a waveform
+type WaveformType string
+
+const (
+	// SineWaveform rises from the min value to the max value and falls back smoothly in every period
+	SineWaveform WaveformType = "sine"
+
+	// SquareWaveform keeps the min value in the first half of every period and the max value in the second half
+	SquareWaveform WaveformType = "square"
+
+	// RandomWalkWaveform starts from the min value and moves up or down randomly by a tenth of
+	// the range in every interval, without going beyond the min and max value
+	RandomWalkWaveform WaveformType = "random-walk"
+)
+
+// WaveformSpec defines a waveform varying a parameter between two values
+type WaveformSpec struct {
+	// +kubebuilder:validation:Enum=sine;square;random-walk
+	Type WaveformType `json:"type"`
+
+	// Min is the lowest value in the format of the parameter varied by the profile
+	Min string `json:"min"`
+
+	// Max is the highest value in the format of the parameter varied by the profile
+	Max string `json:"max"`
+
+	// Period is the duration of a cycle, which is required by the sine and square waveforms
+	// +optional
+	Period string `json:"period,omitempty"`
+
+	// Interval is the duration between two updates of the value, defaults to 1s
+	// +optional
+	Interval string `json:"interval,omitempty"`
+}
+
 func (obj *NetworkChaos) GetSelectorSpecs() map[string]interface{} {
+	if obj.Spec.NodeNetwork {
+		return map[string]interface{}{
+			".":       (*NodeSelector)(&obj.Spec.PodSelector),
+			".Target": obj.Spec.Target,
+		}
+	}
+
 	return map[string]interface{}{
 		".":       &obj.Spec.PodSelector,
 		".Target": obj.Spec.Target,
@@ -14,8 +14,10 @@
 package v1alpha1
 
 import (
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
 	"strconv"
 	"strings"
@@ -152,7 +154,20 @@ func (in *NetworkChaosSpec) Validate() field.ErrorList {
 	var allErrs field.ErrorList
 
 	allErrs = append(allErrs, validateDuration(in, specField)...)
+	allErrs = append(allErrs, validateAbortConditions(in.AbortConditions, specField.Child("abortConditions"))...)
+	allErrs = append(allErrs, in.PodSelector.validateReselectPolicy(specField.Child("reselectPolicy"))...)
+	allErrs = append(allErrs, in.PodSelector.validateRampPolicy(specField.Child("rampPolicy"))...)
+	allErrs = append(allErrs, in.PodSelector.validateGroupBy(specField.Child("groupBy"))...)
+	allErrs = append(allErrs, in.PodSelector.validateSelector(specField.Child("selector"))...)
+	allErrs = append(allErrs, in.Target.validateReselectPolicy(specField.Child("target", "reselectPolicy"))...)
+	allErrs = append(allErrs, in.Target.validateRampPolicy(specField.Child("target", "rampPolicy"))...)
+	allErrs = append(allErrs, in.Target.validateGroupBy(specField.Child("target", "groupBy"))...)
+	allErrs = append(allErrs, in.Target.validateSelector(specField.Child("target", "selector"))...)
 	allErrs = append(allErrs, in.validateTargets(specField.Child("target"))...)
+	allErrs = append(allErrs, in.validateResolvePolicy(specField.Child("resolvePolicy"))...)
+	if in.NodeNetwork {
+		allErrs = append(allErrs, in.validateNodeNetwork(specField)...)
+	}
 	if in.Delay != nil {
 		allErrs = append(allErrs, in.Delay.validateDelay(specField.Child("delay"))...)
 	}
@@ -171,6 +186,309 @@ func (in *NetworkChaosSpec) Validate() field.ErrorList {
 	if in.Target != nil {
 		allErrs = append(allErrs, in.validateTargetPodSelector(specField.Child("target"))...)
 	}
+	if in.PacketFault != nil {
+		allErrs = append(allErrs, in.validatePacketFault(specField)...)
+	} else if in.Action == PacketAction {
+		allErrs = append(allErrs,
+			field.Invalid(specField.Child("packetFault"), in.PacketFault, "packet fault is required in packet action"))
+	}
+	allErrs = append(allErrs, in.PacketFilter.validatePacketFilter(specField)...)
+	if len(in.Device) > 0 {
+		allErrs = append(allErrs, validateDevice(specField.Child("device"), in.Device)...)
+	}
+
+	return allErrs
+}
+
+// validateResolvePolicy validates the interval of the resolve policy, which requires the external targets
+func (in *NetworkChaosSpec) validateResolvePolicy(policyField *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if in.ResolvePolicy == nil {
+		return allErrs
+	}
+
+	if len(in.ExternalTargets) == 0 {
+		allErrs = append(allErrs, field.Invalid(policyField, in.ResolvePolicy,
+			"resolve policy requires the external targets"))
+	}
+
+	interval, err := in.ResolvePolicy.GetInterval()
+	if err != nil {
+		allErrs = append(allErrs, field.Invalid(policyField.Child("interval"), in.ResolvePolicy.Interval,
+			fmt.Sprintf("parse interval field error:%s", err)))
+	} else if interval <= 0 {
+		allErrs = append(allErrs, field.Invalid(policyField.Child("interval"), in.ResolvePolicy.Interval,
+			"interval must be greater than 0"))
+	}
+
+	return allErrs
+}
+
+// validateNodeNetwork validates the combination of the node network with the other fields of the spec
+func (in *NetworkChaosSpec) validateNodeNetwork(spec *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	path := spec.Child("nodeNetwork")
+
+	if len(in.Selector.Nodes) == 0 && len(in.Selector.NodeSelectors) == 0 {
+		allErrs = append(allErrs, field.Invalid(spec.Child("selector"), in.Selector,
+			"node network requires the nodes or node selectors"))
+	}
+	if in.Action == PacketAction {
+		allErrs = append(allErrs, field.Invalid(path, in.NodeNetwork,
+			"node network cannot be used in packet action"))
+	}
+	if in.Ingress {
+		allErrs = append(allErrs, field.Invalid(spec.Child("ingress"), in.Ingress,
+			"ingress cannot be used with node network"))
+	}
+	if in.Mode == RampPodMode {
+		allErrs = append(allErrs, field.Invalid(spec.Child("mode"), in.Mode,
+			"ramp mode cannot be used with node network"))
+	}
+	if in.ReselectPolicy != nil {
+		allErrs = append(allErrs, field.Invalid(spec.Child("reselectPolicy"), in.ReselectPolicy,
+			"reselect policy cannot be used with node network"))
+	}
+	if in.GroupBy != nil {
+		allErrs = append(allErrs, field.Invalid(spec.Child("groupBy"), in.GroupBy,
+			"group by cannot be used with node network"))
+	}
+
+	return allErrs
+}
+
+// maxDeviceNameLength is the limit of the length of a network device name
+const maxDeviceNameLength = 15
+
+// validateDevice validates the name of the network device
+func validateDevice(path *field.Path, device string) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(device) > maxDeviceNameLength {
+		allErrs = append(allErrs, field.Invalid(path, device,
+			fmt.Sprintf("the name of device cannot be longer than %d characters", maxDeviceNameLength)))
+	}
+	if device == "." || device == ".." || strings.ContainsAny(device, "/: \t\n") {
+		allErrs = append(allErrs, field.Invalid(path, device, "invalid name of device"))
+	}
+
+	return allErrs
+}
+
+// maxMultiPorts is the limit of the count of ports in one iptables multiport match, a range counts as two ports
+const maxMultiPorts = 15
+
+// validatePacketFilter validates the protocol and the ports of the packet filter
+func (in *PacketFilter) validatePacketFilter(path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	switch in.Protocol {
+	case "", TCPProtocol, UDPProtocol, ICMPProtocol:
+	default:
+		allErrs = append(allErrs, field.Invalid(path.Child("protocol"), in.Protocol,
+			fmt.Sprintf("protocol %s not supported", in.Protocol)))
+	}
+
+	ports := map[string]string{
+		"sourcePorts":      in.SourcePorts,
+		"destinationPorts": in.DestinationPorts,
+	}
+	for name, value := range ports {
+		if len(value) == 0 {
+			continue
+		}
+
+		if in.Protocol != TCPProtocol && in.Protocol != UDPProtocol {
+			allErrs = append(allErrs, field.Invalid(path.Child(name), value,
+				fmt.Sprintf("ports require protocol %s or %s", TCPProtocol, UDPProtocol)))
+			continue
+		}
+		if err := validatePorts(value); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child(name), value, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+// validatePorts validates the ports separated by commas, and the ranges of ports in the format of "start:end"
+func validatePorts(value string) error {
+	parts := strings.Split(value, ",")
+	count := 0
+	for _, part := range parts {
+		bounds := strings.Split(part, ":")
+		if len(bounds) > 2 {
+			return fmt.Errorf("invalid port range %s", part)
+		}
+
+		var ports []uint64
+		for _, bound := range bounds {
+			port, err := strconv.ParseUint(bound, 10, 16)
+			if err != nil {
+				return fmt.Errorf("parse port %s error:%s", bound, err)
+			}
+			ports = append(ports, port)
+		}
+		if len(ports) == 2 && ports[0] > ports[1] {
+			return fmt.Errorf("the start of port range %s is greater than the end", part)
+		}
+		count += len(ports)
+	}
+
+	if len(parts) > 1 && count > maxMultiPorts {
+		return fmt.Errorf("at most %d ports are allowed, and a range counts as two ports", maxMultiPorts)
+	}
+
+	return nil
+}
+
+// maxPacketFaultBytes is the limit of the count of bytes of the payload prefix and the corrupted bytes,
+// which are compared and written byte by byte in the eBPF program
+const maxPacketFaultBytes = 16
+
+// maxPacketFaultLatency is the limit of the latency of the delay fault, packets delayed longer are
+// dropped by the fq qdisc
+const maxPacketFaultLatency = 10 * time.Second
+
+// TCPFlagBits maps the name of tcp flags to their bits in the tcp header
+var TCPFlagBits = map[string]uint8{
+	"FIN": 0x01,
+	"SYN": 0x02,
+	"RST": 0x04,
+	"PSH": 0x08,
+	"ACK": 0x10,
+	"URG": 0x20,
+}
+
+// ParseTCPFlags parses the tcp flags separated by commas, e.g. "SYN,!ACK", into the mask of the
+// flags to check and the value they should have
+func ParseTCPFlags(flags string) (mask uint8, value uint8, err error) {
+	for _, flag := range strings.Split(flags, ",") {
+		flag = strings.ToUpper(strings.TrimSpace(flag))
+		unset := strings.HasPrefix(flag, "!")
+		bit, ok := TCPFlagBits[strings.TrimPrefix(flag, "!")]
+		if !ok {
+			return 0, 0, fmt.Errorf("unknown tcp flag %s", flag)
+		}
+		if mask&bit != 0 {
+			return 0, 0, fmt.Errorf("duplicated tcp flag %s", flag)
+		}
+
+		mask |= bit
+		if !unset {
+			value |= bit
+		}
+	}
+
+	return mask, value, nil
+}
+
+// ParsePacketFaultBytes parses the bytes in hex of the payload prefix and the corrupted bytes
+func ParsePacketFaultBytes(value string) ([]byte, error) {
+	bytes, err := hex.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes) == 0 || len(bytes) > maxPacketFaultBytes {
+		return nil, fmt.Errorf("the count of bytes should be between 1 and %d", maxPacketFaultBytes)
+	}
+
+	return bytes, nil
+}
+
+// validatePacketFault validates the packet fault and its combination with the other fields of the spec
+func (in *NetworkChaosSpec) validatePacketFault(spec *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	path := spec.Child("packetFault")
+	fault := in.PacketFault
+
+	if in.Action != PacketAction {
+		return append(allErrs, field.Invalid(path, fault, "packet fault can only be used in packet action"))
+	}
+	if in.Ingress {
+		allErrs = append(allErrs, field.Invalid(spec.Child("ingress"), in.Ingress,
+			"ingress cannot be used in packet action"))
+	}
+
+	isTransport := in.Protocol == TCPProtocol || in.Protocol == UDPProtocol
+	switch fault.Fault {
+	case DropPacketFault:
+	case ResetPacketFault:
+		if in.Protocol != TCPProtocol {
+			allErrs = append(allErrs, field.Invalid(path.Child("fault"), fault.Fault,
+				fmt.Sprintf("reset fault requires protocol %s", TCPProtocol)))
+		}
+	case DelayPacketFault:
+		latency, err := time.ParseDuration(fault.Latency)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("latency"), fault.Latency,
+				fmt.Sprintf("parse latency field error:%s", err)))
+		} else if latency <= 0 || latency > maxPacketFaultLatency {
+			allErrs = append(allErrs, field.Invalid(path.Child("latency"), fault.Latency,
+				fmt.Sprintf("latency should be positive and at most %s", maxPacketFaultLatency)))
+		}
+	case CorruptPacketFault:
+		if !isTransport {
+			allErrs = append(allErrs, field.Invalid(path.Child("fault"), fault.Fault,
+				fmt.Sprintf("corrupt fault requires protocol %s or %s", TCPProtocol, UDPProtocol)))
+		}
+		if fault.Corrupt == nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("corrupt"), fault.Corrupt,
+				"corrupt is required by corrupt fault"))
+		}
+	default:
+		allErrs = append(allErrs, field.Invalid(path.Child("fault"), fault.Fault,
+			fmt.Sprintf("fault %s not supported", fault.Fault)))
+	}
+
+	if len(fault.Latency) > 0 && fault.Fault != DelayPacketFault {
+		allErrs = append(allErrs, field.Invalid(path.Child("latency"), fault.Latency,
+			"latency can only be used in delay fault"))
+	}
+	if fault.Corrupt != nil {
+		if fault.Fault != CorruptPacketFault {
+			allErrs = append(allErrs, field.Invalid(path.Child("corrupt"), fault.Corrupt,
+				"corrupt can only be used in corrupt fault"))
+		}
+		if fault.Corrupt.Offset < 0 || fault.Corrupt.Offset%2 != 0 || fault.Corrupt.Offset > math.MaxUint16 {
+			allErrs = append(allErrs, field.Invalid(path.Child("corrupt", "offset"), fault.Corrupt.Offset,
+				"offset should be even and between 0 and 65535"))
+		}
+		if _, err := ParsePacketFaultBytes(fault.Corrupt.Value); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("corrupt", "value"), fault.Corrupt.Value,
+				fmt.Sprintf("parse value field error:%s", err)))
+		}
+	}
+
+	if len(fault.TCPFlags) > 0 {
+		if in.Protocol != TCPProtocol {
+			allErrs = append(allErrs, field.Invalid(path.Child("tcpFlags"), fault.TCPFlags,
+				fmt.Sprintf("tcp flags require protocol %s", TCPProtocol)))
+		} else if _, _, err := ParseTCPFlags(fault.TCPFlags); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("tcpFlags"), fault.TCPFlags,
+				fmt.Sprintf("parse tcpFlags field error:%s", err)))
+		}
+	}
+	if len(fault.PayloadPrefix) > 0 {
+		if !isTransport {
+			allErrs = append(allErrs, field.Invalid(path.Child("payloadPrefix"), fault.PayloadPrefix,
+				fmt.Sprintf("payload prefix requires protocol %s or %s", TCPProtocol, UDPProtocol)))
+		} else if _, err := ParsePacketFaultBytes(fault.PayloadPrefix); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("payloadPrefix"), fault.PayloadPrefix,
+				fmt.Sprintf("parse payloadPrefix field error:%s", err)))
+		}
+	}
+	if len(fault.Percent) > 0 {
+		percent, err := strconv.ParseFloat(fault.Percent, 32)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("percent"), fault.Percent,
+				fmt.Sprintf("parse percent field error:%s", err)))
+		} else if percent < 0 || percent > 100 {
+			allErrs = append(allErrs, field.Invalid(path.Child("percent"), fault.Percent,
+				"percent should be between 0 and 100"))
+		}
+	}
 
 	return allErrs
 }
@@ -178,13 +496,18 @@ func (in *NetworkChaosSpec) Validate() field.ErrorList {
 // validateDelay validates the delay
 func (in *DelaySpec) validateDelay(delay *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
-	_, err := time.ParseDuration(in.Latency)
-	if err != nil {
+	if in.Profile != nil {
+		if len(in.Latency) > 0 {
+			allErrs = append(allErrs,
+				field.Invalid(delay.Child("latency"), in.Latency, "latency cannot be set together with profile"))
+		}
+		allErrs = append(allErrs, in.Profile.validateProfile(delay.Child("profile"), parseLatency)...)
+	} else if _, err := time.ParseDuration(in.Latency); err != nil {
 		allErrs = append(allErrs,
 			field.Invalid(delay.Child("latency"), in.Latency,
 				fmt.Sprintf("parse latency field error:%s", err)))
 	}
-	_, err = time.ParseDuration(in.Jitter)
+	_, err := time.ParseDuration(in.Jitter)
 	if err != nil {
 		allErrs = append(allErrs,
 			field.Invalid(delay.Child("jitter"), in.Jitter,
@@ -227,14 +550,19 @@ func (in *ReorderSpec) validateReorder(reorder *field.Path) field.ErrorList {
 func (in *LossSpec) validateLoss(loss *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	_, err := strconv.ParseFloat(in.Loss, 32)
-	if err != nil {
+	if in.Profile != nil {
+		if len(in.Loss) > 0 {
+			allErrs = append(allErrs,
+				field.Invalid(loss.Child("loss"), in.Loss, "loss cannot be set together with profile"))
+		}
+		allErrs = append(allErrs, in.Profile.validateProfile(loss.Child("profile"), parseLoss)...)
+	} else if _, err := strconv.ParseFloat(in.Loss, 32); err != nil {
 		allErrs = append(allErrs,
 			field.Invalid(loss.Child("loss"), in.Loss,
 				fmt.Sprintf("parse loss field error:%s", err)))
 	}
 
-	_, err = strconv.ParseFloat(in.Correlation, 32)
+	_, err := strconv.ParseFloat(in.Correlation, 32)
 	if err != nil {
 		allErrs = append(allErrs,
 			field.Invalid(loss.Child("correlation"), in.Correlation,
@@ -285,6 +613,14 @@ func (in *CorruptSpec) validateCorrupt(corrupt *field.Path) field.ErrorList {
 // validateBandwidth validates the bandwidth
 func (in *BandwidthSpec) validateBandwidth(bandwidth *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
+	if in.Profile != nil {
+		if len(in.Rate) > 0 {
+			allErrs = append(allErrs,
+				field.Invalid(bandwidth.Child("rate"), in.Rate, "rate cannot be set together with profile"))
+		}
+		return append(allErrs, in.Profile.validateProfile(bandwidth.Child("profile"), parseRate)...)
+	}
+
 	_, err := ConvertUnitToBytes(in.Rate)
 
 	if err != nil {
@@ -295,6 +631,111 @@ func (in *BandwidthSpec) validateBandwidth(bandwidth *field.Path) field.ErrorLis
 	return allErrs
 }
 
+// validateProfile validates the profile, whose values are parsed by parse
+func (in *ProfileSpec) validateProfile(profile *field.Path, parse func(string) (float64, error)) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if (len(in.Steps) == 0) == (in.Waveform == nil) {
+		return append(allErrs,
+			field.Invalid(profile, in, "either steps or waveform should be set"))
+	}
+
+	for i, step := range in.Steps {
+		stepField := profile.Child("steps").Index(i)
+		if _, err := parse(step.Value); err != nil {
+			allErrs = append(allErrs,
+				field.Invalid(stepField.Child("value"), step.Value,
+					fmt.Sprintf("parse value field error:%s", err)))
+		}
+		allErrs = append(allErrs, validatePositiveDuration(stepField.Child("duration"), step.Duration)...)
+	}
+
+	if in.Waveform != nil {
+		allErrs = append(allErrs, in.Waveform.validateWaveform(profile.Child("waveform"), parse)...)
+	}
+
+	return allErrs
+}
+
+// validateWaveform validates the waveform, whose values are parsed by parse
+func (in *WaveformSpec) validateWaveform(waveform *field.Path, parse func(string) (float64, error)) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	min, err := parse(in.Min)
+	if err != nil {
+		allErrs = append(allErrs,
+			field.Invalid(waveform.Child("min"), in.Min,
+				fmt.Sprintf("parse min field error:%s", err)))
+	}
+	max, err := parse(in.Max)
+	if err != nil {
+		allErrs = append(allErrs,
+			field.Invalid(waveform.Child("max"), in.Max,
+				fmt.Sprintf("parse max field error:%s", err)))
+	}
+	if len(allErrs) == 0 && min > max {
+		allErrs = append(allErrs,
+			field.Invalid(waveform.Child("max"), in.Max, "max should not be less than min"))
+	}
+
+	switch in.Type {
+	case SineWaveform, SquareWaveform:
+		allErrs = append(allErrs, validatePositiveDuration(waveform.Child("period"), in.Period)...)
+	case RandomWalkWaveform:
+	default:
+		allErrs = append(allErrs,
+			field.Invalid(waveform.Child("type"), in.Type, "unknown waveform type"))
+	}
+
+	if len(in.Interval) > 0 {
+		allErrs = append(allErrs, validatePositiveDuration(waveform.Child("interval"), in.Interval)...)
+	}
+
+	return allErrs
+}
+
+func validatePositiveDuration(path *field.Path, value string) field.ErrorList {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return field.ErrorList{field.Invalid(path, value, fmt.Sprintf("parse duration error:%s", err))}
+	}
+	if duration <= 0 {
+		return field.ErrorList{field.Invalid(path, value, "duration should be positive")}
+	}
+
+	return nil
+}
+
+func parseLatency(value string) (float64, error) {
+	latency, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if latency < 0 {
+		return 0, fmt.Errorf("latency should not be negative")
+	}
+
+	return float64(latency), nil
+}
+
+func parseLoss(value string) (float64, error) {
+	loss, err := strconv.ParseFloat(value, 32)
+	if err != nil {
+		return 0, err
+	}
+	if loss < 0 || loss > 100 {
+		return 0, fmt.Errorf("loss should be between 0 and 100")
+	}
+
+	return loss, nil
+}
+
+func parseRate(value string) (float64, error) {
+	rate, err := ConvertUnitToBytes(value)
+
+	return float64(rate), err
+}
+
 func ConvertUnitToBytes(nu string) (uint64, error) {
 	// normalize input
 	s := strings.ToLower(strings.TrimSpace(nu))
@@ -324,7 +765,7 @@ func ConvertUnitToBytes(nu string) (uint64, error) {
 
 // validateTarget validates the target
 func (in *NetworkChaosSpec) validateTargetPodSelector(target *field.Path) field.ErrorList {
-	modes := []PodMode{OnePodMode, AllPodMode, FixedPodMode, FixedPercentPodMode, RandomMaxPercentPodMode}
+	modes := []PodMode{OnePodMode, AllPodMode, FixedPodMode, FixedPercentPodMode, RandomMaxPercentPodMode, RampPodMode}
 
 	for _, mode := range modes {
 		if in.Target.Mode == mode {
@@ -344,11 +785,23 @@ func (in *NetworkChaosSpec) validateTargets(target *field.Path) field.ErrorList
 		return nil
 	}
 
+	if in.Ingress {
+		if in.Direction != From && in.Direction != Both {
+			allErrs = append(allErrs,
+				field.Invalid(target.Child("ingress"), in.Ingress,
+					"ingress can only be used with `from` and `both` direction"))
+		}
+
+		// the inbound traffic is shaped on the selected pods, so neither the targets
+		// nor the external targets are required
+		return allErrs
+	}
+
 	if (in.Direction == From || in.Direction == Both) &&
 		in.ExternalTargets != nil && in.Action != PartitionAction {
 		allErrs = append(allErrs,
 			field.Invalid(target.Child("direction"), in.Direction,
-				"external targets cannot be used with `from` and `both` direction in netem action yet"))
+				"external targets cannot be used with `from` and `both` direction in netem action without ingress"))
 	}
 
 	if (in.Direction == From || in.Direction == Both) && in.Target == nil {
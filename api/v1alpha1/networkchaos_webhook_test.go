@@ -258,6 +258,399 @@ var _ = Describe("networkchaos_webhook", func() {
 					},
 					expect: "error",
 				},
+				{
+					name: "validate the ports without protocol",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo13",
+						},
+						Spec: NetworkChaosSpec{
+							PacketFilter: PacketFilter{DestinationPorts: "5432"},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate the reversed port range",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo14",
+						},
+						Spec: NetworkChaosSpec{
+							PacketFilter: PacketFilter{Protocol: TCPProtocol, DestinationPorts: "8080:8000"},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate the packet filter",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo15",
+						},
+						Spec: NetworkChaosSpec{
+							PacketFilter: PacketFilter{Protocol: UDPProtocol, SourcePorts: "53", DestinationPorts: "80,8000:8080"},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "",
+				},
+				{
+					name: "validate the delay profile",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo16",
+						},
+						Spec: NetworkChaosSpec{
+							TcParameter: TcParameter{
+								Delay: &DelaySpec{
+									Jitter:      DefaultJitter,
+									Correlation: DefaultCorrelation,
+									Profile: &ProfileSpec{
+										Steps: []ProfileStep{
+											{Value: "10ms", Duration: "1m"},
+											{Value: "100ms", Duration: "1m"},
+										},
+										Repeat: true,
+									},
+								},
+							},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "",
+				},
+				{
+					name: "validate the loss profile with both steps and waveform",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo17",
+						},
+						Spec: NetworkChaosSpec{
+							TcParameter: TcParameter{
+								Loss: &LossSpec{
+									Correlation: DefaultCorrelation,
+									Profile: &ProfileSpec{
+										Steps:    []ProfileStep{{Value: "10", Duration: "1m"}},
+										Waveform: &WaveformSpec{Type: SquareWaveform, Min: "0", Max: "50", Period: "1m"},
+									},
+								},
+							},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate the bandwidth waveform without period",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo18",
+						},
+						Spec: NetworkChaosSpec{
+							TcParameter: TcParameter{
+								Bandwidth: &BandwidthSpec{
+									Limit:  100,
+									Buffer: 10000,
+									Profile: &ProfileSpec{
+										Waveform: &WaveformSpec{Type: SineWaveform, Min: "1mbps", Max: "10mbps"},
+									},
+								},
+							},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate the ingress with external targets",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo19",
+						},
+						Spec: NetworkChaosSpec{
+							Action:          DelayAction,
+							Direction:       From,
+							Ingress:         true,
+							ExternalTargets: []string{"8.8.8.8"},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "",
+				},
+				{
+					name: "validate the ingress with direction to",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo20",
+						},
+						Spec: NetworkChaosSpec{
+							Action:    DelayAction,
+							Direction: To,
+							Ingress:   true,
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate the device",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo21",
+						},
+						Spec: NetworkChaosSpec{
+							Action: PartitionAction,
+							Device: "a-very-long-device-name",
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate the reset packet fault",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo22",
+						},
+						Spec: NetworkChaosSpec{
+							Action:       PacketAction,
+							PacketFilter: PacketFilter{Protocol: TCPProtocol, DestinationPorts: "3306"},
+							PacketFault: &PacketFaultSpec{
+								Fault:    ResetPacketFault,
+								TCPFlags: "PSH,!SYN",
+								Percent:  "50",
+							},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "",
+				},
+				{
+					name: "validate the reset packet fault without tcp",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo23",
+						},
+						Spec: NetworkChaosSpec{
+							Action:       PacketAction,
+							PacketFilter: PacketFilter{Protocol: UDPProtocol},
+							PacketFault: &PacketFaultSpec{
+								Fault: ResetPacketFault,
+							},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate the corrupt packet fault",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo24",
+						},
+						Spec: NetworkChaosSpec{
+							Action:       PacketAction,
+							PacketFilter: PacketFilter{Protocol: UDPProtocol},
+							PacketFault: &PacketFaultSpec{
+								Fault:         CorruptPacketFault,
+								PayloadPrefix: "474554",
+								Corrupt: &PacketCorruptSpec{
+									Offset: 3,
+									Value:  "xyz",
+								},
+							},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate the packet action without packet fault",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo25",
+						},
+						Spec: NetworkChaosSpec{
+							Action: PacketAction,
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate the resolve policy",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo26",
+						},
+						Spec: NetworkChaosSpec{
+							Action:          PartitionAction,
+							ExternalTargets: []string{"example.com"},
+							ResolvePolicy: &ResolvePolicy{
+								Interval: "30s",
+							},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "",
+				},
+				{
+					name: "validate the resolve policy without external targets",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo27",
+						},
+						Spec: NetworkChaosSpec{
+							Action: PartitionAction,
+							ResolvePolicy: &ResolvePolicy{
+								Interval: "30s",
+							},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate the interval of resolve policy",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo28",
+						},
+						Spec: NetworkChaosSpec{
+							Action:          PartitionAction,
+							ExternalTargets: []string{"example.com"},
+							ResolvePolicy: &ResolvePolicy{
+								Interval: "0s",
+							},
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate the node network",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo29",
+						},
+						Spec: NetworkChaosSpec{
+							PodSelector: PodSelector{
+								Selector: PodSelectorSpec{
+									Nodes: []string{"node1"},
+								},
+								Mode: OnePodMode,
+							},
+							Action:      PartitionAction,
+							Direction:   To,
+							NodeNetwork: true,
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "",
+				},
+				{
+					name: "validate the node network without nodes",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo30",
+						},
+						Spec: NetworkChaosSpec{
+							PodSelector: PodSelector{
+								Selector: PodSelectorSpec{
+									LabelSelectors: map[string]string{"app": "foo"},
+								},
+								Mode: OnePodMode,
+							},
+							Action:      PartitionAction,
+							NodeNetwork: true,
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate the node network in packet action",
+					chaos: NetworkChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo31",
+						},
+						Spec: NetworkChaosSpec{
+							PodSelector: PodSelector{
+								Selector: PodSelectorSpec{
+									NodeSelectors: map[string]string{"kubernetes.io/hostname": "node1"},
+								},
+								Mode: OnePodMode,
+							},
+							Action: PacketAction,
+							PacketFault: &PacketFaultSpec{
+								Fault: DropPacketFault,
+							},
+							NodeNetwork: true,
+						},
+					},
+					execute: func(chaos *NetworkChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
 			}
 
 			for _, tc := range tcs {
@@ -270,6 +663,21 @@ var _ = Describe("networkchaos_webhook", func() {
 			}
 		})
 	})
+	Context("parseTCPFlags", func() {
+		It("should parse the tcp flags successfully", func() {
+			mask, value, err := ParseTCPFlags("syn, !ACK")
+			Expect(err).Should(Succeed())
+			Expect(mask).To(Equal(uint8(0x12)))
+			Expect(value).To(Equal(uint8(0x02)))
+		})
+
+		It("should return error with unknown or duplicated flags", func() {
+			_, _, err := ParseTCPFlags("SYN,ECE")
+			Expect(err).Should(HaveOccurred())
+			_, _, err = ParseTCPFlags("SYN,!SYN")
+			Expect(err).Should(HaveOccurred())
+		})
+	})
 	Context("convertUnitToBytes", func() {
 		It("should convert number with unit successfully", func() {
 			n, err := ConvertUnitToBytes("  10   mbPs  ")
@@ -0,0 +1,52 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// KindNodeNetworkChaos is the kind for network chaos on the host network of a node
+const KindNodeNetworkChaos = "NodeNetworkChaos"
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+
+// NodeNetworkChaos is the Schema for the NodeNetworkChaos API. It has the same name as the node,
+// and its rules are set in the host network namespace of the node.
+type NodeNetworkChaos struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the rules on the host network of the node
+	Spec PodNetworkChaosSpec `json:"spec"`
+
+	// +optional
+	// Most recently observed status of the rules on the node
+	Status PodNetworkChaosStatus `json:"status"`
+}
+
+// +kubebuilder:object:root=true
+
+// NodeNetworkChaosList contains a list of NodeNetworkChaos
+type NodeNetworkChaosList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NodeNetworkChaos `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NodeNetworkChaos{}, &NodeNetworkChaosList{})
+}
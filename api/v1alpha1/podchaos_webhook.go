@@ -83,6 +83,19 @@ func (in *PodChaosSpec) Validate() field.ErrorList {
 	specField := field.NewPath("spec")
 	allErrs := in.validateContainerNames(specField.Child("containerNames"))
 	allErrs = append(allErrs, validateDuration(in, specField)...)
+	allErrs = append(allErrs, validateAbortConditions(in.AbortConditions, specField.Child("abortConditions"))...)
+	allErrs = append(allErrs, in.PodSelector.validateReselectPolicy(specField.Child("reselectPolicy"))...)
+	allErrs = append(allErrs, in.PodSelector.validateRampPolicy(specField.Child("rampPolicy"))...)
+	allErrs = append(allErrs, in.PodSelector.validateGroupBy(specField.Child("groupBy"))...)
+	allErrs = append(allErrs, in.PodSelector.validateSelector(specField.Child("selector"))...)
+	if in.ReselectPolicy != nil && (in.Action == PodKillAction || in.Action == ContainerKillAction) {
+		allErrs = append(allErrs, field.Invalid(specField.Child("reselectPolicy"), in.ReselectPolicy,
+			fmt.Sprintf("reselect policy is not supported on %s action", in.Action)))
+	}
+	if in.Mode == RampPodMode && (in.Action == PodKillAction || in.Action == ContainerKillAction) {
+		allErrs = append(allErrs, field.Invalid(specField.Child("mode"), in.Mode,
+			fmt.Sprintf("mode %s is not supported on %s action", in.Mode, in.Action)))
+	}
 
 	return allErrs
 }
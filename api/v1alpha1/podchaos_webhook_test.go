@@ -84,6 +84,181 @@ var _ = Describe("podchaos_webhook", func() {
 					},
 					expect: "error",
 				},
+				{
+					name: "validate the reselect policy on one-shot action",
+					chaos: PodChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo8",
+						},
+						Spec: PodChaosSpec{
+							Action: PodKillAction,
+							ContainerSelector: ContainerSelector{
+								PodSelector: PodSelector{
+									ReselectPolicy: &ReselectPolicy{Interval: "1m"},
+								},
+							},
+						},
+					},
+					execute: func(chaos *PodChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate the reselect interval",
+					chaos: PodChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo9",
+						},
+						Spec: PodChaosSpec{
+							Action: PodFailureAction,
+							ContainerSelector: ContainerSelector{
+								PodSelector: PodSelector{
+									ReselectPolicy: &ReselectPolicy{Interval: "1"},
+								},
+							},
+						},
+					},
+					execute: func(chaos *PodChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate the ramp mode on one-shot action",
+					chaos: PodChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo10",
+						},
+						Spec: PodChaosSpec{
+							Action: PodKillAction,
+							ContainerSelector: ContainerSelector{
+								PodSelector: PodSelector{
+									Mode:       RampPodMode,
+									RampPolicy: &RampPolicy{Steps: []int32{10, 50}, Interval: "2m"},
+								},
+							},
+						},
+					},
+					execute: func(chaos *PodChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate the ramp mode without ramp policy",
+					chaos: PodChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo11",
+						},
+						Spec: PodChaosSpec{
+							Action: PodFailureAction,
+							ContainerSelector: ContainerSelector{
+								PodSelector: PodSelector{
+									Mode: RampPodMode,
+								},
+							},
+						},
+					},
+					execute: func(chaos *PodChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate the decreasing ramp steps",
+					chaos: PodChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo12",
+						},
+						Spec: PodChaosSpec{
+							Action: PodFailureAction,
+							ContainerSelector: ContainerSelector{
+								PodSelector: PodSelector{
+									Mode:       RampPodMode,
+									RampPolicy: &RampPolicy{Steps: []int32{50, 10}, Interval: "2m"},
+								},
+							},
+						},
+					},
+					execute: func(chaos *PodChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate the topology key of group by",
+					chaos: PodChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo14",
+						},
+						Spec: PodChaosSpec{
+							Action: PodFailureAction,
+							ContainerSelector: ContainerSelector{
+								PodSelector: PodSelector{
+									Mode:    AllPodMode,
+									GroupBy: &GroupBy{Type: GroupByTopology, Groups: OneGroupMode},
+								},
+							},
+						},
+					},
+					execute: func(chaos *PodChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate the selector of the protected pods",
+					chaos: PodChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo15",
+						},
+						Spec: PodChaosSpec{
+							Action: PodFailureAction,
+							ContainerSelector: ContainerSelector{
+								PodSelector: PodSelector{
+									Mode: AllPodMode,
+									Selector: PodSelectorSpec{
+										AnnotationSelectors: map[string]string{ProtectedAnnotationKey: "true"},
+									},
+								},
+							},
+						},
+					},
+					execute: func(chaos *PodChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "error",
+				},
+				{
+					name: "validate the ramp policy",
+					chaos: PodChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: metav1.NamespaceDefault,
+							Name:      "foo13",
+						},
+						Spec: PodChaosSpec{
+							Action: PodFailureAction,
+							ContainerSelector: ContainerSelector{
+								PodSelector: PodSelector{
+									Mode:       RampPodMode,
+									RampPolicy: &RampPolicy{Steps: []int32{10, 25, 50}, Interval: "2m"},
+								},
+							},
+						},
+					},
+					execute: func(chaos *PodChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "",
+				},
 			}
 
 			for _, tc := range tcs {
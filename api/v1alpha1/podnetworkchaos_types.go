@@ -52,6 +52,10 @@ type PodNetworkChaosSpec struct {
 	// The tc rules on the pod
 	// +optional
 	TrafficControls []RawTrafficControl `json:"tcs,omitempty"`
+
+	// The packet faults on the pod
+	// +optional
+	PacketFaults []RawPacketFault `json:"packetFaults,omitempty"`
 }
 
 // RawIPSet represents an ipset on specific pod
@@ -90,6 +94,13 @@ type RawIptables struct {
 	// The block direction of this iptables rule
 	Direction ChainDirection `json:"direction"`
 
+	// The protocol and ports of the blocked packets
+	PacketFilter `json:",inline"`
+
+	// The network device of the blocked packets, all devices are matched if it's empty
+	// +optional
+	Device string `json:"device,omitempty"`
+
 	RawRuleSource `json:",inline"`
 }
 
@@ -115,6 +126,37 @@ type RawTrafficControl struct {
 	// +optional
 	IPSet string `json:"ipset,omitempty"`
 
+	// The protocol and ports of the controlled packets
+	PacketFilter `json:",inline"`
+
+	// Ingress represents the traffic control is set on the inbound traffic through an IFB device,
+	// and the ipset matches the source address of the packets
+	// +optional
+	Ingress bool `json:"ingress,omitempty"`
+
+	// The network device to set the traffic control on, the default device eth0 is used if it's empty
+	// +optional
+	Device string `json:"device,omitempty"`
+
+	// The name and namespace of the source network chaos
+	Source string `json:"source"`
+}
+
+// RawPacketFault represents the packet fault injected by an eBPF program on specific pod
+type RawPacketFault struct {
+	PacketFaultSpec `json:",inline"`
+
+	// The name of target ipset, which matches the destination address of the packets
+	// +optional
+	IPSet string `json:"ipset,omitempty"`
+
+	// The protocol and ports of the matched packets
+	PacketFilter `json:",inline"`
+
+	// The network device to attach the eBPF program to, the default device eth0 is used if it's empty
+	// +optional
+	Device string `json:"device,omitempty"`
+
 	// The name and namespace of the source network chaos
 	Source string `json:"source"`
 }
@@ -142,6 +184,40 @@ type TcParameter struct {
 	Bandwidth *BandwidthSpec `json:"bandwidth,omitempty"`
 }
 
+// NetworkProtocol represents the protocol of the packets
+type NetworkProtocol string
+
+const (
+	// TCPProtocol represents the tcp protocol
+	TCPProtocol NetworkProtocol = "tcp"
+
+	// UDPProtocol represents the udp protocol
+	UDPProtocol NetworkProtocol = "udp"
+
+	// ICMPProtocol represents the icmp protocol
+	ICMPProtocol NetworkProtocol = "icmp"
+)
+
+// PacketFilter represents the filter of the packets by the protocol and ports.
+// All packets are matched if it's empty.
+type PacketFilter struct {
+	// Protocol is the protocol of the packets.
+	// Supported protocol: tcp, udp, icmp
+	// +optional
+	// +kubebuilder:validation:Enum=tcp;udp;icmp;""
+	Protocol NetworkProtocol `json:"protocol,omitempty"`
+
+	// SourcePorts is the source ports of the packets, which requires tcp or udp protocol.
+	// The ports are separated by commas, and a range of ports is represented as "start:end", e.g. "80,8000:8080"
+	// +optional
+	SourcePorts string `json:"sourcePorts,omitempty"`
+
+	// DestinationPorts is the destination ports of the packets, which requires tcp or udp protocol.
+	// The ports are separated by commas, and a range of ports is represented as "start:end", e.g. "80,8000:8080"
+	// +optional
+	DestinationPorts string `json:"destinationPorts,omitempty"`
+}
+
 // RawRuleSource represents the name and namespace of the source network chaos
 type RawRuleSource struct {
 	Source string `json:"source"`
@@ -152,6 +228,11 @@ type PodNetworkChaosStatus struct {
 	FailedMessage string `json:"failedMessage,omitempty"`
 
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Devices are the network devices which have been set with the traffic control,
+	// they will be flushed once there is no traffic control on them
+	// +optional
+	Devices []string `json:"devices,omitempty"`
 }
 
 // +kubebuilder:object:root=true
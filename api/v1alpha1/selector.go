@@ -13,7 +13,13 @@
 
 package v1alpha1
 
-import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+import (
+	"reflect"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
 
 // LabelSelectorRequirements is list of LabelSelectorRequirement
 type LabelSelectorRequirements []metav1.LabelSelectorRequirement
@@ -34,6 +40,9 @@ const (
 	FixedPercentPodMode PodMode = "fixed-percent"
 	// RandomMaxPercentPodMode to specify a maximum % that can be inject chaos action.
 	RandomMaxPercentPodMode PodMode = "random-max-percent"
+	// RampPodMode represents that the system will do the chaos action on an increasing share of the pods,
+	// following the steps of the ramp policy.
+	RampPodMode PodMode = "ramp"
 )
 
 // PodSelectorSpec defines the some selectors to select objects.
@@ -83,12 +92,164 @@ type PodSelectorSpec struct {
 	// supported value: Pending / Running / Succeeded / Failed / Unknown
 	// +optional
 	PodPhaseSelectors []string `json:"podPhaseSelectors,omitempty"`
+
+	// ServiceSelectors is a map of string keys and a set values that used to select the pods behind services.
+	// The key defines the namespace which services belong, and the each values is a set of service names.
+	// The pods are resolved from the endpoints of the services.
+	// +optional
+	ServiceSelectors map[string][]string `json:"serviceSelectors,omitempty"`
+
+	// WorkloadSelectors is a set of workloads, the pods owned by them are selected.
+	// +optional
+	WorkloadSelectors []WorkloadSelector `json:"workloadSelectors,omitempty"`
+
+	// Exclude is used to exclude the pods from the selected ones.
+	// The pods matching all the selectors of Exclude are not selected.
+	// +optional
+	Exclude *PodExcludeSelectorSpec `json:"exclude,omitempty"`
+}
+
+// PodExcludeSelectorSpec defines the selectors to exclude objects, which have the same meanings as
+// the ones of PodSelectorSpec.
+// If the all selectors are empty, no object will be excluded.
+type PodExcludeSelectorSpec struct {
+	// Namespaces is a set of namespace to which objects belong.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Nodes is a set of node name and objects must belong to these nodes.
+	// +optional
+	Nodes []string `json:"nodes,omitempty"`
+
+	// Pods is a map of string keys and a set values that used to select pods.
+	// The key defines the namespace which pods belong,
+	// and the each values is a set of pod names.
+	// +optional
+	Pods map[string][]string `json:"pods,omitempty"`
+
+	// Map of string keys and values that can be used to select nodes.
+	// Selector which must match a node's labels,
+	// and objects must belong to these selected nodes.
+	// +optional
+	NodeSelectors map[string]string `json:"nodeSelectors,omitempty"`
+
+	// Map of string keys and values that can be used to select objects.
+	// A selector based on fields.
+	// +optional
+	FieldSelectors map[string]string `json:"fieldSelectors,omitempty"`
+
+	// Map of string keys and values that can be used to select objects.
+	// A selector based on labels.
+	// +optional
+	LabelSelectors map[string]string `json:"labelSelectors,omitempty"`
+
+	// a slice of label selector expressions that can be used to select objects.
+	// A list of selectors based on set-based label expressions.
+	// +optional
+	ExpressionSelectors LabelSelectorRequirements `json:"expressionSelectors,omitempty"`
+
+	// Map of string keys and values that can be used to select objects.
+	// A selector based on annotations.
+	// +optional
+	AnnotationSelectors map[string]string `json:"annotationSelectors,omitempty"`
+
+	// PodPhaseSelectors is a set of condition of a pod at the current time.
+	// supported value: Pending / Running / Succeeded / Failed / Unknown
+	// +optional
+	PodPhaseSelectors []string `json:"podPhaseSelectors,omitempty"`
+
+	// ServiceSelectors is a map of string keys and a set values that used to select the pods behind services.
+	// The key defines the namespace which services belong, and the each values is a set of service names.
+	// +optional
+	ServiceSelectors map[string][]string `json:"serviceSelectors,omitempty"`
+
+	// WorkloadSelectors is a set of workloads, the pods owned by them are selected.
+	// +optional
+	WorkloadSelectors []WorkloadSelector `json:"workloadSelectors,omitempty"`
+}
+
+// IsEmpty returns whether all selectors of the exclude spec are empty
+func (in *PodExcludeSelectorSpec) IsEmpty() bool {
+	return in == nil || reflect.DeepEqual(*in, PodExcludeSelectorSpec{})
+}
+
+// ToPodSelectorSpec converts the exclude spec into a PodSelectorSpec selecting the excluded pods
+func (in *PodExcludeSelectorSpec) ToPodSelectorSpec() PodSelectorSpec {
+	return PodSelectorSpec{
+		Namespaces:          in.Namespaces,
+		Nodes:               in.Nodes,
+		Pods:                in.Pods,
+		NodeSelectors:       in.NodeSelectors,
+		FieldSelectors:      in.FieldSelectors,
+		LabelSelectors:      in.LabelSelectors,
+		ExpressionSelectors: in.ExpressionSelectors,
+		AnnotationSelectors: in.AnnotationSelectors,
+		PodPhaseSelectors:   in.PodPhaseSelectors,
+		ServiceSelectors:    in.ServiceSelectors,
+		WorkloadSelectors:   in.WorkloadSelectors,
+	}
+}
+
+// WorkloadKind represents the kind of the workload owning the pods
+type WorkloadKind string
+
+const (
+	DeploymentWorkload  WorkloadKind = "Deployment"
+	StatefulSetWorkload WorkloadKind = "StatefulSet"
+	DaemonSetWorkload   WorkloadKind = "DaemonSet"
+	JobWorkload         WorkloadKind = "Job"
+	// RolloutWorkload is the Rollout of Argo Rollouts
+	RolloutWorkload WorkloadKind = "Rollout"
+)
+
+// WorkloadSelector selects the pods owned by a workload, through the chain of ownerReferences
+type WorkloadSelector struct {
+	// Kind is the kind of the workload.
+	// Supported kind: Deployment / StatefulSet / DaemonSet / Job / Rollout
+	// +kubebuilder:validation:Enum=Deployment;StatefulSet;DaemonSet;Job;Rollout
+	Kind WorkloadKind `json:"kind"`
+
+	// Namespace is the namespace of the workload. The workloads in all selected namespaces
+	// with the name are matched if it's empty.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name is the name of the workload
+	Name string `json:"name"`
+}
+
+// HasReferences returns whether the pods are selected by the references of services or workloads
+func (in *PodSelectorSpec) HasReferences() bool {
+	return len(in.ServiceSelectors) > 0 || len(in.WorkloadSelectors) > 0
 }
 
+// DefaultNamespace sets the namespaces of the referenced services and workloads, or the namespace
+// of the chaos if there is no reference, as the namespace selectors if they are empty
 func (in *PodSelectorSpec) DefaultNamespace(namespace string) {
-	if len(in.Namespaces) == 0 {
+	if len(in.Namespaces) > 0 {
+		return
+	}
+
+	if !in.HasReferences() {
 		in.Namespaces = []string{namespace}
+		return
 	}
+
+	namespaces := make(map[string]struct{})
+	for ns := range in.ServiceSelectors {
+		namespaces[ns] = struct{}{}
+	}
+	for _, workload := range in.WorkloadSelectors {
+		if len(workload.Namespace) == 0 {
+			namespaces[namespace] = struct{}{}
+		} else {
+			namespaces[workload.Namespace] = struct{}{}
+		}
+	}
+	for ns := range namespaces {
+		in.Namespaces = append(in.Namespaces, ns)
+	}
+	sort.Strings(in.Namespaces)
 }
 
 type PodSelector struct {
@@ -96,8 +257,8 @@ type PodSelector struct {
 	Selector PodSelectorSpec `json:"selector"`
 
 	// Mode defines the mode to run chaos action.
-	// Supported mode: one / all / fixed / fixed-percent / random-max-percent
-	// +kubebuilder:validation:Enum=one;all;fixed;fixed-percent;random-max-percent
+	// Supported mode: one / all / fixed / fixed-percent / random-max-percent / ramp
+	// +kubebuilder:validation:Enum=one;all;fixed;fixed-percent;random-max-percent;ramp
 	Mode PodMode `json:"mode"`
 
 	// Value is required when the mode is set to `FixedPodMode` / `FixedPercentPodMod` / `RandomMaxPercentPodMod`.
@@ -106,8 +267,130 @@ type PodSelector struct {
 	// IF `RandomMaxPercentPodMod`,  provide a number from 0-100 to specify the max percent of pods to do chaos action
 	// +optional
 	Value string `json:"value,omitempty"`
+
+	// ReselectPolicy makes the controller re-evaluate the selector periodically during the experiment.
+	// New pods matching the selector will be injected, and the vanished ones will be marked as gone.
+	// If not set, the targets are selected only once at the beginning of the experiment.
+	// +optional
+	ReselectPolicy *ReselectPolicy `json:"reselectPolicy,omitempty"`
+
+	// GroupBy groups the pods matching the selector before applying the mode.
+	// If it's set, the mode and value are applied within each group, e.g. one pod of every owner,
+	// or all pods in one random zone.
+	// +optional
+	GroupBy *GroupBy `json:"groupBy,omitempty"`
+
+	// RampPolicy is required when the mode is set to `RampPodMode`.
+	// All matching pods are selected, but only a share of them is injected at every stage,
+	// and the share increases every interval until the last step is reached.
+	// +optional
+	RampPolicy *RampPolicy `json:"rampPolicy,omitempty"`
+}
+
+// ReselectPolicy defines how to re-select the targets during an experiment
+type ReselectPolicy struct {
+	// Interval is the interval to re-evaluate the selector, e.g. "30s", "5m"
+	Interval string `json:"interval"`
 }
 
+// GroupByType represents the key to group the pods
+type GroupByType string
+
+const (
+	// GroupByNode groups the pods by the node they are running on
+	GroupByNode GroupByType = "node"
+	// GroupByOwner groups the pods by their controller, e.g. ReplicaSet, StatefulSet or DaemonSet.
+	// Every pod without a controller is a group by itself.
+	GroupByOwner GroupByType = "owner"
+	// GroupByTopology groups the pods by a label of the node they are running on, e.g. the zone.
+	// The pods whose node doesn't have the label are not selected.
+	GroupByTopology GroupByType = "topology"
+)
+
+// GroupMode represents which groups the mode is applied within
+type GroupMode string
+
+const (
+	// AllGroupMode applies the mode within every group
+	AllGroupMode GroupMode = "all"
+	// OneGroupMode applies the mode within one group selected randomly
+	OneGroupMode GroupMode = "one"
+)
+
+// GroupBy defines how to group the pods before applying the mode
+type GroupBy struct {
+	// Type is the key to group the pods.
+	// Supported type: node / owner / topology
+	// +kubebuilder:validation:Enum=node;owner;topology
+	Type GroupByType `json:"type"`
+
+	// TopologyKey is the label of the nodes to group the pods, e.g. "topology.kubernetes.io/zone".
+	// It's required when the type is `topology`.
+	// +optional
+	TopologyKey string `json:"topologyKey,omitempty"`
+
+	// Groups defines which groups the mode is applied within.
+	// Supported mode: all / one, the default is all.
+	// +optional
+	// +kubebuilder:validation:Enum=all;one
+	Groups GroupMode `json:"groups,omitempty"`
+}
+
+// RampPolicy defines how the share of the injected targets increases during an experiment
+type RampPolicy struct {
+	// Steps are the percents of the targets to inject at every stage, e.g. [10, 25, 50]
+	// +kubebuilder:validation:MinItems=1
+	Steps []int32 `json:"steps"`
+
+	// Interval is the duration of every stage, e.g. "2m"
+	Interval string `json:"interval"`
+}
+
+// GetInterval returns the duration of every stage
+func (in *RampPolicy) GetInterval() (time.Duration, error) {
+	return time.ParseDuration(in.Interval)
+}
+
+// Percent returns the percent of the targets to inject at the stage
+func (in *RampPolicy) Percent(stage int32) int32 {
+	if len(in.Steps) == 0 {
+		return 100
+	}
+	if stage < 0 {
+		stage = 0
+	}
+	if int(stage) >= len(in.Steps) {
+		stage = int32(len(in.Steps) - 1)
+	}
+
+	return in.Steps[stage]
+}
+
+// GetRampPolicy returns the ramp policy of the selector.
+// It returns nil if the selector is not in ramp mode.
+func (in *PodSelector) GetRampPolicy() *RampPolicy {
+	if in == nil || in.Mode != RampPodMode {
+		return nil
+	}
+
+	return in.RampPolicy
+}
+
+// GetReselectInterval returns the interval to re-evaluate the selector.
+// It returns zero if the selector shouldn't be re-evaluated.
+func (in *PodSelector) GetReselectInterval() (time.Duration, error) {
+	if in == nil || in.ReselectPolicy == nil {
+		return 0, nil
+	}
+
+	return time.ParseDuration(in.ReselectPolicy.Interval)
+}
+
+// NodeSelector selects the nodes by the nodes and node selectors of the PodSelector,
+// and the mode applies on the nodes
+// +kubebuilder:object:generate=false
+type NodeSelector PodSelector
+
 type ContainerSelector struct {
 	PodSelector `json:",inline"`
 
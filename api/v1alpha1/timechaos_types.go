@@ -50,6 +50,11 @@ type TimeChaosSpec struct {
 
 	// Duration represents the duration of the chaos action
 	Duration *string `json:"duration,omitempty"`
+
+	// AbortConditions are the steady-state checks evaluated while the experiment is running.
+	// The experiment will be stopped and recovered once any of them is breached.
+	// +optional
+	AbortConditions []AbortCondition `json:"abortConditions,omitempty"`
 }
 
 // SetDefaultValue will set default value for empty fields
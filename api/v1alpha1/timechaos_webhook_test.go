@@ -98,6 +98,21 @@ var _ = Describe("timechaos_webhook", func() {
 					},
 					expect: "error",
 				},
+				{
+					name: "dry-run",
+					chaos: TimeChaos{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace:   metav1.NamespaceDefault,
+							Name:        "foo7",
+							Annotations: map[string]string{DryRunAnnotationKey: "true"},
+						},
+						Spec: TimeChaosSpec{TimeOffset: "1s"},
+					},
+					execute: func(chaos *TimeChaos) error {
+						return chaos.ValidateCreate()
+					},
+					expect: "",
+				},
 			}
 
 			for _, tc := range tcs {
@@ -36,6 +36,14 @@ func (in *AwsChaos) IsPaused() bool {
 	return true
 }
 
+// IsDryRun returns whether this resource runs in dry-run mode
+func (in *AwsChaos) IsDryRun() bool {
+	if in.Annotations == nil || in.Annotations[DryRunAnnotationKey] != "true" {
+		return false
+	}
+	return true
+}
+
 // GetObjectMeta would return the ObjectMeta for chaos
 func (in *AwsChaos) GetObjectMeta() *metav1.ObjectMeta {
 	return &in.ObjectMeta
@@ -133,6 +141,11 @@ func (in *AwsChaos) DurationExceeded(now time.Time) (bool, time.Duration, error)
 	return false, 0, nil
 }
 
+// GetAbortConditions returns the abort conditions of the chaos
+func (in *AwsChaos) GetAbortConditions() []AbortCondition {
+	return in.Spec.AbortConditions
+}
+
 func (in *AwsChaos) IsOneShot() bool {
 	
 	if in.Spec.Action==Ec2Restart {
@@ -158,6 +171,14 @@ func (in *DNSChaos) IsPaused() bool {
 	return true
 }
 
+// IsDryRun returns whether this resource runs in dry-run mode
+func (in *DNSChaos) IsDryRun() bool {
+	if in.Annotations == nil || in.Annotations[DryRunAnnotationKey] != "true" {
+		return false
+	}
+	return true
+}
+
 // GetObjectMeta would return the ObjectMeta for chaos
 func (in *DNSChaos) GetObjectMeta() *metav1.ObjectMeta {
 	return &in.ObjectMeta
@@ -255,6 +276,11 @@ func (in *DNSChaos) DurationExceeded(now time.Time) (bool, time.Duration, error)
 	return false, 0, nil
 }
 
+// GetAbortConditions returns the abort conditions of the chaos
+func (in *DNSChaos) GetAbortConditions() []AbortCondition {
+	return in.Spec.AbortConditions
+}
+
 func (in *DNSChaos) IsOneShot() bool {
 	
 	return false
@@ -276,6 +302,14 @@ func (in *GcpChaos) IsPaused() bool {
 	return true
 }
 
+// IsDryRun returns whether this resource runs in dry-run mode
+func (in *GcpChaos) IsDryRun() bool {
+	if in.Annotations == nil || in.Annotations[DryRunAnnotationKey] != "true" {
+		return false
+	}
+	return true
+}
+
 // GetObjectMeta would return the ObjectMeta for chaos
 func (in *GcpChaos) GetObjectMeta() *metav1.ObjectMeta {
 	return &in.ObjectMeta
@@ -373,6 +407,11 @@ func (in *GcpChaos) DurationExceeded(now time.Time) (bool, time.Duration, error)
 	return false, 0, nil
 }
 
+// GetAbortConditions returns the abort conditions of the chaos
+func (in *GcpChaos) GetAbortConditions() []AbortCondition {
+	return in.Spec.AbortConditions
+}
+
 func (in *GcpChaos) IsOneShot() bool {
 	
 	if in.Spec.Action==NodeReset {
@@ -398,6 +437,14 @@ func (in *HTTPChaos) IsPaused() bool {
 	return true
 }
 
+// IsDryRun returns whether this resource runs in dry-run mode
+func (in *HTTPChaos) IsDryRun() bool {
+	if in.Annotations == nil || in.Annotations[DryRunAnnotationKey] != "true" {
+		return false
+	}
+	return true
+}
+
 // GetObjectMeta would return the ObjectMeta for chaos
 func (in *HTTPChaos) GetObjectMeta() *metav1.ObjectMeta {
 	return &in.ObjectMeta
@@ -495,6 +542,11 @@ func (in *HTTPChaos) DurationExceeded(now time.Time) (bool, time.Duration, error
 	return false, 0, nil
 }
 
+// GetAbortConditions returns the abort conditions of the chaos
+func (in *HTTPChaos) GetAbortConditions() []AbortCondition {
+	return in.Spec.AbortConditions
+}
+
 func (in *HTTPChaos) IsOneShot() bool {
 	
 	return false
@@ -516,6 +568,14 @@ func (in *IOChaos) IsPaused() bool {
 	return true
 }
 
+// IsDryRun returns whether this resource runs in dry-run mode
+func (in *IOChaos) IsDryRun() bool {
+	if in.Annotations == nil || in.Annotations[DryRunAnnotationKey] != "true" {
+		return false
+	}
+	return true
+}
+
 // GetObjectMeta would return the ObjectMeta for chaos
 func (in *IOChaos) GetObjectMeta() *metav1.ObjectMeta {
 	return &in.ObjectMeta
@@ -613,6 +673,11 @@ func (in *IOChaos) DurationExceeded(now time.Time) (bool, time.Duration, error)
 	return false, 0, nil
 }
 
+// GetAbortConditions returns the abort conditions of the chaos
+func (in *IOChaos) GetAbortConditions() []AbortCondition {
+	return in.Spec.AbortConditions
+}
+
 func (in *IOChaos) IsOneShot() bool {
 	
 	return false
@@ -634,6 +699,14 @@ func (in *JVMChaos) IsPaused() bool {
 	return true
 }
 
+// IsDryRun returns whether this resource runs in dry-run mode
+func (in *JVMChaos) IsDryRun() bool {
+	if in.Annotations == nil || in.Annotations[DryRunAnnotationKey] != "true" {
+		return false
+	}
+	return true
+}
+
 // GetObjectMeta would return the ObjectMeta for chaos
 func (in *JVMChaos) GetObjectMeta() *metav1.ObjectMeta {
 	return &in.ObjectMeta
@@ -731,6 +804,11 @@ func (in *JVMChaos) DurationExceeded(now time.Time) (bool, time.Duration, error)
 	return false, 0, nil
 }
 
+// GetAbortConditions returns the abort conditions of the chaos
+func (in *JVMChaos) GetAbortConditions() []AbortCondition {
+	return in.Spec.AbortConditions
+}
+
 func (in *JVMChaos) IsOneShot() bool {
 	
 	return false
@@ -752,6 +830,14 @@ func (in *KernelChaos) IsPaused() bool {
 	return true
 }
 
+// IsDryRun returns whether this resource runs in dry-run mode
+func (in *KernelChaos) IsDryRun() bool {
+	if in.Annotations == nil || in.Annotations[DryRunAnnotationKey] != "true" {
+		return false
+	}
+	return true
+}
+
 // GetObjectMeta would return the ObjectMeta for chaos
 func (in *KernelChaos) GetObjectMeta() *metav1.ObjectMeta {
 	return &in.ObjectMeta
@@ -849,6 +935,11 @@ func (in *KernelChaos) DurationExceeded(now time.Time) (bool, time.Duration, err
 	return false, 0, nil
 }
 
+// GetAbortConditions returns the abort conditions of the chaos
+func (in *KernelChaos) GetAbortConditions() []AbortCondition {
+	return in.Spec.AbortConditions
+}
+
 func (in *KernelChaos) IsOneShot() bool {
 	
 	return false
@@ -870,6 +961,14 @@ func (in *NetworkChaos) IsPaused() bool {
 	return true
 }
 
+// IsDryRun returns whether this resource runs in dry-run mode
+func (in *NetworkChaos) IsDryRun() bool {
+	if in.Annotations == nil || in.Annotations[DryRunAnnotationKey] != "true" {
+		return false
+	}
+	return true
+}
+
 // GetObjectMeta would return the ObjectMeta for chaos
 func (in *NetworkChaos) GetObjectMeta() *metav1.ObjectMeta {
 	return &in.ObjectMeta
@@ -967,6 +1066,11 @@ func (in *NetworkChaos) DurationExceeded(now time.Time) (bool, time.Duration, er
 	return false, 0, nil
 }
 
+// GetAbortConditions returns the abort conditions of the chaos
+func (in *NetworkChaos) GetAbortConditions() []AbortCondition {
+	return in.Spec.AbortConditions
+}
+
 func (in *NetworkChaos) IsOneShot() bool {
 	
 	return false
@@ -988,6 +1092,14 @@ func (in *PodChaos) IsPaused() bool {
 	return true
 }
 
+// IsDryRun returns whether this resource runs in dry-run mode
+func (in *PodChaos) IsDryRun() bool {
+	if in.Annotations == nil || in.Annotations[DryRunAnnotationKey] != "true" {
+		return false
+	}
+	return true
+}
+
 // GetObjectMeta would return the ObjectMeta for chaos
 func (in *PodChaos) GetObjectMeta() *metav1.ObjectMeta {
 	return &in.ObjectMeta
@@ -1085,6 +1197,11 @@ func (in *PodChaos) DurationExceeded(now time.Time) (bool, time.Duration, error)
 	return false, 0, nil
 }
 
+// GetAbortConditions returns the abort conditions of the chaos
+func (in *PodChaos) GetAbortConditions() []AbortCondition {
+	return in.Spec.AbortConditions
+}
+
 func (in *PodChaos) IsOneShot() bool {
 	
 	if in.Spec.Action==PodKillAction || in.Spec.Action==ContainerKillAction {
@@ -1110,6 +1227,14 @@ func (in *StressChaos) IsPaused() bool {
 	return true
 }
 
+// IsDryRun returns whether this resource runs in dry-run mode
+func (in *StressChaos) IsDryRun() bool {
+	if in.Annotations == nil || in.Annotations[DryRunAnnotationKey] != "true" {
+		return false
+	}
+	return true
+}
+
 // GetObjectMeta would return the ObjectMeta for chaos
 func (in *StressChaos) GetObjectMeta() *metav1.ObjectMeta {
 	return &in.ObjectMeta
@@ -1207,6 +1332,11 @@ func (in *StressChaos) DurationExceeded(now time.Time) (bool, time.Duration, err
 	return false, 0, nil
 }
 
+// GetAbortConditions returns the abort conditions of the chaos
+func (in *StressChaos) GetAbortConditions() []AbortCondition {
+	return in.Spec.AbortConditions
+}
+
 func (in *StressChaos) IsOneShot() bool {
 	
 	return false
@@ -1228,6 +1358,14 @@ func (in *TimeChaos) IsPaused() bool {
 	return true
 }
 
+// IsDryRun returns whether this resource runs in dry-run mode
+func (in *TimeChaos) IsDryRun() bool {
+	if in.Annotations == nil || in.Annotations[DryRunAnnotationKey] != "true" {
+		return false
+	}
+	return true
+}
+
 // GetObjectMeta would return the ObjectMeta for chaos
 func (in *TimeChaos) GetObjectMeta() *metav1.ObjectMeta {
 	return &in.ObjectMeta
@@ -1325,6 +1463,11 @@ func (in *TimeChaos) DurationExceeded(now time.Time) (bool, time.Duration, error
 	return false, 0, nil
 }
 
+// GetAbortConditions returns the abort conditions of the chaos
+func (in *TimeChaos) GetAbortConditions() []AbortCondition {
+	return in.Spec.AbortConditions
+}
+
 func (in *TimeChaos) IsOneShot() bool {
 	
 	return false
@@ -22,6 +22,62 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AbortCondition) DeepCopyInto(out *AbortCondition) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(string)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Prometheus != nil {
+		in, out := &in.Prometheus, &out.Prometheus
+		*out = new(PrometheusAbortCondition)
+		**out = **in
+	}
+	if in.HTTP != nil {
+		in, out := &in.HTTP, &out.HTTP
+		*out = new(HTTPAbortCondition)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodReadiness != nil {
+		in, out := &in.PodReadiness, &out.PodReadiness
+		*out = new(PodReadinessAbortCondition)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AbortCondition.
+func (in *AbortCondition) DeepCopy() *AbortCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(AbortCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AbortStatus) DeepCopyInto(out *AbortStatus) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AbortStatus.
+func (in *AbortStatus) DeepCopy() *AbortStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AbortStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ActionParameterRules) DeepCopyInto(out *ActionParameterRules) {
 	*out = *in
@@ -189,6 +245,13 @@ func (in *AwsChaosSpec) DeepCopyInto(out *AwsChaosSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.AbortConditions != nil {
+		in, out := &in.AbortConditions, &out.AbortConditions
+		*out = make([]AbortCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.SecretName != nil {
 		in, out := &in.SecretName, &out.SecretName
 		*out = new(string)
@@ -266,6 +329,11 @@ func (in *BandwidthSpec) DeepCopyInto(out *BandwidthSpec) {
 		*out = new(uint32)
 		**out = **in
 	}
+	if in.Profile != nil {
+		in, out := &in.Profile, &out.Profile
+		*out = new(ProfileSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BandwidthSpec.
@@ -340,6 +408,96 @@ func (in *ChaosOnlyScheduleSpec) DeepCopy() *ChaosOnlyScheduleSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChaosPolicy) DeepCopyInto(out *ChaosPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChaosPolicy.
+func (in *ChaosPolicy) DeepCopy() *ChaosPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ChaosPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ChaosPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChaosPolicyList) DeepCopyInto(out *ChaosPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ChaosPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChaosPolicyList.
+func (in *ChaosPolicyList) DeepCopy() *ChaosPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ChaosPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ChaosPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChaosPolicySpec) DeepCopyInto(out *ChaosPolicySpec) {
+	*out = *in
+	if in.MaxPodPercentPerNamespace != nil {
+		in, out := &in.MaxPodPercentPerNamespace, &out.MaxPodPercentPerNamespace
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxConcurrent != nil {
+		in, out := &in.MaxConcurrent, &out.MaxConcurrent
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ProtectedNamespaces != nil {
+		in, out := &in.ProtectedNamespaces, &out.ProtectedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChaosPolicySpec.
+func (in *ChaosPolicySpec) DeepCopy() *ChaosPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ChaosPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ChaosStatus) DeepCopyInto(out *ChaosStatus) {
 	*out = *in
@@ -349,6 +507,18 @@ func (in *ChaosStatus) DeepCopyInto(out *ChaosStatus) {
 		copy(*out, *in)
 	}
 	in.Experiment.DeepCopyInto(&out.Experiment)
+	if in.Abort != nil {
+		in, out := &in.Abort, &out.Abort
+		*out = new(AbortStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Ramp != nil {
+		in, out := &in.Ramp, &out.Ramp
+		*out = make([]RampStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChaosStatus.
@@ -479,6 +649,26 @@ func (in *DNSChaos) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSChaosActionParams) DeepCopyInto(out *DNSChaosActionParams) {
+	*out = *in
+	if in.Records != nil {
+		in, out := &in.Records, &out.Records
+		*out = new(DNSStaticRecords)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSChaosActionParams.
+func (in *DNSChaosActionParams) DeepCopy() *DNSChaosActionParams {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSChaosActionParams)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNSChaosList) DeepCopyInto(out *DNSChaosList) {
 	*out = *in
@@ -511,6 +701,27 @@ func (in *DNSChaosList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSChaosRule) DeepCopyInto(out *DNSChaosRule) {
+	*out = *in
+	if in.Patterns != nil {
+		in, out := &in.Patterns, &out.Patterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.DNSChaosActionParams.DeepCopyInto(&out.DNSChaosActionParams)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSChaosRule.
+func (in *DNSChaosRule) DeepCopy() *DNSChaosRule {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSChaosRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNSChaosSpec) DeepCopyInto(out *DNSChaosSpec) {
 	*out = *in
@@ -520,11 +731,26 @@ func (in *DNSChaosSpec) DeepCopyInto(out *DNSChaosSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.AbortConditions != nil {
+		in, out := &in.AbortConditions, &out.AbortConditions
+		*out = make([]AbortCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.DomainNamePatterns != nil {
 		in, out := &in.DomainNamePatterns, &out.DomainNamePatterns
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	in.DNSChaosActionParams.DeepCopyInto(&out.DNSChaosActionParams)
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]DNSChaosRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSChaosSpec.
@@ -553,6 +779,31 @@ func (in *DNSChaosStatus) DeepCopy() *DNSChaosStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSStaticRecords) DeepCopyInto(out *DNSStaticRecords) {
+	*out = *in
+	if in.A != nil {
+		in, out := &in.A, &out.A
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AAAA != nil {
+		in, out := &in.AAAA, &out.AAAA
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSStaticRecords.
+func (in *DNSStaticRecords) DeepCopy() *DNSStaticRecords {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSStaticRecords)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DelaySpec) DeepCopyInto(out *DelaySpec) {
 	*out = *in
@@ -561,6 +812,11 @@ func (in *DelaySpec) DeepCopyInto(out *DelaySpec) {
 		*out = new(ReorderSpec)
 		**out = **in
 	}
+	if in.Profile != nil {
+		in, out := &in.Profile, &out.Profile
+		*out = new(ProfileSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DelaySpec.
@@ -668,7 +924,7 @@ func (in *ExperimentStatus) DeepCopyInto(out *ExperimentStatus) {
 			if (*in)[i] != nil {
 				in, out := &(*in)[i], &(*out)[i]
 				*out = new(Record)
-				**out = **in
+				(*in).DeepCopyInto(*out)
 			}
 		}
 	}
@@ -811,6 +1067,13 @@ func (in *GcpChaosSpec) DeepCopyInto(out *GcpChaosSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.AbortConditions != nil {
+		in, out := &in.AbortConditions, &out.AbortConditions
+		*out = make([]AbortCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.SecretName != nil {
 		in, out := &in.SecretName, &out.SecretName
 		*out = new(string)
@@ -874,6 +1137,46 @@ func (in *GcpSelector) DeepCopy() *GcpSelector {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupBy) DeepCopyInto(out *GroupBy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupBy.
+func (in *GroupBy) DeepCopy() *GroupBy {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupBy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPAbortCondition) DeepCopyInto(out *HTTPAbortCondition) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(string)
+		**out = **in
+	}
+	if in.ExpectedStatusCodes != nil {
+		in, out := &in.ExpectedStatusCodes, &out.ExpectedStatusCodes
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPAbortCondition.
+func (in *HTTPAbortCondition) DeepCopy() *HTTPAbortCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPAbortCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPChaos) DeepCopyInto(out *HTTPChaos) {
 	*out = *in
@@ -972,6 +1275,13 @@ func (in *HTTPChaosSpec) DeepCopyInto(out *HTTPChaosSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.AbortConditions != nil {
+		in, out := &in.AbortConditions, &out.AbortConditions
+		*out = make([]AbortCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPChaosSpec.
@@ -1121,6 +1431,13 @@ func (in *IOChaosSpec) DeepCopyInto(out *IOChaosSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.AbortConditions != nil {
+		in, out := &in.AbortConditions, &out.AbortConditions
+		*out = make([]AbortCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IOChaosSpec.
@@ -1239,6 +1556,13 @@ func (in *JVMChaosSpec) DeepCopyInto(out *JVMChaosSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.AbortConditions != nil {
+		in, out := &in.AbortConditions, &out.AbortConditions
+		*out = make([]AbortCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.JVMParameter.DeepCopyInto(&out.JVMParameter)
 }
 
@@ -1366,6 +1690,13 @@ func (in *KernelChaosSpec) DeepCopyInto(out *KernelChaosSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.AbortConditions != nil {
+		in, out := &in.AbortConditions, &out.AbortConditions
+		*out = make([]AbortCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KernelChaosSpec.
@@ -1418,6 +1749,11 @@ func (in LabelSelectorRequirements) DeepCopy() LabelSelectorRequirements {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LossSpec) DeepCopyInto(out *LossSpec) {
 	*out = *in
+	if in.Profile != nil {
+		in, out := &in.Profile, &out.Profile
+		*out = new(ProfileSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LossSpec.
@@ -1534,6 +1870,13 @@ func (in *NetworkChaosSpec) DeepCopyInto(out *NetworkChaosSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.AbortConditions != nil {
+		in, out := &in.AbortConditions, &out.AbortConditions
+		*out = make([]AbortCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.TcParameter.DeepCopyInto(&out.TcParameter)
 	if in.Target != nil {
 		in, out := &in.Target, &out.Target
@@ -1545,6 +1888,17 @@ func (in *NetworkChaosSpec) DeepCopyInto(out *NetworkChaosSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ResolvePolicy != nil {
+		in, out := &in.ResolvePolicy, &out.ResolvePolicy
+		*out = new(ResolvePolicy)
+		**out = **in
+	}
+	if in.PacketFault != nil {
+		in, out := &in.PacketFault, &out.PacketFault
+		*out = new(PacketFaultSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	out.PacketFilter = in.PacketFilter
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkChaosSpec.
@@ -1568,6 +1922,13 @@ func (in *NetworkChaosStatus) DeepCopyInto(out *NetworkChaosStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.ResolvedTargets != nil {
+		in, out := &in.ResolvedTargets, &out.ResolvedTargets
+		*out = make([]ResolvedTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkChaosStatus.
@@ -1581,22 +1942,7 @@ func (in *NetworkChaosStatus) DeepCopy() *NetworkChaosStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ParameterRules) DeepCopyInto(out *ParameterRules) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParameterRules.
-func (in *ParameterRules) DeepCopy() *ParameterRules {
-	if in == nil {
-		return nil
-	}
-	out := new(ParameterRules)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodChaos) DeepCopyInto(out *PodChaos) {
+func (in *NodeNetworkChaos) DeepCopyInto(out *NodeNetworkChaos) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -1604,18 +1950,18 @@ func (in *PodChaos) DeepCopyInto(out *PodChaos) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodChaos.
-func (in *PodChaos) DeepCopy() *PodChaos {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeNetworkChaos.
+func (in *NodeNetworkChaos) DeepCopy() *NodeNetworkChaos {
 	if in == nil {
 		return nil
 	}
-	out := new(PodChaos)
+	out := new(NodeNetworkChaos)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PodChaos) DeepCopyObject() runtime.Object {
+func (in *NodeNetworkChaos) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1623,7 +1969,131 @@ func (in *PodChaos) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodChaosList) DeepCopyInto(out *PodChaosList) {
+func (in *NodeNetworkChaosList) DeepCopyInto(out *NodeNetworkChaosList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NodeNetworkChaos, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeNetworkChaosList.
+func (in *NodeNetworkChaosList) DeepCopy() *NodeNetworkChaosList {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeNetworkChaosList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeNetworkChaosList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PacketCorruptSpec) DeepCopyInto(out *PacketCorruptSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacketCorruptSpec.
+func (in *PacketCorruptSpec) DeepCopy() *PacketCorruptSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PacketCorruptSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PacketFaultSpec) DeepCopyInto(out *PacketFaultSpec) {
+	*out = *in
+	if in.Corrupt != nil {
+		in, out := &in.Corrupt, &out.Corrupt
+		*out = new(PacketCorruptSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacketFaultSpec.
+func (in *PacketFaultSpec) DeepCopy() *PacketFaultSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PacketFaultSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PacketFilter) DeepCopyInto(out *PacketFilter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PacketFilter.
+func (in *PacketFilter) DeepCopy() *PacketFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(PacketFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParameterRules) DeepCopyInto(out *ParameterRules) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParameterRules.
+func (in *ParameterRules) DeepCopy() *ParameterRules {
+	if in == nil {
+		return nil
+	}
+	out := new(ParameterRules)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodChaos) DeepCopyInto(out *PodChaos) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodChaos.
+func (in *PodChaos) DeepCopy() *PodChaos {
+	if in == nil {
+		return nil
+	}
+	out := new(PodChaos)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PodChaos) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodChaosList) DeepCopyInto(out *PodChaosList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
@@ -1663,6 +2133,13 @@ func (in *PodChaosSpec) DeepCopyInto(out *PodChaosSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.AbortConditions != nil {
+		in, out := &in.AbortConditions, &out.AbortConditions
+		*out = make([]AbortCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodChaosSpec.
@@ -1691,6 +2168,106 @@ func (in *PodChaosStatus) DeepCopy() *PodChaosStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodExcludeSelectorSpec) DeepCopyInto(out *PodExcludeSelectorSpec) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.NodeSelectors != nil {
+		in, out := &in.NodeSelectors, &out.NodeSelectors
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.FieldSelectors != nil {
+		in, out := &in.FieldSelectors, &out.FieldSelectors
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LabelSelectors != nil {
+		in, out := &in.LabelSelectors, &out.LabelSelectors
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ExpressionSelectors != nil {
+		in, out := &in.ExpressionSelectors, &out.ExpressionSelectors
+		*out = make(LabelSelectorRequirements, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AnnotationSelectors != nil {
+		in, out := &in.AnnotationSelectors, &out.AnnotationSelectors
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodPhaseSelectors != nil {
+		in, out := &in.PodPhaseSelectors, &out.PodPhaseSelectors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceSelectors != nil {
+		in, out := &in.ServiceSelectors, &out.ServiceSelectors
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.WorkloadSelectors != nil {
+		in, out := &in.WorkloadSelectors, &out.WorkloadSelectors
+		*out = make([]WorkloadSelector, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodExcludeSelectorSpec.
+func (in *PodExcludeSelectorSpec) DeepCopy() *PodExcludeSelectorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PodExcludeSelectorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodHttpChaos) DeepCopyInto(out *PodHttpChaos) {
 	*out = *in
@@ -2117,7 +2694,7 @@ func (in *PodNetworkChaos) DeepCopyInto(out *PodNetworkChaos) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodNetworkChaos.
@@ -2194,6 +2771,13 @@ func (in *PodNetworkChaosSpec) DeepCopyInto(out *PodNetworkChaosSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PacketFaults != nil {
+		in, out := &in.PacketFaults, &out.PacketFaults
+		*out = make([]RawPacketFault, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodNetworkChaosSpec.
@@ -2209,6 +2793,11 @@ func (in *PodNetworkChaosSpec) DeepCopy() *PodNetworkChaosSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodNetworkChaosStatus) DeepCopyInto(out *PodNetworkChaosStatus) {
 	*out = *in
+	if in.Devices != nil {
+		in, out := &in.Devices, &out.Devices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodNetworkChaosStatus.
@@ -2221,10 +2810,47 @@ func (in *PodNetworkChaosStatus) DeepCopy() *PodNetworkChaosStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodReadinessAbortCondition) DeepCopyInto(out *PodReadinessAbortCondition) {
+	*out = *in
+	if in.LabelSelectors != nil {
+		in, out := &in.LabelSelectors, &out.LabelSelectors
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodReadinessAbortCondition.
+func (in *PodReadinessAbortCondition) DeepCopy() *PodReadinessAbortCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(PodReadinessAbortCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodSelector) DeepCopyInto(out *PodSelector) {
 	*out = *in
 	in.Selector.DeepCopyInto(&out.Selector)
+	if in.ReselectPolicy != nil {
+		in, out := &in.ReselectPolicy, &out.ReselectPolicy
+		*out = new(ReselectPolicy)
+		**out = **in
+	}
+	if in.GroupBy != nil {
+		in, out := &in.GroupBy, &out.GroupBy
+		*out = new(GroupBy)
+		**out = **in
+	}
+	if in.RampPolicy != nil {
+		in, out := &in.RampPolicy, &out.RampPolicy
+		*out = new(RampPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSelector.
@@ -2305,6 +2931,31 @@ func (in *PodSelectorSpec) DeepCopyInto(out *PodSelectorSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ServiceSelectors != nil {
+		in, out := &in.ServiceSelectors, &out.ServiceSelectors
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.WorkloadSelectors != nil {
+		in, out := &in.WorkloadSelectors, &out.WorkloadSelectors
+		*out = make([]WorkloadSelector, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = new(PodExcludeSelectorSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSelectorSpec.
@@ -2317,6 +2968,100 @@ func (in *PodSelectorSpec) DeepCopy() *PodSelectorSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProfileSpec) DeepCopyInto(out *ProfileSpec) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]ProfileStep, len(*in))
+		copy(*out, *in)
+	}
+	if in.Waveform != nil {
+		in, out := &in.Waveform, &out.Waveform
+		*out = new(WaveformSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProfileSpec.
+func (in *ProfileSpec) DeepCopy() *ProfileSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProfileSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProfileStep) DeepCopyInto(out *ProfileStep) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProfileStep.
+func (in *ProfileStep) DeepCopy() *ProfileStep {
+	if in == nil {
+		return nil
+	}
+	out := new(ProfileStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusAbortCondition) DeepCopyInto(out *PrometheusAbortCondition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusAbortCondition.
+func (in *PrometheusAbortCondition) DeepCopy() *PrometheusAbortCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusAbortCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RampPolicy) DeepCopyInto(out *RampPolicy) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RampPolicy.
+func (in *RampPolicy) DeepCopy() *RampPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RampPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RampStatus) DeepCopyInto(out *RampStatus) {
+	*out = *in
+	if in.NextStageTime != nil {
+		in, out := &in.NextStageTime, &out.NextStageTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RampStatus.
+func (in *RampStatus) DeepCopy() *RampStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RampStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RawIPSet) DeepCopyInto(out *RawIPSet) {
 	*out = *in
@@ -2346,6 +3091,7 @@ func (in *RawIptables) DeepCopyInto(out *RawIptables) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	out.PacketFilter = in.PacketFilter
 	out.RawRuleSource = in.RawRuleSource
 }
 
@@ -2359,6 +3105,23 @@ func (in *RawIptables) DeepCopy() *RawIptables {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RawPacketFault) DeepCopyInto(out *RawPacketFault) {
+	*out = *in
+	in.PacketFaultSpec.DeepCopyInto(&out.PacketFaultSpec)
+	out.PacketFilter = in.PacketFilter
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RawPacketFault.
+func (in *RawPacketFault) DeepCopy() *RawPacketFault {
+	if in == nil {
+		return nil
+	}
+	out := new(RawPacketFault)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RawRuleSource) DeepCopyInto(out *RawRuleSource) {
 	*out = *in
@@ -2378,6 +3141,7 @@ func (in *RawRuleSource) DeepCopy() *RawRuleSource {
 func (in *RawTrafficControl) DeepCopyInto(out *RawTrafficControl) {
 	*out = *in
 	in.TcParameter.DeepCopyInto(&out.TcParameter)
+	out.PacketFilter = in.PacketFilter
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RawTrafficControl.
@@ -2393,6 +3157,19 @@ func (in *RawTrafficControl) DeepCopy() *RawTrafficControl {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Record) DeepCopyInto(out *Record) {
 	*out = *in
+	if in.Commands != nil {
+		in, out := &in.Commands, &out.Commands
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.InjectedAt != nil {
+		in, out := &in.InjectedAt, &out.InjectedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.RecoveredAt != nil {
+		in, out := &in.RecoveredAt, &out.RecoveredAt
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Record.
@@ -2420,6 +3197,56 @@ func (in *ReorderSpec) DeepCopy() *ReorderSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReselectPolicy) DeepCopyInto(out *ReselectPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReselectPolicy.
+func (in *ReselectPolicy) DeepCopy() *ReselectPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ReselectPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResolvePolicy) DeepCopyInto(out *ResolvePolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolvePolicy.
+func (in *ResolvePolicy) DeepCopy() *ResolvePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ResolvePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResolvedTarget) DeepCopyInto(out *ResolvedTarget) {
+	*out = *in
+	if in.Cidrs != nil {
+		in, out := &in.Cidrs, &out.Cidrs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolvedTarget.
+func (in *ResolvedTarget) DeepCopy() *ResolvedTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(ResolvedTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Schedule) DeepCopyInto(out *Schedule) {
 	*out = *in
@@ -2615,6 +3442,13 @@ func (in *StressChaosSpec) DeepCopyInto(out *StressChaosSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.AbortConditions != nil {
+		in, out := &in.AbortConditions, &out.AbortConditions
+		*out = make([]AbortCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StressChaosSpec.
@@ -2747,7 +3581,7 @@ func (in *TcParameter) DeepCopyInto(out *TcParameter) {
 	if in.Loss != nil {
 		in, out := &in.Loss, &out.Loss
 		*out = new(LossSpec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Duplicate != nil {
 		in, out := &in.Duplicate, &out.Duplicate
@@ -2894,6 +3728,13 @@ func (in *TimeChaosSpec) DeepCopyInto(out *TimeChaosSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.AbortConditions != nil {
+		in, out := &in.AbortConditions, &out.AbortConditions
+		*out = make([]AbortCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TimeChaosSpec.
@@ -2937,6 +3778,21 @@ func (in *Timespec) DeepCopy() *Timespec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WaveformSpec) DeepCopyInto(out *WaveformSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WaveformSpec.
+func (in *WaveformSpec) DeepCopy() *WaveformSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WaveformSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Workflow) DeepCopyInto(out *Workflow) {
 	*out = *in
@@ -3233,3 +4089,18 @@ func (in *WorkflowStatus) DeepCopy() *WorkflowStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadSelector) DeepCopyInto(out *WorkloadSelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadSelector.
+func (in *WorkloadSelector) DeepCopy() *WorkloadSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadSelector)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -31,6 +31,7 @@ import (
 var alwaysAllowedKind = []string{
 	v1alpha1.KindAwsChaos,
 	v1alpha1.KindPodNetworkChaos,
+	v1alpha1.KindNodeNetworkChaos,
 	v1alpha1.KindPodIOChaos,
 	v1alpha1.KindGcpChaos,
 	v1alpha1.KindPodHttpChaos,
@@ -106,6 +107,12 @@ func (v *AuthValidator) Handle(ctx context.Context, req admission.Request) admis
 	affectedNamespaces := make(map[string]struct{})
 
 	for _, spec := range specs {
+		// the host network of the nodes is shared by all namespaces
+		if _, ok := spec.(*v1alpha1.NodeSelector); ok {
+			requireClusterPrivileges = true
+			continue
+		}
+
 		var selector *v1alpha1.PodSelector
 		if s, ok := spec.(*v1alpha1.ContainerSelector); ok {
 			selector = &s.PodSelector
@@ -126,6 +133,14 @@ func (v *AuthValidator) Handle(ctx context.Context, req admission.Request) admis
 		}
 	}
 
+	// the pods checked by the abort conditions are read with the identity of the controller manager,
+	// so the user should have the privileges on their namespaces as well
+	for _, condition := range chaos.GetAbortConditions() {
+		if condition.PodReadiness != nil {
+			affectedNamespaces[condition.PodReadiness.GetNamespace(req.Namespace)] = struct{}{}
+		}
+	}
+
 	if requireClusterPrivileges {
 		allow, err := v.auth(username, groups, "", requestKind)
 		if err != nil {
@@ -0,0 +1,394 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/controllers/common"
+	"github.com/chaos-mesh/chaos-mesh/pkg/selector/pod"
+)
+
+var policyLog = ctrl.Log.WithName("validate-policy")
+
+// +kubebuilder:webhook:path=/validate-policy,mutating=false,failurePolicy=fail,groups=chaos-mesh.org,resources=*,verbs=create;update,versions=v1alpha1,name=vpolicy.kb.io
+
+// PolicyValidator rejects the experiments which exceed the limits of any ChaosPolicy, or specify any protected pod
+type PolicyValidator struct {
+	client client.Client
+	reader client.Reader
+
+	decoder *admission.Decoder
+
+	clusterScoped         bool
+	targetNamespace       string
+	enableFilterNamespace bool
+}
+
+// NewPolicyValidator returns a new PolicyValidator
+func NewPolicyValidator(client client.Client, reader client.Reader,
+	clusterScoped bool, targetNamespace string, enableFilterNamespace bool) *PolicyValidator {
+	return &PolicyValidator{
+		client:                client,
+		reader:                reader,
+		clusterScoped:         clusterScoped,
+		targetNamespace:       targetNamespace,
+		enableFilterNamespace: enableFilterNamespace,
+	}
+}
+
+// Handle resolves the targets of the experiment and checks them against all policies
+func (v *PolicyValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	requestKind := req.Kind.Kind
+	kind, ok := v1alpha1.AllKinds()[requestKind]
+	if !ok {
+		return admission.Allowed(fmt.Sprintf("skip the policy check for type %s", requestKind))
+	}
+	chaos, ok := kind.Chaos.DeepCopyObject().(common.InnerObjectWithSelector)
+	if !ok {
+		return admission.Allowed(fmt.Sprintf("skip the policy check for type %s", requestKind))
+	}
+
+	if err := v.decoder.Decode(req, chaos); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	// resuming a paused experiment makes it affect the cluster again, even if its status hasn't been updated
+	resumed := false
+	var old common.InnerObjectWithSelector
+	if req.Operation == admissionv1beta1.Update {
+		old = kind.Chaos.DeepCopyObject().(common.InnerObjectWithSelector)
+		if err := v.decoder.DecodeRaw(req.OldObject, old); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		resumed = old.IsPaused() && !chaos.IsPaused()
+	}
+
+	// the limits only matter for a running experiment, and a dry-run experiment doesn't affect the cluster
+	if chaos.IsDeleted() || chaos.IsDryRun() || chaos.IsPaused() ||
+		(chaos.GetStatus().Experiment.DesiredPhase == v1alpha1.StoppedPhase && !resumed) {
+		return admission.Allowed("")
+	}
+
+	// the controllers update the status frequently, only the changes of the spec are checked. Turning off the
+	// dry-run mode or resuming the experiment makes it affect the cluster.
+	if old != nil && reflect.DeepEqual(specOf(old), specOf(chaos)) && old.IsDryRun() == chaos.IsDryRun() && !resumed {
+		return admission.Allowed("")
+	}
+
+	self := types.NamespacedName{Namespace: req.Namespace, Name: req.Name}
+	if reason, err := v.checkProtected(ctx, chaos); err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	} else if len(reason) > 0 {
+		policyLog.Info("experiment selects protected pods", "chaos", self, "reason", reason)
+		return admission.Denied(reason)
+	}
+
+	var policies v1alpha1.ChaosPolicyList
+	if err := v.reader.List(ctx, &policies); err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	if len(policies.Items) == 0 {
+		return admission.Allowed("")
+	}
+
+	targets, err := v.resolveTargets(ctx, chaos)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	running, err := v.listRunning(ctx, self, requestKind)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	for i := range policies.Items {
+		policy := &policies.Items[i]
+		if reason, err := v.check(ctx, policy, requestKind, targets, running); err != nil {
+			return admission.Errored(http.StatusInternalServerError, err)
+		} else if len(reason) > 0 {
+			policyLog.Info("experiment is rejected by policy", "policy", policy.Name, "chaos", self, "reason", reason)
+			return admission.Denied(fmt.Sprintf("rejected by ChaosPolicy %s: %s", policy.Name, reason))
+		}
+	}
+
+	return admission.Allowed("")
+}
+
+// InjectDecoder injects the decoder.
+func (v *PolicyValidator) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+	return nil
+}
+
+// policyTargets are the pods which could be affected by the experiment
+type policyTargets struct {
+	// selections are the worst cases of the selectors
+	selections []policySelection
+	// allModeNamespaces are the namespaces in which all pods are selected, with the mode selecting them
+	allModeNamespaces map[string]v1alpha1.PodMode
+}
+
+// policySelection is the worst case of a selector. Some modes choose the pods randomly, so any of the candidates
+// could be selected, up to the max count.
+type policySelection struct {
+	candidates []types.NamespacedName
+	max        int
+}
+
+// newPods returns the max count of the pods in every namespace which could be selected by the experiment, and are
+// not under chaos yet
+func (t *policyTargets) newPods(running map[types.NamespacedName]struct{}) map[string]int {
+	selected := make(map[string]int)
+	candidates := make(map[string]map[types.NamespacedName]struct{})
+	for _, selection := range t.selections {
+		counts := make(map[string]int)
+		for _, p := range selection.candidates {
+			if _, ok := running[p]; ok {
+				continue
+			}
+			counts[p.Namespace]++
+
+			if candidates[p.Namespace] == nil {
+				candidates[p.Namespace] = make(map[types.NamespacedName]struct{})
+			}
+			candidates[p.Namespace][p] = struct{}{}
+		}
+
+		for namespace, count := range counts {
+			if count > selection.max {
+				count = selection.max
+			}
+			selected[namespace] += count
+		}
+	}
+
+	for namespace, count := range selected {
+		// the pods selected by different selectors may be the same
+		if count > len(candidates[namespace]) {
+			selected[namespace] = len(candidates[namespace])
+		}
+	}
+	return selected
+}
+
+// runningExperiments summarizes the experiments already running in the cluster
+type runningExperiments struct {
+	// count is the count of the running experiments of the same kind
+	count int
+	// pods is the set of the pods under chaos
+	pods map[types.NamespacedName]struct{}
+}
+
+func (v *PolicyValidator) resolveTargets(ctx context.Context, chaos common.InnerObjectWithSelector) (*policyTargets, error) {
+	targets := &policyTargets{
+		allModeNamespaces: make(map[string]v1alpha1.PodMode),
+	}
+
+	for _, spec := range chaos.GetSelectorSpecs() {
+		selector := podSelectorOf(spec)
+		if selector == nil {
+			continue
+		}
+
+		pods, max, err := pod.SelectCandidates(ctx, v.client, v.reader, selector, v.clusterScoped, v.targetNamespace, v.enableFilterNamespace)
+		if err != nil {
+			return nil, err
+		}
+		// an experiment selecting nothing doesn't affect the cluster
+		if len(pods) == 0 || max == 0 {
+			continue
+		}
+
+		selection := policySelection{max: max}
+		selectsAll := selectsAllPods(selector)
+		for _, p := range pods {
+			selection.candidates = append(selection.candidates, types.NamespacedName{Namespace: p.Namespace, Name: p.Name})
+			if selectsAll {
+				targets.allModeNamespaces[p.Namespace] = selector.Mode
+			}
+		}
+		targets.selections = append(targets.selections, selection)
+		if selectsAll {
+			for _, namespace := range selector.Selector.Namespaces {
+				targets.allModeNamespaces[namespace] = selector.Mode
+			}
+		}
+	}
+
+	return targets, nil
+}
+
+func (v *PolicyValidator) listRunning(ctx context.Context, self types.NamespacedName, requestKind string) (*runningExperiments, error) {
+	running := &runningExperiments{
+		pods: make(map[types.NamespacedName]struct{}),
+	}
+
+	for name, kind := range v1alpha1.AllKinds() {
+		list := kind.ChaosList.DeepCopyObject()
+		if err := v.client.List(ctx, list); err != nil {
+			return nil, err
+		}
+
+		items := reflect.ValueOf(list).Elem().FieldByName("Items")
+		for i := 0; i < items.Len(); i++ {
+			obj, ok := items.Index(i).Addr().Interface().(common.InnerObjectWithSelector)
+			if !ok {
+				continue
+			}
+			meta := obj.GetObjectMeta()
+			if name == requestKind && meta.Namespace == self.Namespace && meta.Name == self.Name {
+				continue
+			}
+			if obj.IsDeleted() || obj.IsDryRun() || obj.GetStatus().Experiment.DesiredPhase == v1alpha1.StoppedPhase {
+				continue
+			}
+
+			if name == requestKind {
+				running.count++
+			}
+
+			specs := obj.GetSelectorSpecs()
+			for _, record := range obj.GetStatus().Experiment.Records {
+				if podSelectorOf(specs[record.SelectorKey]) == nil {
+					continue
+				}
+				if record.Phase == v1alpha1.Gone || record.Phase == v1alpha1.Failed || record.Phase == v1alpha1.WouldInject {
+					continue
+				}
+
+				parts := strings.Split(record.Id, "/")
+				if len(parts) < 2 {
+					continue
+				}
+				running.pods[types.NamespacedName{Namespace: parts[0], Name: parts[1]}] = struct{}{}
+			}
+		}
+	}
+
+	return running, nil
+}
+
+// checkProtected returns the reason if the experiment specifies any protected pod explicitly. The
+// protected pods matching the other selectors are skipped silently by the selector.
+func (v *PolicyValidator) checkProtected(ctx context.Context, chaos common.InnerObjectWithSelector) (string, error) {
+	for _, spec := range chaos.GetSelectorSpecs() {
+		selector := podSelectorOf(spec)
+		if selector == nil {
+			continue
+		}
+
+		for namespace, names := range selector.Selector.Pods {
+			for _, name := range names {
+				var p v1.Pod
+				if err := v.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &p); err != nil {
+					if apierrors.IsNotFound(err) {
+						continue
+					}
+					return "", err
+				}
+				if pod.IsProtectedPod(&p) {
+					return fmt.Sprintf("pod %s/%s is protected by annotation %s", namespace, name, v1alpha1.ProtectedAnnotationKey), nil
+				}
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// selectsAllPods returns whether all pods matching the selector will be injected, including the ramp reaching 100%
+func selectsAllPods(selector *v1alpha1.PodSelector) bool {
+	// only the pods in one of the groups are selected
+	if selector.GroupBy != nil && selector.GroupBy.Groups == v1alpha1.OneGroupMode {
+		return false
+	}
+	if selector.Mode == v1alpha1.AllPodMode {
+		return true
+	}
+	policy := selector.GetRampPolicy()
+	return policy != nil && len(policy.Steps) > 0 && policy.Percent(int32(len(policy.Steps)-1)) >= 100
+}
+
+// check returns the reason if the experiment exceeds the limits of the policy
+func (v *PolicyValidator) check(ctx context.Context, policy *v1alpha1.ChaosPolicy, requestKind string,
+	targets *policyTargets, running *runningExperiments) (string, error) {
+	for _, namespace := range policy.Spec.ProtectedNamespaces {
+		if mode, ok := targets.allModeNamespaces[namespace]; ok {
+			return fmt.Sprintf("mode %s is forbidden in the protected namespace %s", mode, namespace), nil
+		}
+	}
+
+	if max, ok := policy.Spec.MaxConcurrent[requestKind]; ok && running.count+1 > int(max) {
+		return fmt.Sprintf("%d %s are running, and at most %d are allowed", running.count, requestKind, max), nil
+	}
+
+	if policy.Spec.MaxPodPercentPerNamespace == nil {
+		return "", nil
+	}
+
+	affected := targets.newPods(running.pods)
+	if len(affected) == 0 {
+		return "", nil
+	}
+	for p := range running.pods {
+		if _, ok := affected[p.Namespace]; ok {
+			affected[p.Namespace]++
+		}
+	}
+
+	for namespace, count := range affected {
+		var pods v1.PodList
+		if err := v.client.List(ctx, &pods, client.InNamespace(namespace)); err != nil {
+			return "", err
+		}
+		if len(pods.Items) == 0 {
+			continue
+		}
+
+		max := int(*policy.Spec.MaxPodPercentPerNamespace)
+		if count*100 > max*len(pods.Items) {
+			return fmt.Sprintf("up to %d of %d pods in namespace %s would be under chaos, and at most %d%% are allowed",
+				count, len(pods.Items), namespace, max), nil
+		}
+	}
+
+	return "", nil
+}
+
+func specOf(obj interface{}) interface{} {
+	return reflect.ValueOf(obj).Elem().FieldByName("Spec").Interface()
+}
+
+func podSelectorOf(spec interface{}) *v1alpha1.PodSelector {
+	switch s := spec.(type) {
+	case *v1alpha1.PodSelector:
+		return s
+	case *v1alpha1.ContainerSelector:
+		return &s.PodSelector
+	}
+
+	return nil
+}
@@ -0,0 +1,221 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	. "github.com/chaos-mesh/chaos-mesh/pkg/testutils"
+)
+
+func TestCheckPolicy(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	objects, pods := GenerateNPods("p", 10, PodArg{Namespace: "staging"})
+	v := NewPolicyValidator(fake.NewFakeClient(objects...), nil, true, "", false)
+
+	key := func(i int) types.NamespacedName {
+		return types.NamespacedName{Namespace: pods[i].Namespace, Name: pods[i].Name}
+	}
+	maxPercent := int32(30)
+	policy := &v1alpha1.ChaosPolicy{
+		Spec: v1alpha1.ChaosPolicySpec{
+			MaxPodPercentPerNamespace: &maxPercent,
+			MaxConcurrent:             map[string]int32{v1alpha1.KindNetworkChaos: 1},
+			ProtectedNamespaces:       []string{"kube-system"},
+		},
+	}
+
+	type TestCase struct {
+		name     string
+		kind     string
+		targets  *policyTargets
+		running  *runningExperiments
+		rejected bool
+	}
+
+	selectionOf := func(max int, indexes ...int) []policySelection {
+		selection := policySelection{max: max}
+		for _, i := range indexes {
+			selection.candidates = append(selection.candidates, key(i))
+		}
+		return []policySelection{selection}
+	}
+
+	tcs := []TestCase{
+		{
+			name: "within the limits",
+			kind: v1alpha1.KindPodChaos,
+			targets: &policyTargets{
+				selections: selectionOf(2, 0, 1),
+			},
+			running: &runningExperiments{
+				pods: map[types.NamespacedName]struct{}{key(1): {}},
+			},
+			rejected: false,
+		},
+		{
+			name: "exceed the percent of pods counting the running experiments",
+			kind: v1alpha1.KindPodChaos,
+			targets: &policyTargets{
+				selections: selectionOf(2, 0, 1),
+			},
+			running: &runningExperiments{
+				pods: map[types.NamespacedName]struct{}{key(2): {}, key(3): {}},
+			},
+			rejected: true,
+		},
+		{
+			name: "select a few of many candidates",
+			kind: v1alpha1.KindPodChaos,
+			targets: &policyTargets{
+				selections: selectionOf(3, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9),
+			},
+			running:  &runningExperiments{},
+			rejected: false,
+		},
+		{
+			name: "exceed the percent of pods in the worst case",
+			kind: v1alpha1.KindPodChaos,
+			targets: &policyTargets{
+				selections: selectionOf(4, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9),
+			},
+			running:  &runningExperiments{},
+			rejected: true,
+		},
+		{
+			name: "exceed the concurrent experiments",
+			kind: v1alpha1.KindNetworkChaos,
+			targets: &policyTargets{
+				selections: selectionOf(1, 0),
+			},
+			running: &runningExperiments{
+				count: 1,
+			},
+			rejected: true,
+		},
+		{
+			name: "select all pods in protected namespace",
+			kind: v1alpha1.KindPodChaos,
+			targets: &policyTargets{
+				allModeNamespaces: map[string]v1alpha1.PodMode{"kube-system": v1alpha1.AllPodMode},
+			},
+			running:  &runningExperiments{},
+			rejected: true,
+		},
+	}
+
+	for _, tc := range tcs {
+		reason, err := v.check(context.Background(), policy, tc.kind, tc.targets, tc.running)
+		g.Expect(err).ShouldNot(HaveOccurred(), tc.name)
+		g.Expect(len(reason) > 0).To(Equal(tc.rejected), tc.name)
+	}
+}
+
+func TestResolveTargets(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	objects, _ := GenerateNPods("p", 10, PodArg{Namespace: "staging"})
+	v := NewPolicyValidator(fake.NewFakeClient(objects...), nil, true, "", false)
+
+	chaosOf := func(mode v1alpha1.PodMode, value string) *v1alpha1.PodChaos {
+		return &v1alpha1.PodChaos{
+			Spec: v1alpha1.PodChaosSpec{
+				Action: v1alpha1.PodFailureAction,
+				ContainerSelector: v1alpha1.ContainerSelector{
+					PodSelector: v1alpha1.PodSelector{
+						Selector: v1alpha1.PodSelectorSpec{Namespaces: []string{"staging"}},
+						Mode:     mode,
+						Value:    value,
+					},
+				},
+			},
+		}
+	}
+
+	// the random modes are evaluated by the max count they could select, rather than a random choice
+	targets, err := v.resolveTargets(context.Background(), chaosOf(v1alpha1.RandomMaxPercentPodMode, "50"))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(targets.selections).To(HaveLen(1))
+	g.Expect(targets.selections[0].candidates).To(HaveLen(10))
+	g.Expect(targets.selections[0].max).To(Equal(5))
+	g.Expect(targets.newPods(nil)).To(Equal(map[string]int{"staging": 5}))
+
+	targets, err = v.resolveTargets(context.Background(), chaosOf(v1alpha1.OnePodMode, ""))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(targets.newPods(nil)).To(Equal(map[string]int{"staging": 1}))
+
+	targets, err = v.resolveTargets(context.Background(), chaosOf(v1alpha1.FixedPodMode, "20"))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(targets.newPods(nil)).To(Equal(map[string]int{"staging": 10}))
+}
+
+func TestSelectsAllPods(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(selectsAllPods(&v1alpha1.PodSelector{Mode: v1alpha1.AllPodMode})).To(BeTrue())
+	g.Expect(selectsAllPods(&v1alpha1.PodSelector{Mode: v1alpha1.OnePodMode})).To(BeFalse())
+	g.Expect(selectsAllPods(&v1alpha1.PodSelector{
+		Mode:    v1alpha1.AllPodMode,
+		GroupBy: &v1alpha1.GroupBy{Type: v1alpha1.GroupByNode, Groups: v1alpha1.OneGroupMode},
+	})).To(BeFalse())
+	g.Expect(selectsAllPods(&v1alpha1.PodSelector{
+		Mode:       v1alpha1.RampPodMode,
+		RampPolicy: &v1alpha1.RampPolicy{Steps: []int32{10, 100}, Interval: "2m"},
+	})).To(BeTrue())
+	g.Expect(selectsAllPods(&v1alpha1.PodSelector{
+		Mode:       v1alpha1.RampPodMode,
+		RampPolicy: &v1alpha1.RampPolicy{Steps: []int32{10, 50}, Interval: "2m"},
+	})).To(BeFalse())
+}
+
+func TestCheckProtected(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	objects, _ := GenerateNPods("p", 2, PodArg{})
+	protected := NewPod(PodArg{Name: "leader", Ans: map[string]string{v1alpha1.ProtectedAnnotationKey: "true"}})
+	objects = append(objects, &protected)
+	v := NewPolicyValidator(fake.NewFakeClient(objects...), nil, true, "", false)
+
+	chaosOf := func(names ...string) *v1alpha1.PodChaos {
+		return &v1alpha1.PodChaos{
+			Spec: v1alpha1.PodChaosSpec{
+				Action: v1alpha1.PodFailureAction,
+				ContainerSelector: v1alpha1.ContainerSelector{
+					PodSelector: v1alpha1.PodSelector{
+						Selector: v1alpha1.PodSelectorSpec{
+							Pods: map[string][]string{metav1.NamespaceDefault: names},
+						},
+						Mode: v1alpha1.AllPodMode,
+					},
+				},
+			},
+		}
+	}
+
+	reason, err := v.checkProtected(context.Background(), chaosOf("p0", "p1", "not-found"))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(reason).To(BeEmpty())
+
+	reason, err = v.checkProtected(context.Background(), chaosOf("p0", "leader"))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(reason).To(ContainSubstring("default/leader"))
+}
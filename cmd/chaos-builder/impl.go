@@ -44,6 +44,14 @@ func (in *{{.Type}}) IsPaused() bool {
 	return true
 }
 
+// IsDryRun returns whether this resource runs in dry-run mode
+func (in *{{.Type}}) IsDryRun() bool {
+	if in.Annotations == nil || in.Annotations[DryRunAnnotationKey] != "true" {
+		return false
+	}
+	return true
+}
+
 // GetObjectMeta would return the ObjectMeta for chaos
 func (in *{{.Type}}) GetObjectMeta() *metav1.ObjectMeta {
 	return &in.ObjectMeta
@@ -141,6 +149,11 @@ func (in *{{.Type}}) DurationExceeded(now time.Time) (bool, time.Duration, error
 	return false, 0, nil
 }
 
+// GetAbortConditions returns the abort conditions of the chaos
+func (in *{{.Type}}) GetAbortConditions() []AbortCondition {
+	return in.Spec.AbortConditions
+}
+
 func (in *{{.Type}}) IsOneShot() bool {
 	{{if .OneShotExp}}
 	if {{.OneShotExp}} {
@@ -27,6 +27,7 @@ import (
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	controllermetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -83,6 +84,8 @@ type RunParams struct {
 	fx.In
 
 	Mgr     ctrl.Manager
+	Client  client.Client
+	Reader  client.Reader `name:"no-cache"`
 	Logger  logr.Logger
 	AuthCli *authorizationv1.AuthorizationV1Client
 
@@ -162,6 +165,11 @@ func Run(params RunParams) error {
 			ccfg.ControllerCfg.ClusterScoped, ccfg.ControllerCfg.TargetNamespace, ccfg.ControllerCfg.EnableFilterNamespace),
 	},
 	)
+	hookServer.Register("/validate-policy", &webhook.Admission{
+		Handler: apiWebhook.NewPolicyValidator(params.Client, params.Reader,
+			ccfg.ControllerCfg.ClusterScoped, ccfg.ControllerCfg.TargetNamespace, ccfg.ControllerCfg.EnableFilterNamespace),
+	},
+	)
 
 	setupLog.Info("Starting manager")
 	if err := mgr.Start(stopCh); err != nil {
@@ -42,6 +42,9 @@ func init() {
 	flag.StringVar(&conf.Cert, "cert", "", "certificate of grpc server")
 	flag.StringVar(&conf.Key, "key", "", "key of grpc server")
 	flag.BoolVar(&conf.Profiling, "pprof", false, "enable pprof")
+	flag.IntVar(&conf.KubeletPort, "kubelet-port", 10250, "the port of kubelet, which is protected from the network chaos on the host network")
+	flag.StringVar(&conf.Firewall, "firewall", chaosdaemon.AutoFirewall, "the backend to set network rules, which is auto, iptables or nftables. iptables opts out of the detection of the node")
+	flag.StringVar(&conf.StateDir, "state-dir", "/var/run/chaos-daemon", "the directory on the host to keep the leases of the faults and the rules of dns chaos across restarts")
 
 	flag.Parse()
 }
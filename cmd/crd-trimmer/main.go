@@ -0,0 +1,150 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// crd-trimmer keeps the CRDs of the workflows and schedules under the size limit of etcd. They embed the specs
+// of every kind of chaos, so the full schemas of the pod selectors and abort conditions would be repeated dozens
+// of times in them. The trimmer replaces these nested schemas with objects preserving unknown fields, and the
+// embedded specs are still validated by the webhooks of the chaos.
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+var (
+	log = zap.New(zap.UseDevMode(true))
+
+	// embeddingCRDs are the CRDs embedding the specs of chaos
+	embeddingCRDs = map[string]struct{}{
+		"schedules.chaos-mesh.org":     {},
+		"workflows.chaos-mesh.org":     {},
+		"workflownodes.chaos-mesh.org": {},
+	}
+
+	crdNamePattern = regexp.MustCompile(`^  name: (\S+)$`)
+	// trimmedFieldPattern matches the properties whose schemas are trimmed
+	trimmedFieldPattern = regexp.MustCompile(`^( *)(selector|abortConditions):$`)
+)
+
+func main() {
+	for _, path := range os.Args[1:] {
+		log := log.WithValues("file", path)
+
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			log.Error(err, "fail to read file")
+			os.Exit(1)
+		}
+
+		trimmed := trim(string(content))
+		if trimmed == string(content) {
+			continue
+		}
+
+		err = ioutil.WriteFile(path, []byte(trimmed), 0644)
+		if err != nil {
+			log.Error(err, "fail to write file")
+			os.Exit(1)
+		}
+		log.Info("trimmed")
+	}
+}
+
+// trim replaces the schemas of the trimmed properties in the embedding CRDs of the yaml documents
+func trim(content string) string {
+	lines := strings.Split(content, "\n")
+
+	out := make([]string, 0, len(lines))
+	crd := ""
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		out = append(out, line)
+
+		if line == "---" {
+			crd = ""
+			continue
+		}
+		if matches := crdNamePattern.FindStringSubmatch(line); matches != nil {
+			crd = matches[1]
+			continue
+		}
+		if _, ok := embeddingCRDs[crd]; !ok {
+			continue
+		}
+
+		matches := trimmedFieldPattern.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+		end := blockEnd(lines, i+1, len(matches[1]))
+		out = append(out, preserveUnknownFields(lines[i+1:end], len(matches[1])+2)...)
+		i = end - 1
+	}
+
+	return strings.Join(out, "\n")
+}
+
+// preserveUnknownFields converts the schema of a property into an object, or an array of objects, preserving
+// unknown fields. The description of the property is kept.
+func preserveUnknownFields(schema []string, indent int) []string {
+	prefix := strings.Repeat(" ", indent)
+
+	var description []string
+	isArray := false
+	for i := 0; i < len(schema); i++ {
+		switch {
+		case strings.HasPrefix(schema[i], prefix+"description:"):
+			end := blockEnd(schema, i+1, indent)
+			description = schema[i:end]
+			i = end - 1
+		case schema[i] == prefix+"type: array":
+			isArray = true
+		}
+	}
+
+	out := append([]string{}, description...)
+	if isArray {
+		return append(out,
+			prefix+"items:",
+			prefix+"  type: object",
+			prefix+"  x-kubernetes-preserve-unknown-fields: true",
+			prefix+"type: array",
+		)
+	}
+	return append(out,
+		prefix+"type: object",
+		prefix+"x-kubernetes-preserve-unknown-fields: true",
+	)
+}
+
+// blockEnd returns the index of the line following the last one from start, which is indented deeper than the
+// indent before any line isn't
+func blockEnd(lines []string, start int, indent int) int {
+	end := start
+	for i := start; i < len(lines); i++ {
+		line := strings.TrimLeft(lines[i], " ")
+		if len(line) == 0 {
+			continue
+		}
+		if len(lines[i])-len(line) <= indent {
+			break
+		}
+		end = i + 1
+	}
+	return end
+}
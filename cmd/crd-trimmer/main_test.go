@@ -0,0 +1,105 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import "testing"
+
+func Test_trim(t *testing.T) {
+	schema := `  names:
+    kind: Workflow
+spec:
+  properties:
+    abortConditions:
+      description: AbortConditions are the steady-state checks
+      items:
+        properties:
+          name:
+            type: string
+        type: object
+      type: array
+    duration:
+      type: string
+    selector:
+      description: Selector is used to select pods that are used to inject
+        chaos action.
+      properties:
+        namespaces:
+          items:
+            type: string
+          type: array
+      type: object
+`
+	trimmedSchema := `  names:
+    kind: Workflow
+spec:
+  properties:
+    abortConditions:
+      description: AbortConditions are the steady-state checks
+      items:
+        type: object
+        x-kubernetes-preserve-unknown-fields: true
+      type: array
+    duration:
+      type: string
+    selector:
+      description: Selector is used to select pods that are used to inject
+        chaos action.
+      type: object
+      x-kubernetes-preserve-unknown-fields: true
+`
+
+	type args struct {
+		content string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "embedding",
+			args: args{
+				content: "---\nmetadata:\n  name: workflows.chaos-mesh.org\n" + schema,
+			},
+			want: "---\nmetadata:\n  name: workflows.chaos-mesh.org\n" + trimmedSchema,
+		}, {
+			name: "trimmed",
+			args: args{
+				content: "---\nmetadata:\n  name: workflows.chaos-mesh.org\n" + trimmedSchema,
+			},
+			want: "---\nmetadata:\n  name: workflows.chaos-mesh.org\n" + trimmedSchema,
+		}, {
+			name: "chaos",
+			args: args{
+				content: "---\nmetadata:\n  name: podchaos.chaos-mesh.org\n" + schema,
+			},
+			want: "---\nmetadata:\n  name: podchaos.chaos-mesh.org\n" + schema,
+		}, {
+			name: "multiple documents",
+			args: args{
+				content: "---\nmetadata:\n  name: schedules.chaos-mesh.org\n" + schema +
+					"---\nmetadata:\n  name: podchaos.chaos-mesh.org\n" + schema,
+			},
+			want: "---\nmetadata:\n  name: schedules.chaos-mesh.org\n" + trimmedSchema +
+				"---\nmetadata:\n  name: podchaos.chaos-mesh.org\n" + schema,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := trim(tt.args.content); got != tt.want {
+				t.Errorf("trim() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
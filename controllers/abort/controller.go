@@ -0,0 +1,162 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package abort
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sTypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/controller"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
+)
+
+// Reconciler evaluates the abort conditions of a running chaos
+type Reconciler struct {
+	// Object is used to mark the target type of this Reconciler
+	Object v1alpha1.InnerObject
+
+	// Client is used to operate on the Kubernetes cluster
+	client.Client
+
+	Prober *Prober
+	// Tracker remembers the last evaluation and the consecutive breaches of every abort condition
+	Tracker *controller.Tracker
+
+	Recorder recorder.ChaosRecorder
+	Log      logr.Logger
+}
+
+// Reconcile the abort conditions
+func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	obj := r.Object.DeepCopyObject().(v1alpha1.InnerObject)
+
+	if err := r.Client.Get(context.TODO(), req.NamespacedName, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.Log.Info("chaos not found")
+			r.Tracker.Forget(req.NamespacedName)
+		} else {
+			// TODO: handle this error
+			r.Log.Error(err, "unable to get chaos")
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// a oneshot chaos has nothing to recover, so it's meaningless to abort it
+	conditions := obj.GetAbortConditions()
+	if len(conditions) == 0 || obj.IsDeleted() || obj.IsOneShot() || obj.GetStatus().IsAborted() {
+		r.Tracker.Forget(req.NamespacedName)
+		return ctrl.Result{}, nil
+	}
+
+	// the conditions are only evaluated during the running phase, a paused experiment will not be aborted
+	if obj.GetStatus().Experiment.DesiredPhase != v1alpha1.RunningPhase {
+		r.Tracker.Forget(req.NamespacedName)
+		return ctrl.Result{}, nil
+	}
+
+	var requeueAfter time.Duration
+	for i := range conditions {
+		condition := &conditions[i]
+
+		interval, err := condition.GetInterval()
+		if err != nil {
+			r.Log.Error(err, "fail to parse interval of abort condition", "condition", condition.Name)
+			continue
+		}
+
+		now := time.Now()
+		due, wait := r.Tracker.Due(req.NamespacedName, condition.Name, interval, now)
+		if !due {
+			requeueAfter = controller.ShorterRequeue(requeueAfter, wait)
+			continue
+		}
+		r.Tracker.Done(req.NamespacedName, condition.Name, now)
+		requeueAfter = controller.ShorterRequeue(requeueAfter, interval)
+
+		breached, detail, err := r.Prober.Probe(context.TODO(), req.Namespace, condition)
+		if err != nil {
+			r.Log.Error(err, "fail to evaluate abort condition", "condition", condition.Name)
+			r.Recorder.Event(obj, recorder.Failed{
+				Activity: fmt.Sprintf("evaluate abort condition %s", condition.Name),
+				Err:      err.Error(),
+			})
+			continue
+		}
+		if !breached {
+			r.Tracker.ResetCount(req.NamespacedName, condition.Name)
+			continue
+		}
+
+		breaches := r.Tracker.Increase(req.NamespacedName, condition.Name)
+		r.Log.Info("abort condition is breached", "condition", condition.Name, "detail", detail, "breaches", breaches)
+		r.Recorder.Event(obj, recorder.AbortConditionBreached{
+			Name:   condition.Name,
+			Detail: detail,
+		})
+		if breaches < condition.GetFailureThreshold() {
+			continue
+		}
+
+		return r.abort(req.NamespacedName, obj, condition.Name, detail)
+	}
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// abort marks the chaos as aborted, then the desiredphase controller will stop it
+func (r *Reconciler) abort(name k8sTypes.NamespacedName, obj v1alpha1.InnerObject, conditionName string, detail string) (ctrl.Result, error) {
+	updateError := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		obj := r.Object.DeepCopyObject().(v1alpha1.InnerObject)
+
+		if err := r.Client.Get(context.TODO(), name, obj); err != nil {
+			r.Log.Error(err, "unable to get chaos")
+			return err
+		}
+
+		if obj.GetStatus().IsAborted() {
+			return nil
+		}
+
+		obj.GetStatus().Abort = &v1alpha1.AbortStatus{
+			Condition: conditionName,
+			Reason:    detail,
+			Time:      metav1.Now(),
+		}
+		return r.Client.Update(context.TODO(), obj)
+	})
+	if updateError != nil {
+		r.Log.Error(updateError, "fail to update")
+		r.Recorder.Event(obj, recorder.Failed{
+			Activity: "abort experiment",
+			Err:      updateError.Error(),
+		})
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	r.Tracker.Forget(name)
+	r.Recorder.Event(obj, recorder.Aborted{
+		Name: conditionName,
+	})
+	return ctrl.Result{}, nil
+}
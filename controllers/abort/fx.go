@@ -0,0 +1,69 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package abort
+
+import (
+	"github.com/go-logr/logr"
+	"go.uber.org/fx"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/chaos-mesh/chaos-mesh/controllers/config"
+	"github.com/chaos-mesh/chaos-mesh/controllers/types"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/builder"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/controller"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
+)
+
+type Params struct {
+	fx.In
+
+	Mgr             ctrl.Manager
+	Client          client.Client
+	Logger          logr.Logger
+	RecorderBuilder *recorder.RecorderBuilder
+	Objs            []types.Object `group:"objs"`
+	Reader          client.Reader  `name:"no-cache"`
+}
+
+func NewController(params Params) (types.Controller, error) {
+	prober := &Prober{
+		Client:                params.Client,
+		Reader:                params.Reader,
+		PrometheusAddress:     config.ControllerCfg.PrometheusAddress,
+		HTTPAllowedHosts:      config.ControllerCfg.AbortHTTPAllowedHosts,
+		ClusterScoped:         config.ControllerCfg.ClusterScoped,
+		TargetNamespace:       config.ControllerCfg.TargetNamespace,
+		EnableFilterNamespace: config.ControllerCfg.EnableFilterNamespace,
+	}
+
+	for _, obj := range params.Objs {
+		err := builder.Default(params.Mgr).
+			For(obj.Object).
+			Named(obj.Name + "-abort").
+			Complete(&Reconciler{
+				Object:   obj.Object,
+				Client:   params.Client,
+				Prober:   prober,
+				Tracker:  controller.NewTracker(),
+				Recorder: params.RecorderBuilder.Build("abort"),
+				Log:      params.Logger.WithName("abort"),
+			})
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return "abort", nil
+}
@@ -0,0 +1,286 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package abort
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/pkg/selector/pod"
+)
+
+const prometheusQueryTimeout = 10 * time.Second
+
+// errRedirectNotAllowed is returned by the http conditions redirected to a host not allowed, it means the
+// condition can't be evaluated rather than a breach
+var errRedirectNotAllowed = errors.New("redirection is not allowed")
+
+// Prober evaluates the abort conditions
+type Prober struct {
+	client.Client
+	Reader client.Reader
+
+	HTTPClient *http.Client
+
+	// PrometheusAddress is the address of the prometheus server queried by the prometheus conditions
+	PrometheusAddress string
+
+	// HTTPAllowedHosts are the hosts which the http conditions are allowed to request, in the format of
+	// "<host>" or "<host>:<port>". A host without port matches all ports.
+	HTTPAllowedHosts []string
+
+	ClusterScoped         bool
+	TargetNamespace       string
+	EnableFilterNamespace bool
+}
+
+// Probe evaluates the condition. It returns whether the condition is breached and the detail of the breach.
+// An error is returned if the condition cannot be evaluated, which should not be regarded as a breach.
+func (p *Prober) Probe(ctx context.Context, namespace string, condition *v1alpha1.AbortCondition) (bool, string, error) {
+	switch {
+	case condition.Prometheus != nil:
+		return p.probePrometheus(ctx, condition.Prometheus)
+	case condition.HTTP != nil:
+		return p.probeHTTP(ctx, condition.HTTP)
+	case condition.PodReadiness != nil:
+		return p.probePodReadiness(ctx, namespace, condition.PodReadiness)
+	}
+
+	return false, "", errors.Errorf("abort condition %s has nothing to check", condition.Name)
+}
+
+type prometheusResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+type prometheusSample struct {
+	Value []interface{} `json:"value"`
+}
+
+func (p *Prober) probePrometheus(ctx context.Context, condition *v1alpha1.PrometheusAbortCondition) (bool, string, error) {
+	threshold, err := strconv.ParseFloat(condition.Threshold, 64)
+	if err != nil {
+		return false, "", errors.Wrap(err, "parse threshold")
+	}
+
+	if len(p.PrometheusAddress) == 0 {
+		return false, "", errors.New("the address of prometheus is not configured")
+	}
+
+	value, err := p.queryPrometheus(ctx, p.PrometheusAddress, condition.Query)
+	if err != nil {
+		return false, "", err
+	}
+
+	if condition.Operator.Compare(value, threshold) {
+		return true, fmt.Sprintf("%s returns %v, which is %s %s", condition.Query, value, condition.Operator, condition.Threshold), nil
+	}
+
+	return false, "", nil
+}
+
+// queryPrometheus runs an instant query and returns the result as a float number
+func (p *Prober) queryPrometheus(ctx context.Context, address string, query string) (float64, error) {
+	ctx, cancel := context.WithTimeout(ctx, prometheusQueryTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		strings.TrimSuffix(address, "/")+"/api/v1/query?query="+url.QueryEscape(query), nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := p.httpClient().Do(req)
+	if err != nil {
+		return 0, errors.Wrap(err, "query prometheus")
+	}
+	defer resp.Body.Close()
+
+	var body prometheusResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return 0, errors.Wrapf(err, "decode response of prometheus with status code %d", resp.StatusCode)
+	}
+	if body.Status != "success" {
+		return 0, errors.Errorf("prometheus returns %s: %s", body.Status, body.Error)
+	}
+
+	var sample []interface{}
+	switch body.Data.ResultType {
+	case "scalar":
+		if err := json.Unmarshal(body.Data.Result, &sample); err != nil {
+			return 0, errors.Wrap(err, "decode scalar result")
+		}
+	case "vector":
+		var vector []prometheusSample
+		if err := json.Unmarshal(body.Data.Result, &vector); err != nil {
+			return 0, errors.Wrap(err, "decode vector result")
+		}
+		if len(vector) != 1 {
+			return 0, errors.Errorf("query should return exactly one element, but got %d", len(vector))
+		}
+		sample = vector[0].Value
+	default:
+		return 0, errors.Errorf("result type %s is not supported", body.Data.ResultType)
+	}
+
+	// a sample is in the format of [ <unix_time>, "<value>" ]
+	if len(sample) != 2 {
+		return 0, errors.Errorf("unexpected sample %v", sample)
+	}
+	value, ok := sample[1].(string)
+	if !ok {
+		return 0, errors.Errorf("unexpected sample %v", sample)
+	}
+
+	return strconv.ParseFloat(value, 64)
+}
+
+func (p *Prober) probeHTTP(ctx context.Context, condition *v1alpha1.HTTPAbortCondition) (bool, string, error) {
+	timeout, err := condition.GetTimeout()
+	if err != nil {
+		return false, "", errors.Wrap(err, "parse timeout")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	method := condition.Method
+	if len(method) == 0 {
+		method = http.MethodGet
+	}
+	req, err := http.NewRequestWithContext(ctx, method, condition.URL, nil)
+	if err != nil {
+		return false, "", err
+	}
+	if !p.isHTTPAllowed(req.URL) {
+		return false, "", errors.Errorf("host of %s is not allowed", condition.URL)
+	}
+
+	// the failure of the request is exactly what the condition is watching for
+	resp, err := p.probeHTTPClient().Do(req)
+	if errors.Is(err, errRedirectNotAllowed) {
+		return false, "", err
+	}
+	if err != nil {
+		return true, fmt.Sprintf("request to %s failed: %s", condition.URL, err), nil
+	}
+	resp.Body.Close()
+
+	if len(condition.ExpectedStatusCodes) == 0 {
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return false, "", nil
+		}
+	} else {
+		for _, code := range condition.ExpectedStatusCodes {
+			if int(code) == resp.StatusCode {
+				return false, "", nil
+			}
+		}
+	}
+
+	return true, fmt.Sprintf("%s responds with unexpected status code %d", condition.URL, resp.StatusCode), nil
+}
+
+func (p *Prober) probePodReadiness(ctx context.Context, namespace string, condition *v1alpha1.PodReadinessAbortCondition) (bool, string, error) {
+	selector := v1alpha1.PodSelectorSpec{
+		Namespaces:     []string{condition.GetNamespace(namespace)},
+		LabelSelectors: condition.LabelSelectors,
+	}
+
+	// the protected pods are observed as well, as they are never affected by the chaos but may be by its effects
+	pods, err := pod.ListPods(ctx, p.Client, p.Reader, selector, p.ClusterScoped, p.TargetNamespace, p.EnableFilterNamespace)
+	if err != nil {
+		return false, "", errors.Wrap(err, "select pods")
+	}
+
+	// a typo in the selector shouldn't abort the experiment
+	if len(pods) == 0 {
+		return false, "", errors.New("no pod is selected")
+	}
+
+	ready := 0
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		for _, c := range pod.Status.Conditions {
+			if c.Type == v1.PodReady && c.Status == v1.ConditionTrue {
+				ready++
+				break
+			}
+		}
+	}
+
+	percent := ready * 100 / len(pods)
+	if percent < int(condition.MinReadyPercent) {
+		return true, fmt.Sprintf("%d of %d pods are ready, which is less than %d%%", ready, len(pods), condition.MinReadyPercent), nil
+	}
+
+	return false, "", nil
+}
+
+// isHTTPAllowed returns whether the url can be requested by the http conditions
+func (p *Prober) isHTTPAllowed(u *url.URL) bool {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return false
+	}
+
+	for _, host := range p.HTTPAllowedHosts {
+		if host == u.Host || host == u.Hostname() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// probeHTTPClient returns a client which doesn't follow the redirections to the hosts not allowed
+func (p *Prober) probeHTTPClient() *http.Client {
+	client := *p.httpClient()
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if !p.isHTTPAllowed(req.URL) {
+			return errors.Wrapf(errRedirectNotAllowed, "redirect to %s", req.URL)
+		}
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return nil
+	}
+
+	return &client
+}
+
+func (p *Prober) httpClient() *http.Client {
+	if p.HTTPClient != nil {
+		return p.HTTPClient
+	}
+
+	return http.DefaultClient
+}
@@ -0,0 +1,179 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package abort
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	. "github.com/chaos-mesh/chaos-mesh/pkg/testutils"
+)
+
+func TestProbePrometheus(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("query") {
+		case "vector":
+			w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1620000000,"0.1"]}]}}`))
+		case "scalar":
+			w.Write([]byte(`{"status":"success","data":{"resultType":"scalar","result":[1620000000,"3"]}}`))
+		case "empty":
+			w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[]}}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"status":"error","error":"parse error"}`))
+		}
+	}))
+	defer server.Close()
+
+	p := &Prober{PrometheusAddress: server.URL}
+
+	breached, _, err := p.Probe(context.Background(), "default", &v1alpha1.AbortCondition{
+		Prometheus: &v1alpha1.PrometheusAbortCondition{Query: "vector", Operator: v1alpha1.GreaterThanOperator, Threshold: "0.05"},
+	})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(breached).To(BeTrue())
+
+	breached, _, err = p.Probe(context.Background(), "default", &v1alpha1.AbortCondition{
+		Prometheus: &v1alpha1.PrometheusAbortCondition{Query: "scalar", Operator: v1alpha1.LessThanOperator, Threshold: "2"},
+	})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(breached).To(BeFalse())
+
+	_, _, err = p.Probe(context.Background(), "default", &v1alpha1.AbortCondition{
+		Prometheus: &v1alpha1.PrometheusAbortCondition{Query: "empty", Operator: v1alpha1.LessThanOperator, Threshold: "2"},
+	})
+	g.Expect(err).Should(HaveOccurred())
+
+	_, _, err = p.Probe(context.Background(), "default", &v1alpha1.AbortCondition{
+		Prometheus: &v1alpha1.PrometheusAbortCondition{Query: "invalid", Operator: v1alpha1.LessThanOperator, Threshold: "2"},
+	})
+	g.Expect(err).Should(HaveOccurred())
+}
+
+func TestProbeHTTP(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/healthz" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		if r.URL.Path == "/redirect" {
+			http.Redirect(w, r, "http://not-allowed.local/healthz", http.StatusFound)
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	p := &Prober{HTTPAllowedHosts: []string{serverURL.Host}}
+
+	breached, _, err := p.Probe(context.Background(), "default", &v1alpha1.AbortCondition{
+		HTTP: &v1alpha1.HTTPAbortCondition{URL: server.URL + "/healthz"},
+	})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(breached).To(BeFalse())
+
+	breached, _, err = p.Probe(context.Background(), "default", &v1alpha1.AbortCondition{
+		HTTP: &v1alpha1.HTTPAbortCondition{URL: server.URL + "/unavailable"},
+	})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(breached).To(BeTrue())
+
+	breached, _, err = p.Probe(context.Background(), "default", &v1alpha1.AbortCondition{
+		HTTP: &v1alpha1.HTTPAbortCondition{URL: server.URL + "/unavailable", ExpectedStatusCodes: []int32{http.StatusServiceUnavailable}},
+	})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(breached).To(BeFalse())
+
+	// the redirection to a host not allowed can't be evaluated, which is not a breach
+	breached, _, err = p.Probe(context.Background(), "default", &v1alpha1.AbortCondition{
+		HTTP: &v1alpha1.HTTPAbortCondition{URL: server.URL + "/redirect"},
+	})
+	g.Expect(err).Should(HaveOccurred())
+	g.Expect(breached).To(BeFalse())
+
+	// the hosts not allowed are never requested
+	p.HTTPAllowedHosts = []string{serverURL.Hostname() + ":1"}
+	breached, _, err = p.Probe(context.Background(), "default", &v1alpha1.AbortCondition{
+		HTTP: &v1alpha1.HTTPAbortCondition{URL: server.URL + "/unavailable"},
+	})
+	g.Expect(err).Should(HaveOccurred())
+	g.Expect(breached).To(BeFalse())
+
+	p.HTTPAllowedHosts = []string{serverURL.Hostname()}
+	breached, _, err = p.Probe(context.Background(), "default", &v1alpha1.AbortCondition{
+		HTTP: &v1alpha1.HTTPAbortCondition{URL: server.URL + "/healthz"},
+	})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(breached).To(BeFalse())
+}
+
+func TestProbePodReadiness(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	readyCondition := v1.PodCondition{Type: v1.PodReady, Status: v1.ConditionTrue}
+
+	var objects []runtime.Object
+	for i, name := range []string{"p0", "p1", "p2", "p3"} {
+		pod := NewPod(PodArg{Name: name, Labels: map[string]string{"app": "test"}})
+		if i < 3 {
+			pod.Status.Conditions = []v1.PodCondition{readyCondition}
+		} else {
+			// the protected pod is checked as well
+			pod.Annotations = map[string]string{v1alpha1.ProtectedAnnotationKey: "true"}
+		}
+		objects = append(objects, &pod)
+	}
+
+	p := &Prober{
+		Client:        fake.NewFakeClient(objects...),
+		ClusterScoped: true,
+	}
+
+	condition := &v1alpha1.AbortCondition{
+		PodReadiness: &v1alpha1.PodReadinessAbortCondition{
+			LabelSelectors:  map[string]string{"app": "test"},
+			MinReadyPercent: 75,
+		},
+	}
+	breached, _, err := p.Probe(context.Background(), "default", condition)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(breached).To(BeFalse())
+
+	condition.PodReadiness.MinReadyPercent = 80
+	breached, _, err = p.Probe(context.Background(), "default", condition)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(breached).To(BeTrue())
+
+	// no pod is selected, so the condition can't be evaluated
+	condition.PodReadiness.LabelSelectors = map[string]string{"app": "typo"}
+	breached, _, err = p.Probe(context.Background(), "default", condition)
+	g.Expect(err).Should(HaveOccurred())
+	g.Expect(breached).To(BeFalse())
+}
@@ -28,33 +28,42 @@ type Delegate struct {
 }
 
 func (i *Delegate) callAccordingToAction(action, methodName string, defaultPhase v1alpha1.Phase, args ...interface{}) (v1alpha1.Phase, error) {
-	implType := reflect.TypeOf(i.impl).Elem()
-	implVal := reflect.ValueOf(i.impl)
+	impl, ok := i.implOf(action)
+	if !ok {
+		return defaultPhase, errors.Errorf("unknown action %s", action)
+	}
 
 	reflectArgs := []reflect.Value{}
 	for _, arg := range args {
 		reflectArgs = append(reflectArgs, reflect.ValueOf(arg))
 	}
+	rets := impl.MethodByName(methodName).Call(reflectArgs)
+
+	// nil.(error) will panic :(
+	err := rets[1].Interface()
+	if err == nil {
+		return rets[0].Interface().(v1alpha1.Phase), nil
+	}
+
+	return rets[0].Interface().(v1alpha1.Phase), err.(error)
+}
+
+func (i *Delegate) implOf(action string) (reflect.Value, bool) {
+	implType := reflect.TypeOf(i.impl).Elem()
+	implVal := reflect.ValueOf(i.impl)
+
 	for i := 0; i < implType.NumField(); i++ {
 		field := implType.Field(i)
 
 		actions := strings.Split(field.Tag.Get("action"), ",")
 		for i := range actions {
 			if actions[i] == action {
-				rets := implVal.Elem().FieldByIndex(field.Index).MethodByName(methodName).Call(reflectArgs)
-
-				// nil.(error) will panic :(
-				err := rets[1].Interface()
-				if err == nil {
-					return rets[0].Interface().(v1alpha1.Phase), nil
-				}
-
-				return rets[0].Interface().(v1alpha1.Phase), err.(error)
+				return implVal.Elem().FieldByIndex(field.Index), true
 			}
 		}
 	}
 
-	return defaultPhase, errors.Errorf("unknown action %s", action)
+	return reflect.Value{}, false
 }
 
 func (i *Delegate) getAction(obj v1alpha1.InnerObject) string {
@@ -69,6 +78,29 @@ func (i *Delegate) Recover(ctx context.Context, index int, records []*v1alpha1.R
 	return i.callAccordingToAction(i.getAction(obj), "Recover", v1alpha1.Injected, ctx, index, records, obj)
 }
 
+// DryRun renders the commands by the impl of the action, nothing is rendered if the impl doesn't support dry-run
+func (i *Delegate) DryRun(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) ([]string, error) {
+	action := i.getAction(obj)
+	impl, ok := i.implOf(action)
+	if !ok {
+		return nil, errors.Errorf("unknown action %s", action)
+	}
+
+	method := impl.MethodByName("DryRun")
+	if !method.IsValid() {
+		return nil, nil
+	}
+	rets := method.Call([]reflect.Value{
+		reflect.ValueOf(ctx), reflect.ValueOf(index), reflect.ValueOf(records), reflect.ValueOf(obj),
+	})
+
+	err := rets[1].Interface()
+	if err == nil {
+		return rets[0].Interface().([]string), nil
+	}
+	return rets[0].Interface().([]string), err.(error)
+}
+
 func New(impl interface{}) Delegate {
 	return Delegate{
 		impl,
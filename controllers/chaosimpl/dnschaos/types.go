@@ -15,23 +15,17 @@ package dnschaos
 
 import (
 	"context"
-	"fmt"
-	"net"
 	"time"
 
-	dnspb "github.com/chaos-mesh/k8s_dns_chaos/pb"
 	"github.com/go-logr/logr"
 	"go.uber.org/fx"
-	"google.golang.org/grpc"
-	v1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
 	"github.com/chaos-mesh/chaos-mesh/controllers/chaosimpl/utils"
 	"github.com/chaos-mesh/chaos-mesh/controllers/common"
-	"github.com/chaos-mesh/chaos-mesh/controllers/config"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/chaosdaemon"
 	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
-	"github.com/chaos-mesh/chaos-mesh/pkg/selector/pod"
 )
 
 type Impl struct {
@@ -50,24 +44,18 @@ func (impl *Impl) Apply(ctx context.Context, index int, records []*v1alpha1.Reco
 		return v1alpha1.NotInjected, err
 	}
 
-	service, err := pod.GetService(ctx, impl.Client, "", config.ControllerCfg.Namespace, config.ControllerCfg.DNSServiceName)
-	if err != nil {
-		impl.Log.Error(err, "fail to get service")
-		return v1alpha1.NotInjected, err
-	}
-
 	dnschaos := obj.(*v1alpha1.DNSChaos)
-	err = impl.setDNSServerRules(service.Spec.ClusterIP, config.ControllerCfg.DNSServicePort, dnschaos.Name, decodedContainer.Pod, dnschaos.Spec.Action, dnschaos.Spec.DomainNamePatterns)
+	rules, err := rulesOf(dnschaos)
 	if err != nil {
-		impl.Log.Error(err, "fail to set DNS server rules")
 		return v1alpha1.NotInjected, err
 	}
-
 	_, err = decodedContainer.PbClient.SetDNSServer(ctx, &pb.SetDNSServerRequest{
 		ContainerId: decodedContainer.ContainerId,
-		DnsServer:   service.Spec.ClusterIP,
 		Enable:      true,
 		EnterNS:     true,
+		Name:        ruleName(dnschaos),
+		Rules:       rules,
+		Lease:       leaseOf(dnschaos, records[index]),
 	})
 	if err != nil {
 		impl.Log.Error(err, "set dns server")
@@ -77,43 +65,6 @@ func (impl *Impl) Apply(ctx context.Context, index int, records []*v1alpha1.Reco
 	return v1alpha1.Injected, nil
 }
 
-func (impl *Impl) setDNSServerRules(dnsServerIP string, port int, name string, pod *v1.Pod, action v1alpha1.DNSChaosAction, patterns []string) error {
-	impl.Log.Info("setDNSServerRules", "name", name)
-
-	pbPods := make([]*dnspb.Pod, 1)
-	pbPods[0] = &dnspb.Pod{
-		Name:      pod.Name,
-		Namespace: pod.Namespace,
-	}
-
-	conn, err := grpc.Dial(net.JoinHostPort(dnsServerIP, fmt.Sprintf("%d", port)), grpc.WithInsecure())
-	if err != nil {
-		return err
-	}
-	defer conn.Close()
-
-	c := dnspb.NewDNSClient(conn)
-	request := &dnspb.SetDNSChaosRequest{
-		Name:     name,
-		Action:   string(action),
-		Pods:     pbPods,
-		Patterns: patterns,
-	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	response, err := c.SetDNSChaos(ctx, request)
-	if err != nil {
-		return err
-	}
-
-	if !response.Result {
-		return fmt.Errorf("set dns chaos to dns server error %s", response.Msg)
-	}
-
-	return nil
-}
-
 func (impl *Impl) Recover(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) (v1alpha1.Phase, error) {
 	decodedContainer, err := impl.decoder.DecodeContainerRecord(ctx, records[index])
 	if decodedContainer.PbClient != nil {
@@ -128,58 +79,89 @@ func (impl *Impl) Recover(ctx context.Context, index int, records []*v1alpha1.Re
 	}
 
 	dnschaos := obj.(*v1alpha1.DNSChaos)
-
-	// get dns server's ip used for chaos
-	service, err := pod.GetService(ctx, impl.Client, "", config.ControllerCfg.Namespace, config.ControllerCfg.DNSServiceName)
-	if err != nil {
-		impl.Log.Error(err, "fail to get service")
-		return v1alpha1.Injected, err
-	}
-	impl.Log.Info("Cancel DNS chaos to DNS service", "ip", service.Spec.ClusterIP)
-
-	err = impl.cancelDNSServerRules(service.Spec.ClusterIP, config.ControllerCfg.DNSServicePort, dnschaos.Name)
-	if err != nil {
-		impl.Log.Error(err, "fail to cancelDNSServerRules")
-		return v1alpha1.Injected, err
-	}
-
 	_, err = decodedContainer.PbClient.SetDNSServer(ctx, &pb.SetDNSServerRequest{
 		ContainerId: decodedContainer.ContainerId,
 		Enable:      false,
 		EnterNS:     true,
+		Name:        ruleName(dnschaos),
+		Lease:       leaseOf(dnschaos, records[index]),
 	})
 	if err != nil {
 		impl.Log.Error(err, "recover pod for DNS chaos")
 		return v1alpha1.Injected, err
 	}
 
-	return v1alpha1.NotInjected, err
+	return v1alpha1.NotInjected, nil
 }
 
-func (impl *Impl) cancelDNSServerRules(dnsServerIP string, port int, name string) error {
-	conn, err := grpc.Dial(net.JoinHostPort(dnsServerIP, fmt.Sprintf("%d", port)), grpc.WithInsecure())
+// Renew renews the lease of the rule in the dns server of chaos-daemon, which is removed once the lease expires
+func (impl *Impl) Renew(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) (bool, error) {
+	decodedContainer, err := impl.decoder.DecodeContainerRecord(ctx, records[index])
+	if decodedContainer.PbClient != nil {
+		defer decodedContainer.PbClient.Close()
+	}
 	if err != nil {
-		return err
+		return false, err
+	}
+
+	return chaosdaemon.RenewLeases(ctx, decodedContainer.PbClient, leaseOf(obj.(*v1alpha1.DNSChaos), records[index]))
+}
+
+// ruleName returns the name of the rule of the chaos in the dns server of chaos-daemon, which is shared by
+// the containers in the same network namespace
+func ruleName(dnschaos *v1alpha1.DNSChaos) string {
+	return dnschaos.Namespace + "/" + dnschaos.Name
+}
+
+// rulesOf returns the rules of the chaos in order, the action of the spec is matched after the rules
+func rulesOf(dnschaos *v1alpha1.DNSChaos) ([]*pb.DNSRule, error) {
+	rules := []*pb.DNSRule{}
+	for _, rule := range dnschaos.Spec.Rules {
+		in, err := ruleOf(rule.Action, rule.Patterns, rule.DNSChaosActionParams)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, in)
 	}
-	defer conn.Close()
 
-	c := dnspb.NewDNSClient(conn)
-	request := &dnspb.CancelDNSChaosRequest{
-		Name: name,
+	if dnschaos.Spec.Action != "" {
+		in, err := ruleOf(dnschaos.Spec.Action, dnschaos.Spec.DomainNamePatterns, dnschaos.Spec.DNSChaosActionParams)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, in)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-	response, err := c.CancelDNSChaos(ctx, request)
-	if err != nil {
-		return err
+	return rules, nil
+}
+
+func ruleOf(action v1alpha1.DNSChaosAction, patterns []string, params v1alpha1.DNSChaosActionParams) (*pb.DNSRule, error) {
+	rule := &pb.DNSRule{
+		Action:   string(action),
+		Patterns: patterns,
+		Rcode:    string(params.Rcode),
+	}
+
+	if len(params.Delay) > 0 {
+		delay, err := time.ParseDuration(params.Delay)
+		if err != nil {
+			return nil, err
+		}
+		rule.Delay = int64(delay)
 	}
 
-	if !response.Result {
-		return fmt.Errorf("set dns chaos to dns server error %s", response.Msg)
+	if params.Records != nil {
+		rule.A = params.Records.A
+		rule.Aaaa = params.Records.AAAA
+		rule.Cname = params.Records.CNAME
 	}
 
-	return nil
+	return rule, nil
+}
+
+// leaseOf returns the lease of the rule used by the container of the record
+func leaseOf(dnschaos *v1alpha1.DNSChaos, record *v1alpha1.Record) *pb.LeaseRequest {
+	return chaosdaemon.Lease("dnschaos", dnschaos.Namespace, dnschaos.Name, record.Id)
 }
 
 func NewImpl(c client.Client, log logr.Logger, decoder *utils.ContianerRecordDecoder) *common.ChaosImplPair {
@@ -30,6 +30,7 @@ import (
 	"github.com/chaos-mesh/chaos-mesh/controllers/chaosimpl/httpchaos/podhttpchaosmanager"
 	"github.com/chaos-mesh/chaos-mesh/controllers/chaosimpl/iochaos/podiochaosmanager"
 	"github.com/chaos-mesh/chaos-mesh/controllers/common"
+	podhttpchaosctrl "github.com/chaos-mesh/chaos-mesh/controllers/podhttpchaos"
 	"github.com/chaos-mesh/chaos-mesh/controllers/utils/controller"
 )
 
@@ -75,11 +76,42 @@ func (impl *Impl) Apply(ctx context.Context, index int, records []*v1alpha1.Reco
 		return waitForApplySync, nil
 	}
 
-	podId, _ := controller.ParseNamespacedNameContainer(records[index].Id)
+	m, err := impl.prepare(ctx, record, httpchaos)
+	if err != nil {
+		return v1alpha1.NotInjected, err
+	}
+
+	generationNumber, err := m.Commit(ctx)
+	if err != nil {
+		return v1alpha1.NotInjected, err
+	}
+
+	// modify the custom status
+	httpchaos.Status.Instances[record.Id] = generationNumber
+	return waitForApplySync, nil
+}
+
+// DryRun renders the tproxy command which would be executed in the pod
+func (impl *Impl) DryRun(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) ([]string, error) {
+	m, err := impl.prepare(ctx, records[index], obj.(*v1alpha1.HTTPChaos))
+	if err != nil {
+		return nil, err
+	}
+
+	podhttpchaos, err := m.DryRun(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return podhttpchaosctrl.RenderCommands(podhttpchaos)
+}
+
+// prepare appends the modification of the podhttpchaos for the record into the manager
+func (impl *Impl) prepare(ctx context.Context, record *v1alpha1.Record, httpchaos *v1alpha1.HTTPChaos) (*podhttpchaosmanager.PodHttpManager, error) {
+	podId, _ := controller.ParseNamespacedNameContainer(record.Id)
 	var pod v1.Pod
 	err := impl.Client.Get(ctx, podId, &pod)
 	if err != nil {
-		return v1alpha1.NotInjected, err
+		return nil, err
 	}
 
 	source := httpchaos.Namespace + "/" + httpchaos.Name
@@ -104,14 +136,7 @@ func (impl *Impl) Apply(ctx context.Context, index int, records []*v1alpha1.Reco
 			Actions: httpchaos.Spec.PodHttpChaosActions,
 		},
 	})
-	generationNumber, err := m.Commit(ctx)
-	if err != nil {
-		return v1alpha1.NotInjected, err
-	}
-
-	// modify the custom status
-	httpchaos.Status.Instances[record.Id] = generationNumber
-	return waitForApplySync, nil
+	return m, nil
 }
 
 func (impl *Impl) Recover(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) (v1alpha1.Phase, error) {
@@ -101,6 +101,25 @@ func (m *PodHttpManager) Commit(ctx context.Context) (int64, error) {
 	return chaos.GetGeneration(), nil
 }
 
+// DryRun applies the modification on a copy of the podhttpchaos without committing it
+func (m *PodHttpManager) DryRun(ctx context.Context) (*v1alpha1.PodHttpChaos, error) {
+	chaos := &v1alpha1.PodHttpChaos{}
+
+	err := m.Client.Get(ctx, m.Key, chaos)
+	if err != nil && !k8sError.IsNotFound(err) {
+		m.Log.Error(err, "error while getting podhttpchaos")
+		return nil, err
+	}
+
+	err = m.T.Apply(chaos)
+	if err != nil {
+		m.Log.Error(err, "error while applying transactions", "transaction", m.T)
+		return nil, err
+	}
+
+	return chaos, nil
+}
+
 func (m *PodHttpManager) CreateNewPodHttpChaos(ctx context.Context) error {
 	var err error
 	chaos := &v1alpha1.PodHttpChaos{}
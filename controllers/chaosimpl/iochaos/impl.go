@@ -29,6 +29,7 @@ import (
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
 	"github.com/chaos-mesh/chaos-mesh/controllers/chaosimpl/iochaos/podiochaosmanager"
 	"github.com/chaos-mesh/chaos-mesh/controllers/common"
+	podiochaosctrl "github.com/chaos-mesh/chaos-mesh/controllers/podiochaos"
 	"github.com/chaos-mesh/chaos-mesh/controllers/utils/controller"
 )
 
@@ -84,11 +85,42 @@ func (impl *Impl) Apply(ctx context.Context, index int, records []*v1alpha1.Reco
 		return waitForApplySync, nil
 	}
 
-	podId, containerName := controller.ParseNamespacedNameContainer(records[index].Id)
+	m, err := impl.prepare(ctx, record, iochaos)
+	if err != nil {
+		return v1alpha1.NotInjected, err
+	}
+
+	generationNumber, err := m.Commit(ctx, iochaos)
+	if err != nil {
+		return v1alpha1.NotInjected, err
+	}
+
+	// modify the custom status
+	iochaos.Status.Instances[record.Id] = generationNumber
+	return waitForApplySync, nil
+}
+
+// DryRun renders the toda command which would be executed in the container
+func (impl *Impl) DryRun(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) ([]string, error) {
+	m, err := impl.prepare(ctx, records[index], obj.(*v1alpha1.IOChaos))
+	if err != nil {
+		return nil, err
+	}
+
+	podiochaos, err := m.DryRun(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return podiochaosctrl.RenderCommands(podiochaos)
+}
+
+// prepare appends the modification of the podiochaos for the record into the manager
+func (impl *Impl) prepare(ctx context.Context, record *v1alpha1.Record, iochaos *v1alpha1.IOChaos) (*podiochaosmanager.PodIOManager, error) {
+	podId, containerName := controller.ParseNamespacedNameContainer(record.Id)
 	var pod v1.Pod
 	err := impl.Client.Get(ctx, podId, &pod)
 	if err != nil {
-		return v1alpha1.NotInjected, err
+		return nil, err
 	}
 
 	source := iochaos.Namespace + "/" + iochaos.Name
@@ -118,14 +150,7 @@ func (impl *Impl) Apply(ctx context.Context, index int, records []*v1alpha1.Reco
 		MistakeSpec:      iochaos.Spec.Mistake,
 		Source:           m.Source,
 	})
-	generationNumber, err := m.Commit(ctx, iochaos)
-	if err != nil {
-		return v1alpha1.NotInjected, err
-	}
-
-	// modify the custom status
-	iochaos.Status.Instances[record.Id] = generationNumber
-	return waitForApplySync, nil
+	return m, nil
 }
 
 func (impl *Impl) Recover(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) (v1alpha1.Phase, error) {
@@ -100,6 +100,25 @@ func (m *PodIOManager) Commit(ctx context.Context, owner *v1alpha1.IOChaos) (int
 	return chaos.GetGeneration(), nil
 }
 
+// DryRun applies the modification on a copy of the podiochaos without committing it
+func (m *PodIOManager) DryRun(ctx context.Context) (*v1alpha1.PodIOChaos, error) {
+	chaos := &v1alpha1.PodIOChaos{}
+
+	err := m.Client.Get(ctx, m.Key, chaos)
+	if err != nil && !k8sError.IsNotFound(err) {
+		m.Log.Error(err, "error while getting podiochaos")
+		return nil, err
+	}
+
+	err = m.T.Apply(chaos)
+	if err != nil {
+		m.Log.Error(err, "error while applying transactions", "transaction", m.T)
+		return nil, err
+	}
+
+	return chaos, nil
+}
+
 func (m *PodIOManager) CreateNewPodIOChaos(ctx context.Context) error {
 	var err error
 	chaos := &v1alpha1.PodIOChaos{}
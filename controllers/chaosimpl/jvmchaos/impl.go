@@ -73,6 +73,24 @@ func (impl *Impl) Apply(ctx context.Context, index int, records []*v1alpha1.Reco
 	return v1alpha1.Injected, nil
 }
 
+// DryRun renders the requests which would be sent to the sandbox in the pod
+func (impl *Impl) DryRun(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) ([]string, error) {
+	jvmchaos := obj.(*v1alpha1.JVMChaos)
+
+	var pod v1.Pod
+	err := impl.Client.Get(ctx, controller.ParseNamespacedName(records[index].Id), &pod)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonBytes, err := jvm.ToSandboxAction(genSUID(&pod, jvmchaos), jvmchaos)
+	if err != nil {
+		return nil, err
+	}
+
+	return jvm.RenderInjection(pod.Status.PodIP, sandboxPort, jsonBytes), nil
+}
+
 func genSUID(pod *v1.Pod, chaos *v1alpha1.JVMChaos) string {
 	return fmt.Sprintf("%s:%s:%s:%s:%s",
 		pod.Name,
@@ -21,6 +21,7 @@ import (
 	"github.com/chaos-mesh/chaos-mesh/controllers/action"
 	"github.com/chaos-mesh/chaos-mesh/controllers/chaosimpl/networkchaos/partition"
 	"github.com/chaos-mesh/chaos-mesh/controllers/chaosimpl/networkchaos/podnetworkchaosmanager"
+	"github.com/chaos-mesh/chaos-mesh/controllers/chaosimpl/networkchaos/resolver"
 	"github.com/chaos-mesh/chaos-mesh/controllers/chaosimpl/networkchaos/trafficcontrol"
 	"github.com/chaos-mesh/chaos-mesh/controllers/common"
 )
@@ -28,7 +29,7 @@ import (
 type Impl struct {
 	fx.In
 
-	TrafficControl *trafficcontrol.Impl `action:"bandwidth,netem,delay,loss,duplicate,corrupt"`
+	TrafficControl *trafficcontrol.Impl `action:"bandwidth,netem,delay,loss,duplicate,corrupt,packet"`
 	Partition      *partition.Impl      `action:"partition"`
 }
 
@@ -39,7 +40,7 @@ func NewImpl(impl Impl) *common.ChaosImplPair {
 		Object:     &v1alpha1.NetworkChaos{},
 		Impl:       &delegate,
 		ObjectList: &v1alpha1.NetworkChaosList{},
-		Controlls:  []runtime.Object{&v1alpha1.PodNetworkChaos{}},
+		Controlls:  []runtime.Object{&v1alpha1.PodNetworkChaos{}, &v1alpha1.NodeNetworkChaos{}},
 	}
 }
 
@@ -48,6 +49,10 @@ var Module = fx.Provide(
 		Group:  "impl",
 		Target: NewImpl,
 	},
+	fx.Annotated{
+		Group:  "controller",
+		Target: resolver.NewController,
+	},
 	trafficcontrol.NewImpl,
 	partition.NewImpl,
 	podnetworkchaosmanager.NewBuilder,
@@ -26,9 +26,10 @@ import (
 
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
 	"github.com/chaos-mesh/chaos-mesh/controllers/chaosimpl/networkchaos/podnetworkchaosmanager"
+	"github.com/chaos-mesh/chaos-mesh/controllers/chaosimpl/networkchaos/resolver"
+	podnetworkchaosctrl "github.com/chaos-mesh/chaos-mesh/controllers/podnetworkchaos"
 	"github.com/chaos-mesh/chaos-mesh/controllers/podnetworkchaos/ipset"
 	"github.com/chaos-mesh/chaos-mesh/controllers/podnetworkchaos/iptable"
-	"github.com/chaos-mesh/chaos-mesh/controllers/podnetworkchaos/netutils"
 	"github.com/chaos-mesh/chaos-mesh/controllers/utils/controller"
 	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
 )
@@ -67,8 +68,7 @@ func (impl *Impl) Apply(ctx context.Context, index int, records []*v1alpha1.Reco
 	phase := record.Phase
 
 	if phase == waitForApplySync {
-		podnetworkchaos := &v1alpha1.PodNetworkChaos{}
-		err := impl.Client.Get(ctx, controller.ParseNamespacedName(record.Id), podnetworkchaos)
+		chaosStatus, err := podnetworkchaosmanager.GetStatus(ctx, impl.Client, networkchaos, record)
 		if err != nil {
 			if k8sError.IsNotFound(err) {
 				return v1alpha1.NotInjected, nil
@@ -83,65 +83,83 @@ func (impl *Impl) Apply(ctx context.Context, index int, records []*v1alpha1.Reco
 			return waitForApplySync, err
 		}
 
-		if podnetworkchaos.Status.FailedMessage != "" {
-			return waitForApplySync, errors.New(podnetworkchaos.Status.FailedMessage)
+		if chaosStatus.FailedMessage != "" {
+			return waitForApplySync, errors.New(chaosStatus.FailedMessage)
 		}
 
-		if podnetworkchaos.Status.ObservedGeneration >= networkchaos.Status.Instances[record.Id] {
+		if chaosStatus.ObservedGeneration >= networkchaos.Status.Instances[record.Id] {
 			return v1alpha1.Injected, nil
 		}
 
 		return waitForApplySync, nil
 	}
 
-	var pod v1.Pod
-	err := impl.Client.Get(ctx, controller.ParseNamespacedName(record.Id), &pod)
+	if record.SelectorKey != "." && record.SelectorKey != ".Target" {
+		impl.Log.Info("unknown selector key", "record", record)
+		return v1alpha1.NotInjected, nil
+	}
+
+	m, shouldCommit, err := impl.prepare(ctx, index, records, networkchaos)
 	if err != nil {
-		// TODO: handle this error
 		return v1alpha1.NotInjected, err
 	}
+	if !shouldCommit {
+		return v1alpha1.Injected, nil
+	}
 
-	source := networkchaos.Namespace + "/" + networkchaos.Name
-	m := func() *podnetworkchaosmanager.PodNetworkManager {
-		shouldInit := true
-
-		if record.SelectorKey == ".Target" {
-			for _, r := range records {
-				if r.Id == record.Id {
-					// Only init in the "." selector key so it won't be cleared
-					// by another one with the same key in the ".Target"
-					shouldInit = false
-				}
-			}
-		}
+	generationNumber, err := m.Commit(ctx, networkchaos)
+	if err != nil {
+		return v1alpha1.NotInjected, err
+	}
 
-		if shouldInit {
-			return impl.builder.WithInit(source, types.NamespacedName{
-				Namespace: pod.Namespace,
-				Name:      pod.Name,
-			})
-		}
+	// modify the custom status
+	networkchaos.Status.Instances[record.Id] = generationNumber
+	return waitForApplySync, nil
+}
 
-		return impl.builder.Build(source, types.NamespacedName{
-			Namespace: pod.Namespace,
-			Name:      pod.Name,
-		})
-	}()
+// DryRun renders the commands which would be executed on the pod of the record
+func (impl *Impl) DryRun(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) ([]string, error) {
+	networkchaos, ok := obj.(*v1alpha1.NetworkChaos)
+	if !ok {
+		return nil, errors.New("chaos is not NetworkChaos")
+	}
 
-	if record.SelectorKey == "." {
-		shouldCommit := false
+	m, shouldCommit, err := impl.prepare(ctx, index, records, networkchaos)
+	if err != nil || !shouldCommit {
+		return nil, err
+	}
+
+	podnetworkchaos, err := m.DryRun(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return podnetworkchaosctrl.RenderCommands(podnetworkchaos)
+}
+
+// prepare appends the modification of the podnetworkchaos for the record into the manager,
+// shouldCommit is false if nothing needs to be changed on the pod
+func (impl *Impl) prepare(ctx context.Context, index int, records []*v1alpha1.Record, networkchaos *v1alpha1.NetworkChaos) (*podnetworkchaosmanager.PodNetworkManager, bool, error) {
+	record := records[index]
+	source := networkchaos.Namespace + "/" + networkchaos.Name
+
+	m, err := impl.newManager(ctx, source, records, index, networkchaos)
+	if err != nil {
+		return nil, false, err
+	}
 
+	shouldCommit := false
+	if record.SelectorKey == "." {
 		if networkchaos.Spec.Direction == v1alpha1.To || networkchaos.Spec.Direction == v1alpha1.Both {
 			var targets []*v1alpha1.Record
 			for _, record := range records {
-				if record.SelectorKey == ".Target" {
+				if record.SelectorKey == ".Target" && record.Phase != v1alpha1.Gone {
 					targets = append(targets, record)
 				}
 			}
 
 			err := impl.SetDrop(ctx, m, targets, networkchaos, targetIPSetPostFix, v1alpha1.Output)
 			if err != nil {
-				return v1alpha1.NotInjected, err
+				return nil, false, err
 			}
 
 			shouldCommit = true
@@ -150,45 +168,30 @@ func (impl *Impl) Apply(ctx context.Context, index int, records []*v1alpha1.Reco
 		if networkchaos.Spec.Direction == v1alpha1.From || networkchaos.Spec.Direction == v1alpha1.Both {
 			var targets []*v1alpha1.Record
 			for _, record := range records {
-				if record.SelectorKey == ".Target" {
+				if record.SelectorKey == ".Target" && record.Phase != v1alpha1.Gone {
 					targets = append(targets, record)
 				}
 			}
 
 			err := impl.SetDrop(ctx, m, targets, networkchaos, targetIPSetPostFix, v1alpha1.Input)
 			if err != nil {
-				return v1alpha1.NotInjected, err
+				return nil, false, err
 			}
 
 			shouldCommit = true
 		}
-
-		if shouldCommit {
-			generationNumber, err := m.Commit(ctx, networkchaos)
-			if err != nil {
-				return v1alpha1.NotInjected, err
-			}
-
-			// modify the custom status
-			networkchaos.Status.Instances[record.Id] = generationNumber
-			return waitForApplySync, nil
-		}
-
-		return v1alpha1.Injected, nil
-	} else if record.SelectorKey == ".Target" {
-		shouldCommit := false
-
+	} else {
 		if networkchaos.Spec.Direction == v1alpha1.From || networkchaos.Spec.Direction == v1alpha1.Both {
 			var targets []*v1alpha1.Record
 			for _, record := range records {
-				if record.SelectorKey == "." {
+				if record.SelectorKey == "." && record.Phase != v1alpha1.Gone {
 					targets = append(targets, record)
 				}
 			}
 
 			err := impl.SetDrop(ctx, m, targets, networkchaos, sourceIPSetPostFix, v1alpha1.Output)
 			if err != nil {
-				return v1alpha1.NotInjected, err
+				return nil, false, err
 			}
 
 			shouldCommit = true
@@ -197,35 +200,66 @@ func (impl *Impl) Apply(ctx context.Context, index int, records []*v1alpha1.Reco
 		if networkchaos.Spec.Direction == v1alpha1.To || networkchaos.Spec.Direction == v1alpha1.Both {
 			var targets []*v1alpha1.Record
 			for _, record := range records {
-				if record.SelectorKey == "." {
+				if record.SelectorKey == "." && record.Phase != v1alpha1.Gone {
 					targets = append(targets, record)
 				}
 			}
 
 			err := impl.SetDrop(ctx, m, targets, networkchaos, sourceIPSetPostFix, v1alpha1.Input)
 			if err != nil {
-				return v1alpha1.NotInjected, err
+				return nil, false, err
 			}
 
 			shouldCommit = true
 		}
+	}
 
-		if shouldCommit {
-			generationNumber, err := m.Commit(ctx, networkchaos)
-			if err != nil {
-				return v1alpha1.NotInjected, err
-			}
+	return m, shouldCommit, nil
+}
+
+// newManager builds the manager of the podnetworkchaos of the record, or the nodenetworkchaos of a node record
+func (impl *Impl) newManager(ctx context.Context, source string, records []*v1alpha1.Record, index int, networkchaos *v1alpha1.NetworkChaos) (*podnetworkchaosmanager.PodNetworkManager, error) {
+	record := records[index]
 
-			// modify the custom status
-			networkchaos.Status.Instances[record.Id] = generationNumber
-			return waitForApplySync, nil
+	if podnetworkchaosmanager.IsNodeRecord(networkchaos, record) {
+		var node v1.Node
+		err := impl.Client.Get(ctx, podnetworkchaosmanager.RecordKey(networkchaos, record), &node)
+		if err != nil {
+			return nil, err
 		}
 
-		return v1alpha1.Injected, nil
-	} else {
-		impl.Log.Info("unknown selector key", "record", record)
-		return v1alpha1.NotInjected, nil
+		return impl.builder.WithInitForNode(source, node.Name), nil
+	}
+
+	var pod v1.Pod
+	err := impl.Client.Get(ctx, controller.ParseNamespacedName(record.Id), &pod)
+	if err != nil {
+		// TODO: handle this error
+		return nil, err
 	}
+
+	shouldInit := true
+	if record.SelectorKey == ".Target" {
+		for _, r := range records {
+			if r.Id == record.Id {
+				// Only init in the "." selector key so it won't be cleared
+				// by another one with the same key in the ".Target"
+				shouldInit = false
+			}
+		}
+	}
+
+	if shouldInit {
+		return impl.builder.WithInit(source, types.NamespacedName{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+		}), nil
+	}
+
+	return impl.builder.Build(source, types.NamespacedName{
+		Namespace: pod.Namespace,
+		Name:      pod.Name,
+	}), nil
 }
 
 func (impl *Impl) Recover(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) (v1alpha1.Phase, error) {
@@ -243,8 +277,7 @@ func (impl *Impl) Recover(ctx context.Context, index int, records []*v1alpha1.Re
 	phase := record.Phase
 
 	if phase == waitForRecoverSync {
-		podnetworkchaos := &v1alpha1.PodNetworkChaos{}
-		err := impl.Client.Get(ctx, controller.ParseNamespacedName(record.Id), podnetworkchaos)
+		chaosStatus, err := podnetworkchaosmanager.GetStatus(ctx, impl.Client, networkchaos, record)
 		if err != nil {
 			// TODO: handle this error
 			if k8sError.IsNotFound(err) {
@@ -253,35 +286,41 @@ func (impl *Impl) Recover(ctx context.Context, index int, records []*v1alpha1.Re
 			return waitForRecoverSync, err
 		}
 
-		if podnetworkchaos.Status.FailedMessage != "" {
-			return waitForRecoverSync, errors.New(podnetworkchaos.Status.FailedMessage)
+		if chaosStatus.FailedMessage != "" {
+			return waitForRecoverSync, errors.New(chaosStatus.FailedMessage)
 		}
 
-		if podnetworkchaos.Status.ObservedGeneration >= networkchaos.Status.Instances[record.Id] {
+		if chaosStatus.ObservedGeneration >= networkchaos.Status.Instances[record.Id] {
 			return v1alpha1.NotInjected, nil
 		}
 
 		return waitForRecoverSync, nil
 	}
 
-	var pod v1.Pod
-	err := impl.Client.Get(ctx, controller.ParseNamespacedName(record.Id), &pod)
-	if err != nil {
-		// TODO: handle this error
-		if k8sError.IsNotFound(err) {
-			return v1alpha1.NotInjected, nil
+	source := networkchaos.Namespace + "/" + networkchaos.Name
+	var m *podnetworkchaosmanager.PodNetworkManager
+	if podnetworkchaosmanager.IsNodeRecord(networkchaos, record) {
+		m = impl.builder.WithInitForNode(source, record.Id)
+	} else {
+		var pod v1.Pod
+		err := impl.Client.Get(ctx, controller.ParseNamespacedName(record.Id), &pod)
+		if err != nil {
+			// TODO: handle this error
+			if k8sError.IsNotFound(err) {
+				return v1alpha1.NotInjected, nil
+			}
+			return v1alpha1.Injected, err
 		}
-		return v1alpha1.Injected, err
-	}
 
-	source := networkchaos.Namespace + "/" + networkchaos.Name
-	m := impl.builder.WithInit(source, types.NamespacedName{
-		Namespace: pod.Namespace,
-		Name:      pod.Name,
-	})
+		m = impl.builder.WithInit(source, types.NamespacedName{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+		})
+	}
 	generationNumber, err := m.Commit(ctx, networkchaos)
 	if err != nil {
-		if err == podnetworkchaosmanager.ErrPodNotFound || err == podnetworkchaosmanager.ErrPodNotRunning {
+		if err == podnetworkchaosmanager.ErrPodNotFound || err == podnetworkchaosmanager.ErrPodNotRunning ||
+			err == podnetworkchaosmanager.ErrNodeNotFound {
 			return v1alpha1.NotInjected, nil
 		}
 
@@ -299,7 +338,7 @@ func (impl *Impl) Recover(ctx context.Context, index int, records []*v1alpha1.Re
 }
 
 func (impl *Impl) SetDrop(ctx context.Context, m *podnetworkchaosmanager.PodNetworkManager, targets []*v1alpha1.Record, networkchaos *v1alpha1.NetworkChaos, ipSetPostFix string, chainDirection v1alpha1.ChainDirection) error {
-	externalCidrs, err := netutils.ResolveCidrs(networkchaos.Spec.ExternalTargets)
+	externalCidrs, err := resolver.ExternalCidrs(networkchaos)
 	if err != nil {
 		return err
 	}
@@ -311,9 +350,11 @@ func (impl *Impl) SetDrop(ctx context.Context, m *podnetworkchaosmanager.PodNetw
 	if len(targets)+len(externalCidrs) == 0 {
 		impl.Log.Info("apply traffic control", "sources", m.Source)
 		m.T.Append(v1alpha1.RawIptables{
-			Name:      iptable.GenerateName(pbChainDirection, networkchaos),
-			Direction: chainDirection,
-			IPSets:    nil,
+			Name:         iptable.GenerateName(pbChainDirection, networkchaos),
+			Direction:    chainDirection,
+			PacketFilter: networkchaos.Spec.PacketFilter,
+			Device:       m.Device(networkchaos),
+			IPSets:       nil,
 			RawRuleSource: v1alpha1.RawRuleSource{
 				Source: m.Source,
 			},
@@ -321,22 +362,19 @@ func (impl *Impl) SetDrop(ctx context.Context, m *podnetworkchaosmanager.PodNetw
 		return nil
 	}
 
-	targetPods := []v1.Pod{}
-	for _, record := range targets {
-		var pod v1.Pod
-		err := impl.Client.Get(ctx, controller.ParseNamespacedName(record.Id), &pod)
-		if err != nil {
-			// TODO: handle this error
-			return err
-		}
-		targetPods = append(targetPods, pod)
+	targetPods, nodeCidrs, err := podnetworkchaosmanager.GetTargets(ctx, impl.Client, networkchaos, targets)
+	if err != nil {
+		// TODO: handle this error
+		return err
 	}
-	dstIpset := ipset.BuildIPSet(targetPods, externalCidrs, networkchaos, ipSetPostFix, m.Source)
+	dstIpset := ipset.BuildIPSet(targetPods, append(nodeCidrs, externalCidrs...), networkchaos, ipSetPostFix, m.Source)
 	m.T.Append(dstIpset)
 	m.T.Append(v1alpha1.RawIptables{
-		Name:      iptable.GenerateName(pbChainDirection, networkchaos),
-		Direction: chainDirection,
-		IPSets:    []string{dstIpset.Name},
+		Name:         iptable.GenerateName(pbChainDirection, networkchaos),
+		Direction:    chainDirection,
+		PacketFilter: networkchaos.Spec.PacketFilter,
+		Device:       m.Device(networkchaos),
+		IPSets:       []string{dstIpset.Name},
 		RawRuleSource: v1alpha1.RawRuleSource{
 			Source: m.Source,
 		},
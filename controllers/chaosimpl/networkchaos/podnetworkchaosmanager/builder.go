@@ -76,3 +76,17 @@ func (b *Builder) WithInit(source string, key types.NamespacedName) *PodNetworkM
 		T:   t,
 	}
 }
+
+// BuildForNode builds the manager of the nodenetworkchaos of the node
+func (b *Builder) BuildForNode(source string, name string) *PodNetworkManager {
+	m := b.Build(source, types.NamespacedName{Name: name})
+	m.Node = true
+	return m
+}
+
+// WithInitForNode builds the manager of the nodenetworkchaos of the node, and clears the rules of the source
+func (b *Builder) WithInitForNode(source string, name string) *PodNetworkManager {
+	m := b.WithInit(source, types.NamespacedName{Name: name})
+	m.Node = true
+	return m
+}
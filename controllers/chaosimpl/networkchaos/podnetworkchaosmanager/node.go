@@ -0,0 +1,210 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podnetworkchaosmanager
+
+import (
+	"context"
+	"errors"
+
+	v1 "k8s.io/api/core/v1"
+	k8sError "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/controllers/podnetworkchaos/ipset"
+	"github.com/chaos-mesh/chaos-mesh/controllers/podnetworkchaos/netutils"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/controller"
+)
+
+// ErrNodeNotFound means the node of the nodenetworkchaos may be deleted
+var ErrNodeNotFound = errors.New("node not found")
+
+// IsNodeRecord returns whether the record is a node selected by the network chaos on the host network
+func IsNodeRecord(networkchaos *v1alpha1.NetworkChaos, record *v1alpha1.Record) bool {
+	return networkchaos.Spec.NodeNetwork && record.SelectorKey == "."
+}
+
+// RecordKey returns the key of the podnetworkchaos, or the nodenetworkchaos of a node record
+func RecordKey(networkchaos *v1alpha1.NetworkChaos, record *v1alpha1.Record) types.NamespacedName {
+	if IsNodeRecord(networkchaos, record) {
+		return types.NamespacedName{Name: record.Id}
+	}
+
+	return controller.ParseNamespacedName(record.Id)
+}
+
+// GetStatus returns the status of the podnetworkchaos, or the nodenetworkchaos of a node record
+func GetStatus(ctx context.Context, c client.Reader, networkchaos *v1alpha1.NetworkChaos, record *v1alpha1.Record) (*v1alpha1.PodNetworkChaosStatus, error) {
+	key := RecordKey(networkchaos, record)
+
+	if IsNodeRecord(networkchaos, record) {
+		nodenetworkchaos := &v1alpha1.NodeNetworkChaos{}
+		if err := c.Get(ctx, key, nodenetworkchaos); err != nil {
+			return nil, err
+		}
+		return &nodenetworkchaos.Status, nil
+	}
+
+	podnetworkchaos := &v1alpha1.PodNetworkChaos{}
+	if err := c.Get(ctx, key, podnetworkchaos); err != nil {
+		return nil, err
+	}
+	return &podnetworkchaos.Status, nil
+}
+
+// GetTargets returns the pods of the records, and the addresses of the nodes of the node records
+func GetTargets(ctx context.Context, c client.Reader, networkchaos *v1alpha1.NetworkChaos, records []*v1alpha1.Record) ([]v1.Pod, []string, error) {
+	pods := []v1.Pod{}
+	var cidrs []string
+	for _, record := range records {
+		if IsNodeRecord(networkchaos, record) {
+			var node v1.Node
+			if err := c.Get(ctx, RecordKey(networkchaos, record), &node); err != nil {
+				return nil, nil, err
+			}
+			for _, ip := range ipset.NodeIPs(&node) {
+				cidrs = append(cidrs, netutils.IPToCidr(ip))
+			}
+			continue
+		}
+
+		var pod v1.Pod
+		if err := c.Get(ctx, RecordKey(networkchaos, record), &pod); err != nil {
+			return nil, nil, err
+		}
+		pods = append(pods, pod)
+	}
+
+	return pods, cidrs, nil
+}
+
+// Device returns the network device of the rules of the network chaos on the pod or the node of the manager.
+// The device of the network chaos on the host network only applies on the nodes, and the default device is
+// used on the target pods.
+func (m *PodNetworkManager) Device(networkchaos *v1alpha1.NetworkChaos) string {
+	if networkchaos.Spec.NodeNetwork && !m.Node {
+		return ""
+	}
+
+	return networkchaos.Spec.Device
+}
+
+func (m *PodNetworkManager) commitNode(ctx context.Context) (int64, error) {
+	m.Log.Info("running modification on node", "key", m.Key, "modification", m.T)
+	updateError := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		chaos := &v1alpha1.NodeNetworkChaos{}
+
+		err := m.Client.Get(ctx, m.Key, chaos)
+		if err != nil {
+			if !k8sError.IsNotFound(err) {
+				m.Log.Error(err, "error while getting nodenetworkchaos")
+				return err
+			}
+
+			err := m.CreateNewNodeNetworkChaos(ctx)
+			if err != nil {
+				m.Log.Error(err, "error while creating new nodenetworkchaos")
+				return err
+			}
+
+			return nil
+		}
+
+		err = m.applyOnNode(chaos)
+		if err != nil {
+			m.Log.Error(err, "error while applying transactions", "transaction", m.T)
+			return err
+		}
+
+		return m.Client.Update(ctx, chaos)
+	})
+	if updateError != nil {
+		return 0, updateError
+	}
+
+	chaos := &v1alpha1.NodeNetworkChaos{}
+	err := m.Reader.Get(ctx, m.Key, chaos)
+	if err != nil {
+		m.Log.Error(err, "error while getting the latest generation number")
+		return 0, err
+	}
+	return chaos.GetGeneration(), nil
+}
+
+func (m *PodNetworkManager) dryRunNode(ctx context.Context) (*v1alpha1.PodNetworkChaos, error) {
+	chaos := &v1alpha1.NodeNetworkChaos{}
+
+	err := m.Client.Get(ctx, m.Key, chaos)
+	if err != nil && !k8sError.IsNotFound(err) {
+		m.Log.Error(err, "error while getting nodenetworkchaos")
+		return nil, err
+	}
+
+	err = m.applyOnNode(chaos)
+	if err != nil {
+		m.Log.Error(err, "error while applying transactions", "transaction", m.T)
+		return nil, err
+	}
+
+	return &v1alpha1.PodNetworkChaos{
+		ObjectMeta: chaos.ObjectMeta,
+		Spec:       chaos.Spec,
+	}, nil
+}
+
+// applyOnNode applies the transaction on the spec of the nodenetworkchaos, which is the same as the podnetworkchaos
+func (m *PodNetworkManager) applyOnNode(chaos *v1alpha1.NodeNetworkChaos) error {
+	podnetworkchaos := &v1alpha1.PodNetworkChaos{Spec: chaos.Spec}
+	if err := m.T.Apply(podnetworkchaos); err != nil {
+		return err
+	}
+
+	chaos.Spec = podnetworkchaos.Spec
+	return nil
+}
+
+func (m *PodNetworkManager) CreateNewNodeNetworkChaos(ctx context.Context) error {
+	node := v1.Node{}
+	err := m.Client.Get(ctx, m.Key, &node)
+	if err != nil {
+		if !k8sError.IsNotFound(err) {
+			m.Log.Error(err, "error while finding node")
+			return err
+		}
+
+		m.Log.Info("node not found", "key", m.Key, "error", err.Error())
+		return ErrNodeNotFound
+	}
+
+	chaos := &v1alpha1.NodeNetworkChaos{}
+	chaos.Name = m.Key.Name
+	chaos.OwnerReferences = []metav1.OwnerReference{
+		{
+			APIVersion: "v1",
+			Kind:       "Node",
+			Name:       node.Name,
+			UID:        node.UID,
+		},
+	}
+	err = m.applyOnNode(chaos)
+	if err != nil {
+		m.Log.Error(err, "error while applying transactions", "transaction", m.T)
+		return err
+	}
+
+	return m.Client.Create(ctx, chaos)
+}
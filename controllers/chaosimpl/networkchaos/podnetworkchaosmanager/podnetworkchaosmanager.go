@@ -49,6 +49,9 @@ type PodNetworkManager struct {
 
 	Key types.NamespacedName
 	T   *PodNetworkTransaction
+
+	// Node represents the modification is on the nodenetworkchaos of the node with the name of the key
+	Node bool
 }
 
 // CommitResponse is a tuple (Key, Err)
@@ -59,6 +62,10 @@ type CommitResponse struct {
 
 // Commit will update all modifications to the cluster
 func (m *PodNetworkManager) Commit(ctx context.Context, owner *v1alpha1.NetworkChaos) (int64, error) {
+	if m.Node {
+		return m.commitNode(ctx)
+	}
+
 	m.Log.Info("running modification on pod", "key", m.Key, "modification", m.T)
 	updateError := retry.RetryOnConflict(retry.DefaultRetry, func() error {
 		chaos := &v1alpha1.PodNetworkChaos{}
@@ -100,6 +107,29 @@ func (m *PodNetworkManager) Commit(ctx context.Context, owner *v1alpha1.NetworkC
 	return chaos.GetGeneration(), nil
 }
 
+// DryRun applies the modification on a copy of the podnetworkchaos without committing it
+func (m *PodNetworkManager) DryRun(ctx context.Context) (*v1alpha1.PodNetworkChaos, error) {
+	if m.Node {
+		return m.dryRunNode(ctx)
+	}
+
+	chaos := &v1alpha1.PodNetworkChaos{}
+
+	err := m.Client.Get(ctx, m.Key, chaos)
+	if err != nil && !k8sError.IsNotFound(err) {
+		m.Log.Error(err, "error while getting podnetworkchaos")
+		return nil, err
+	}
+
+	err = m.T.Apply(chaos)
+	if err != nil {
+		m.Log.Error(err, "error while applying transactions", "transaction", m.T)
+		return nil, err
+	}
+
+	return chaos, nil
+}
+
 func (m *PodNetworkManager) CreateNewPodNetworkChaos(ctx context.Context) error {
 	var err error
 	chaos := &v1alpha1.PodNetworkChaos{}
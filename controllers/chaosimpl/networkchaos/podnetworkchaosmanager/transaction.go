@@ -17,6 +17,7 @@ import (
 	"fmt"
 
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	tcpkg "github.com/chaos-mesh/chaos-mesh/controllers/podnetworkchaos/tc"
 )
 
 // PodNetworkTransaction represents a modification on podnetwork
@@ -61,6 +62,14 @@ func (s *Clear) Apply(chaos *v1alpha1.PodNetworkChaos) error {
 	}
 	chaos.Spec.TrafficControls = qdiscs
 
+	faults := []v1alpha1.RawPacketFault{}
+	for _, fault := range chaos.Spec.PacketFaults {
+		if fault.Source != s.Source {
+			faults = append(faults, fault)
+		}
+	}
+	chaos.Spec.PacketFaults = faults
+
 	return nil
 }
 
@@ -77,7 +86,23 @@ func (a *Append) Apply(chaos *v1alpha1.PodNetworkChaos) error {
 	case v1alpha1.RawIptables:
 		chaos.Spec.Iptables = append(chaos.Spec.Iptables, item)
 	case v1alpha1.RawTrafficControl:
+		if !item.Ingress {
+			for _, fault := range chaos.Spec.PacketFaults {
+				if fault.Fault == v1alpha1.DelayPacketFault && sameDevice(fault.Device, item.Device) {
+					return fmt.Errorf("traffic control conflicts with the delay packet fault of %s on device %s", fault.Source, deviceOrDefault(item.Device))
+				}
+			}
+		}
 		chaos.Spec.TrafficControls = append(chaos.Spec.TrafficControls, item)
+	case v1alpha1.RawPacketFault:
+		if item.Fault == v1alpha1.DelayPacketFault {
+			for _, tc := range chaos.Spec.TrafficControls {
+				if !tc.Ingress && sameDevice(tc.Device, item.Device) {
+					return fmt.Errorf("delay packet fault conflicts with the traffic control of %s on device %s", tc.Source, deviceOrDefault(item.Device))
+				}
+			}
+		}
+		chaos.Spec.PacketFaults = append(chaos.Spec.PacketFaults, item)
 	default:
 		return fmt.Errorf("unknown type of item")
 	}
@@ -85,6 +110,20 @@ func (a *Append) Apply(chaos *v1alpha1.PodNetworkChaos) error {
 	return nil
 }
 
+// sameDevice returns whether the devices are the same one. The delayed packets are held by the fq qdisc
+// on the root of the device, which would replace the traffic control on the outbound traffic of it.
+func sameDevice(a string, b string) bool {
+	return deviceOrDefault(a) == deviceOrDefault(b)
+}
+
+// deviceOrDefault returns the device, or the default device if it's not specified
+func deviceOrDefault(device string) string {
+	if len(device) == 0 {
+		return tcpkg.Device
+	}
+	return device
+}
+
 // Clear will clear all related items in podnetworkchaos
 func (t *PodNetworkTransaction) Clear(source string) {
 	t.Steps = append(t.Steps, &Clear{
@@ -95,7 +134,7 @@ func (t *PodNetworkTransaction) Clear(source string) {
 // Append adds an item to corresponding list in podnetworkchaos
 func (t *PodNetworkTransaction) Append(item interface{}) error {
 	switch item.(type) {
-	case v1alpha1.RawIPSet, v1alpha1.RawIptables, v1alpha1.RawTrafficControl:
+	case v1alpha1.RawIPSet, v1alpha1.RawIptables, v1alpha1.RawTrafficControl, v1alpha1.RawPacketFault:
 		t.Steps = append(t.Steps, &Append{
 			Item: item,
 		})
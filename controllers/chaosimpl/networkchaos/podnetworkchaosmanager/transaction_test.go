@@ -0,0 +1,61 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podnetworkchaosmanager
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+)
+
+func TestTransactionDelayPacketFaultConflict(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	delay := v1alpha1.RawPacketFault{
+		PacketFaultSpec: v1alpha1.PacketFaultSpec{Fault: v1alpha1.DelayPacketFault, Latency: "10ms"},
+		Source:          "default/packet",
+	}
+	drop := v1alpha1.RawPacketFault{
+		PacketFaultSpec: v1alpha1.PacketFaultSpec{Fault: v1alpha1.DropPacketFault},
+		Source:          "default/packet",
+	}
+	tc := v1alpha1.RawTrafficControl{
+		Type:   v1alpha1.Netem,
+		Device: "eth0",
+		Source: "default/netem",
+	}
+
+	apply := func(chaos *v1alpha1.PodNetworkChaos, items ...interface{}) error {
+		transaction := &PodNetworkTransaction{}
+		for _, item := range items {
+			g.Expect(transaction.Append(item)).Should(Succeed())
+		}
+		return transaction.Apply(chaos)
+	}
+
+	// the delayed packets and the traffic control on the outbound traffic share the root of the device
+	g.Expect(apply(&v1alpha1.PodNetworkChaos{}, tc, delay)).ShouldNot(Succeed())
+	g.Expect(apply(&v1alpha1.PodNetworkChaos{}, delay, tc)).ShouldNot(Succeed())
+
+	// the other faults, the inbound traffic and the other devices don't conflict
+	g.Expect(apply(&v1alpha1.PodNetworkChaos{}, tc, drop)).Should(Succeed())
+	ingress := tc
+	ingress.Ingress = true
+	g.Expect(apply(&v1alpha1.PodNetworkChaos{}, ingress, delay)).Should(Succeed())
+	other := tc
+	other.Device = "eth1"
+	g.Expect(apply(&v1alpha1.PodNetworkChaos{}, other, delay)).Should(Succeed())
+}
@@ -0,0 +1,307 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolver
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/controllers/chaosimpl/networkchaos/podnetworkchaosmanager"
+	"github.com/chaos-mesh/chaos-mesh/controllers/podnetworkchaos/ipset"
+	"github.com/chaos-mesh/chaos-mesh/controllers/podnetworkchaos/netutils"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/controller"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
+)
+
+// Reconciler resolves the external targets of the networkchaos with resolve policy periodically,
+// and updates the addresses of them in the ipsets of the podnetworkchaos
+type Reconciler struct {
+	// Client is used to operate on the Kubernetes cluster
+	client.Client
+
+	// Resolve converts an external target into cidrs
+	Resolve func(name string) ([]string, error)
+
+	Recorder recorder.ChaosRecorder
+	Log      logr.Logger
+
+	// Tracker remembers when the external targets of every networkchaos are resolved
+	Tracker *controller.Tracker
+}
+
+// Reconcile the addresses of the external targets
+func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.TODO()
+
+	obj := &v1alpha1.NetworkChaos{}
+	if err := r.Client.Get(ctx, req.NamespacedName, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.Log.Info("chaos not found")
+			r.Tracker.Forget(req.NamespacedName)
+		} else {
+			// TODO: handle this error
+			r.Log.Error(err, "unable to get chaos")
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if obj.IsDeleted() || obj.Spec.ResolvePolicy == nil || len(obj.Spec.ExternalTargets) == 0 {
+		return ctrl.Result{}, nil
+	}
+	interval, err := obj.Spec.ResolvePolicy.GetInterval()
+	if err != nil || interval <= 0 {
+		r.Log.Error(err, "invalid resolve interval", "interval", obj.Spec.ResolvePolicy.Interval)
+		return ctrl.Result{}, nil
+	}
+
+	// there is no ipset to update while the experiment is paused or aborted
+	status := obj.GetStatus()
+	if status.Experiment.DesiredPhase != v1alpha1.RunningPhase || status.IsAborted() {
+		return ctrl.Result{}, nil
+	}
+
+	now := time.Now()
+	if due, wait := r.Tracker.Due(req.NamespacedName, "", interval, now); !due {
+		return ctrl.Result{RequeueAfter: wait}, nil
+	}
+	r.Tracker.Done(req.NamespacedName, "", now)
+
+	targets := []v1alpha1.ResolvedTarget{}
+	for _, name := range obj.Spec.ExternalTargets {
+		cidrs, err := r.Resolve(name)
+		if err != nil {
+			// the previous addresses are kept in the ipsets until the next resolution
+			r.Log.Error(err, "fail to resolve external target", "target", name)
+			r.Recorder.Event(obj, recorder.Failed{
+				Activity: "resolve external target " + name,
+				Err:      err.Error(),
+			})
+			return ctrl.Result{RequeueAfter: interval}, nil
+		}
+		sort.Strings(cidrs)
+
+		targets = append(targets, v1alpha1.ResolvedTarget{
+			Name:  name,
+			Cidrs: cidrs,
+		})
+	}
+
+	if reflect.DeepEqual(obj.Status.ResolvedTargets, targets) {
+		return ctrl.Result{RequeueAfter: interval}, nil
+	}
+
+	err = r.updateIPSets(ctx, obj, targets)
+	if err != nil {
+		r.Log.Error(err, "fail to update ipsets")
+		r.Recorder.Event(obj, recorder.Failed{
+			Activity: "update ipsets",
+			Err:      err.Error(),
+		})
+		// resolve again on the retry, as the status hasn't been updated
+		r.Tracker.Forget(req.NamespacedName)
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	updateError := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		obj := &v1alpha1.NetworkChaos{}
+
+		if err := r.Client.Get(ctx, req.NamespacedName, obj); err != nil {
+			r.Log.Error(err, "unable to get chaos")
+			return err
+		}
+
+		obj.Status.ResolvedTargets = targets
+		return r.Client.Update(ctx, obj)
+	})
+	if updateError != nil {
+		r.Log.Error(updateError, "fail to update")
+		r.Recorder.Event(obj, recorder.Failed{
+			Activity: "update resolved targets",
+			Err:      updateError.Error(),
+		})
+		r.Tracker.Forget(req.NamespacedName)
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	for _, target := range changedTargets(obj.Status.ResolvedTargets, targets) {
+		r.Log.Info("external target is resolved into new addresses", "target", target.Name, "cidrs", target.Cidrs)
+		r.Recorder.Event(obj, recorder.ExternalTargetResolved{
+			Name: target.Name,
+		})
+	}
+	r.Recorder.Event(obj, recorder.Updated{
+		Field: "resolvedTargets",
+	})
+	return ctrl.Result{RequeueAfter: interval}, nil
+}
+
+// updateIPSets replaces the addresses of the external targets in the ipsets of the networkchaos on every
+// selected pod and node, the addresses of the pods and nodes in the ipsets are kept. The podnetworkchaos
+// controller will flush the updated ipsets into the pods and nodes.
+func (r *Reconciler) updateIPSets(ctx context.Context, networkchaos *v1alpha1.NetworkChaos, targets []v1alpha1.ResolvedTarget) error {
+	source := networkchaos.Namespace + "/" + networkchaos.Name
+
+	externalCidrs := []string{}
+	for _, target := range targets {
+		externalCidrs = append(externalCidrs, target.Cidrs...)
+	}
+
+	var records []*v1alpha1.Record
+	var ids []string
+	podCidrs := make(map[string]struct{})
+	for _, record := range networkchaos.Status.Experiment.Records {
+		if record.Phase == v1alpha1.Gone || containsID(ids, record.Id) {
+			continue
+		}
+		records = append(records, record)
+		ids = append(ids, record.Id)
+
+		var ips []string
+		if podnetworkchaosmanager.IsNodeRecord(networkchaos, record) {
+			var node v1.Node
+			err := r.Client.Get(ctx, podnetworkchaosmanager.RecordKey(networkchaos, record), &node)
+			if err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return err
+			}
+			ips = ipset.NodeIPs(&node)
+		} else {
+			var pod v1.Pod
+			err := r.Client.Get(ctx, controller.ParseNamespacedName(record.Id), &pod)
+			if err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return err
+			}
+			ips = ipset.PodIPs(&pod)
+		}
+		for _, ip := range ips {
+			podCidrs[netutils.IPToCidr(ip)] = struct{}{}
+		}
+	}
+
+	for _, record := range records {
+		key := podnetworkchaosmanager.RecordKey(networkchaos, record)
+		isNode := podnetworkchaosmanager.IsNodeRecord(networkchaos, record)
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			var chaos runtime.Object
+			var spec *v1alpha1.PodNetworkChaosSpec
+			if isNode {
+				nodenetworkchaos := &v1alpha1.NodeNetworkChaos{}
+				chaos, spec = nodenetworkchaos, &nodenetworkchaos.Spec
+			} else {
+				podnetworkchaos := &v1alpha1.PodNetworkChaos{}
+				chaos, spec = podnetworkchaos, &podnetworkchaos.Spec
+			}
+			if err := r.Client.Get(ctx, key, chaos); err != nil {
+				return client.IgnoreNotFound(err)
+			}
+
+			if !replaceExternalCidrs(spec, source, externalCidrs, podCidrs) {
+				return nil
+			}
+
+			r.Log.Info("updating ipsets", "key", key, "node", isNode, "ipsets", spec.IPSets)
+			return r.Client.Update(ctx, chaos)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// replaceExternalCidrs replaces the addresses other than the ones of the pods and nodes in the ipsets
+// of the source with the external cidrs. It returns whether the ipsets have been changed.
+func replaceExternalCidrs(spec *v1alpha1.PodNetworkChaosSpec, source string, externalCidrs []string, podCidrs map[string]struct{}) bool {
+	changed := false
+	for i, set := range spec.IPSets {
+		if set.Source != source {
+			continue
+		}
+
+		cidrs := append([]string{}, externalCidrs...)
+		for _, cidr := range set.Cidrs {
+			if _, ok := podCidrs[cidr]; ok {
+				cidrs = append(cidrs, cidr)
+			}
+		}
+		if !reflect.DeepEqual(set.Cidrs, cidrs) {
+			spec.IPSets[i].Cidrs = cidrs
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// changedTargets returns the targets whose addresses are different from the previous ones
+func changedTargets(previous []v1alpha1.ResolvedTarget, current []v1alpha1.ResolvedTarget) []v1alpha1.ResolvedTarget {
+	changed := []v1alpha1.ResolvedTarget{}
+	for _, target := range current {
+		found := false
+		for _, p := range previous {
+			if p.Name == target.Name && reflect.DeepEqual(p.Cidrs, target.Cidrs) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			changed = append(changed, target)
+		}
+	}
+	return changed
+}
+
+func containsID(ids []string, id string) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
+// ExternalCidrs returns the cidrs of the external targets of the networkchaos. The addresses recorded by the
+// resolve policy are used if they are present, so all the ipsets of the networkchaos share the same addresses.
+func ExternalCidrs(networkchaos *v1alpha1.NetworkChaos) ([]string, error) {
+	resolved := networkchaos.Status.ResolvedTargets
+	if len(resolved) != len(networkchaos.Spec.ExternalTargets) {
+		return netutils.ResolveCidrs(networkchaos.Spec.ExternalTargets)
+	}
+
+	cidrs := []string{}
+	for i, name := range networkchaos.Spec.ExternalTargets {
+		if resolved[i].Name != name {
+			return netutils.ResolveCidrs(networkchaos.Spec.ExternalTargets)
+		}
+		cidrs = append(cidrs, resolved[i].Cidrs...)
+	}
+	return cidrs, nil
+}
@@ -0,0 +1,250 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sTypes "k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/cmd/chaos-controller-manager/provider"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/controller"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
+)
+
+func TestReconcile(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	name := k8sTypes.NamespacedName{Namespace: "default", Name: "chaos"}
+	chaos := &v1alpha1.NetworkChaos{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: name.Namespace,
+			Name:      name.Name,
+		},
+		Spec: v1alpha1.NetworkChaosSpec{
+			Action:          v1alpha1.PartitionAction,
+			ExternalTargets: []string{"example.com"},
+			ResolvePolicy:   &v1alpha1.ResolvePolicy{Interval: "1m"},
+		},
+		Status: v1alpha1.NetworkChaosStatus{
+			ChaosStatus: v1alpha1.ChaosStatus{
+				Experiment: v1alpha1.ExperimentStatus{
+					DesiredPhase: v1alpha1.RunningPhase,
+					Records: []*v1alpha1.Record{
+						{Id: "default/source", SelectorKey: ".", Phase: v1alpha1.Injected},
+						{Id: "default/target", SelectorKey: ".Target", Phase: v1alpha1.Injected},
+					},
+				},
+			},
+		},
+	}
+	pods := []*v1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "source"},
+			Status:     v1.PodStatus{PodIP: "10.0.0.1"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "target"},
+			Status:     v1.PodStatus{PodIP: "10.0.0.2"},
+		},
+	}
+	podnetworkchaos := &v1alpha1.PodNetworkChaos{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "source"},
+		Spec: v1alpha1.PodNetworkChaosSpec{
+			IPSets: []v1alpha1.RawIPSet{
+				{
+					Name:          "chaos_tgt",
+					Cidrs:         []string{"1.1.1.1/32", "10.0.0.2/32"},
+					RawRuleSource: v1alpha1.RawRuleSource{Source: "default/chaos"},
+				},
+				{
+					Name:          "other_tgt",
+					Cidrs:         []string{"1.1.1.1/32"},
+					RawRuleSource: v1alpha1.RawRuleSource{Source: "default/other"},
+				},
+			},
+		},
+	}
+
+	addresses := []string{"2.2.2.2/32"}
+	var resolveError error
+	r := &Reconciler{
+		Client: fake.NewFakeClientWithScheme(provider.NewScheme(), chaos, pods[0], pods[1], podnetworkchaos),
+		Resolve: func(name string) ([]string, error) {
+			return addresses, resolveError
+		},
+		Recorder: recorder.NewDebugRecorder(),
+		Log:      ctrl.Log.WithName("test"),
+		Tracker:  controller.NewTracker(),
+	}
+
+	reconcile := func() time.Duration {
+		result, err := r.Reconcile(ctrl.Request{NamespacedName: name})
+		g.Expect(err).ShouldNot(HaveOccurred())
+		return result.RequeueAfter
+	}
+	getIPSets := func() []v1alpha1.RawIPSet {
+		obj := &v1alpha1.PodNetworkChaos{}
+		g.Expect(r.Client.Get(context.TODO(), k8sTypes.NamespacedName{Namespace: "default", Name: "source"}, obj)).Should(Succeed())
+		return obj.Spec.IPSets
+	}
+	getResolved := func() []v1alpha1.ResolvedTarget {
+		obj := &v1alpha1.NetworkChaos{}
+		g.Expect(r.Client.Get(context.TODO(), name, obj)).Should(Succeed())
+		return obj.Status.ResolvedTargets
+	}
+
+	// the addresses of the external target are replaced, while the ones of the pods and other chaos are kept
+	g.Expect(reconcile()).To(Equal(time.Minute))
+	g.Expect(getResolved()).To(Equal([]v1alpha1.ResolvedTarget{{Name: "example.com", Cidrs: []string{"2.2.2.2/32"}}}))
+	ipsets := getIPSets()
+	g.Expect(ipsets[0].Cidrs).To(Equal([]string{"2.2.2.2/32", "10.0.0.2/32"}))
+	g.Expect(ipsets[1].Cidrs).To(Equal([]string{"1.1.1.1/32"}))
+
+	// the domain isn't resolved again until the interval passes
+	addresses = []string{"3.3.3.3/32"}
+	g.Expect(reconcile()).To(BeNumerically("<=", time.Minute))
+	g.Expect(getIPSets()[0].Cidrs).To(Equal([]string{"2.2.2.2/32", "10.0.0.2/32"}))
+
+	// the previous addresses are kept if the domain fails to be resolved
+	r.Tracker.Forget(name)
+	resolveError = errors.New("no such host")
+	reconcile()
+	g.Expect(getResolved()[0].Cidrs).To(Equal([]string{"2.2.2.2/32"}))
+	g.Expect(getIPSets()[0].Cidrs).To(Equal([]string{"2.2.2.2/32", "10.0.0.2/32"}))
+
+	r.Tracker.Forget(name)
+	resolveError = nil
+	reconcile()
+	g.Expect(getResolved()[0].Cidrs).To(Equal([]string{"3.3.3.3/32"}))
+	g.Expect(getIPSets()[0].Cidrs).To(Equal([]string{"3.3.3.3/32", "10.0.0.2/32"}))
+}
+
+func TestReconcileNodeNetwork(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	name := k8sTypes.NamespacedName{Namespace: "default", Name: "chaos"}
+	chaos := &v1alpha1.NetworkChaos{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: name.Namespace,
+			Name:      name.Name,
+		},
+		Spec: v1alpha1.NetworkChaosSpec{
+			Action:          v1alpha1.PartitionAction,
+			Direction:       v1alpha1.Both,
+			ExternalTargets: []string{"example.com"},
+			ResolvePolicy:   &v1alpha1.ResolvePolicy{Interval: "1m"},
+			NodeNetwork:     true,
+		},
+		Status: v1alpha1.NetworkChaosStatus{
+			ChaosStatus: v1alpha1.ChaosStatus{
+				Experiment: v1alpha1.ExperimentStatus{
+					DesiredPhase: v1alpha1.RunningPhase,
+					Records: []*v1alpha1.Record{
+						{Id: "node1", SelectorKey: ".", Phase: v1alpha1.Injected},
+						{Id: "default/target", SelectorKey: ".Target", Phase: v1alpha1.Injected},
+					},
+				},
+			},
+		},
+	}
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+		Status: v1.NodeStatus{
+			Addresses: []v1.NodeAddress{{Type: v1.NodeInternalIP, Address: "192.168.0.1"}},
+		},
+	}
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "target"},
+		Status:     v1.PodStatus{PodIP: "10.0.0.2"},
+	}
+	nodenetworkchaos := &v1alpha1.NodeNetworkChaos{
+		ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+		Spec: v1alpha1.PodNetworkChaosSpec{
+			IPSets: []v1alpha1.RawIPSet{
+				{
+					Name:          "chaos_tgt",
+					Cidrs:         []string{"1.1.1.1/32", "10.0.0.2/32"},
+					RawRuleSource: v1alpha1.RawRuleSource{Source: "default/chaos"},
+				},
+			},
+		},
+	}
+	podnetworkchaos := &v1alpha1.PodNetworkChaos{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "target"},
+		Spec: v1alpha1.PodNetworkChaosSpec{
+			IPSets: []v1alpha1.RawIPSet{
+				{
+					Name:          "chaos_src",
+					Cidrs:         []string{"1.1.1.1/32", "192.168.0.1/32"},
+					RawRuleSource: v1alpha1.RawRuleSource{Source: "default/chaos"},
+				},
+			},
+		},
+	}
+
+	r := &Reconciler{
+		Client: fake.NewFakeClientWithScheme(provider.NewScheme(), chaos, node, pod, nodenetworkchaos, podnetworkchaos),
+		Resolve: func(name string) ([]string, error) {
+			return []string{"2.2.2.2/32"}, nil
+		},
+		Recorder: recorder.NewDebugRecorder(),
+		Log:      ctrl.Log.WithName("test"),
+		Tracker:  controller.NewTracker(),
+	}
+
+	_, err := r.Reconcile(ctrl.Request{NamespacedName: name})
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	// the addresses of the pods and the nodes are kept in the ipsets of both the node and the pod
+	updatedNode := &v1alpha1.NodeNetworkChaos{}
+	g.Expect(r.Client.Get(context.TODO(), k8sTypes.NamespacedName{Name: "node1"}, updatedNode)).Should(Succeed())
+	g.Expect(updatedNode.Spec.IPSets[0].Cidrs).To(Equal([]string{"2.2.2.2/32", "10.0.0.2/32"}))
+
+	updatedPod := &v1alpha1.PodNetworkChaos{}
+	g.Expect(r.Client.Get(context.TODO(), k8sTypes.NamespacedName{Namespace: "default", Name: "target"}, updatedPod)).Should(Succeed())
+	g.Expect(updatedPod.Spec.IPSets[0].Cidrs).To(Equal([]string{"2.2.2.2/32", "192.168.0.1/32"}))
+}
+
+func TestExternalCidrs(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	chaos := &v1alpha1.NetworkChaos{
+		Spec: v1alpha1.NetworkChaosSpec{
+			ExternalTargets: []string{"192.168.0.0/24", "10.0.0.1"},
+		},
+	}
+
+	// the external targets are resolved if they haven't been recorded
+	cidrs, err := ExternalCidrs(chaos)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(cidrs).To(Equal([]string{"192.168.0.0/24", "10.0.0.1/32"}))
+
+	chaos.Status.ResolvedTargets = []v1alpha1.ResolvedTarget{
+		{Name: "192.168.0.0/24", Cidrs: []string{"192.168.0.0/24"}},
+		{Name: "10.0.0.1", Cidrs: []string{"10.0.0.2/32"}},
+	}
+	cidrs, err = ExternalCidrs(chaos)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(cidrs).To(Equal([]string{"192.168.0.0/24", "10.0.0.2/32"}))
+}
@@ -0,0 +1,45 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolver
+
+import (
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/controllers/podnetworkchaos/netutils"
+	"github.com/chaos-mesh/chaos-mesh/controllers/types"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/builder"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/controller"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
+)
+
+func NewController(mgr ctrl.Manager, client client.Client, logger logr.Logger, recorderBuilder *recorder.RecorderBuilder) (types.Controller, error) {
+	err := builder.Default(mgr).
+		For(&v1alpha1.NetworkChaos{}).
+		Named("networkchaos-resolver").
+		Complete(&Reconciler{
+			Client:   client,
+			Resolve:  netutils.ResolveCidr,
+			Recorder: recorderBuilder.Build("networkchaos-resolver"),
+			Log:      logger.WithName("networkchaos-resolver"),
+			Tracker:  controller.NewTracker(),
+		})
+	if err != nil {
+		return "", err
+	}
+
+	return "networkchaos-resolver", nil
+}
@@ -27,8 +27,9 @@ import (
 
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
 	"github.com/chaos-mesh/chaos-mesh/controllers/chaosimpl/networkchaos/podnetworkchaosmanager"
+	"github.com/chaos-mesh/chaos-mesh/controllers/chaosimpl/networkchaos/resolver"
+	podnetworkchaosctrl "github.com/chaos-mesh/chaos-mesh/controllers/podnetworkchaos"
 	"github.com/chaos-mesh/chaos-mesh/controllers/podnetworkchaos/ipset"
-	"github.com/chaos-mesh/chaos-mesh/controllers/podnetworkchaos/netutils"
 	"github.com/chaos-mesh/chaos-mesh/controllers/utils/controller"
 )
 
@@ -37,8 +38,9 @@ const (
 	networkChaosSourceMsg = "This is a source pod."
 	networkChaosTargetMsg = "This is a target pod."
 
-	targetIPSetPostFix = "tgt"
-	sourceIPSetPostFix = "src"
+	targetIPSetPostFix  = "tgt"
+	sourceIPSetPostFix  = "src"
+	ingressIPSetPostFix = "ing"
 )
 
 const (
@@ -70,8 +72,7 @@ func (impl *Impl) Apply(ctx context.Context, index int, records []*v1alpha1.Reco
 	phase := record.Phase
 
 	if phase == waitForApplySync {
-		podnetworkchaos := &v1alpha1.PodNetworkChaos{}
-		err := impl.Client.Get(ctx, controller.ParseNamespacedName(record.Id), podnetworkchaos)
+		chaosStatus, err := podnetworkchaosmanager.GetStatus(ctx, impl.Client, networkchaos, record)
 		if err != nil {
 			if k8sError.IsNotFound(err) {
 				return v1alpha1.NotInjected, nil
@@ -86,84 +87,130 @@ func (impl *Impl) Apply(ctx context.Context, index int, records []*v1alpha1.Reco
 			return waitForApplySync, err
 		}
 
-		if podnetworkchaos.Status.FailedMessage != "" {
-			return waitForApplySync, errors.New(podnetworkchaos.Status.FailedMessage)
+		if chaosStatus.FailedMessage != "" {
+			return waitForApplySync, errors.New(chaosStatus.FailedMessage)
 		}
 
-		if podnetworkchaos.Status.ObservedGeneration >= networkchaos.Status.Instances[record.Id] {
+		if chaosStatus.ObservedGeneration >= networkchaos.Status.Instances[record.Id] {
 			return v1alpha1.Injected, nil
 		}
 
 		return waitForApplySync, nil
 	}
 
-	var pod v1.Pod
-	err := impl.Client.Get(ctx, controller.ParseNamespacedName(record.Id), &pod)
+	if record.SelectorKey != "." && record.SelectorKey != ".Target" {
+		impl.Log.Info("unknown selector key", "record", record)
+		return v1alpha1.NotInjected, nil
+	}
+
+	m, shouldCommit, err := impl.prepare(ctx, index, records, networkchaos)
 	if err != nil {
-		// TODO: handle this error
 		return v1alpha1.NotInjected, err
 	}
+	if !shouldCommit {
+		return v1alpha1.Injected, nil
+	}
 
+	generationNumber, err := m.Commit(ctx, networkchaos)
+	if err != nil {
+		return v1alpha1.NotInjected, err
+	}
+
+	// modify the custom status
+	networkchaos.Status.Instances[record.Id] = generationNumber
+	return waitForApplySync, nil
+}
+
+// DryRun renders the commands which would be executed on the pod of the record
+func (impl *Impl) DryRun(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) ([]string, error) {
+	networkchaos := obj.(*v1alpha1.NetworkChaos)
+
+	m, shouldCommit, err := impl.prepare(ctx, index, records, networkchaos)
+	if err != nil || !shouldCommit {
+		return nil, err
+	}
+
+	podnetworkchaos, err := m.DryRun(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return podnetworkchaosctrl.RenderCommands(podnetworkchaos)
+}
+
+// prepare appends the modification of the podnetworkchaos for the record into the manager,
+// shouldCommit is false if nothing needs to be changed on the pod
+func (impl *Impl) prepare(ctx context.Context, index int, records []*v1alpha1.Record, networkchaos *v1alpha1.NetworkChaos) (*podnetworkchaosmanager.PodNetworkManager, bool, error) {
+	record := records[index]
 	source := networkchaos.Namespace + "/" + networkchaos.Name
-	m := impl.builder.WithInit(source, types.NamespacedName{
-		Namespace: pod.Namespace,
-		Name:      pod.Name,
-	})
+
+	var m *podnetworkchaosmanager.PodNetworkManager
+	if podnetworkchaosmanager.IsNodeRecord(networkchaos, record) {
+		var node v1.Node
+		err := impl.Client.Get(ctx, podnetworkchaosmanager.RecordKey(networkchaos, record), &node)
+		if err != nil {
+			return nil, false, err
+		}
+
+		m = impl.builder.WithInitForNode(source, node.Name)
+	} else {
+		var pod v1.Pod
+		err := impl.Client.Get(ctx, controller.ParseNamespacedName(record.Id), &pod)
+		if err != nil {
+			// TODO: handle this error
+			return nil, false, err
+		}
+
+		m = impl.builder.WithInit(source, types.NamespacedName{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+		})
+	}
 
 	if record.SelectorKey == "." {
-		if networkchaos.Spec.Direction == v1alpha1.To || networkchaos.Spec.Direction == v1alpha1.Both {
-			var targets []*v1alpha1.Record
-			for _, record := range records {
-				if record.SelectorKey == ".Target" {
-					targets = append(targets, record)
-				}
+		var targets []*v1alpha1.Record
+		for _, record := range records {
+			if record.SelectorKey == ".Target" && record.Phase != v1alpha1.Gone {
+				targets = append(targets, record)
 			}
+		}
 
-			err := impl.ApplyTc(ctx, m, targets, networkchaos, targetIPSetPostFix)
+		shouldCommit := false
+		if networkchaos.Spec.Direction == v1alpha1.To || networkchaos.Spec.Direction == v1alpha1.Both {
+			err := impl.ApplyTc(ctx, m, targets, networkchaos, targetIPSetPostFix, false)
 			if err != nil {
-				return v1alpha1.NotInjected, err
+				return nil, false, err
 			}
+			shouldCommit = true
+		}
 
-			generationNumber, err := m.Commit(ctx, networkchaos)
+		// with ingress, the traffic from the targets is shaped on the inbound traffic of the pod itself
+		if networkchaos.Spec.Ingress && (networkchaos.Spec.Direction == v1alpha1.From || networkchaos.Spec.Direction == v1alpha1.Both) {
+			err := impl.ApplyTc(ctx, m, targets, networkchaos, ingressIPSetPostFix, true)
 			if err != nil {
-				return v1alpha1.NotInjected, err
+				return nil, false, err
 			}
-
-			// modify the custom status
-			networkchaos.Status.Instances[record.Id] = generationNumber
-			return waitForApplySync, nil
+			shouldCommit = true
 		}
 
-		return v1alpha1.Injected, nil
-	} else if record.SelectorKey == ".Target" {
+		return m, shouldCommit, nil
+	} else if !networkchaos.Spec.Ingress {
 		if networkchaos.Spec.Direction == v1alpha1.From || networkchaos.Spec.Direction == v1alpha1.Both {
 			var targets []*v1alpha1.Record
 			for _, record := range records {
-				if record.SelectorKey == "." {
+				if record.SelectorKey == "." && record.Phase != v1alpha1.Gone {
 					targets = append(targets, record)
 				}
 			}
 
-			err := impl.ApplyTc(ctx, m, targets, networkchaos, sourceIPSetPostFix)
+			err := impl.ApplyTc(ctx, m, targets, networkchaos, sourceIPSetPostFix, false)
 			if err != nil {
-				return v1alpha1.NotInjected, err
+				return nil, false, err
 			}
-
-			generationNumber, err := m.Commit(ctx, networkchaos)
-			if err != nil {
-				return v1alpha1.NotInjected, err
-			}
-
-			// modify the custom status
-			networkchaos.Status.Instances[record.Id] = generationNumber
-			return waitForApplySync, nil
+			return m, true, nil
 		}
-
-		return v1alpha1.Injected, nil
-	} else {
-		impl.Log.Info("unknown selector key", "record", record)
-		return v1alpha1.NotInjected, nil
 	}
+
+	return m, false, nil
 }
 
 func (impl *Impl) Recover(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) (v1alpha1.Phase, error) {
@@ -181,8 +228,7 @@ func (impl *Impl) Recover(ctx context.Context, index int, records []*v1alpha1.Re
 	phase := record.Phase
 
 	if phase == waitForRecoverSync {
-		podnetworkchaos := &v1alpha1.PodNetworkChaos{}
-		err := impl.Client.Get(ctx, controller.ParseNamespacedName(record.Id), podnetworkchaos)
+		chaosStatus, err := podnetworkchaosmanager.GetStatus(ctx, impl.Client, networkchaos, record)
 		if err != nil {
 			// TODO: handle this error
 			if k8sError.IsNotFound(err) {
@@ -191,42 +237,48 @@ func (impl *Impl) Recover(ctx context.Context, index int, records []*v1alpha1.Re
 			return waitForRecoverSync, err
 		}
 
-		if podnetworkchaos.Status.FailedMessage != "" {
-			return waitForRecoverSync, errors.New(podnetworkchaos.Status.FailedMessage)
+		if chaosStatus.FailedMessage != "" {
+			return waitForRecoverSync, errors.New(chaosStatus.FailedMessage)
 		}
 
-		if podnetworkchaos.Status.ObservedGeneration >= networkchaos.Status.Instances[record.Id] {
+		if chaosStatus.ObservedGeneration >= networkchaos.Status.Instances[record.Id] {
 			return v1alpha1.NotInjected, nil
 		}
 
 		return waitForRecoverSync, nil
 	}
 
-	var pod v1.Pod
-	err := impl.Client.Get(ctx, controller.ParseNamespacedName(record.Id), &pod)
-	if err != nil {
-		// TODO: handle this error
-		if k8sError.IsNotFound(err) {
-			return v1alpha1.NotInjected, nil
-		}
-
-		if k8sError.IsForbidden(err) {
-			if strings.Contains(err.Error(), "because it is being terminated") {
+	source := networkchaos.Namespace + "/" + networkchaos.Name
+	var m *podnetworkchaosmanager.PodNetworkManager
+	if podnetworkchaosmanager.IsNodeRecord(networkchaos, record) {
+		m = impl.builder.WithInitForNode(source, record.Id)
+	} else {
+		var pod v1.Pod
+		err := impl.Client.Get(ctx, controller.ParseNamespacedName(record.Id), &pod)
+		if err != nil {
+			// TODO: handle this error
+			if k8sError.IsNotFound(err) {
 				return v1alpha1.NotInjected, nil
 			}
+
+			if k8sError.IsForbidden(err) {
+				if strings.Contains(err.Error(), "because it is being terminated") {
+					return v1alpha1.NotInjected, nil
+				}
+			}
+			return v1alpha1.Injected, err
 		}
-		return v1alpha1.Injected, err
-	}
 
-	source := networkchaos.Namespace + "/" + networkchaos.Name
-	// TODO: use the DI but not construct it manually
-	m := impl.builder.WithInit(source, types.NamespacedName{
-		Namespace: pod.Namespace,
-		Name:      pod.Name,
-	})
+		// TODO: use the DI but not construct it manually
+		m = impl.builder.WithInit(source, types.NamespacedName{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+		})
+	}
 	generationNumber, err := m.Commit(ctx, networkchaos)
 	if err != nil {
-		if err == podnetworkchaosmanager.ErrPodNotFound || err == podnetworkchaosmanager.ErrPodNotRunning {
+		if err == podnetworkchaosmanager.ErrPodNotFound || err == podnetworkchaosmanager.ErrPodNotRunning ||
+			err == podnetworkchaosmanager.ErrNodeNotFound {
 			return v1alpha1.NotInjected, nil
 		}
 		return v1alpha1.Injected, err
@@ -237,7 +289,9 @@ func (impl *Impl) Recover(ctx context.Context, index int, records []*v1alpha1.Re
 	return waitForRecoverSync, nil
 }
 
-func (impl *Impl) ApplyTc(ctx context.Context, m *podnetworkchaosmanager.PodNetworkManager, targets []*v1alpha1.Record, networkchaos *v1alpha1.NetworkChaos, ipSetPostFix string) error {
+// ApplyTc appends the traffic control or the packet fault between the pod and the targets into the manager, which
+// is set on the inbound traffic of the pod if ingress is true, otherwise the outbound traffic
+func (impl *Impl) ApplyTc(ctx context.Context, m *podnetworkchaosmanager.PodNetworkManager, targets []*v1alpha1.Record, networkchaos *v1alpha1.NetworkChaos, ipSetPostFix string, ingress bool) error {
 	spec := networkchaos.Spec
 	tcType := v1alpha1.Bandwidth
 	switch spec.Action {
@@ -245,49 +299,67 @@ func (impl *Impl) ApplyTc(ctx context.Context, m *podnetworkchaosmanager.PodNetw
 		tcType = v1alpha1.Netem
 	case v1alpha1.BandwidthAction:
 		tcType = v1alpha1.Bandwidth
+	case v1alpha1.PacketAction:
+		if spec.PacketFault == nil {
+			return fmt.Errorf("packet fault is required in packet action")
+		}
 	default:
 		return fmt.Errorf("unknown action %s", spec.Action)
 	}
 
-	externalCidrs, err := netutils.ResolveCidrs(networkchaos.Spec.ExternalTargets)
+	externalCidrs, err := resolver.ExternalCidrs(networkchaos)
 	if err != nil {
 		return err
 	}
 
 	if len(targets)+len(externalCidrs) == 0 {
 		impl.Log.Info("apply traffic control", "sources", m.Source)
-		m.T.Append(v1alpha1.RawTrafficControl{
-			Type:        tcType,
-			TcParameter: spec.TcParameter,
-			Source:      m.Source,
-		})
+		m.T.Append(buildRule(spec, tcType, ingress, m.Device(networkchaos), "", m.Source))
 		return nil
 	}
 
-	targetPods := []v1.Pod{}
-	for _, record := range targets {
-		var pod v1.Pod
-		err := impl.Client.Get(ctx, controller.ParseNamespacedName(record.Id), &pod)
-		if err != nil {
-			// TODO: handle this error
-			return err
-		}
-		targetPods = append(targetPods, pod)
+	targetPods, nodeCidrs, err := podnetworkchaosmanager.GetTargets(ctx, impl.Client, networkchaos, targets)
+	if err != nil {
+		// TODO: handle this error
+		return err
+	}
+	ipSetPrefix := string(tcType[0:2])
+	if spec.Action == v1alpha1.PacketAction {
+		ipSetPrefix = string(v1alpha1.PacketAction[0:2])
 	}
-	dstIpset := ipset.BuildIPSet(targetPods, externalCidrs, networkchaos, string(tcType[0:2])+ipSetPostFix, m.Source)
+	dstIpset := ipset.BuildIPSet(targetPods, append(nodeCidrs, externalCidrs...), networkchaos, ipSetPrefix+ipSetPostFix, m.Source)
 	impl.Log.Info("apply traffic control with filter", "sources", m.Source, "ipset", dstIpset)
 
 	m.T.Append(dstIpset)
-	m.T.Append(v1alpha1.RawTrafficControl{
-		Type:        tcType,
-		TcParameter: spec.TcParameter,
-		Source:      m.Source,
-		IPSet:       dstIpset.Name,
-	})
+	m.T.Append(buildRule(spec, tcType, ingress, m.Device(networkchaos), dstIpset.Name, m.Source))
 
 	return nil
 }
 
+// buildRule builds the packet fault in packet action, otherwise the traffic control of the type on the device,
+// which only applies on the packets to the ipset if it's not empty
+func buildRule(spec v1alpha1.NetworkChaosSpec, tcType v1alpha1.TcType, ingress bool, device string, ipset string, source string) interface{} {
+	if spec.Action == v1alpha1.PacketAction {
+		return v1alpha1.RawPacketFault{
+			PacketFaultSpec: *spec.PacketFault,
+			IPSet:           ipset,
+			PacketFilter:    spec.PacketFilter,
+			Device:          device,
+			Source:          source,
+		}
+	}
+
+	return v1alpha1.RawTrafficControl{
+		Type:         tcType,
+		TcParameter:  spec.TcParameter,
+		PacketFilter: spec.PacketFilter,
+		Ingress:      ingress,
+		Device:       device,
+		Source:       source,
+		IPSet:        ipset,
+	}
+}
+
 func NewImpl(c client.Client, b *podnetworkchaosmanager.Builder, log logr.Logger) *Impl {
 	return &Impl{
 		Client:  c,
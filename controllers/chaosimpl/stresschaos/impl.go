@@ -15,6 +15,7 @@ package stresschaos
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -25,6 +26,8 @@ import (
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
 	"github.com/chaos-mesh/chaos-mesh/controllers/chaosimpl/utils"
 	"github.com/chaos-mesh/chaos-mesh/controllers/common"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/chaosdaemon"
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/command"
 	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
 )
 
@@ -57,20 +60,18 @@ func (impl *Impl) Apply(ctx context.Context, index int, records []*v1alpha1.Reco
 		return v1alpha1.Injected, nil
 	}
 
-	stressors := stresschaos.Spec.StressngStressors
-	if len(stressors) == 0 {
-		stressors, err = stresschaos.Spec.Stressors.Normalize()
-		if err != nil {
-			impl.Log.Info("fail to ")
-			// TODO: add an event here
-			return v1alpha1.NotInjected, err
-		}
+	stressors, err := stressorsOf(stresschaos)
+	if err != nil {
+		impl.Log.Info("fail to ")
+		// TODO: add an event here
+		return v1alpha1.NotInjected, err
 	}
 	res, err := pbClient.ExecStressors(ctx, &pb.ExecStressRequest{
 		Scope:     pb.ExecStressRequest_CONTAINER,
 		Target:    containerId,
 		Stressors: stressors,
 		EnterNS:   true,
+		Lease:     leaseOf(stresschaos, records[index]),
 	})
 	if err != nil {
 		return v1alpha1.NotInjected, err
@@ -87,6 +88,23 @@ func (impl *Impl) Apply(ctx context.Context, index int, records []*v1alpha1.Reco
 	return v1alpha1.Injected, nil
 }
 
+// DryRun renders the stress-ng command which would be executed in the container
+func (impl *Impl) DryRun(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) ([]string, error) {
+	stressors, err := stressorsOf(obj.(*v1alpha1.StressChaos))
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{command.Render(command.StressNg, strings.Fields(stressors)...)}, nil
+}
+
+func stressorsOf(stresschaos *v1alpha1.StressChaos) (string, error) {
+	if len(stresschaos.Spec.StressngStressors) > 0 {
+		return stresschaos.Spec.StressngStressors, nil
+	}
+	return stresschaos.Spec.Stressors.Normalize()
+}
+
 func (impl *Impl) Recover(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) (v1alpha1.Phase, error) {
 	decodedContainer, err := impl.decoder.DecodeContainerRecord(ctx, records[index])
 	pbClient := decodedContainer.PbClient
@@ -113,6 +131,7 @@ func (impl *Impl) Recover(ctx context.Context, index int, records []*v1alpha1.Re
 	if _, err = pbClient.CancelStressors(ctx, &pb.CancelStressRequest{
 		Instance:  instance.UID,
 		StartTime: instance.StartTime.UnixNano() / int64(time.Millisecond),
+		Lease:     leaseOf(stresschaos, records[index]),
 	}); err != nil {
 		// TODO: check whether the erorr still exists
 		return v1alpha1.Injected, nil
@@ -121,6 +140,31 @@ func (impl *Impl) Recover(ctx context.Context, index int, records []*v1alpha1.Re
 	return v1alpha1.NotInjected, nil
 }
 
+// Renew renews the lease of the stressors, the instance is forgotten if the lease has expired
+// as the stressors have been killed by chaos-daemon
+func (impl *Impl) Renew(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) (bool, error) {
+	decodedContainer, err := impl.decoder.DecodeContainerRecord(ctx, records[index])
+	pbClient := decodedContainer.PbClient
+	if pbClient != nil {
+		defer pbClient.Close()
+	}
+	if err != nil {
+		return false, err
+	}
+
+	stresschaos := obj.(*v1alpha1.StressChaos)
+	expired, err := chaosdaemon.RenewLeases(ctx, pbClient, leaseOf(stresschaos, records[index]))
+	if expired {
+		delete(stresschaos.Status.Instances, records[index].Id)
+	}
+	return expired, err
+}
+
+// leaseOf returns the lease of the stressors in the container of the record
+func leaseOf(stresschaos *v1alpha1.StressChaos, record *v1alpha1.Record) *pb.LeaseRequest {
+	return chaosdaemon.Lease("stresschaos", stresschaos.Namespace, stresschaos.Name, record.Id)
+}
+
 func NewImpl(c client.Client, log logr.Logger, decoder *utils.ContianerRecordDecoder) *common.ChaosImplPair {
 	return &common.ChaosImplPair{
 		Name:   "stresschaos",
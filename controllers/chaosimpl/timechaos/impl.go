@@ -15,6 +15,7 @@ package timechaos
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -24,6 +25,7 @@ import (
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
 	"github.com/chaos-mesh/chaos-mesh/controllers/chaosimpl/utils"
 	"github.com/chaos-mesh/chaos-mesh/controllers/common"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/chaosdaemon"
 	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
 	timeUtils "github.com/chaos-mesh/chaos-mesh/pkg/time/utils"
 )
@@ -46,30 +48,54 @@ func (impl *Impl) Apply(ctx context.Context, index int, records []*v1alpha1.Reco
 	}
 
 	timechaos := obj.(*v1alpha1.TimeChaos)
-	mask, err := timeUtils.EncodeClkIds(timechaos.Spec.ClockIds)
+	req, err := timeRequestOf(timechaos)
 	if err != nil {
 		return v1alpha1.NotInjected, err
 	}
+	req.ContainerId = containerId
+	req.Lease = leaseOf(timechaos, records[index])
 
-	duration, err := time.ParseDuration(timechaos.Spec.TimeOffset)
+	impl.Log.Info("setting time shift", "mask", req.ClkIdsMask, "sec", req.Sec, "nsec", req.Nsec, "containerId", containerId)
+	_, err = pbClient.SetTimeOffset(ctx, req)
 	if err != nil {
 		return v1alpha1.NotInjected, err
 	}
 
-	sec, nsec := secAndNSecFromDuration(duration)
+	return v1alpha1.Injected, nil
+}
 
-	impl.Log.Info("setting time shift", "mask", mask, "sec", sec, "nsec", nsec, "containerId", containerId)
-	_, err = pbClient.SetTimeOffset(ctx, &pb.TimeRequest{
-		ContainerId: containerId,
-		Sec:         sec,
-		Nsec:        nsec,
-		ClkIdsMask:  mask,
-	})
+// DryRun renders the request which would be sent to chaos-daemon to shift the time of the container
+func (impl *Impl) DryRun(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) ([]string, error) {
+	req, err := timeRequestOf(obj.(*v1alpha1.TimeChaos))
 	if err != nil {
-		return v1alpha1.NotInjected, err
+		return nil, err
 	}
 
-	return v1alpha1.Injected, nil
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	return []string{"SetTimeOffset " + string(body)}, nil
+}
+
+// timeRequestOf returns the request to shift the time by the offset of the chaos, without the container and lease
+func timeRequestOf(timechaos *v1alpha1.TimeChaos) (*pb.TimeRequest, error) {
+	mask, err := timeUtils.EncodeClkIds(timechaos.Spec.ClockIds)
+	if err != nil {
+		return nil, err
+	}
+
+	duration, err := time.ParseDuration(timechaos.Spec.TimeOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	sec, nsec := secAndNSecFromDuration(duration)
+	return &pb.TimeRequest{
+		Sec:        sec,
+		Nsec:       nsec,
+		ClkIdsMask: mask,
+	}, nil
 }
 
 func (impl *Impl) Recover(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) (v1alpha1.Phase, error) {
@@ -90,6 +116,7 @@ func (impl *Impl) Recover(ctx context.Context, index int, records []*v1alpha1.Re
 	impl.Log.Info("recover for container", "containerId", containerId)
 	_, err = pbClient.RecoverTimeOffset(ctx, &pb.TimeRequest{
 		ContainerId: containerId,
+		Lease:       leaseOf(obj.(*v1alpha1.TimeChaos), records[index]),
 	})
 	if err != nil {
 		return v1alpha1.Injected, err
@@ -98,6 +125,25 @@ func (impl *Impl) Recover(ctx context.Context, index int, records []*v1alpha1.Re
 	return v1alpha1.NotInjected, nil
 }
 
+// Renew renews the lease of the time offset, which is recovered by chaos-daemon once the lease expires
+func (impl *Impl) Renew(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) (bool, error) {
+	decodedContainer, err := impl.decoder.DecodeContainerRecord(ctx, records[index])
+	pbClient := decodedContainer.PbClient
+	if pbClient != nil {
+		defer pbClient.Close()
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return chaosdaemon.RenewLeases(ctx, pbClient, leaseOf(obj.(*v1alpha1.TimeChaos), records[index]))
+}
+
+// leaseOf returns the lease of the time offset in the container of the record
+func leaseOf(timechaos *v1alpha1.TimeChaos, record *v1alpha1.Record) *pb.LeaseRequest {
+	return chaosdaemon.Lease("timechaos", timechaos.Namespace, timechaos.Name, record.Id)
+}
+
 func secAndNSecFromDuration(duration time.Duration) (sec int64, nsec int64) {
 	sec = duration.Nanoseconds() / 1e9
 	nsec = duration.Nanoseconds() - (sec * 1e9)
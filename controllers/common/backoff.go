@@ -0,0 +1,94 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"time"
+
+	k8sTypes "k8s.io/apimachinery/pkg/types"
+
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/controller"
+)
+
+// RecordBackoff tracks the failed attempts of every record, so that a failing
+// record will be retried with an exponential backoff without blocking the others
+type RecordBackoff struct {
+	baseDelay time.Duration
+	maxDelay  time.Duration
+	limit     int
+
+	tracker *controller.Tracker
+}
+
+func NewRecordBackoff(baseDelay time.Duration, maxDelay time.Duration, limit int) *RecordBackoff {
+	return &RecordBackoff{
+		baseDelay: baseDelay,
+		maxDelay:  maxDelay,
+		limit:     limit,
+
+		tracker: controller.NewTracker(),
+	}
+}
+
+func backoffKey(id string, operation Operation) string {
+	return id + "|" + string(operation)
+}
+
+// delay returns the delay after the given count of failed attempts
+func (b *RecordBackoff) delay(attempts int) time.Duration {
+	// avoid overflow of the shift
+	if attempts > 32 {
+		return b.maxDelay
+	}
+
+	delay := b.baseDelay << uint(attempts-1)
+	if delay <= 0 || delay > b.maxDelay {
+		return b.maxDelay
+	}
+	return delay
+}
+
+// Wait returns how long the operation on the record should wait before the next attempt
+func (b *RecordBackoff) Wait(name k8sTypes.NamespacedName, id string, operation Operation, now time.Time) time.Duration {
+	key := backoffKey(id, operation)
+	attempts := b.tracker.Count(name, key)
+	if attempts == 0 {
+		return 0
+	}
+
+	_, wait := b.tracker.Due(name, key, b.delay(attempts), now)
+	return wait
+}
+
+// Fail records a failed attempt. It returns the count of failed attempts, and whether
+// the limit has been reached. Once the limit is reached, the record is retried with the max delay
+// until its state is reset.
+func (b *RecordBackoff) Fail(name k8sTypes.NamespacedName, id string, operation Operation, now time.Time) (int, bool) {
+	key := backoffKey(id, operation)
+	attempts := b.tracker.Increase(name, key)
+	b.tracker.Done(name, key, now)
+
+	return attempts, attempts >= b.limit
+}
+
+// Reset clears the retry states of the record
+func (b *RecordBackoff) Reset(name k8sTypes.NamespacedName, id string) {
+	b.tracker.Reset(name, backoffKey(id, Apply))
+	b.tracker.Reset(name, backoffKey(id, Recover))
+}
+
+// Forget clears the retry states of all records belonging to the object
+func (b *RecordBackoff) Forget(name k8sTypes.NamespacedName) {
+	b.tracker.Forget(name)
+}
@@ -0,0 +1,58 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	k8sTypes "k8s.io/apimachinery/pkg/types"
+)
+
+func TestRecordBackoff(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	name := k8sTypes.NamespacedName{Namespace: "default", Name: "chaos"}
+	now := time.Now()
+	b := NewRecordBackoff(time.Second, 5*time.Second, 5)
+
+	g.Expect(b.Wait(name, "pod", Apply, now)).To(Equal(time.Duration(0)))
+
+	expectedDelays := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second}
+	for i, delay := range expectedDelays {
+		attempts, giveUp := b.Fail(name, "pod", Apply, now)
+		g.Expect(attempts).To(Equal(i + 1))
+		g.Expect(giveUp).To(BeFalse())
+		g.Expect(b.Wait(name, "pod", Apply, now)).To(Equal(delay))
+	}
+
+	// other records and operations are not affected
+	g.Expect(b.Wait(name, "another-pod", Apply, now)).To(Equal(time.Duration(0)))
+	g.Expect(b.Wait(name, "pod", Recover, now)).To(Equal(time.Duration(0)))
+
+	// the record is still retried with the max delay once the limit is reached
+	attempts, giveUp := b.Fail(name, "pod", Apply, now)
+	g.Expect(attempts).To(Equal(5))
+	g.Expect(giveUp).To(BeTrue())
+	g.Expect(b.Wait(name, "pod", Apply, now)).To(Equal(5 * time.Second))
+
+	b.Fail(name, "pod", Recover, now)
+	b.Reset(name, "pod")
+	g.Expect(b.Wait(name, "pod", Recover, now)).To(Equal(time.Duration(0)))
+
+	b.Fail(name, "pod", Apply, now)
+	b.Forget(name)
+	g.Expect(b.Wait(name, "pod", Apply, now)).To(Equal(time.Duration(0)))
+}
@@ -17,14 +17,18 @@ import (
 	"context"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/chaosdaemon"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/controller"
 	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
 	"github.com/chaos-mesh/chaos-mesh/pkg/selector"
 )
@@ -46,6 +50,18 @@ type ChaosImpl interface {
 	Recover(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) (v1alpha1.Phase, error)
 }
 
+// DryRunnable is implemented by the ChaosImpl which could render the commands it would execute on the target
+type DryRunnable interface {
+	DryRun(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) ([]string, error)
+}
+
+// Renewable is implemented by the ChaosImpl whose faults are held by a lease on chaos-daemon, which recovers the
+// faults if the lease isn't renewed in time. Renew returns true if the lease has expired, and the record will be
+// applied again.
+type Renewable interface {
+	Renew(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) (bool, error)
+}
+
 // Reconciler for common chaos
 type Reconciler struct {
 	Impl ChaosImpl
@@ -61,6 +77,12 @@ type Reconciler struct {
 
 	Selector *selector.Selector
 
+	// Backoff is used to retry the failed records with an exponential backoff
+	Backoff *RecordBackoff
+
+	// Reselector tracks when the selectors with reselect policy should be evaluated again
+	Reselector *controller.Tracker
+
 	Log logr.Logger
 }
 
@@ -79,6 +101,8 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	if err := r.Client.Get(context.TODO(), req.NamespacedName, obj); err != nil {
 		if apierrors.IsNotFound(err) {
 			r.Log.Info("chaos not found")
+			r.Backoff.Forget(req.NamespacedName)
+			r.Reselector.Forget(req.NamespacedName)
 		} else {
 			// TODO: handle this error
 			r.Log.Error(err, "unable to get chaos")
@@ -92,6 +116,9 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	records := obj.GetStatus().Experiment.Records
 	selectors := obj.GetSelectorSpecs()
 
+	// requeueAfter is the shortest time to wait until the next retry of a failed record
+	// or the next evaluation of the selectors
+	var requeueAfter time.Duration
 	if records == nil {
 		for name, sel := range selectors {
 			targets, err := r.Selector.Select(context.TODO(), sel)
@@ -112,11 +139,19 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 				})
 				shouldUpdate = true
 			}
+			r.Reselector.Done(req.NamespacedName, name, time.Now())
+		}
+	} else if desiredPhase == v1alpha1.RunningPhase && !obj.IsOneShot() {
+		// dynamically update the records when some of the pods/containers are replaced
+		var changed bool
+		records, changed, requeueAfter = r.reselect(req.NamespacedName, obj, records)
+		if changed {
+			shouldUpdate = true
 		}
-		// TODO: dynamic upgrade the records when some of these pods/containers stopped
 	}
 
-	needRetry := false
+	dryRun := obj.IsDryRun()
+	quotas := rampQuotas(obj, records)
 	for index, record := range records {
 		var err error
 		r.Log.Info("iterating record", "record", record, "desiredPhase", desiredPhase)
@@ -125,10 +160,54 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		// Not Injected -> Not Injected/* -> Injected -> Injected/* -> Not Injected
 		// Every steps should follow the cycle. For example, if it's in "Not Injected/*" status, and it wants to recover
 		// then it has to apply and then recover, but not recover directly.
+		// A record in "Failed" or "Gone" phase is terminal, and will never be operated again. Nothing is injected on the
+		// target of a "Failed" record.
+		// In dry-run mode, a record goes from "Not Injected" to "Would Inject" without touching the target.
+		// A deselected record is recovered, and then it turns into "Gone".
 
 		originalPhase := record.Phase
 		operation := Nothing
-		if desiredPhase == v1alpha1.RunningPhase && originalPhase != v1alpha1.Injected {
+		if originalPhase == v1alpha1.Failed || originalPhase == v1alpha1.Gone {
+			continue
+		}
+		if originalPhase == v1alpha1.WouldInject {
+			if dryRun && desiredPhase == v1alpha1.RunningPhase && !record.Deselected {
+				continue
+			}
+
+			// nothing has been injected, so it's safe to turn it back without recovering
+			record.Phase = v1alpha1.NotInjected
+			record.Commands = nil
+			originalPhase = v1alpha1.NotInjected
+			shouldUpdate = true
+		}
+
+		if record.Deselected && originalPhase == v1alpha1.NotInjected {
+			r.markGone(index, records, obj)
+			shouldUpdate = true
+			continue
+		}
+
+		recordDesiredPhase := desiredPhase
+		if record.Deselected {
+			recordDesiredPhase = v1alpha1.StoppedPhase
+		}
+		if dryRun && originalPhase != v1alpha1.NotInjected {
+			// the record has been (partially) injected before the dry-run mode is turned on, it should be recovered
+			recordDesiredPhase = v1alpha1.StoppedPhase
+		}
+		if recordDesiredPhase == v1alpha1.RunningPhase && originalPhase == v1alpha1.Injected {
+			var expired bool
+			expired, requeueAfter = r.renew(context.TODO(), index, records, obj, requeueAfter)
+			if expired {
+				// the faults have been recovered by chaos-daemon, so the record should be applied again
+				r.Log.Info("the lease has expired", "id", record.Id)
+				record.Phase = v1alpha1.NotInjected
+				originalPhase = v1alpha1.NotInjected
+				shouldUpdate = true
+			}
+		}
+		if recordDesiredPhase == v1alpha1.RunningPhase && originalPhase != v1alpha1.Injected {
 			// The originalPhase has three possible situations: Not Injected, Not Injedcted/* or Injected/*
 			// In the first two situations, it should apply, in the last situation, it should recover
 
@@ -138,7 +217,7 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 				operation = Recover
 			}
 		}
-		if desiredPhase == v1alpha1.StoppedPhase && originalPhase != v1alpha1.NotInjected {
+		if recordDesiredPhase == v1alpha1.StoppedPhase && originalPhase != v1alpha1.NotInjected {
 			// The originalPhase has three possible situations: Not Injedcted/*, Injected, or Injected/*
 			// In the first one situations, it should apply, in the last two situations, it should recover
 
@@ -149,51 +228,99 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 			}
 		}
 
-		if operation == Apply {
-			r.Log.Info("apply chaos", "id", records[index].Id)
-			record.Phase, err = r.Impl.Apply(context.TODO(), index, records, obj)
-			if record.Phase != originalPhase {
-				shouldUpdate = true
-			}
-			if err != nil {
-				// TODO: add backoff and retry mechanism
-				// but the retry shouldn't block other resource process
-				r.Log.Error(err, "fail to apply chaos")
-				r.Recorder.Event(obj, recorder.Failed{
-					Activity: "apply chaos",
-					Err:      err.Error(),
-				})
-				needRetry = true
+		if operation == Nothing {
+			continue
+		}
+
+		// The failed record is waiting for the next retry, and it shouldn't block other records
+		if wait := r.Backoff.Wait(req.NamespacedName, record.Id, operation, time.Now()); wait > 0 {
+			r.Log.Info("record is backing off", "id", record.Id, "operation", operation, "wait", wait)
+			requeueAfter = controller.ShorterRequeue(requeueAfter, wait)
+			continue
+		}
+
+		// The selector in ramp mode only allows a share of the targets to be injected at the current stage. The records
+		// backing off are skipped before, so that they don't take the share of the records ready to be applied.
+		if quota, ok := quotas[record.SelectorKey]; ok && operation == Apply && originalPhase == v1alpha1.NotInjected {
+			if quota <= 0 {
 				continue
 			}
+			quotas[record.SelectorKey] = quota - 1
+		}
 
-			if record.Phase == v1alpha1.Injected {
-				r.Recorder.Event(obj, recorder.Applied{
-					Id: records[index].Id,
-				})
+		if operation == Apply && dryRun && originalPhase == v1alpha1.NotInjected {
+			r.Log.Info("dry run chaos", "id", records[index].Id)
+			record.Commands, err = r.dryRun(context.TODO(), index, records, obj)
+			if err == nil {
+				record.Phase = v1alpha1.WouldInject
 			}
-		} else if operation == Recover {
+		} else if operation == Apply {
+			r.Log.Info("apply chaos", "id", records[index].Id)
+			record.Phase, err = r.Impl.Apply(context.TODO(), index, records, obj)
+		} else {
 			r.Log.Info("recover chaos", "id", records[index].Id)
 			record.Phase, err = r.Impl.Recover(context.TODO(), index, records, obj)
-			if record.Phase != originalPhase {
-				shouldUpdate = true
-			}
-			if err != nil {
-				// TODO: add backoff and retry mechanism
-				// but the retry shouldn't block other resource process
-				r.Log.Error(err, "fail to recover chaos")
-				r.Recorder.Event(obj, recorder.Failed{
-					Activity: "recover chaos",
-					Err:      err.Error(),
+		}
+		if record.Phase != originalPhase {
+			shouldUpdate = true
+		}
+
+		if err != nil {
+			r.Log.Error(err, "fail to "+string(operation)+" chaos")
+			record.LastError = err.Error()
+			record.FailedAttempts++
+			shouldUpdate = true
+			r.Recorder.Event(obj, recorder.Failed{
+				Activity: string(operation) + " chaos",
+				Err:      err.Error(),
+			})
+
+			// Only the record which has nothing injected is given up. Otherwise, the faults on the target would
+			// leak, so it keeps being retried with the max delay.
+			attempts, giveUp := r.Backoff.Fail(req.NamespacedName, record.Id, operation, time.Now())
+			if giveUp && record.Phase == v1alpha1.NotInjected {
+				r.Log.Info("give up the record", "id", record.Id, "operation", operation, "attempts", attempts)
+				r.Recorder.Event(obj, recorder.GaveUp{
+					Activity: string(operation) + " chaos",
+					Id:       record.Id,
+					Attempts: attempts,
 				})
-				needRetry = true
+				record.Phase = v1alpha1.Failed
+				r.Backoff.Reset(req.NamespacedName, record.Id)
+				shouldUpdate = true
 				continue
 			}
 
-			if record.Phase == v1alpha1.NotInjected {
-				r.Recorder.Event(obj, recorder.Recovered{
-					Id: records[index].Id,
-				})
+			requeueAfter = controller.ShorterRequeue(requeueAfter, r.Backoff.Wait(req.NamespacedName, record.Id, operation, time.Now()))
+			continue
+		}
+
+		r.Backoff.Reset(req.NamespacedName, record.Id)
+		if record.LastError != "" {
+			record.LastError = ""
+			shouldUpdate = true
+		}
+		if record.Phase == v1alpha1.WouldInject {
+			r.Recorder.Event(obj, recorder.WouldInject{
+				Id: records[index].Id,
+			})
+		}
+		if operation == Apply && record.Phase == v1alpha1.Injected {
+			now := metav1.Now()
+			record.InjectedAt = &now
+			r.Recorder.Event(obj, recorder.Applied{
+				Id: records[index].Id,
+			})
+		}
+		if operation == Recover && record.Phase == v1alpha1.NotInjected {
+			now := metav1.Now()
+			record.RecoveredAt = &now
+			r.Recorder.Event(obj, recorder.Recovered{
+				Id: records[index].Id,
+			})
+
+			if record.Deselected {
+				r.markGone(index, records, obj)
 			}
 		}
 	}
@@ -234,5 +361,31 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 			Field: "records",
 		})
 	}
-	return ctrl.Result{Requeue: needRetry}, nil
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// dryRun renders the commands which would be executed on the target, the ChaosImpl without dry-run support
+// renders nothing
+func (r *Reconciler) dryRun(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) ([]string, error) {
+	impl, ok := r.Impl.(DryRunnable)
+	if !ok {
+		return nil, nil
+	}
+
+	return impl.DryRun(ctx, index, records, obj)
+}
+
+// renew renews the lease of an injected record if the ChaosImpl supports it, and returns whether the lease has
+// expired and the time to requeue for the next renewal
+func (r *Reconciler) renew(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject, requeueAfter time.Duration) (bool, time.Duration) {
+	impl, ok := r.Impl.(Renewable)
+	if !ok {
+		return false, requeueAfter
+	}
+
+	expired, err := impl.Renew(ctx, index, records, obj)
+	if err != nil {
+		r.Log.Error(err, "fail to renew the lease", "id", records[index].Id)
+	}
+	return expired, controller.ShorterRequeue(requeueAfter, chaosdaemon.LeaseRenewInterval)
 }
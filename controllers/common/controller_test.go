@@ -0,0 +1,433 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sTypes "k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/cmd/chaos-controller-manager/provider"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/chaosdaemon"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/controller"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
+	"github.com/chaos-mesh/chaos-mesh/pkg/selector"
+	"github.com/chaos-mesh/chaos-mesh/pkg/selector/container"
+	"github.com/chaos-mesh/chaos-mesh/pkg/testutils"
+)
+
+type fakeImpl struct {
+	applied   int
+	recovered int
+
+	// applyErr is returned by Apply if it's not nil
+	applyErr error
+	// recoverErr is returned by Recover if it's not nil
+	recoverErr error
+}
+
+func (impl *fakeImpl) Apply(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) (v1alpha1.Phase, error) {
+	impl.applied++
+	if impl.applyErr != nil {
+		return v1alpha1.NotInjected, impl.applyErr
+	}
+	return v1alpha1.Injected, nil
+}
+
+func (impl *fakeImpl) Recover(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) (v1alpha1.Phase, error) {
+	impl.recovered++
+	if impl.recoverErr != nil {
+		return v1alpha1.Injected, impl.recoverErr
+	}
+	return v1alpha1.NotInjected, nil
+}
+
+func (impl *fakeImpl) DryRun(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) ([]string, error) {
+	return []string{"stress-ng --cpu 1"}, nil
+}
+
+// renewableImpl holds the faults by a lease, which expires if expired is true
+type renewableImpl struct {
+	fakeImpl
+
+	renewed int
+	expired bool
+}
+
+func (impl *renewableImpl) Renew(ctx context.Context, index int, records []*v1alpha1.Record, obj v1alpha1.InnerObject) (bool, error) {
+	impl.renewed++
+	return impl.expired, nil
+}
+
+func TestReconcileDryRun(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	name := k8sTypes.NamespacedName{Namespace: "default", Name: "chaos"}
+	chaos := &v1alpha1.StressChaos{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   name.Namespace,
+			Name:        name.Name,
+			Annotations: map[string]string{v1alpha1.DryRunAnnotationKey: "true"},
+		},
+		Status: v1alpha1.StressChaosStatus{
+			ChaosStatus: v1alpha1.ChaosStatus{
+				Experiment: v1alpha1.ExperimentStatus{
+					DesiredPhase: v1alpha1.RunningPhase,
+					Records: []*v1alpha1.Record{
+						{Id: "default/p0/c0", SelectorKey: ".", Phase: v1alpha1.NotInjected},
+					},
+				},
+			},
+		},
+	}
+
+	impl := &fakeImpl{}
+	r := &Reconciler{
+		Impl:       impl,
+		Object:     &v1alpha1.StressChaos{},
+		Client:     fake.NewFakeClientWithScheme(provider.NewScheme(), chaos),
+		Recorder:   recorder.NewDebugRecorder(),
+		Backoff:    NewRecordBackoff(time.Second, time.Second, 0),
+		Reselector: controller.NewTracker(),
+		Log:        ctrl.Log.WithName("test"),
+	}
+
+	reconcileAndGet := func() *v1alpha1.Record {
+		_, err := r.Reconcile(ctrl.Request{NamespacedName: name})
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		obj := &v1alpha1.StressChaos{}
+		g.Expect(r.Client.Get(context.TODO(), name, obj)).Should(Succeed())
+		return obj.Status.Experiment.Records[0]
+	}
+
+	record := reconcileAndGet()
+	g.Expect(record.Phase).To(Equal(v1alpha1.WouldInject))
+	g.Expect(record.Commands).To(Equal([]string{"stress-ng --cpu 1"}))
+	g.Expect(impl.applied).To(Equal(0))
+
+	// turn off the dry-run mode, then the chaos is injected
+	obj := &v1alpha1.StressChaos{}
+	g.Expect(r.Client.Get(context.TODO(), name, obj)).Should(Succeed())
+	obj.Annotations = nil
+	g.Expect(r.Client.Update(context.TODO(), obj)).Should(Succeed())
+
+	record = reconcileAndGet()
+	g.Expect(record.Phase).To(Equal(v1alpha1.Injected))
+	g.Expect(record.Commands).To(BeEmpty())
+	g.Expect(impl.applied).To(Equal(1))
+
+	// turn on the dry-run mode again, then the injected chaos is recovered
+	g.Expect(r.Client.Get(context.TODO(), name, obj)).Should(Succeed())
+	obj.Annotations = map[string]string{v1alpha1.DryRunAnnotationKey: "true"}
+	g.Expect(r.Client.Update(context.TODO(), obj)).Should(Succeed())
+
+	record = reconcileAndGet()
+	g.Expect(record.Phase).To(Equal(v1alpha1.NotInjected))
+	g.Expect(impl.recovered).To(Equal(1))
+
+	record = reconcileAndGet()
+	g.Expect(record.Phase).To(Equal(v1alpha1.WouldInject))
+}
+
+func TestReconcileRecordStatus(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	name := k8sTypes.NamespacedName{Namespace: "default", Name: "chaos"}
+	chaos := &v1alpha1.StressChaos{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: name.Namespace,
+			Name:      name.Name,
+		},
+		Status: v1alpha1.StressChaosStatus{
+			ChaosStatus: v1alpha1.ChaosStatus{
+				Experiment: v1alpha1.ExperimentStatus{
+					DesiredPhase: v1alpha1.RunningPhase,
+					Records: []*v1alpha1.Record{
+						{Id: "default/p0/c0", SelectorKey: ".", Phase: v1alpha1.NotInjected},
+					},
+				},
+			},
+		},
+	}
+
+	impl := &fakeImpl{applyErr: errors.New("container not found")}
+	r := &Reconciler{
+		Impl:       impl,
+		Object:     &v1alpha1.StressChaos{},
+		Client:     fake.NewFakeClientWithScheme(provider.NewScheme(), chaos),
+		Recorder:   recorder.NewDebugRecorder(),
+		Backoff:    NewRecordBackoff(time.Nanosecond, time.Nanosecond, 5),
+		Reselector: controller.NewTracker(),
+		Log:        ctrl.Log.WithName("test"),
+	}
+
+	reconcileAndGet := func() *v1alpha1.Record {
+		_, err := r.Reconcile(ctrl.Request{NamespacedName: name})
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		obj := &v1alpha1.StressChaos{}
+		g.Expect(r.Client.Get(context.TODO(), name, obj)).Should(Succeed())
+		return obj.Status.Experiment.Records[0]
+	}
+
+	record := reconcileAndGet()
+	g.Expect(record.Phase).To(Equal(v1alpha1.NotInjected))
+	g.Expect(record.LastError).To(Equal("container not found"))
+	g.Expect(record.FailedAttempts).To(Equal(int32(1)))
+	g.Expect(record.InjectedAt).To(BeNil())
+
+	time.Sleep(time.Millisecond)
+	record = reconcileAndGet()
+	g.Expect(record.FailedAttempts).To(Equal(int32(2)))
+
+	// the next attempt succeeds, the error is cleared
+	impl.applyErr = nil
+	time.Sleep(time.Millisecond)
+	record = reconcileAndGet()
+	g.Expect(record.Phase).To(Equal(v1alpha1.Injected))
+	g.Expect(record.LastError).To(BeEmpty())
+	g.Expect(record.FailedAttempts).To(Equal(int32(2)))
+	g.Expect(record.InjectedAt).NotTo(BeNil())
+	g.Expect(record.RecoveredAt).To(BeNil())
+
+	// stop the chaos, then the target is recovered
+	obj := &v1alpha1.StressChaos{}
+	g.Expect(r.Client.Get(context.TODO(), name, obj)).Should(Succeed())
+	obj.Status.Experiment.DesiredPhase = v1alpha1.StoppedPhase
+	g.Expect(r.Client.Update(context.TODO(), obj)).Should(Succeed())
+
+	record = reconcileAndGet()
+	g.Expect(record.Phase).To(Equal(v1alpha1.NotInjected))
+	g.Expect(record.RecoveredAt).NotTo(BeNil())
+}
+
+func TestReconcileRamp(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	name := k8sTypes.NamespacedName{Namespace: "default", Name: "chaos"}
+	chaos := &v1alpha1.StressChaos{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: name.Namespace,
+			Name:      name.Name,
+		},
+		Spec: v1alpha1.StressChaosSpec{
+			ContainerSelector: v1alpha1.ContainerSelector{
+				PodSelector: v1alpha1.PodSelector{
+					Mode:       v1alpha1.RampPodMode,
+					RampPolicy: &v1alpha1.RampPolicy{Steps: []int32{10, 50, 100}, Interval: "2m"},
+				},
+			},
+		},
+		Status: v1alpha1.StressChaosStatus{
+			ChaosStatus: v1alpha1.ChaosStatus{
+				Experiment: v1alpha1.ExperimentStatus{
+					DesiredPhase: v1alpha1.RunningPhase,
+				},
+			},
+		},
+	}
+	for i := 0; i < 4; i++ {
+		chaos.Status.Experiment.Records = append(chaos.Status.Experiment.Records, &v1alpha1.Record{
+			Id:          fmt.Sprintf("default/p%d/c0", i),
+			SelectorKey: ".",
+			Phase:       v1alpha1.NotInjected,
+		})
+	}
+
+	impl := &fakeImpl{}
+	r := &Reconciler{
+		Impl:       impl,
+		Object:     &v1alpha1.StressChaos{},
+		Client:     fake.NewFakeClientWithScheme(provider.NewScheme(), chaos),
+		Recorder:   recorder.NewDebugRecorder(),
+		Backoff:    NewRecordBackoff(time.Second, time.Second, 5),
+		Reselector: controller.NewTracker(),
+		Log:        ctrl.Log.WithName("test"),
+	}
+
+	countInjected := func() int {
+		_, err := r.Reconcile(ctrl.Request{NamespacedName: name})
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		obj := &v1alpha1.StressChaos{}
+		g.Expect(r.Client.Get(context.TODO(), name, obj)).Should(Succeed())
+		injected := 0
+		for _, record := range obj.Status.Experiment.Records {
+			if record.Phase == v1alpha1.Injected {
+				injected++
+			}
+		}
+		return injected
+	}
+
+	// the record backing off doesn't take the share of the others
+	r.Backoff.Fail(name, "default/p0/c0", Apply, time.Now())
+
+	// 10% of 4 targets is rounded up to 1
+	g.Expect(countInjected()).To(Equal(1))
+	g.Expect(countInjected()).To(Equal(1))
+
+	// go to the next stage
+	obj := &v1alpha1.StressChaos{}
+	g.Expect(r.Client.Get(context.TODO(), name, obj)).Should(Succeed())
+	obj.Status.Ramp = []v1alpha1.RampStatus{{SelectorKey: ".", Stage: 1}}
+	g.Expect(r.Client.Update(context.TODO(), obj)).Should(Succeed())
+
+	g.Expect(countInjected()).To(Equal(2))
+	g.Expect(impl.applied).To(Equal(2))
+}
+
+func TestReconcileRenew(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	name := k8sTypes.NamespacedName{Namespace: "default", Name: "chaos"}
+	chaos := &v1alpha1.StressChaos{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: name.Namespace,
+			Name:      name.Name,
+		},
+		Status: v1alpha1.StressChaosStatus{
+			ChaosStatus: v1alpha1.ChaosStatus{
+				Experiment: v1alpha1.ExperimentStatus{
+					DesiredPhase: v1alpha1.RunningPhase,
+					Records: []*v1alpha1.Record{
+						{Id: "default/p0/c0", SelectorKey: ".", Phase: v1alpha1.Injected},
+					},
+				},
+			},
+		},
+	}
+
+	impl := &renewableImpl{}
+	r := &Reconciler{
+		Impl:       impl,
+		Object:     &v1alpha1.StressChaos{},
+		Client:     fake.NewFakeClientWithScheme(provider.NewScheme(), chaos),
+		Recorder:   recorder.NewDebugRecorder(),
+		Backoff:    NewRecordBackoff(time.Second, time.Second, 0),
+		Reselector: controller.NewTracker(),
+		Log:        ctrl.Log.WithName("test"),
+	}
+
+	// the lease of the injected record is renewed periodically
+	result, err := r.Reconcile(ctrl.Request{NamespacedName: name})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(result.RequeueAfter).To(Equal(chaosdaemon.LeaseRenewInterval))
+	g.Expect(impl.renewed).To(Equal(1))
+	g.Expect(impl.applied).To(Equal(0))
+
+	// the record is applied again once the lease has expired
+	impl.expired = true
+	_, err = r.Reconcile(ctrl.Request{NamespacedName: name})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(impl.renewed).To(Equal(2))
+	g.Expect(impl.applied).To(Equal(1))
+
+	obj := &v1alpha1.StressChaos{}
+	g.Expect(r.Client.Get(context.TODO(), name, obj)).Should(Succeed())
+	g.Expect(obj.Status.Experiment.Records[0].Phase).To(Equal(v1alpha1.Injected))
+	g.Expect(obj.Status.Experiment.Records[0].InjectedAt).NotTo(BeNil())
+}
+
+func TestReconcileReselect(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	name := k8sTypes.NamespacedName{Namespace: "default", Name: "chaos"}
+	chaos := &v1alpha1.StressChaos{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: name.Namespace,
+			Name:      name.Name,
+		},
+		Spec: v1alpha1.StressChaosSpec{
+			ContainerSelector: v1alpha1.ContainerSelector{
+				PodSelector: v1alpha1.PodSelector{
+					Selector: v1alpha1.PodSelectorSpec{
+						Namespaces:     []string{"default"},
+						LabelSelectors: map[string]string{"app": "a"},
+					},
+					Mode:           v1alpha1.AllPodMode,
+					ReselectPolicy: &v1alpha1.ReselectPolicy{Interval: "1m"},
+				},
+				ContainerNames: []string{"c0"},
+			},
+		},
+		Status: v1alpha1.StressChaosStatus{
+			ChaosStatus: v1alpha1.ChaosStatus{
+				Experiment: v1alpha1.ExperimentStatus{
+					DesiredPhase: v1alpha1.RunningPhase,
+					Records: []*v1alpha1.Record{
+						{Id: "default/p0/c0", SelectorKey: ".", Phase: v1alpha1.Injected},
+						{Id: "default/p1/c0", SelectorKey: ".", Phase: v1alpha1.Injected},
+						{Id: "default/p2/c0", SelectorKey: ".", Phase: v1alpha1.Injected},
+					},
+				},
+			},
+		},
+	}
+	// p0 still matches the selector, p1 doesn't match it anymore, and p2 has been deleted
+	p0 := testutils.NewPod(testutils.PodArg{Name: "p0", Labels: map[string]string{"app": "a"}})
+	p1 := testutils.NewPod(testutils.PodArg{Name: "p1", Labels: map[string]string{"app": "b"}})
+
+	impl := &fakeImpl{recoverErr: errors.New("fail to recover")}
+	c := fake.NewFakeClientWithScheme(provider.NewScheme(), chaos, &p0, &p1)
+	r := &Reconciler{
+		Impl:       impl,
+		Object:     &v1alpha1.StressChaos{},
+		Client:     c,
+		Selector:   selector.New(selector.SelectorParams{ContainerSelector: container.New(container.Params{Client: c, Reader: c})}),
+		Recorder:   recorder.NewDebugRecorder(),
+		Backoff:    NewRecordBackoff(time.Second, time.Second, 0),
+		Reselector: controller.NewTracker(),
+		Log:        ctrl.Log.WithName("test"),
+	}
+
+	reconcileAndGet := func() []*v1alpha1.Record {
+		_, err := r.Reconcile(ctrl.Request{NamespacedName: name})
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		obj := &v1alpha1.StressChaos{}
+		g.Expect(r.Client.Get(context.TODO(), name, obj)).Should(Succeed())
+		return obj.Status.Experiment.Records
+	}
+
+	// the record of the deleted pod is gone even if it fails to be recovered, while the record of the deselected
+	// pod is kept until it's recovered
+	records := reconcileAndGet()
+	g.Expect(records[0].Phase).To(Equal(v1alpha1.Injected))
+	g.Expect(records[0].Deselected).To(BeFalse())
+	g.Expect(records[1].Phase).To(Equal(v1alpha1.Injected))
+	g.Expect(records[1].Deselected).To(BeTrue())
+	g.Expect(records[1].LastError).To(Equal("fail to recover"))
+	g.Expect(records[2].Phase).To(Equal(v1alpha1.Gone))
+
+	// the deselected record keeps being recovered until it succeeds, and then it's gone
+	impl.recoverErr = nil
+	r.Backoff.Reset(name, "default/p1/c0")
+	records = reconcileAndGet()
+	g.Expect(records[0].Phase).To(Equal(v1alpha1.Injected))
+	g.Expect(records[1].Phase).To(Equal(v1alpha1.Gone))
+	g.Expect(records[1].Deselected).To(BeFalse())
+	g.Expect(records[1].RecoveredAt).NotTo(BeNil())
+	g.Expect(impl.applied).To(Equal(0))
+}
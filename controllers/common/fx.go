@@ -27,6 +27,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	"github.com/chaos-mesh/chaos-mesh/controllers/config"
 	"github.com/chaos-mesh/chaos-mesh/controllers/types"
 	"github.com/chaos-mesh/chaos-mesh/controllers/utils/builder"
 	"github.com/chaos-mesh/chaos-mesh/controllers/utils/controller"
@@ -120,7 +121,13 @@ func NewController(params Params) (types.Controller, error) {
 			Reader:   reader,
 			Recorder: recorderBuilder.Build("records"),
 			Selector: selector,
-			Log:      logger.WithName("records"),
+			Backoff: NewRecordBackoff(
+				config.ControllerCfg.RecordRetryBaseDelay,
+				config.ControllerCfg.RecordRetryMaxDelay,
+				config.ControllerCfg.RecordRetryLimit,
+			),
+			Reselector: controller.NewTracker(),
+			Log:        logger.WithName("records"),
 		})
 		if err != nil {
 			return "", err
@@ -0,0 +1,60 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"math"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+)
+
+// RampableSelector is a selector whose targets could be injected stage by stage
+type RampableSelector interface {
+	GetRampPolicy() *v1alpha1.RampPolicy
+}
+
+// rampQuotas returns how many more targets of every selector in ramp mode could be injected at the current stage.
+// The selectors without ramp policy are not included.
+func rampQuotas(obj InnerObjectWithSelector, records []*v1alpha1.Record) map[string]int {
+	quotas := make(map[string]int)
+
+	for key, sel := range obj.GetSelectorSpecs() {
+		rampable, ok := sel.(RampableSelector)
+		if !ok {
+			continue
+		}
+		policy := rampable.GetRampPolicy()
+		if policy == nil {
+			continue
+		}
+
+		total := 0
+		started := 0
+		for _, record := range records {
+			if record.SelectorKey != key || record.Phase == v1alpha1.Gone || record.Deselected {
+				continue
+			}
+			total++
+			if record.Phase != v1alpha1.NotInjected && record.Phase != v1alpha1.Failed {
+				started++
+			}
+		}
+
+		percent := policy.Percent(obj.GetStatus().GetRampStage(key))
+		allowed := int(math.Ceil(float64(total) * float64(percent) / 100))
+		quotas[key] = allowed - started
+	}
+
+	return quotas
+}
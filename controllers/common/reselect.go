@@ -0,0 +1,186 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	k8sTypes "k8s.io/apimachinery/pkg/types"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/controller"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
+)
+
+// ReselectableSelector is a selector which could be re-evaluated periodically during the experiment
+type ReselectableSelector interface {
+	GetReselectInterval() (time.Duration, error)
+}
+
+// reselect re-evaluates the selectors with reselect policy, marks the records of deleted targets as gone,
+// marks the records of the targets which don't match the selectors anymore as deselected, and appends records
+// for the new targets. It returns the updated records, whether the records have been changed, and the time to
+// wait until the next evaluation.
+func (r *Reconciler) reselect(name k8sTypes.NamespacedName, obj InnerObjectWithSelector, records []*v1alpha1.Record) ([]*v1alpha1.Record, bool, time.Duration) {
+	changed := false
+	var requeueAfter time.Duration
+
+	for key, sel := range obj.GetSelectorSpecs() {
+		reselectable, ok := sel.(ReselectableSelector)
+		if !ok {
+			continue
+		}
+		interval, err := reselectable.GetReselectInterval()
+		if err != nil {
+			r.Log.Error(err, "fail to parse reselect interval", "selectorKey", key)
+			continue
+		}
+		if interval <= 0 {
+			continue
+		}
+
+		now := time.Now()
+		due, wait := r.Reselector.Due(name, key, interval, now)
+		if !due {
+			requeueAfter = controller.ShorterRequeue(requeueAfter, wait)
+			continue
+		}
+		r.Reselector.Done(name, key, now)
+		requeueAfter = controller.ShorterRequeue(requeueAfter, interval)
+
+		var selected []string
+		for _, record := range records {
+			if record.SelectorKey == key && record.Phase != v1alpha1.Gone && !record.Deselected {
+				selected = append(selected, record.Id)
+			}
+		}
+
+		alive, added, err := r.Selector.Reselect(context.TODO(), sel, selected)
+		if err != nil {
+			r.Log.Error(err, "fail to reselect", "selectorKey", key)
+			r.Recorder.Event(obj, recorder.Failed{
+				Activity: "reselect targets",
+				Err:      err.Error(),
+			})
+			continue
+		}
+
+		aliveSet := make(map[string]struct{})
+		for _, id := range alive {
+			aliveSet[id] = struct{}{}
+		}
+		for index, record := range records {
+			if record.SelectorKey != key || record.Phase == v1alpha1.Gone {
+				continue
+			}
+			if _, ok := aliveSet[record.Id]; ok {
+				continue
+			}
+
+			exists, err := r.targetExists(record.Id)
+			if err != nil {
+				r.Log.Error(err, "fail to get the target", "id", record.Id)
+				continue
+			}
+			if !exists {
+				r.markGone(index, records, obj)
+				r.Backoff.Reset(name, record.Id)
+				changed = true
+				continue
+			}
+
+			// the target still exists, so the faults on it are recovered before the record turns into "Gone"
+			if !record.Deselected {
+				r.Log.Info("target is deselected", "id", record.Id, "selectorKey", key)
+				record.Deselected = true
+				changed = true
+			}
+		}
+
+		for _, target := range added {
+			revived := false
+			for _, record := range records {
+				if record.SelectorKey != key || record.Id != target.Id() {
+					continue
+				}
+
+				// the target with the same id comes back, e.g. a pod of StatefulSet is recreated
+				if record.Phase == v1alpha1.Gone {
+					record.Phase = v1alpha1.NotInjected
+					revived = true
+					break
+				}
+				// the deselected target matches the selector again before it's recovered
+				if record.Deselected {
+					record.Deselected = false
+					revived = true
+					break
+				}
+			}
+			if !revived {
+				records = append(records, &v1alpha1.Record{
+					Id:          target.Id(),
+					SelectorKey: key,
+					Phase:       v1alpha1.NotInjected,
+				})
+			}
+
+			r.Log.Info("new target is selected", "id", target.Id(), "selectorKey", key)
+			r.Recorder.Event(obj, recorder.TargetSelected{
+				Id: target.Id(),
+			})
+			changed = true
+		}
+	}
+
+	return records, changed, requeueAfter
+}
+
+// targetExists returns whether the pod of the target still exists. The id of the target is "namespace/pod" or
+// "namespace/pod/container".
+func (r *Reconciler) targetExists(id string) (bool, error) {
+	podId, _ := controller.ParseNamespacedNameContainer(id)
+
+	var pod v1.Pod
+	err := r.Client.Get(context.TODO(), podId, &pod)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// markGone marks the record as gone. If the target has been deleted but the record is still injected, it tries
+// to recover it once to clean up the related resources, but the result is ignored as the target has gone.
+func (r *Reconciler) markGone(index int, records []*v1alpha1.Record, obj InnerObjectWithSelector) {
+	record := records[index]
+	if strings.HasPrefix(string(record.Phase), string(v1alpha1.Injected)) {
+		if _, err := r.Impl.Recover(context.TODO(), index, records, obj); err != nil {
+			r.Log.Info("fail to recover the vanished target", "id", record.Id, "err", err.Error())
+		}
+	}
+
+	r.Log.Info("target is gone", "id", record.Id, "selectorKey", record.SelectorKey)
+	r.Recorder.Event(obj, recorder.TargetGone{
+		Id: record.Id,
+	})
+	record.Phase = v1alpha1.Gone
+	record.Deselected = false
+}
@@ -15,6 +15,7 @@ package condition
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 
 	"github.com/go-logr/logr"
@@ -83,7 +84,13 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		allInjected := corev1.ConditionTrue
 		allRecovered := corev1.ConditionTrue
 		for _, record := range obj.GetStatus().Experiment.Records {
-			if record.Phase != v1alpha1.NotInjected {
+			// the target of a gone record doesn't exist anymore
+			if record.Phase == v1alpha1.Gone {
+				continue
+			}
+
+			// nothing has been injected on the target of a dry-run or failed record
+			if record.Phase != v1alpha1.NotInjected && record.Phase != v1alpha1.WouldInject && record.Phase != v1alpha1.Failed {
 				allRecovered = corev1.ConditionFalse
 			}
 
@@ -108,8 +115,19 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 			}
 		}
 
+		if abort := obj.GetStatus().Abort; abort != nil {
+			newConditionMap[v1alpha1.ConditionAborted] = StatusAndReason{
+				Status: corev1.ConditionTrue,
+				Reason: fmt.Sprintf("%s: %s", abort.Condition, abort.Reason),
+			}
+		} else {
+			newConditionMap[v1alpha1.ConditionAborted] = StatusAndReason{
+				Status: corev1.ConditionFalse,
+			}
+		}
+
 		if !reflect.DeepEqual(newConditionMap, conditionMap) {
-			conditions := make([]v1alpha1.ChaosCondition, 0, 5)
+			conditions := make([]v1alpha1.ChaosCondition, 0, 6)
 			for k, v := range newConditionMap {
 				conditions = append(conditions, v1alpha1.ChaosCondition{
 					Type:   k,
@@ -57,6 +57,10 @@ func validate(config *config.ChaosControllerConfig) error {
 		return fmt.Errorf("K8sConfigMapWatcher config ClusterScoped is not same with controller-manager ClusterScoped. k8s configmap watcher: %t, controller manager: %t", config.WatcherConfig.ClusterScoped, config.ClusterScoped)
 	}
 
+	if config.RecordRetryLimit <= 0 {
+		return fmt.Errorf("record retry limit should be greater than 0, got %d", config.RecordRetryLimit)
+	}
+
 	if !config.ClusterScoped {
 		if strings.TrimSpace(config.TargetNamespace) == "" {
 			return fmt.Errorf("no target namespace specified with namespace scoped mode")
@@ -93,6 +93,17 @@ var _ = Describe("Namespace-scoped Chaos", func() {
 					},
 					expectValid: false,
 				},
+				{
+					name: "record retry limit should be positive",
+					config: config.ChaosControllerConfig{
+						WatcherConfig: &watcher.Config{
+							ClusterScoped: true,
+						},
+						ClusterScoped:    true,
+						RecordRetryLimit: 0,
+					},
+					expectValid: false,
+				},
 			}
 
 			for _, testCase := range testCases {
@@ -107,6 +107,11 @@ func (ctx *reconcileContext) CalcDesiredPhase() (v1alpha1.DesiredPhase, []record
 
 	ctx.requeueAfter = untilStop
 
+	// An aborted chaos will never run again
+	if ctx.obj.GetStatus().IsAborted() {
+		return v1alpha1.StoppedPhase, events
+	}
+
 	// Then decide the pause logic
 	if ctx.obj.IsPaused() {
 		if ctx.obj.GetStatus().Experiment.DesiredPhase != v1alpha1.StoppedPhase {
@@ -69,7 +69,8 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	if obj.IsDeleted() {
 		resumed := true
 		for _, record := range records {
-			if record.Phase != v1alpha1.NotInjected {
+			// nothing is injected on the target of a failed record
+			if record.Phase != v1alpha1.NotInjected && record.Phase != v1alpha1.Gone && record.Phase != v1alpha1.WouldInject && record.Phase != v1alpha1.Failed {
 				resumed = false
 			}
 		}
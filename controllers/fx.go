@@ -16,6 +16,7 @@ package controllers
 import (
 	"go.uber.org/fx"
 
+	"github.com/chaos-mesh/chaos-mesh/controllers/abort"
 	"github.com/chaos-mesh/chaos-mesh/controllers/chaosimpl"
 	"github.com/chaos-mesh/chaos-mesh/controllers/common"
 	"github.com/chaos-mesh/chaos-mesh/controllers/condition"
@@ -24,6 +25,7 @@ import (
 	"github.com/chaos-mesh/chaos-mesh/controllers/podhttpchaos"
 	"github.com/chaos-mesh/chaos-mesh/controllers/podiochaos"
 	"github.com/chaos-mesh/chaos-mesh/controllers/podnetworkchaos"
+	"github.com/chaos-mesh/chaos-mesh/controllers/ramp"
 	"github.com/chaos-mesh/chaos-mesh/controllers/schedule"
 	"github.com/chaos-mesh/chaos-mesh/controllers/utils/chaosdaemon"
 	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
@@ -48,10 +50,22 @@ var Module = fx.Options(
 			Group:  "controller",
 			Target: condition.NewController,
 		},
+		fx.Annotated{
+			Group:  "controller",
+			Target: abort.NewController,
+		},
+		fx.Annotated{
+			Group:  "controller",
+			Target: ramp.NewController,
+		},
 		fx.Annotated{
 			Group:  "controller",
 			Target: podnetworkchaos.NewController,
 		},
+		fx.Annotated{
+			Group:  "controller",
+			Target: podnetworkchaos.NewNodeController,
+		},
 		fx.Annotated{
 			Group:  "controller",
 			Target: podhttpchaos.NewController,
@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 
 	"github.com/go-logr/logr"
 	v1 "k8s.io/api/core/v1"
@@ -30,6 +31,7 @@ import (
 
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
 	"github.com/chaos-mesh/chaos-mesh/controllers/utils/chaosdaemon"
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/command"
 	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
 )
 
@@ -57,6 +59,23 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		return ctrl.Result{}, nil
 	}
 
+	// tproxy is held by the lease on chaos-daemon, which is killed if the lease isn't renewed in time
+	lease := chaosdaemon.Lease("podhttpchaos", obj.Namespace, obj.Name)
+	if obj.ObjectMeta.Generation <= obj.Status.ObservedGeneration && obj.Status.FailedMessage == "" && len(obj.Spec.Rules) > 0 {
+		expired, err := r.ChaosDaemonClientBuilder.RenewPodLeases(ctx, r.Client, req.NamespacedName, lease)
+		if !expired {
+			if err != nil {
+				r.Log.Error(err, "fail to renew the lease", "pod", obj.Namespace+"/"+obj.Name)
+			}
+			return ctrl.Result{RequeueAfter: chaosdaemon.LeaseRenewInterval}, nil
+		}
+
+		// tproxy has been killed, so a new one is started
+		r.Log.Info("the lease has expired, applying http chaos again", "pod", obj.Namespace+"/"+obj.Name)
+		obj.Status.Pid = 0
+		obj.Status.StartTime = 0
+	}
+
 	r.Log.Info("updating http chaos", "pod", obj.Namespace+"/"+obj.Name, "spec", obj.Spec)
 
 	pod := &v1.Pod{}
@@ -117,20 +136,9 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 
 	containerID := pod.Status.ContainerStatuses[0].ContainerID
 
-	rules := make([]v1alpha1.PodHttpChaosBaseRule, 0)
-	proxyPortsMap := make(map[uint32]bool)
-	proxyPorts := make([]uint32, 0)
-
-	for _, rule := range obj.Spec.Rules {
-		proxyPortsMap[uint32(rule.Port)] = true
-		rules = append(rules, rule.PodHttpChaosBaseRule)
-	}
+	config := tproxyConfigOf(obj)
 
-	for port := range proxyPortsMap {
-		proxyPorts = append(proxyPorts, port)
-	}
-
-	input, err := json.Marshal(rules)
+	input, err := json.Marshal(config.Rules)
 	if err != nil {
 		r.Recorder.Event(obj, "Warning", "Failed", err.Error())
 		return ctrl.Result{}, nil
@@ -140,12 +148,13 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 
 	res, err := pbClient.ApplyHttpChaos(ctx, &pb.ApplyHttpChaosRequest{
 		Rules:       string(input),
-		ProxyPorts:  proxyPorts,
+		ProxyPorts:  config.ProxyPorts,
 		ContainerId: containerID,
 
 		Instance:  obj.Status.Pid,
 		StartTime: obj.Status.StartTime,
 		EnterNS:   true,
+		Lease:     lease,
 	})
 	if err != nil {
 		r.Recorder.Event(obj, "Warning", "Failed", err.Error())
@@ -161,5 +170,35 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	pid = res.Instance
 	startTime = res.StartTime
 
-	return ctrl.Result{}, nil
+	if len(obj.Spec.Rules) == 0 {
+		return ctrl.Result{}, nil
+	}
+	return ctrl.Result{RequeueAfter: chaosdaemon.LeaseRenewInterval}, nil
+}
+
+// tproxyConfigOf returns the rules of the podhttpchaos and the ports which tproxy should intercept
+func tproxyConfigOf(chaos *v1alpha1.PodHttpChaos) *command.TproxyConfig {
+	rules := make([]v1alpha1.PodHttpChaosBaseRule, 0)
+	proxyPortsMap := make(map[uint32]bool)
+	proxyPorts := make([]uint32, 0)
+
+	for _, rule := range chaos.Spec.Rules {
+		proxyPortsMap[uint32(rule.Port)] = true
+		rules = append(rules, rule.PodHttpChaosBaseRule)
+	}
+
+	for port := range proxyPortsMap {
+		proxyPorts = append(proxyPorts, port)
+	}
+	sort.Slice(proxyPorts, func(i, j int) bool { return proxyPorts[i] < proxyPorts[j] })
+
+	return &command.TproxyConfig{
+		ProxyPorts: proxyPorts,
+		Rules:      rules,
+	}
+}
+
+// RenderCommands renders the commands which chaos daemon will execute to apply the podhttpchaos
+func RenderCommands(chaos *v1alpha1.PodHttpChaos) ([]string, error) {
+	return command.RenderTproxy(tproxyConfigOf(chaos))
 }
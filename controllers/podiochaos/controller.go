@@ -30,6 +30,7 @@ import (
 
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
 	"github.com/chaos-mesh/chaos-mesh/controllers/utils/chaosdaemon"
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/command"
 	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
 )
 
@@ -57,9 +58,23 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		return ctrl.Result{}, nil
 	}
 
+	// toda is held by the lease on chaos-daemon, which is killed if the lease isn't renewed in time
+	lease := chaosdaemon.Lease("podiochaos", obj.Namespace, obj.Name)
 	if obj.ObjectMeta.Generation <= obj.Status.ObservedGeneration && obj.Status.FailedMessage == "" {
-		r.Log.Info("the target pod has been up to date", "pod", obj.Namespace+"/"+obj.Name)
-		return ctrl.Result{}, nil
+		if len(obj.Spec.Actions) == 0 {
+			r.Log.Info("the target pod has been up to date", "pod", obj.Namespace+"/"+obj.Name)
+			return ctrl.Result{}, nil
+		}
+
+		expired, err := r.ChaosDaemonClientBuilder.RenewPodLeases(ctx, r.Client, req.NamespacedName, lease)
+		if !expired {
+			if err != nil {
+				r.Log.Error(err, "fail to renew the lease", "pod", obj.Namespace+"/"+obj.Name)
+			}
+			return ctrl.Result{RequeueAfter: chaosdaemon.LeaseRenewInterval}, nil
+		}
+
+		r.Log.Info("the lease has expired, applying io chaos again", "pod", obj.Namespace+"/"+obj.Name)
 	}
 
 	r.Log.Info("updating io chaos", "pod", obj.Namespace+"/"+obj.Name, "spec", obj.Spec)
@@ -152,6 +167,7 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		Instance:  obj.Status.Pid,
 		StartTime: obj.Status.StartTime,
 		EnterNS:   true,
+		Lease:     lease,
 	})
 	if err != nil {
 		r.Recorder.Event(obj, "Warning", "Failed", err.Error())
@@ -161,5 +177,18 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 	startTime = res.StartTime
 	pid = res.Instance
 
-	return ctrl.Result{}, nil
+	if len(obj.Spec.Actions) == 0 {
+		return ctrl.Result{}, nil
+	}
+	return ctrl.Result{RequeueAfter: chaosdaemon.LeaseRenewInterval}, nil
+}
+
+// RenderCommands renders the commands which chaos daemon will execute to apply the podiochaos
+func RenderCommands(chaos *v1alpha1.PodIOChaos) ([]string, error) {
+	actions, err := json.Marshal(chaos.Spec.Actions)
+	if err != nil {
+		return nil, err
+	}
+
+	return command.RenderToda(chaos.Spec.VolumeMountPath, string(actions)), nil
 }
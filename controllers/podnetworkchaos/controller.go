@@ -16,9 +16,14 @@ package podnetworkchaos
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
@@ -32,6 +37,7 @@ import (
 	tcpkg "github.com/chaos-mesh/chaos-mesh/controllers/podnetworkchaos/tc"
 	"github.com/chaos-mesh/chaos-mesh/controllers/utils/chaosdaemon"
 	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/command"
 	pbutils "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/netem"
 	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
 	"github.com/chaos-mesh/chaos-mesh/pkg/netem"
@@ -66,9 +72,23 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		return ctrl.Result{}, nil
 	}
 
+	leases := podLeases(obj)
 	if obj.ObjectMeta.Generation <= obj.Status.ObservedGeneration && obj.Status.FailedMessage == "" {
-		r.Log.Info("the target pod has been up to date", "pod", obj.Namespace+"/"+obj.Name)
-		return ctrl.Result{}, nil
+		if len(leases) == 0 {
+			r.Log.Info("the target pod has been up to date", "pod", obj.Namespace+"/"+obj.Name)
+			return ctrl.Result{}, nil
+		}
+
+		expired, err := r.ChaosDaemonClientBuilder.RenewPodLeases(ctx, r.Client, req.NamespacedName, leases...)
+		if !expired {
+			if err != nil {
+				r.Log.Error(err, "fail to renew the leases", "pod", obj.Namespace+"/"+obj.Name)
+			}
+			return ctrl.Result{RequeueAfter: chaosdaemon.LeaseRenewInterval}, nil
+		}
+
+		// the rules have been recovered by chaos-daemon, e.g. it has lost contact with the controller for a while
+		r.Log.Info("the lease has expired, setting the rules again", "pod", obj.Namespace+"/"+obj.Name)
 	}
 
 	r.Log.Info("updating podnetworkchaos", "pod", obj.Namespace+"/"+obj.Name, "spec", obj.Spec)
@@ -86,6 +106,10 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 
 	failedMessage := ""
 	observedGeneration := obj.ObjectMeta.Generation
+	// the devices set before are flushed if there is no traffic control or packet fault on them anymore,
+	// and they are kept in the status until the traffic control is set successfully
+	devices := appendDevices(appendDevices([]string{tcpkg.Device}, obj.Status.Devices...), usedDevices(obj)...)
+	statusDevices := devices
 	defer func() {
 		if err != nil {
 			failedMessage = err.Error()
@@ -101,6 +125,7 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 
 			obj.Status.FailedMessage = failedMessage
 			obj.Status.ObservedGeneration = observedGeneration
+			obj.Status.Devices = statusDevices
 
 			return r.Client.Status().Update(context.TODO(), obj)
 		})
@@ -150,7 +175,7 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		return ctrl.Result{Requeue: true}, nil
 	}
 
-	err = r.SetTcs(ctx, pod, obj)
+	err = r.SetTcs(ctx, pod, obj, devices)
 	if err != nil {
 		r.Recorder.Event(obj, recorder.Failed{
 			Activity: "set tc",
@@ -158,12 +183,206 @@ func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
 		})
 		return ctrl.Result{Requeue: true}, nil
 	}
+	statusDevices = usedDevices(obj)
 
-	return ctrl.Result{}, nil
+	if len(leases) == 0 {
+		return ctrl.Result{}, nil
+	}
+	return ctrl.Result{RequeueAfter: chaosdaemon.LeaseRenewInterval}, nil
 }
 
 // SetIPSets sets ipset on pod
 func (r *Reconciler) SetIPSets(ctx context.Context, pod *corev1.Pod, chaos *v1alpha1.PodNetworkChaos) error {
+	return ipset.FlushIPSets(ctx, r.ChaosDaemonClientBuilder, pod, buildIPSets(chaos))
+}
+
+// SetIptables sets iptables on pod
+func (r *Reconciler) SetIptables(ctx context.Context, pod *corev1.Pod, chaos *v1alpha1.PodNetworkChaos) error {
+	chains, err := buildChains(chaos)
+	if err != nil {
+		r.Log.Error(err, "unknown direction")
+		return err
+	}
+	return iptable.SetIptablesChains(ctx, r.ChaosDaemonClientBuilder, pod, chains, iptablesLease(chaos))
+}
+
+// SetTcs sets traffic control related chaos and packet faults on the devices of pod, the devices
+// without them are flushed
+func (r *Reconciler) SetTcs(ctx context.Context, pod *corev1.Pod, chaos *v1alpha1.PodNetworkChaos, devices []string) error {
+	available, err := tcpkg.ListDevices(ctx, r.ChaosDaemonClientBuilder, pod)
+	if err != nil {
+		if status.Code(err) != codes.Unimplemented {
+			return err
+		}
+		// the chaos daemon is too old to list the devices, so they are not checked
+		r.Log.Info("chaos daemon doesn't support listing devices", "pod", pod.Namespace+"/"+pod.Name)
+		available = nil
+	}
+
+	used := usedDevices(chaos)
+	for _, device := range devices {
+		if available != nil && !containsDevice(available, device) {
+			if containsDevice(used, device) {
+				return fmt.Errorf("device %s doesn't exist in pod %s/%s, available devices: %s",
+					device, pod.Namespace, pod.Name, strings.Join(available, ", "))
+			}
+			// the device has been removed together with its traffic control
+			continue
+		}
+
+		// the inbound traffic is set first, so the tcs on the outbound traffic are always
+		// the last ones set on the device
+		for _, ingress := range []bool{true, false} {
+			tcs, err := buildTcs(chaos, device, ingress)
+			if err != nil {
+				return err
+			}
+
+			r.Log.Info("setting tcs", "tcs", tcs, "device", device, "ingress", ingress)
+			err = tcpkg.SetTcs(ctx, r.ChaosDaemonClientBuilder, pod, device, tcs, ingress, tcLease(chaos, device, ingress))
+			if err != nil {
+				return err
+			}
+		}
+
+		faults, err := buildPacketFaults(chaos, device)
+		if err != nil {
+			return err
+		}
+
+		r.Log.Info("setting packet faults", "faults", faults, "device", device)
+		err = tcpkg.SetPacketFaults(ctx, r.ChaosDaemonClientBuilder, pod, device, faults)
+		if err != nil {
+			// the chaos daemon is too old to inject packet faults, which doesn't matter if there is none
+			if status.Code(err) != codes.Unimplemented || len(faults) > 0 {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// iptablesLease returns the lease of the iptables rules on the pod
+func iptablesLease(chaos *v1alpha1.PodNetworkChaos) *pb.LeaseRequest {
+	return chaosdaemon.Lease("podnetworkchaos", chaos.Namespace, chaos.Name, "iptables")
+}
+
+// tcLease returns the lease of the traffic control on the device of the pod
+func tcLease(chaos *v1alpha1.PodNetworkChaos, device string, ingress bool) *pb.LeaseRequest {
+	if ingress {
+		return chaosdaemon.Lease("podnetworkchaos", chaos.Namespace, chaos.Name, "tc-ingress", device)
+	}
+	return chaosdaemon.Lease("podnetworkchaos", chaos.Namespace, chaos.Name, "tc", device)
+}
+
+// podLeases returns the leases which hold the iptables rules and the traffic control of the podnetworkchaos
+// on chaos-daemon
+func podLeases(chaos *v1alpha1.PodNetworkChaos) []*pb.LeaseRequest {
+	leases := []*pb.LeaseRequest{}
+	if len(chaos.Spec.Iptables) > 0 {
+		leases = append(leases, iptablesLease(chaos))
+	}
+	for _, device := range usedDevices(chaos) {
+		for _, ingress := range []bool{true, false} {
+			// the invalid traffic control has never been set
+			if tcs, err := buildTcs(chaos, device, ingress); err == nil && len(tcs) > 0 {
+				leases = append(leases, tcLease(chaos, device, ingress))
+			}
+		}
+	}
+	return leases
+}
+
+// usedDevices returns the devices referred by the traffic controls and the packet faults of the podnetworkchaos
+func usedDevices(chaos *v1alpha1.PodNetworkChaos) []string {
+	devices := []string{}
+	for _, tc := range chaos.Spec.TrafficControls {
+		devices = appendDevices(devices, deviceOrDefault(tc.Device))
+	}
+	for _, fault := range chaos.Spec.PacketFaults {
+		devices = appendDevices(devices, deviceOrDefault(fault.Device))
+	}
+	return devices
+}
+
+// deviceOrDefault returns the device, or the default device if it's not specified
+func deviceOrDefault(device string) string {
+	if len(device) == 0 {
+		return tcpkg.Device
+	}
+	return device
+}
+
+// appendDevices appends the devices which are not in the list yet
+func appendDevices(devices []string, more ...string) []string {
+	for _, device := range more {
+		if !containsDevice(devices, device) {
+			devices = append(devices, device)
+		}
+	}
+	return devices
+}
+
+func containsDevice(devices []string, device string) bool {
+	for _, d := range devices {
+		if d == device {
+			return true
+		}
+	}
+	return false
+}
+
+// RenderCommands renders the commands which chaos daemon will execute to apply the podnetworkchaos
+func RenderCommands(chaos *v1alpha1.PodNetworkChaos) ([]string, error) {
+	var commands []string
+	for _, set := range buildIPSets(chaos) {
+		commands = append(commands, command.RenderIPSet(set)...)
+	}
+
+	chains, err := buildChains(chaos)
+	if err != nil {
+		return nil, err
+	}
+	iptables, err := command.RenderIptablesChains(chains)
+	if err != nil {
+		return nil, err
+	}
+	commands = append(commands, command.RenderIptablesInit()...)
+	commands = append(commands, iptables...)
+
+	for _, device := range appendDevices([]string{tcpkg.Device}, usedDevices(chaos)...) {
+		ingressTcs, err := buildTcs(chaos, device, true)
+		if err != nil {
+			return nil, err
+		}
+		ingressCommands, err := command.RenderIngressTcs(device, ingressTcs)
+		if err != nil {
+			return nil, err
+		}
+		commands = append(commands, ingressCommands...)
+
+		tcs, err := buildTcs(chaos, device, false)
+		if err != nil {
+			return nil, err
+		}
+		tcCommands, err := command.RenderTcs(device, tcs)
+		if err != nil {
+			return nil, err
+		}
+		commands = append(commands, tcCommands...)
+
+		faults, err := buildPacketFaults(chaos, device)
+		if err != nil {
+			return nil, err
+		}
+		commands = append(commands, command.RenderPacketFaults(device, faults)...)
+	}
+
+	return commands, nil
+}
+
+func buildIPSets(chaos *v1alpha1.PodNetworkChaos) []*pb.IPSet {
 	ipsets := []*pb.IPSet{}
 	for _, ipset := range chaos.Spec.IPSets {
 		ipsets = append(ipsets, &pb.IPSet{
@@ -171,11 +390,10 @@ func (r *Reconciler) SetIPSets(ctx context.Context, pod *corev1.Pod, chaos *v1al
 			Cidrs: ipset.Cidrs,
 		})
 	}
-	return ipset.FlushIPSets(ctx, r.ChaosDaemonClientBuilder, pod, ipsets)
+	return ipsets
 }
 
-// SetIptables sets iptables on pod
-func (r *Reconciler) SetIptables(ctx context.Context, pod *corev1.Pod, chaos *v1alpha1.PodNetworkChaos) error {
+func buildChains(chaos *v1alpha1.PodNetworkChaos) ([]*pb.Chain, error) {
 	chains := []*pb.Chain{}
 	for _, chain := range chaos.Spec.Iptables {
 		var direction pb.Chain_Direction
@@ -184,51 +402,76 @@ func (r *Reconciler) SetIptables(ctx context.Context, pod *corev1.Pod, chaos *v1
 		} else if chain.Direction == v1alpha1.Output {
 			direction = pb.Chain_OUTPUT
 		} else {
-			err := fmt.Errorf("unknown direction %s", string(chain.Direction))
-			r.Log.Error(err, "unknown direction")
-			return err
+			return nil, fmt.Errorf("unknown direction %s", string(chain.Direction))
 		}
 		chains = append(chains, &pb.Chain{
-			Name:      chain.Name,
-			Ipsets:    chain.IPSets,
-			Direction: direction,
-			Target:    "DROP",
+			Name:             chain.Name,
+			Ipsets:           chain.IPSets,
+			Direction:        direction,
+			Target:           "DROP",
+			Protocol:         string(chain.Protocol),
+			SourcePorts:      chain.SourcePorts,
+			DestinationPorts: chain.DestinationPorts,
+			Device:           chain.Device,
 		})
 	}
-	return iptable.SetIptablesChains(ctx, r.ChaosDaemonClientBuilder, pod, chains)
+	return chains, nil
 }
 
-// SetTcs sets traffic control related chaos on pod
-func (r *Reconciler) SetTcs(ctx context.Context, pod *corev1.Pod, chaos *v1alpha1.PodNetworkChaos) error {
+// buildTcs builds the tcs of the device on the inbound traffic if ingress is true, otherwise the ones on the outbound traffic
+func buildTcs(chaos *v1alpha1.PodNetworkChaos, device string, ingress bool) ([]*pb.Tc, error) {
 	tcs := []*pb.Tc{}
 	for _, tc := range chaos.Spec.TrafficControls {
+		if tc.Ingress != ingress || deviceOrDefault(tc.Device) != device {
+			continue
+		}
+
 		if tc.Type == v1alpha1.Bandwidth {
 			tbf, err := netem.FromBandwidth(tc.Bandwidth)
 			if err != nil {
-				return err
+				return nil, err
+			}
+			rateProfile, err := netem.FromRateProfile(tc.Bandwidth)
+			if err != nil {
+				return nil, err
 			}
 			tcs = append(tcs, &pb.Tc{
-				Type:  pb.Tc_BANDWIDTH,
-				Tbf:   tbf,
-				Ipset: tc.IPSet,
+				Type:        pb.Tc_BANDWIDTH,
+				Tbf:         tbf,
+				Ipset:       tc.IPSet,
+				Protocol:    string(tc.Protocol),
+				SourcePort:  tc.SourcePorts,
+				EgressPort:  tc.DestinationPorts,
+				RateProfile: rateProfile,
 			})
 		} else if tc.Type == v1alpha1.Netem {
-			netem, err := mergeNetem(tc.TcParameter)
+			em, err := mergeNetem(tc.TcParameter)
 			if err != nil {
-				return err
+				return nil, err
+			}
+			delayProfile, err := netem.FromDelayProfile(tc.Delay)
+			if err != nil {
+				return nil, err
+			}
+			lossProfile, err := netem.FromLossProfile(tc.Loss)
+			if err != nil {
+				return nil, err
 			}
 			tcs = append(tcs, &pb.Tc{
-				Type:  pb.Tc_NETEM,
-				Netem: netem,
-				Ipset: tc.IPSet,
+				Type:         pb.Tc_NETEM,
+				Netem:        em,
+				Ipset:        tc.IPSet,
+				Protocol:     string(tc.Protocol),
+				SourcePort:   tc.SourcePorts,
+				EgressPort:   tc.DestinationPorts,
+				DelayProfile: delayProfile,
+				LossProfile:  lossProfile,
 			})
 		} else {
-			return fmt.Errorf("unknown tc type")
+			return nil, fmt.Errorf("unknown tc type")
 		}
 	}
-
-	r.Log.Info("setting tcs", "tcs", tcs)
-	return tcpkg.SetTcs(ctx, r.ChaosDaemonClientBuilder, pod, tcs)
+	return tcs, nil
 }
 
 // NetemSpec defines the interface to convert to a Netem protobuf
@@ -236,6 +479,118 @@ type NetemSpec interface {
 	ToNetem() (*pb.Netem, error)
 }
 
+// packetFaultTypes maps the type of packet faults to the fault of chaos daemon
+var packetFaultTypes = map[v1alpha1.PacketFaultType]pb.PacketFault_Fault{
+	v1alpha1.DropPacketFault:    pb.PacketFault_DROP,
+	v1alpha1.ResetPacketFault:   pb.PacketFault_RESET,
+	v1alpha1.DelayPacketFault:   pb.PacketFault_DELAY,
+	v1alpha1.CorruptPacketFault: pb.PacketFault_CORRUPT,
+}
+
+// buildPacketFaults builds the packet faults of the device. The delay fault cannot be used together with
+// the traffic control on the outbound traffic of the same device, as both of them replace the root qdisc.
+func buildPacketFaults(chaos *v1alpha1.PodNetworkChaos, device string) ([]*pb.PacketFault, error) {
+	ipsets := map[string][]string{}
+	for _, ipset := range chaos.Spec.IPSets {
+		ipsets[ipset.Name] = ipset.Cidrs
+	}
+
+	faults := []*pb.PacketFault{}
+	for _, fault := range chaos.Spec.PacketFaults {
+		if deviceOrDefault(fault.Device) != device {
+			continue
+		}
+
+		pbFault, err := buildPacketFault(fault, ipsets)
+		if err != nil {
+			return nil, err
+		}
+		faults = append(faults, pbFault)
+	}
+
+	for _, fault := range faults {
+		if fault.Fault != pb.PacketFault_DELAY {
+			continue
+		}
+		for _, tc := range chaos.Spec.TrafficControls {
+			if !tc.Ingress && deviceOrDefault(tc.Device) == device {
+				return nil, fmt.Errorf("packet delay cannot be used together with traffic control on device %s", device)
+			}
+		}
+	}
+
+	return faults, nil
+}
+
+func buildPacketFault(fault v1alpha1.RawPacketFault, ipsets map[string][]string) (*pb.PacketFault, error) {
+	faultType, ok := packetFaultTypes[fault.Fault]
+	if !ok {
+		return nil, fmt.Errorf("unknown packet fault %s", fault.Fault)
+	}
+
+	pbFault := &pb.PacketFault{
+		Fault:            faultType,
+		Protocol:         string(fault.Protocol),
+		SourcePorts:      fault.SourcePorts,
+		DestinationPorts: fault.DestinationPorts,
+		Percent:          100,
+	}
+
+	if len(fault.IPSet) > 0 {
+		cidrs, ok := ipsets[fault.IPSet]
+		if !ok {
+			return nil, fmt.Errorf("ipset %s of packet fault not found", fault.IPSet)
+		}
+		pbFault.Ipset = &pb.IPSet{
+			Name:  fault.IPSet,
+			Cidrs: cidrs,
+		}
+	}
+
+	if len(fault.TCPFlags) > 0 {
+		mask, value, err := v1alpha1.ParseTCPFlags(fault.TCPFlags)
+		if err != nil {
+			return nil, err
+		}
+		pbFault.TcpFlagsMask, pbFault.TcpFlagsValue = uint32(mask), uint32(value)
+	}
+
+	if len(fault.PayloadPrefix) > 0 {
+		prefix, err := v1alpha1.ParsePacketFaultBytes(fault.PayloadPrefix)
+		if err != nil {
+			return nil, err
+		}
+		pbFault.PayloadPrefix = prefix
+	}
+
+	if len(fault.Percent) > 0 {
+		percent, err := strconv.ParseFloat(fault.Percent, 64)
+		if err != nil {
+			return nil, err
+		}
+		pbFault.Percent = percent
+	}
+
+	if fault.Fault == v1alpha1.DelayPacketFault {
+		latency, err := time.ParseDuration(fault.Latency)
+		if err != nil {
+			return nil, err
+		}
+		pbFault.Delay = latency.Nanoseconds()
+	}
+
+	if fault.Corrupt != nil {
+		value, err := v1alpha1.ParsePacketFaultBytes(fault.Corrupt.Value)
+		if err != nil {
+			return nil, err
+		}
+		pbFault.CorruptOffset = uint32(fault.Corrupt.Offset)
+		pbFault.CorruptValue = value
+	}
+
+	return pbFault, nil
+}
+
 // mergeNetem calls ToNetem on all non nil network emulation specs and merges them into one request.
 func mergeNetem(spec v1alpha1.TcParameter) (*pb.Netem, error) {
 	// NOTE: a cleaner way like
@@ -57,3 +57,29 @@ func NewController(mgr ctrl.Manager, client client.Client, logger logr.Logger, b
 
 	return "podnetworkchaos", nil
 }
+
+func NewNodeController(mgr ctrl.Manager, client client.Client, logger logr.Logger, b *chaosdaemon.ChaosDaemonClientBuilder, recorderBuilder *recorder.RecorderBuilder) (types.Controller, error) {
+	err := builder.Default(mgr).
+		For(&v1alpha1.NodeNetworkChaos{}).
+		Named("nodenetworkchaos").
+		WithEventFilter(predicate.Funcs{
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				oldObj := e.ObjectOld.(*v1alpha1.NodeNetworkChaos)
+				newObj := e.ObjectNew.(*v1alpha1.NodeNetworkChaos)
+
+				return !reflect.DeepEqual(oldObj.Spec, newObj.Spec)
+			},
+		}).
+		Complete(&NodeReconciler{
+			Client:   client,
+			Log:      logger.WithName("nodenetworkchaos"),
+			Recorder: recorderBuilder.Build("nodenetworkchaos"),
+
+			ChaosDaemonClientBuilder: b,
+		})
+	if err != nil {
+		return "", err
+	}
+
+	return "nodenetworkchaos", nil
+}
@@ -35,8 +35,8 @@ func BuildIPSet(pods []v1.Pod, externalCidrs []string, networkchaos *v1alpha1.Ne
 	cidrs := externalCidrs
 
 	for _, pod := range pods {
-		if len(pod.Status.PodIP) > 0 {
-			cidrs = append(cidrs, netutils.IPToCidr(pod.Status.PodIP))
+		for _, ip := range PodIPs(&pod) {
+			cidrs = append(cidrs, netutils.IPToCidr(ip))
 		}
 	}
 
@@ -49,6 +49,35 @@ func BuildIPSet(pods []v1.Pod, externalCidrs []string, networkchaos *v1alpha1.Ne
 	}
 }
 
+// PodIPs returns the IPs of all families allocated to the pod
+func PodIPs(pod *v1.Pod) []string {
+	var ips []string
+	for _, podIP := range pod.Status.PodIPs {
+		if len(podIP.IP) > 0 {
+			ips = append(ips, podIP.IP)
+		}
+	}
+
+	// the podIPs may be empty on the clusters without dual-stack support
+	if len(ips) == 0 && len(pod.Status.PodIP) > 0 {
+		ips = append(ips, pod.Status.PodIP)
+	}
+
+	return ips
+}
+
+// NodeIPs returns the internal addresses of the node
+func NodeIPs(node *v1.Node) []string {
+	var ips []string
+	for _, addr := range node.Status.Addresses {
+		if addr.Type == v1.NodeInternalIP && len(addr.Address) > 0 {
+			ips = append(ips, addr.Address)
+		}
+	}
+
+	return ips
+}
+
 // GenerateIPSetName generates name for ipset
 func GenerateIPSetName(networkchaos *v1alpha1.NetworkChaos, namePostFix string) string {
 	return netutils.CompressName(networkchaos.Name, 27, namePostFix)
@@ -87,3 +116,19 @@ func FlushIPSets(ctx context.Context, builder *chaosdaemon.ChaosDaemonClientBuil
 
 	return fmt.Errorf("unable to flush ip sets for pod %s", pod.Name)
 }
+
+// FlushNodeIPSets makes grpc call to chaosdaemon to flush the ipsets in the host network of the node
+func FlushNodeIPSets(ctx context.Context, builder *chaosdaemon.ChaosDaemonClientBuilder, nodeName string, ipsets []*pb.IPSet) error {
+	pbClient, err := builder.BuildForNode(ctx, nodeName)
+	if err != nil {
+		return err
+	}
+	defer pbClient.Close()
+
+	log.Info("attempting to flush ip set on node", "node", nodeName)
+	_, err = pbClient.FlushIPSets(ctx, &pb.IPSetsRequest{
+		Ipsets:      ipsets,
+		HostNetwork: true,
+	})
+	return err
+}
@@ -17,6 +17,7 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
@@ -52,3 +53,32 @@ func Test_generateIPSetName(t *testing.T) {
 		g.Expect(len(name)).Should(Equal(27))
 	})
 }
+
+func TestBuildIPSet(t *testing.T) {
+	g := NewWithT(t)
+
+	pods := []v1.Pod{
+		{
+			Status: v1.PodStatus{
+				PodIP:  "10.0.0.1",
+				PodIPs: []v1.PodIP{{IP: "10.0.0.1"}, {IP: "fd00::1"}},
+			},
+		},
+		{
+			// the pod on the cluster without dual-stack support
+			Status: v1.PodStatus{PodIP: "10.0.0.2"},
+		},
+		{
+			// the pod without ip allocated
+			Status: v1.PodStatus{},
+		},
+	}
+	networkChaos := &v1alpha1.NetworkChaos{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test",
+		},
+	}
+
+	ipset := BuildIPSet(pods, []string{"fd00:1::/64"}, networkChaos, "tgt", "default/test")
+	g.Expect(ipset.Cidrs).To(Equal([]string{"fd00:1::/64", "10.0.0.1/32", "fd00::1/128", "10.0.0.2/32"}))
+}
@@ -29,8 +29,8 @@ import (
 
 var log = ctrl.Log.WithName("iptable")
 
-// SetIptablesChains makes grpc call to chaosdaemon to flush iptable
-func SetIptablesChains(ctx context.Context, builder *chaosdaemon.ChaosDaemonClientBuilder, pod *v1.Pod, chains []*pb.Chain) error {
+// SetIptablesChains makes grpc call to chaosdaemon to flush iptable, the chains are held by the lease
+func SetIptablesChains(ctx context.Context, builder *chaosdaemon.ChaosDaemonClientBuilder, pod *v1.Pod, chains []*pb.Chain, lease *pb.LeaseRequest) error {
 	pbClient, err := builder.Build(ctx, pod)
 	if err != nil {
 		return err
@@ -50,6 +50,7 @@ func SetIptablesChains(ctx context.Context, builder *chaosdaemon.ChaosDaemonClie
 			Chains:      chains,
 			ContainerId: containerID,
 			EnterNS:     true,
+			Lease:       lease,
 		})
 
 		if err != nil {
@@ -63,6 +64,23 @@ func SetIptablesChains(ctx context.Context, builder *chaosdaemon.ChaosDaemonClie
 	return fmt.Errorf("unable to set ip tables chains for pod %s", pod.Name)
 }
 
+// SetNodeIptablesChains makes grpc call to chaosdaemon to set the iptables chains in the host network of the node.
+// The ports of chaos-daemon and kubelet are never blocked by the chains.
+func SetNodeIptablesChains(ctx context.Context, builder *chaosdaemon.ChaosDaemonClientBuilder, nodeName string, chains []*pb.Chain) error {
+	pbClient, err := builder.BuildForNode(ctx, nodeName)
+	if err != nil {
+		return err
+	}
+	defer pbClient.Close()
+
+	log.Info("attempting to set ip table chains on node", "node", nodeName)
+	_, err = pbClient.SetIptablesChains(ctx, &pb.IptablesChainsRequest{
+		Chains:      chains,
+		HostNetwork: true,
+	})
+	return err
+}
+
 // GenerateName generates chain name for network chaos
 func GenerateName(direction pb.Chain_Direction, networkchaos *v1alpha1.NetworkChaos) (chainName string) {
 	switch direction {
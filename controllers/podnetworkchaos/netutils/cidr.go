@@ -15,12 +15,14 @@ package netutils
 
 import (
 	"net"
-	"strings"
 )
 
-// IPToCidr converts from an ip to a full mask cidr
+// IPToCidr converts from an ip to a full mask cidr, which is /32 for IPv4 and /128 for IPv6
 func IPToCidr(ip string) string {
-	// TODO: support IPv6
+	if parsed := net.ParseIP(ip); parsed != nil && parsed.To4() == nil {
+		return ip + "/128"
+	}
+
 	return ip + "/32"
 }
 
@@ -56,14 +58,10 @@ func ResolveCidr(name string) ([]string, error) {
 		return nil, err
 	}
 
+	// both IPv4 and IPv6 addresses are resolved, for the dual-stack clusters
 	cidrs := []string{}
 	for _, addr := range addrs {
-		addr := addr.String()
-
-		// TODO: support IPv6
-		if strings.Contains(addr, ".") {
-			cidrs = append(cidrs, IPToCidr(addr))
-		}
+		cidrs = append(cidrs, IPToCidr(addr.String()))
 	}
 	return cidrs, nil
 }
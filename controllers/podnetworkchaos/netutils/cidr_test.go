@@ -0,0 +1,31 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netutils
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestResolveCidrs(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(IPToCidr("10.0.0.1")).To(Equal("10.0.0.1/32"))
+	g.Expect(IPToCidr("fd00::1")).To(Equal("fd00::1/128"))
+
+	cidrs, err := ResolveCidrs([]string{"10.0.0.1", "fd00::1", "10.1.0.0/16", "fd00:1::/64"})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(cidrs).To(Equal([]string{"10.0.0.1/32", "fd00::1/128", "10.1.0.0/16", "fd00:1::/64"}))
+}
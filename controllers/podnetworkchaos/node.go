@@ -0,0 +1,252 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package podnetworkchaos
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/controllers/podnetworkchaos/ipset"
+	"github.com/chaos-mesh/chaos-mesh/controllers/podnetworkchaos/iptable"
+	tcpkg "github.com/chaos-mesh/chaos-mesh/controllers/podnetworkchaos/tc"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/chaosdaemon"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
+	chaosdaemonclient "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/client"
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+)
+
+// nodeDefaultDevice represents the device of the default route on the node
+const nodeDefaultDevice = ""
+
+// NodeReconciler sets the rules of the nodenetworkchaos on the host network of the node, and renews the lease
+// of the rules periodically. Chaos-daemon recovers the rules once the lease expires.
+type NodeReconciler struct {
+	client.Client
+	Recorder recorder.ChaosRecorder
+
+	Log                      logr.Logger
+	ChaosDaemonClientBuilder *chaosdaemon.ChaosDaemonClientBuilder
+}
+
+func (r *NodeReconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	ctx := context.TODO()
+
+	obj := &v1alpha1.NodeNetworkChaos{}
+
+	if err := r.Client.Get(ctx, req.NamespacedName, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.Log.Info("chaos not found")
+		} else {
+			r.Log.Error(err, "unable to get chaos")
+		}
+		return ctrl.Result{}, nil
+	}
+
+	empty := isEmptySpec(&obj.Spec)
+	if obj.ObjectMeta.Generation <= obj.Status.ObservedGeneration && obj.Status.FailedMessage == "" {
+		if empty {
+			r.Log.Info("the target node has been up to date", "node", obj.Name)
+			return ctrl.Result{}, nil
+		}
+
+		expired, err := r.renewLease(ctx, obj.Name)
+		if !expired {
+			if err != nil {
+				r.Log.Error(err, "fail to renew the lease", "node", obj.Name)
+			}
+			return ctrl.Result{RequeueAfter: chaosdaemon.LeaseRenewInterval}, nil
+		}
+
+		// the rules have been recovered by chaos-daemon, e.g. it has been restarted
+		r.Log.Info("the lease has expired, setting the rules again", "node", obj.Name)
+	}
+
+	r.Log.Info("updating nodenetworkchaos", "node", obj.Name, "spec", obj.Spec)
+
+	node := &corev1.Node{}
+	err := r.Client.Get(ctx, types.NamespacedName{Name: obj.Name}, node)
+	if err != nil {
+		r.Log.Error(err, "fail to find node")
+		return ctrl.Result{}, nil
+	}
+
+	failedMessage := ""
+	observedGeneration := obj.ObjectMeta.Generation
+	// the default device is always flushed, and the other devices set before are flushed
+	// if there is no traffic control on them anymore
+	devices := appendDevices(appendDevices([]string{nodeDefaultDevice}, obj.Status.Devices...), usedNodeDevices(obj)...)
+	statusDevices := obj.Status.Devices
+	defer func() {
+		if err != nil {
+			failedMessage = err.Error()
+		}
+
+		updateError := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+			obj := &v1alpha1.NodeNetworkChaos{}
+
+			if err := r.Client.Get(context.TODO(), req.NamespacedName, obj); err != nil {
+				r.Log.Error(err, "unable to get chaos")
+				return err
+			}
+
+			obj.Status.FailedMessage = failedMessage
+			obj.Status.ObservedGeneration = observedGeneration
+			obj.Status.Devices = statusDevices
+
+			return r.Client.Status().Update(context.TODO(), obj)
+		})
+
+		if updateError != nil {
+			r.Log.Error(updateError, "fail to update")
+			r.Recorder.Event(obj, recorder.Failed{
+				Activity: "update status",
+				Err:      updateError.Error(),
+			})
+		}
+
+		r.Recorder.Event(obj, recorder.Updated{
+			Field: "ObservedGeneration and FailedMessage",
+		})
+	}()
+
+	podnetworkchaos := &v1alpha1.PodNetworkChaos{Spec: obj.Spec}
+
+	err = ipset.FlushNodeIPSets(ctx, r.ChaosDaemonClientBuilder, node.Name, buildIPSets(podnetworkchaos))
+	if err != nil {
+		r.Log.Error(err, "fail to set ipsets")
+		r.Recorder.Event(obj, recorder.Failed{
+			Activity: "set ipsets",
+			Err:      err.Error(),
+		})
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	chains, err := buildChains(podnetworkchaos)
+	if err == nil {
+		err = iptable.SetNodeIptablesChains(ctx, r.ChaosDaemonClientBuilder, node.Name, chains)
+	}
+	if err != nil {
+		r.Log.Error(err, "fail to set iptables")
+		r.Recorder.Event(obj, recorder.Failed{
+			Activity: "set iptables",
+			Err:      err.Error(),
+		})
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	err = r.SetTcs(ctx, node, obj, devices)
+	if err != nil {
+		r.Recorder.Event(obj, recorder.Failed{
+			Activity: "set tc",
+			Err:      err.Error(),
+		})
+		return ctrl.Result{Requeue: true}, nil
+	}
+	statusDevices = removeDevice(usedNodeDevices(obj), nodeDefaultDevice)
+
+	if empty {
+		return ctrl.Result{}, nil
+	}
+	return ctrl.Result{RequeueAfter: chaosdaemon.LeaseRenewInterval}, nil
+}
+
+// SetTcs sets the traffic control on the devices of the node, the device of the default route
+// is used for the traffic control without a device
+func (r *NodeReconciler) SetTcs(ctx context.Context, node *corev1.Node, chaos *v1alpha1.NodeNetworkChaos, devices []string) error {
+	available, err := tcpkg.ListNodeDevices(ctx, r.ChaosDaemonClientBuilder, node.Name)
+	if err != nil {
+		return err
+	}
+
+	used := usedNodeDevices(chaos)
+	for _, device := range devices {
+		if device != nodeDefaultDevice && !containsDevice(available, device) {
+			if containsDevice(used, device) {
+				return fmt.Errorf("device %s doesn't exist on node %s, available devices: %s",
+					device, node.Name, strings.Join(available, ", "))
+			}
+			// the device has been removed together with its traffic control
+			continue
+		}
+
+		tcs, err := buildNodeTcs(chaos, device)
+		if err != nil {
+			return err
+		}
+
+		r.Log.Info("setting tcs on node", "tcs", tcs, "device", device)
+		err = tcpkg.SetNodeTcs(ctx, r.ChaosDaemonClientBuilder, node.Name, device, tcs)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *NodeReconciler) renewLease(ctx context.Context, nodeName string) (bool, error) {
+	pbClient, err := r.ChaosDaemonClientBuilder.BuildForNode(ctx, nodeName)
+	if err != nil {
+		return false, err
+	}
+	defer pbClient.Close()
+
+	return chaosdaemon.RenewLeases(ctx, pbClient, chaosdaemon.Lease(chaosdaemonclient.HostNetworkLease))
+}
+
+// buildNodeTcs builds the traffic control on the outbound traffic of the device of the node
+func buildNodeTcs(chaos *v1alpha1.NodeNetworkChaos, device string) ([]*pb.Tc, error) {
+	// the traffic control is built in the same way as the one on the default device of a pod
+	podnetworkchaos := &v1alpha1.PodNetworkChaos{}
+	for _, tc := range chaos.Spec.TrafficControls {
+		if tc.Device == device {
+			tc.Device = tcpkg.Device
+			podnetworkchaos.Spec.TrafficControls = append(podnetworkchaos.Spec.TrafficControls, tc)
+		}
+	}
+
+	return buildTcs(podnetworkchaos, tcpkg.Device, false)
+}
+
+func usedNodeDevices(chaos *v1alpha1.NodeNetworkChaos) []string {
+	devices := []string{}
+	for _, tc := range chaos.Spec.TrafficControls {
+		devices = appendDevices(devices, tc.Device)
+	}
+	return devices
+}
+
+func removeDevice(devices []string, device string) []string {
+	result := []string{}
+	for _, d := range devices {
+		if d != device {
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+func isEmptySpec(spec *v1alpha1.PodNetworkChaosSpec) bool {
+	return len(spec.IPSets) == 0 && len(spec.Iptables) == 0 && len(spec.TrafficControls) == 0 && len(spec.PacketFaults) == 0
+}
@@ -17,6 +17,8 @@ import (
 	"context"
 	"fmt"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	v1 "k8s.io/api/core/v1"
 
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -27,8 +29,12 @@ import (
 
 var log = ctrl.Log.WithName("tc")
 
-// SetTcs makes grpc call to chaosdaemon to flush traffic control rules
-func SetTcs(ctx context.Context, builder *chaosdaemon.ChaosDaemonClientBuilder, pod *v1.Pod, tcs []*pb.Tc) error {
+// Device is the default network device to set the tc rules on
+const Device = "eth0"
+
+// SetTcs makes grpc call to chaosdaemon to flush traffic control rules of the device, on the inbound traffic if ingress is true.
+// The rules are held by the lease.
+func SetTcs(ctx context.Context, builder *chaosdaemon.ChaosDaemonClientBuilder, pod *v1.Pod, device string, tcs []*pb.Tc, ingress bool, lease *pb.LeaseRequest) error {
 	pbClient, err := builder.Build(ctx, pod)
 	if err != nil {
 		return err
@@ -49,8 +55,10 @@ func SetTcs(ctx context.Context, builder *chaosdaemon.ChaosDaemonClientBuilder,
 			Tcs:         tcs,
 			ContainerId: containerID,
 			// Prevent tcs is empty, used to clean up tc rules
-			Device:  "eth0",
+			Device:  device,
 			EnterNS: true,
+			Ingress: ingress,
+			Lease:   lease,
 		})
 
 		if err != nil {
@@ -63,3 +71,110 @@ func SetTcs(ctx context.Context, builder *chaosdaemon.ChaosDaemonClientBuilder,
 
 	return fmt.Errorf("unable to set tcs for pod %s", pod.Name)
 }
+
+// ListDevices makes grpc call to chaosdaemon to list the network devices of the pod
+func ListDevices(ctx context.Context, builder *chaosdaemon.ChaosDaemonClientBuilder, pod *v1.Pod) ([]string, error) {
+	pbClient, err := builder.Build(ctx, pod)
+	if err != nil {
+		return nil, err
+	}
+	defer pbClient.Close()
+
+	if len(pod.Status.ContainerStatuses) == 0 {
+		return nil, fmt.Errorf("%s %s can't get the state of container", pod.Namespace, pod.Name)
+	}
+
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		containerName := containerStatus.Name
+		containerID := containerStatus.ContainerID
+		log.Info("attempting to list devices", "containerName", containerName, "containerID", containerID)
+
+		resp, err := pbClient.ListInterfaces(ctx, &pb.ListInterfacesRequest{
+			ContainerId: containerID,
+		})
+		if status.Code(err) == codes.Unimplemented {
+			return nil, err
+		}
+
+		if err != nil {
+			log.Error(err, fmt.Sprintf("error while listing devices for container %s, id %s", containerName, containerID))
+		} else {
+			return resp.Interfaces, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unable to list devices for pod %s", pod.Name)
+}
+
+// SetPacketFaults makes grpc call to chaosdaemon to replace the packet faults on the outbound traffic of the device
+func SetPacketFaults(ctx context.Context, builder *chaosdaemon.ChaosDaemonClientBuilder, pod *v1.Pod, device string, faults []*pb.PacketFault) error {
+	pbClient, err := builder.Build(ctx, pod)
+	if err != nil {
+		return err
+	}
+	defer pbClient.Close()
+
+	if len(pod.Status.ContainerStatuses) == 0 {
+		return fmt.Errorf("%s %s can't get the state of container", pod.Namespace, pod.Name)
+	}
+
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		containerName := containerStatus.Name
+		containerID := containerStatus.ContainerID
+		log.Info("attempting to set packet faults", "containerName", containerName, "containerID", containerID)
+
+		_, err = pbClient.SetPacketFaults(ctx, &pb.PacketFaultsRequest{
+			Faults:      faults,
+			ContainerId: containerID,
+			Device:      device,
+			EnterNS:     true,
+		})
+		if status.Code(err) == codes.Unimplemented {
+			return err
+		}
+
+		if err != nil {
+			log.Error(err, fmt.Sprintf("error while setting packet faults for container %s, id %s", containerName, containerID))
+		} else {
+			log.Info("Successfully set packet faults")
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unable to set packet faults for pod %s", pod.Name)
+}
+
+// SetNodeTcs makes grpc call to chaosdaemon to set the traffic control rules of the device in the host network of the node.
+// The packets of chaos-daemon and kubelet are never affected by the tcs.
+func SetNodeTcs(ctx context.Context, builder *chaosdaemon.ChaosDaemonClientBuilder, nodeName string, device string, tcs []*pb.Tc) error {
+	pbClient, err := builder.BuildForNode(ctx, nodeName)
+	if err != nil {
+		return err
+	}
+	defer pbClient.Close()
+
+	log.Info("attempting to set tcs on node", "node", nodeName, "device", device)
+	_, err = pbClient.SetTcs(ctx, &pb.TcsRequest{
+		Tcs:         tcs,
+		Device:      device,
+		HostNetwork: true,
+	})
+	return err
+}
+
+// ListNodeDevices makes grpc call to chaosdaemon to list the network devices in the host network of the node
+func ListNodeDevices(ctx context.Context, builder *chaosdaemon.ChaosDaemonClientBuilder, nodeName string) ([]string, error) {
+	pbClient, err := builder.BuildForNode(ctx, nodeName)
+	if err != nil {
+		return nil, err
+	}
+	defer pbClient.Close()
+
+	resp, err := pbClient.ListInterfaces(ctx, &pb.ListInterfacesRequest{
+		HostNetwork: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Interfaces, nil
+}
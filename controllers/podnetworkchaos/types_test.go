@@ -15,9 +15,13 @@ package podnetworkchaos
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -28,7 +32,9 @@ import (
 
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
 	"github.com/chaos-mesh/chaos-mesh/cmd/chaos-controller-manager/provider"
+	tcpkg "github.com/chaos-mesh/chaos-mesh/controllers/podnetworkchaos/tc"
 	. "github.com/chaos-mesh/chaos-mesh/controllers/test"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/chaosdaemon"
 	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
 	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
 	"github.com/chaos-mesh/chaos-mesh/pkg/mock"
@@ -153,3 +159,325 @@ func TestMergenetem(t *testing.T) {
 		g.Expect(m).Should(Equal(em))
 	})
 }
+
+func TestBuildWithPacketFilter(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	filter := v1alpha1.PacketFilter{
+		Protocol:         v1alpha1.TCPProtocol,
+		SourcePorts:      "8080",
+		DestinationPorts: "5432",
+	}
+	chaos := &v1alpha1.PodNetworkChaos{
+		Spec: v1alpha1.PodNetworkChaosSpec{
+			Iptables: []v1alpha1.RawIptables{{
+				Name:         "chain",
+				Direction:    v1alpha1.Output,
+				PacketFilter: filter,
+			}},
+			TrafficControls: []v1alpha1.RawTrafficControl{{
+				Type: v1alpha1.Netem,
+				TcParameter: v1alpha1.TcParameter{
+					Delay: &v1alpha1.DelaySpec{Latency: "90ms", Jitter: "0ms", Correlation: "0"},
+				},
+				PacketFilter: filter,
+			}},
+		},
+	}
+
+	chains, err := buildChains(chaos)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(chains).To(HaveLen(1))
+	g.Expect(chains[0].Protocol).To(Equal("tcp"))
+	g.Expect(chains[0].SourcePorts).To(Equal("8080"))
+	g.Expect(chains[0].DestinationPorts).To(Equal("5432"))
+
+	tcs, err := buildTcs(chaos, tcpkg.Device, false)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(tcs).To(HaveLen(1))
+	g.Expect(tcs[0].Protocol).To(Equal("tcp"))
+	g.Expect(tcs[0].SourcePort).To(Equal("8080"))
+	g.Expect(tcs[0].EgressPort).To(Equal("5432"))
+}
+
+func TestBuildWithProfile(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	chaos := &v1alpha1.PodNetworkChaos{
+		Spec: v1alpha1.PodNetworkChaosSpec{
+			TrafficControls: []v1alpha1.RawTrafficControl{
+				{
+					Type: v1alpha1.Netem,
+					TcParameter: v1alpha1.TcParameter{
+						Delay: &v1alpha1.DelaySpec{
+							Jitter:      "0ms",
+							Correlation: "0",
+							Profile: &v1alpha1.ProfileSpec{
+								Steps: []v1alpha1.ProfileStep{
+									{Value: "10ms", Duration: "1m"},
+									{Value: "200ms", Duration: "30s"},
+								},
+							},
+						},
+					},
+				},
+				{
+					Type: v1alpha1.Bandwidth,
+					TcParameter: v1alpha1.TcParameter{
+						Bandwidth: &v1alpha1.BandwidthSpec{
+							Limit:  100,
+							Buffer: 10000,
+							Profile: &v1alpha1.ProfileSpec{
+								Waveform: &v1alpha1.WaveformSpec{
+									Type:   v1alpha1.SineWaveform,
+									Min:    "1kbps",
+									Max:    "10kbps",
+									Period: "10m",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tcs, err := buildTcs(chaos, tcpkg.Device, false)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(tcs).To(HaveLen(2))
+
+	// the qdiscs are added with the initial values of the profiles
+	g.Expect(tcs[0].Netem.Time).To(Equal(uint32(10000)))
+	g.Expect(tcs[0].DelayProfile.Waveform).To(Equal(pb.TcProfile_STEPS))
+	g.Expect(tcs[0].DelayProfile.Steps).To(HaveLen(2))
+	g.Expect(tcs[0].DelayProfile.Steps[1].Value).To(Equal(float64(200000)))
+	g.Expect(tcs[0].DelayProfile.Steps[1].Duration).To(Equal(int64(30 * time.Second)))
+
+	g.Expect(tcs[1].Tbf.Rate).To(Equal(uint64(1024)))
+	g.Expect(tcs[1].RateProfile.Waveform).To(Equal(pb.TcProfile_SINE))
+	g.Expect(tcs[1].RateProfile.Max).To(Equal(float64(10240)))
+	g.Expect(tcs[1].RateProfile.Period).To(Equal(int64(10 * time.Minute)))
+	g.Expect(tcs[1].RateProfile.Interval).To(Equal(int64(time.Second)))
+}
+
+func TestBuildWithIngress(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	delay := v1alpha1.TcParameter{
+		Delay: &v1alpha1.DelaySpec{Latency: "90ms", Jitter: "0ms", Correlation: "0"},
+	}
+	chaos := &v1alpha1.PodNetworkChaos{
+		Spec: v1alpha1.PodNetworkChaosSpec{
+			IPSets: []v1alpha1.RawIPSet{{Name: "neing", Cidrs: []string{"8.8.8.8/32"}}},
+			TrafficControls: []v1alpha1.RawTrafficControl{
+				{Type: v1alpha1.Netem, TcParameter: delay},
+				{Type: v1alpha1.Netem, TcParameter: delay, IPSet: "neing", Ingress: true},
+			},
+		},
+	}
+
+	tcs, err := buildTcs(chaos, tcpkg.Device, false)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(tcs).To(HaveLen(1))
+	g.Expect(tcs[0].Ipset).To(BeEmpty())
+
+	tcs, err = buildTcs(chaos, tcpkg.Device, true)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(tcs).To(HaveLen(1))
+	g.Expect(tcs[0].Ipset).To(Equal("neing"))
+
+	// the inbound traffic is shaped on the IFB device, and the outbound traffic on eth0
+	commands, err := RenderCommands(chaos)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(commands).To(ContainElement("tc qdisc add dev ifbeth0 parent 1:4 handle 5: netem delay 90000"))
+	g.Expect(commands).To(ContainElement("tc qdisc add dev eth0 root handle 1: netem delay 90000"))
+}
+
+func TestBuildWithDevice(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	delay := v1alpha1.TcParameter{
+		Delay: &v1alpha1.DelaySpec{Latency: "90ms", Jitter: "0ms", Correlation: "0"},
+	}
+	chaos := &v1alpha1.PodNetworkChaos{
+		Spec: v1alpha1.PodNetworkChaosSpec{
+			Iptables: []v1alpha1.RawIptables{{
+				Name:      "OUTPUT/test",
+				Direction: v1alpha1.Output,
+				Device:    "net1",
+			}},
+			TrafficControls: []v1alpha1.RawTrafficControl{
+				{Type: v1alpha1.Netem, TcParameter: delay, Device: "net1"},
+				{Type: v1alpha1.Netem, TcParameter: delay},
+			},
+		},
+	}
+
+	chains, err := buildChains(chaos)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(chains[0].Device).To(Equal("net1"))
+
+	g.Expect(usedDevices(chaos)).To(Equal([]string{"net1", tcpkg.Device}))
+	tcs, err := buildTcs(chaos, "net1", false)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(tcs).To(HaveLen(1))
+	tcs, err = buildTcs(chaos, tcpkg.Device, false)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(tcs).To(HaveLen(1))
+
+	commands, err := RenderCommands(chaos)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(commands).To(ContainElement("iptables -w -A OUTPUT/test -j DROP -w 5 --out-interface net1"))
+	g.Expect(commands).To(ContainElement("tc qdisc add dev net1 root handle 1: netem delay 90000"))
+	g.Expect(commands).To(ContainElement("tc qdisc add dev eth0 root handle 1: netem delay 90000"))
+}
+
+func TestBuildPacketFaults(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	chaos := &v1alpha1.PodNetworkChaos{
+		Spec: v1alpha1.PodNetworkChaosSpec{
+			IPSets: []v1alpha1.RawIPSet{{
+				Name:  "pa-tgt",
+				Cidrs: []string{"10.0.0.1/32"},
+			}},
+			PacketFaults: []v1alpha1.RawPacketFault{{
+				PacketFaultSpec: v1alpha1.PacketFaultSpec{
+					Fault:         v1alpha1.ResetPacketFault,
+					TCPFlags:      "PSH,!SYN",
+					PayloadPrefix: "474554",
+					Percent:       "50",
+				},
+				IPSet:        "pa-tgt",
+				PacketFilter: v1alpha1.PacketFilter{Protocol: v1alpha1.TCPProtocol, DestinationPorts: "80"},
+			}, {
+				PacketFaultSpec: v1alpha1.PacketFaultSpec{
+					Fault:   v1alpha1.DelayPacketFault,
+					Latency: "10ms",
+				},
+				Device: "net1",
+			}},
+		},
+	}
+
+	g.Expect(usedDevices(chaos)).To(Equal([]string{tcpkg.Device, "net1"}))
+	faults, err := buildPacketFaults(chaos, tcpkg.Device)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(faults).To(Equal([]*pb.PacketFault{{
+		Fault:            pb.PacketFault_RESET,
+		Ipset:            &pb.IPSet{Name: "pa-tgt", Cidrs: []string{"10.0.0.1/32"}},
+		Protocol:         "tcp",
+		DestinationPorts: "80",
+		TcpFlagsMask:     0x0a,
+		TcpFlagsValue:    0x08,
+		PayloadPrefix:    []byte("GET"),
+		Percent:          50,
+	}}))
+
+	faults, err = buildPacketFaults(chaos, "net1")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(faults).To(HaveLen(1))
+	g.Expect(faults[0].Delay).To(Equal(int64(10 * time.Millisecond)))
+	g.Expect(faults[0].Percent).To(Equal(float64(100)))
+
+	commands, err := RenderCommands(chaos)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(commands).To(ContainElement("tc qdisc replace dev net1 root handle ca05: fq"))
+	g.Expect(commands).To(ContainElement("tc filter add dev eth0 egress pref 1 handle 0x1 bpf direct-action name chaos-mesh-packet-fault"))
+
+	// both of the delay and the traffic control replace the root qdisc
+	chaos.Spec.TrafficControls = []v1alpha1.RawTrafficControl{{
+		Type:        v1alpha1.Netem,
+		TcParameter: v1alpha1.TcParameter{Loss: &v1alpha1.LossSpec{Loss: "10", Correlation: "0"}},
+		Device:      "net1",
+	}}
+	_, err = buildPacketFaults(chaos, "net1")
+	g.Expect(err).Should(HaveOccurred())
+}
+
+func TestPodLeases(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	delay := v1alpha1.TcParameter{
+		Delay: &v1alpha1.DelaySpec{Latency: "90ms", Jitter: "0ms", Correlation: "0"},
+	}
+	chaos := &v1alpha1.PodNetworkChaos{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "p0",
+		},
+		Spec: v1alpha1.PodNetworkChaosSpec{
+			IPSets: []v1alpha1.RawIPSet{{Name: "neing", Cidrs: []string{"8.8.8.8/32"}}},
+			Iptables: []v1alpha1.RawIptables{{
+				Name:      "OUTPUT/test",
+				Direction: v1alpha1.Output,
+			}},
+			TrafficControls: []v1alpha1.RawTrafficControl{
+				{Type: v1alpha1.Netem, TcParameter: delay, IPSet: "neing", Ingress: true},
+			},
+		},
+	}
+
+	ids := []string{}
+	for _, lease := range podLeases(chaos) {
+		ids = append(ids, lease.Id)
+	}
+	g.Expect(ids).To(Equal([]string{
+		"podnetworkchaos/default/p0/iptables",
+		"podnetworkchaos/default/p0/tc-ingress/eth0",
+	}))
+
+	g.Expect(podLeases(&v1alpha1.PodNetworkChaos{})).To(BeEmpty())
+}
+
+func TestRenewLeases(t *testing.T) {
+	defer mock.With("MockChaosDaemonClient", &MockChaosDaemonClient{})()
+	defer mock.With("MockSetIptablesChainsError", errors.New("fail to set iptables"))()
+	g := NewGomegaWithT(t)
+
+	objs, _ := GenerateNPods("p", 1, PodArg{})
+	chaos := &v1alpha1.PodNetworkChaos{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  metav1.NamespaceDefault,
+			Name:       "p0",
+			Generation: 1,
+		},
+		Spec: v1alpha1.PodNetworkChaosSpec{
+			Iptables: []v1alpha1.RawIptables{{
+				Name:      "OUTPUT/test",
+				Direction: v1alpha1.Output,
+			}},
+		},
+		Status: v1alpha1.PodNetworkChaosStatus{
+			ObservedGeneration: 1,
+		},
+	}
+	objs = append(objs, chaos)
+
+	fakeClient := fake.NewFakeClientWithScheme(provider.NewScheme(), objs...)
+	h := &Reconciler{
+		Client:   fakeClient,
+		Recorder: recorder.NewDebugRecorder(),
+		Log:      zap.New(zap.UseDevMode(true)),
+	}
+	req := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "p0",
+		},
+	}
+
+	// the rules are kept on the pod by renewing the lease
+	result, err := h.Reconcile(req)
+	g.Expect(err).To(BeNil())
+	g.Expect(result.RequeueAfter).To(Equal(chaosdaemon.LeaseRenewInterval))
+	g.Expect(fakeClient.Get(context.Background(), req.NamespacedName, chaos)).To(BeNil())
+	g.Expect(chaos.Status.FailedMessage).To(BeEmpty())
+
+	// the rules are set again once the lease has expired
+	defer mock.With("MockRenewLeaseError", status.Error(codes.NotFound, "lease not found"))()
+	_, err = h.Reconcile(req)
+	g.Expect(err).To(BeNil())
+	g.Expect(fakeClient.Get(context.Background(), req.NamespacedName, chaos)).To(BeNil())
+	g.Expect(chaos.Status.FailedMessage).NotTo(BeEmpty())
+}
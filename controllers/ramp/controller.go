@@ -0,0 +1,169 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramp
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/controllers/common"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/controller"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
+)
+
+// Reconciler advances the stages of the selectors in ramp mode
+type Reconciler struct {
+	// Object is used to mark the target type of this Reconciler
+	Object common.InnerObjectWithSelector
+
+	// Client is used to operate on the Kubernetes cluster
+	client.Client
+
+	Recorder recorder.ChaosRecorder
+	Log      logr.Logger
+}
+
+// Reconcile the stages of ramp
+func (r *Reconciler) Reconcile(req ctrl.Request) (ctrl.Result, error) {
+	obj := r.Object.DeepCopyObject().(common.InnerObjectWithSelector)
+
+	if err := r.Client.Get(context.TODO(), req.NamespacedName, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.Log.Info("chaos not found")
+		} else {
+			// TODO: handle this error
+			r.Log.Error(err, "unable to get chaos")
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if obj.IsDeleted() || obj.IsOneShot() {
+		return ctrl.Result{}, nil
+	}
+
+	// the ramp is halted while the experiment is paused or aborted, and the current stage will
+	// last for a whole interval again after the experiment is resumed
+	status := obj.GetStatus()
+	running := status.Experiment.DesiredPhase == v1alpha1.RunningPhase && !status.IsAborted()
+
+	now := time.Now()
+	var requeueAfter time.Duration
+	var changes []recorder.RampStageChanged
+	ramps := []v1alpha1.RampStatus{}
+	for key, sel := range obj.GetSelectorSpecs() {
+		rampable, ok := sel.(common.RampableSelector)
+		if !ok {
+			continue
+		}
+		policy := rampable.GetRampPolicy()
+		if policy == nil {
+			continue
+		}
+
+		interval, err := policy.GetInterval()
+		if err != nil {
+			r.Log.Error(err, "fail to parse ramp interval", "selectorKey", key)
+			continue
+		}
+
+		ramp := v1alpha1.RampStatus{
+			SelectorKey: key,
+		}
+		for _, previous := range status.Ramp {
+			if previous.SelectorKey == key {
+				ramp = *previous.DeepCopy()
+			}
+		}
+
+		last := int32(len(policy.Steps) - 1)
+		if !running || ramp.Stage >= last {
+			ramp.NextStageTime = nil
+		} else if ramp.NextStageTime == nil {
+			ramp.NextStageTime = &metav1.Time{Time: now.Add(interval)}
+		} else if !now.Before(ramp.NextStageTime.Time) {
+			ramp.Stage++
+			ramp.NextStageTime = nil
+			if ramp.Stage < last {
+				ramp.NextStageTime = &metav1.Time{Time: now.Add(interval)}
+			}
+			changes = append(changes, recorder.RampStageChanged{
+				SelectorKey: key,
+				Stage:       int(ramp.Stage),
+				Percent:     int(policy.Percent(ramp.Stage)),
+			})
+		}
+
+		if ramp.NextStageTime != nil {
+			requeueAfter = controller.ShorterRequeue(requeueAfter, ramp.NextStageTime.Sub(now))
+		}
+		ramps = append(ramps, ramp)
+	}
+	sortRamps(ramps)
+
+	if rampsEqual(status.Ramp, ramps) {
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	updateError := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		obj := r.Object.DeepCopyObject().(common.InnerObjectWithSelector)
+
+		if err := r.Client.Get(context.TODO(), req.NamespacedName, obj); err != nil {
+			r.Log.Error(err, "unable to get chaos")
+			return err
+		}
+
+		obj.GetStatus().Ramp = ramps
+		return r.Client.Update(context.TODO(), obj)
+	})
+	if updateError != nil {
+		r.Log.Error(updateError, "fail to update")
+		r.Recorder.Event(obj, recorder.Failed{
+			Activity: "update ramp",
+			Err:      updateError.Error(),
+		})
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	for _, change := range changes {
+		r.Log.Info("ramp goes to the next stage", "selectorKey", change.SelectorKey, "stage", change.Stage)
+		r.Recorder.Event(obj, change)
+	}
+	r.Recorder.Event(obj, recorder.Updated{
+		Field: "ramp",
+	})
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+func rampsEqual(current []v1alpha1.RampStatus, desired []v1alpha1.RampStatus) bool {
+	if len(current) == 0 && len(desired) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(current, desired)
+}
+
+func sortRamps(ramps []v1alpha1.RampStatus) {
+	sort.Slice(ramps, func(i, j int) bool {
+		return ramps[i].SelectorKey < ramps[j].SelectorKey
+	})
+}
@@ -0,0 +1,111 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sTypes "k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/cmd/chaos-controller-manager/provider"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
+)
+
+func TestReconcile(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	name := k8sTypes.NamespacedName{Namespace: "default", Name: "chaos"}
+	chaos := &v1alpha1.StressChaos{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: name.Namespace,
+			Name:      name.Name,
+		},
+		Spec: v1alpha1.StressChaosSpec{
+			ContainerSelector: v1alpha1.ContainerSelector{
+				PodSelector: v1alpha1.PodSelector{
+					Mode:       v1alpha1.RampPodMode,
+					RampPolicy: &v1alpha1.RampPolicy{Steps: []int32{10, 50}, Interval: "2m"},
+				},
+			},
+		},
+		Status: v1alpha1.StressChaosStatus{
+			ChaosStatus: v1alpha1.ChaosStatus{
+				Experiment: v1alpha1.ExperimentStatus{
+					DesiredPhase: v1alpha1.RunningPhase,
+				},
+			},
+		},
+	}
+
+	r := &Reconciler{
+		Object:   &v1alpha1.StressChaos{},
+		Client:   fake.NewFakeClientWithScheme(provider.NewScheme(), chaos),
+		Recorder: recorder.NewDebugRecorder(),
+		Log:      ctrl.Log.WithName("test"),
+	}
+
+	reconcileAndGet := func() *v1alpha1.StressChaos {
+		_, err := r.Reconcile(ctrl.Request{NamespacedName: name})
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		obj := &v1alpha1.StressChaos{}
+		g.Expect(r.Client.Get(context.TODO(), name, obj)).Should(Succeed())
+		return obj
+	}
+	update := func(modify func(obj *v1alpha1.StressChaos)) {
+		obj := &v1alpha1.StressChaos{}
+		g.Expect(r.Client.Get(context.TODO(), name, obj)).Should(Succeed())
+		modify(obj)
+		g.Expect(r.Client.Update(context.TODO(), obj)).Should(Succeed())
+	}
+
+	// the ramp starts at the first stage
+	obj := reconcileAndGet()
+	g.Expect(obj.Status.Ramp).To(HaveLen(1))
+	g.Expect(obj.Status.Ramp[0].Stage).To(Equal(int32(0)))
+	g.Expect(obj.Status.Ramp[0].NextStageTime).NotTo(BeNil())
+
+	// the ramp goes to the next stage after the interval
+	update(func(obj *v1alpha1.StressChaos) {
+		obj.Status.Ramp[0].NextStageTime = &metav1.Time{Time: time.Now().Add(-time.Second)}
+	})
+	obj = reconcileAndGet()
+	g.Expect(obj.Status.Ramp[0].Stage).To(Equal(int32(1)))
+	// the last stage is reached
+	g.Expect(obj.Status.Ramp[0].NextStageTime).To(BeNil())
+
+	// the ramp is halted while the experiment is paused
+	update(func(obj *v1alpha1.StressChaos) {
+		obj.Spec.RampPolicy.Steps = []int32{10, 50, 100}
+		obj.Status.Experiment.DesiredPhase = v1alpha1.StoppedPhase
+	})
+	obj = reconcileAndGet()
+	g.Expect(obj.Status.Ramp[0].Stage).To(Equal(int32(1)))
+	g.Expect(obj.Status.Ramp[0].NextStageTime).To(BeNil())
+
+	// the ramp continues after the experiment is resumed
+	update(func(obj *v1alpha1.StressChaos) {
+		obj.Status.Experiment.DesiredPhase = v1alpha1.RunningPhase
+	})
+	obj = reconcileAndGet()
+	g.Expect(obj.Status.Ramp[0].Stage).To(Equal(int32(1)))
+	g.Expect(obj.Status.Ramp[0].NextStageTime).NotTo(BeNil())
+}
@@ -0,0 +1,61 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ramp
+
+import (
+	"github.com/go-logr/logr"
+	"go.uber.org/fx"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/chaos-mesh/chaos-mesh/controllers/common"
+	"github.com/chaos-mesh/chaos-mesh/controllers/types"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/builder"
+	"github.com/chaos-mesh/chaos-mesh/controllers/utils/recorder"
+)
+
+type Params struct {
+	fx.In
+
+	Mgr             ctrl.Manager
+	Client          client.Client
+	Logger          logr.Logger
+	RecorderBuilder *recorder.RecorderBuilder
+	Objs            []types.Object `group:"objs"`
+}
+
+func NewController(params Params) (types.Controller, error) {
+	for _, obj := range params.Objs {
+		// only the chaos with selectors could be in ramp mode
+		object, ok := obj.Object.(common.InnerObjectWithSelector)
+		if !ok {
+			continue
+		}
+
+		err := builder.Default(params.Mgr).
+			For(obj.Object).
+			Named(obj.Name + "-ramp").
+			Complete(&Reconciler{
+				Object:   object,
+				Client:   params.Client,
+				Recorder: params.RecorderBuilder.Build("ramp"),
+				Log:      params.Logger.WithName("ramp"),
+			})
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return "ramp", nil
+}
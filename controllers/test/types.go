@@ -87,10 +87,29 @@ func (c *MockChaosDaemonClient) SetDNSServer(ctx context.Context, in *chaosdaemo
 	return nil, mockError("SetDNSServer")
 }
 
+// ListInterfaces mocks listing the network interfaces of the container, which are lo and eth0 by default
+func (c *MockChaosDaemonClient) ListInterfaces(ctx context.Context, in *chaosdaemon.ListInterfacesRequest, opts ...grpc.CallOption) (*chaosdaemon.ListInterfacesResponse, error) {
+	if resp := mock.On("MockListInterfacesResponse"); resp != nil {
+		return resp.(*chaosdaemon.ListInterfacesResponse), nil
+	}
+	if err := mockError("ListInterfaces"); err != nil {
+		return nil, err
+	}
+	return &chaosdaemon.ListInterfacesResponse{Interfaces: []string{"lo", "eth0"}}, nil
+}
+
 func (c *MockChaosDaemonClient) SetTcs(ctx context.Context, in *chaosdaemon.TcsRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	return nil, mockError("SetTcs")
 }
 
+func (c *MockChaosDaemonClient) SetPacketFaults(ctx context.Context, in *chaosdaemon.PacketFaultsRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return nil, mockError("SetPacketFaults")
+}
+
+func (c *MockChaosDaemonClient) RenewLease(ctx context.Context, in *chaosdaemon.LeaseRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return nil, mockError("RenewLease")
+}
+
 func (c *MockChaosDaemonClient) Close() error {
 	return mockError("CloseChaosDaemonClient")
 }
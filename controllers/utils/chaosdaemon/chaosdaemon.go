@@ -48,7 +48,10 @@ type ChaosDaemonClientBuilder struct {
 }
 
 func (b *ChaosDaemonClientBuilder) FindDaemonIP(ctx context.Context, pod *v1.Pod) (string, error) {
-	nodeName := pod.Spec.NodeName
+	return b.findDaemonIPOnNode(ctx, pod.Spec.NodeName)
+}
+
+func (b *ChaosDaemonClientBuilder) findDaemonIPOnNode(ctx context.Context, nodeName string) (string, error) {
 	log.Info("Creating client to chaos-daemon", "node", nodeName)
 
 	ns := config.ControllerCfg.Namespace
@@ -70,6 +73,11 @@ func (b *ChaosDaemonClientBuilder) FindDaemonIP(ctx context.Context, pod *v1.Pod
 }
 
 func (b *ChaosDaemonClientBuilder) Build(ctx context.Context, pod *v1.Pod) (chaosdaemonclient.ChaosDaemonClientInterface, error) {
+	return b.BuildForNode(ctx, pod.Spec.NodeName)
+}
+
+// BuildForNode builds the client to the chaos-daemon running on the node
+func (b *ChaosDaemonClientBuilder) BuildForNode(ctx context.Context, nodeName string) (chaosdaemonclient.ChaosDaemonClientInterface, error) {
 	if cli := mock.On("MockChaosDaemonClient"); cli != nil {
 		return cli.(chaosdaemonclient.ChaosDaemonClientInterface), nil
 	}
@@ -77,7 +85,7 @@ func (b *ChaosDaemonClientBuilder) Build(ctx context.Context, pod *v1.Pod) (chao
 		return nil, err.(error)
 	}
 
-	daemonIP, err := b.FindDaemonIP(ctx, pod)
+	daemonIP, err := b.findDaemonIPOnNode(ctx, nodeName)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,85 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	chaosdaemonclient "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/client"
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+)
+
+const (
+	// LeaseTTL is how long chaos-daemon keeps the faults without hearing from the controller
+	LeaseTTL = time.Minute
+
+	// LeaseRenewInterval is the interval to renew the leases, which is much shorter than the ttl
+	// so that a lease won't expire when a renewal fails occasionally
+	LeaseRenewInterval = 20 * time.Second
+)
+
+// Lease returns the lease of the faults identified by the segments, e.g. the kind, namespace and name of the chaos.
+// The first segment is reported by chaos-daemon as the kind of the faults once the lease expires.
+func Lease(segments ...string) *pb.LeaseRequest {
+	return &pb.LeaseRequest{
+		Id:  strings.Join(segments, "/"),
+		Ttl: int64(LeaseTTL / time.Second),
+	}
+}
+
+// RenewLeases extends the leases on chaos-daemon. It returns true if any of the leases has expired, and the faults
+// held by it have been recovered by chaos-daemon. A lease unknown to chaos-daemon, e.g. whose state has been lost,
+// is an error rather than an expired one, as its faults may still be there.
+func RenewLeases(ctx context.Context, pbClient chaosdaemonclient.ChaosDaemonClientInterface, leases ...*pb.LeaseRequest) (bool, error) {
+	for _, lease := range leases {
+		_, err := pbClient.RenewLease(ctx, lease)
+		if status.Code(err) == codes.NotFound {
+			return true, nil
+		}
+		if status.Code(err) == codes.FailedPrecondition {
+			return false, errors.Wrapf(err, "chaos-daemon lost the lease %s", lease.Id)
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return false, nil
+}
+
+// RenewPodLeases extends the leases on the chaos-daemon of the node where the pod is running. It returns true if any
+// of the leases has expired.
+func (b *ChaosDaemonClientBuilder) RenewPodLeases(ctx context.Context, c client.Reader, key types.NamespacedName, leases ...*pb.LeaseRequest) (bool, error) {
+	pod := &v1.Pod{}
+	if err := c.Get(ctx, key, pod); err != nil {
+		return false, err
+	}
+
+	pbClient, err := b.Build(ctx, pod)
+	if err != nil {
+		return false, err
+	}
+	defer pbClient.Close()
+
+	return RenewLeases(ctx, pbClient, leases...)
+}
@@ -29,7 +29,13 @@ func IsChaosFinishedWithUntilStop(obj v1alpha1.InnerObject, now time.Time) (bool
 	if obj.IsOneShot() {
 		finished := true
 		for _, record := range status.Experiment.Records {
-			if record.Phase != v1alpha1.Injected {
+			// the target of a gone record doesn't exist anymore
+			if record.Phase == v1alpha1.Gone {
+				continue
+			}
+
+			// the failed record will never be injected
+			if record.Phase != v1alpha1.Injected && record.Phase != v1alpha1.WouldInject && record.Phase != v1alpha1.Failed {
 				finished = false
 			}
 		}
@@ -41,9 +47,15 @@ func IsChaosFinishedWithUntilStop(obj v1alpha1.InnerObject, now time.Time) (bool
 	if status.Experiment.DesiredPhase == v1alpha1.RunningPhase {
 		finished = false
 	} else {
-		// If one of the record has not been recovered, it's not finished
+		// If one of the record has not been recovered, it's not finished. Nothing is injected on the target of a
+		// failed record.
 		for _, record := range status.Experiment.Records {
-			if record.Phase != v1alpha1.NotInjected {
+			// the target of a gone record doesn't exist anymore
+			if record.Phase == v1alpha1.Gone {
+				continue
+			}
+
+			if record.Phase != v1alpha1.NotInjected && record.Phase != v1alpha1.Failed {
 				finished = false
 			}
 		}
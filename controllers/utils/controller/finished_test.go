@@ -198,6 +198,59 @@ func TestIsChaosFinished(t *testing.T) {
 			}),
 			now: beginTime.Add(30 * time.Second),
 
+			expected: true,
+		},
+		// Nothing is injected on the target of a failed record
+		{
+			chaos: makeTestNetworkChaos(beginTime, pointer.StringPtr("20s"), v1alpha1.StoppedPhase, []*v1alpha1.Record{
+				{
+					Id:          "some",
+					SelectorKey: "some",
+					Phase:       v1alpha1.NotInjected,
+				},
+				{
+					Id:          "another",
+					SelectorKey: "some",
+					Phase:       v1alpha1.Failed,
+				},
+			}),
+			now: beginTime.Add(30 * time.Second),
+
+			expected: true,
+		},
+		{
+			chaos: makeTestPodKill(beginTime, pointer.StringPtr("20s"), v1alpha1.StoppedPhase, []*v1alpha1.Record{
+				{
+					Id:          "some",
+					SelectorKey: "some",
+					Phase:       v1alpha1.Injected,
+				},
+				{
+					Id:          "another",
+					SelectorKey: "some",
+					Phase:       v1alpha1.Failed,
+				},
+			}),
+			now: beginTime.Add(30 * time.Second),
+
+			expected: true,
+		},
+		// The target of a gone record doesn't exist anymore
+		{
+			chaos: makeTestNetworkChaos(beginTime, pointer.StringPtr("20s"), v1alpha1.StoppedPhase, []*v1alpha1.Record{
+				{
+					Id:          "some",
+					SelectorKey: "some",
+					Phase:       v1alpha1.NotInjected,
+				},
+				{
+					Id:          "another",
+					SelectorKey: "some",
+					Phase:       v1alpha1.Gone,
+				},
+			}),
+			now: beginTime.Add(30 * time.Second),
+
 			expected: true,
 		},
 	}
@@ -19,8 +19,16 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 )
 
+// ParseNamespacedName parses the "namespace/name" key, the key without a namespace is regarded as
+// the name of a cluster scoped object, e.g. a node
 func ParseNamespacedName(namespacedName string) types.NamespacedName {
 	parts := strings.Split(namespacedName, "/")
+	if len(parts) < 2 {
+		return types.NamespacedName{
+			Name: parts[0],
+		}
+	}
+
 	return types.NamespacedName{
 		Namespace: parts[0],
 		Name:      parts[1],
@@ -0,0 +1,27 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import "time"
+
+// ShorterRequeue returns the shorter one of two positive durations, the zero value means no requeue
+func ShorterRequeue(current time.Duration, wait time.Duration) time.Duration {
+	if wait <= 0 {
+		return current
+	}
+	if current == 0 || wait < current {
+		return wait
+	}
+	return current
+}
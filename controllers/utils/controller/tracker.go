@@ -0,0 +1,138 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type trackerKey struct {
+	name types.NamespacedName
+	key  string
+}
+
+type taskState struct {
+	lastDone time.Time
+	count    int
+}
+
+// Tracker remembers the last time when the tasks of every object are done, e.g. the evaluation of a selector,
+// and counts the consecutive attempts of them, e.g. the failed attempts to apply a record. A task is identified
+// by the name of the object and the key of the task.
+type Tracker struct {
+	sync.Mutex
+
+	states map[trackerKey]*taskState
+}
+
+func NewTracker() *Tracker {
+	return &Tracker{
+		states: make(map[trackerKey]*taskState),
+	}
+}
+
+func (t *Tracker) state(name types.NamespacedName, key string) *taskState {
+	k := trackerKey{name: name, key: key}
+	state, ok := t.states[k]
+	if !ok {
+		state = &taskState{}
+		t.states[k] = state
+	}
+
+	return state
+}
+
+// Due returns whether the task should be done now, which is true if it has never been done or the interval
+// has passed since it's done. If not, it returns the time to wait.
+func (t *Tracker) Due(name types.NamespacedName, key string, interval time.Duration, now time.Time) (bool, time.Duration) {
+	t.Lock()
+	defer t.Unlock()
+
+	state, ok := t.states[trackerKey{name: name, key: key}]
+	if !ok || state.lastDone.IsZero() {
+		return true, 0
+	}
+
+	next := state.lastDone.Add(interval)
+	if !now.Before(next) {
+		return true, 0
+	}
+
+	return false, next.Sub(now)
+}
+
+// Done records the time when the task is done
+func (t *Tracker) Done(name types.NamespacedName, key string, now time.Time) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.state(name, key).lastDone = now
+}
+
+// Count returns the count of the consecutive attempts of the task
+func (t *Tracker) Count(name types.NamespacedName, key string) int {
+	t.Lock()
+	defer t.Unlock()
+
+	state, ok := t.states[trackerKey{name: name, key: key}]
+	if !ok {
+		return 0
+	}
+
+	return state.count
+}
+
+// Increase increases the count of the consecutive attempts of the task, and returns it
+func (t *Tracker) Increase(name types.NamespacedName, key string) int {
+	t.Lock()
+	defer t.Unlock()
+
+	state := t.state(name, key)
+	state.count++
+
+	return state.count
+}
+
+// ResetCount resets the count of the consecutive attempts of the task, and keeps the time when it's done
+func (t *Tracker) ResetCount(name types.NamespacedName, key string) {
+	t.Lock()
+	defer t.Unlock()
+
+	if state, ok := t.states[trackerKey{name: name, key: key}]; ok {
+		state.count = 0
+	}
+}
+
+// Reset clears the state of the task, so it's due immediately
+func (t *Tracker) Reset(name types.NamespacedName, key string) {
+	t.Lock()
+	defer t.Unlock()
+
+	delete(t.states, trackerKey{name: name, key: key})
+}
+
+// Forget clears the states of all tasks belonging to the object
+func (t *Tracker) Forget(name types.NamespacedName) {
+	t.Lock()
+	defer t.Unlock()
+
+	for k := range t.states {
+		if k.name == name {
+			delete(t.states, k)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestTracker(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	name := types.NamespacedName{Namespace: "default", Name: "chaos"}
+	another := types.NamespacedName{Namespace: "default", Name: "another"}
+	now := time.Now()
+	tracker := NewTracker()
+
+	// a task which has never been done is due immediately
+	due, wait := tracker.Due(name, "task", time.Minute, now)
+	g.Expect(due).To(BeTrue())
+	g.Expect(wait).To(Equal(time.Duration(0)))
+
+	tracker.Done(name, "task", now)
+	due, wait = tracker.Due(name, "task", time.Minute, now.Add(20*time.Second))
+	g.Expect(due).To(BeFalse())
+	g.Expect(wait).To(Equal(40 * time.Second))
+	due, _ = tracker.Due(name, "task", time.Minute, now.Add(time.Minute))
+	g.Expect(due).To(BeTrue())
+
+	// other tasks and objects are not affected
+	due, _ = tracker.Due(name, "another-task", time.Minute, now)
+	g.Expect(due).To(BeTrue())
+	due, _ = tracker.Due(another, "task", time.Minute, now)
+	g.Expect(due).To(BeTrue())
+
+	g.Expect(tracker.Increase(name, "task")).To(Equal(1))
+	g.Expect(tracker.Increase(name, "task")).To(Equal(2))
+	g.Expect(tracker.Count(name, "task")).To(Equal(2))
+	g.Expect(tracker.Count(another, "task")).To(Equal(0))
+
+	// resetting the count keeps the time when the task is done
+	tracker.ResetCount(name, "task")
+	g.Expect(tracker.Count(name, "task")).To(Equal(0))
+	due, _ = tracker.Due(name, "task", time.Minute, now)
+	g.Expect(due).To(BeFalse())
+
+	tracker.Reset(name, "task")
+	due, _ = tracker.Due(name, "task", time.Minute, now)
+	g.Expect(due).To(BeTrue())
+
+	tracker.Done(name, "task", now)
+	tracker.Done(name, "another-task", now)
+	tracker.Done(another, "task", now)
+	tracker.Forget(name)
+	due, _ = tracker.Due(name, "task", time.Minute, now)
+	g.Expect(due).To(BeTrue())
+	due, _ = tracker.Due(name, "another-task", time.Minute, now)
+	g.Expect(due).To(BeTrue())
+	due, _ = tracker.Due(another, "task", time.Minute, now)
+	g.Expect(due).To(BeFalse())
+}
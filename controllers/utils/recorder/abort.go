@@ -0,0 +1,55 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recorder
+
+import (
+	"fmt"
+)
+
+type AbortConditionBreached struct {
+	Name   string
+	Detail string
+}
+
+func (b AbortConditionBreached) Type() string {
+	return "Warning"
+}
+
+func (b AbortConditionBreached) Reason() string {
+	return "AbortConditionBreached"
+}
+
+func (b AbortConditionBreached) Message() string {
+	return fmt.Sprintf("Abort condition %s is breached: %s", b.Name, b.Detail)
+}
+
+type Aborted struct {
+	Name string
+}
+
+func (a Aborted) Type() string {
+	return "Warning"
+}
+
+func (a Aborted) Reason() string {
+	return "Aborted"
+}
+
+func (a Aborted) Message() string {
+	return fmt.Sprintf("Experiment has been aborted by the abort condition %s", a.Name)
+}
+
+func init() {
+	register(AbortConditionBreached{}, Aborted{})
+}
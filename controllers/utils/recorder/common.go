@@ -65,6 +65,72 @@ func (r NotSupported) Message() string {
 	return fmt.Sprintf("%s is not supported", r.Activity)
 }
 
+type GaveUp struct {
+	Activity string
+	Id       string
+	Attempts int
+}
+
+func (g GaveUp) Type() string {
+	return "Warning"
+}
+
+func (g GaveUp) Reason() string {
+	return "GaveUp"
+}
+
+func (g GaveUp) Message() string {
+	return fmt.Sprintf("Gave up to %s for %s after %d attempts", g.Activity, g.Id, g.Attempts)
+}
+
+type TargetSelected struct {
+	Id string
+}
+
+func (t TargetSelected) Type() string {
+	return "Normal"
+}
+
+func (t TargetSelected) Reason() string {
+	return "TargetSelected"
+}
+
+func (t TargetSelected) Message() string {
+	return fmt.Sprintf("New target %s is selected", t.Id)
+}
+
+type TargetGone struct {
+	Id string
+}
+
+func (t TargetGone) Type() string {
+	return "Normal"
+}
+
+func (t TargetGone) Reason() string {
+	return "TargetGone"
+}
+
+func (t TargetGone) Message() string {
+	return fmt.Sprintf("Target %s is gone", t.Id)
+}
+
+type WouldInject struct {
+	Id string
+}
+
+func (w WouldInject) Type() string {
+	return "Normal"
+}
+
+func (w WouldInject) Reason() string {
+	return "WouldInject"
+}
+
+func (w WouldInject) Message() string {
+	return fmt.Sprintf("Chaos would be applied for %s in dry-run mode", w.Id)
+}
+
 func init() {
-	register(Applied{}, Recovered{}, NotSupported{})
+	register(Applied{}, Recovered{}, NotSupported{}, GaveUp{}, TargetSelected{}, TargetGone{}, WouldInject{})
 }
@@ -0,0 +1,40 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recorder
+
+import (
+	"fmt"
+)
+
+type RampStageChanged struct {
+	SelectorKey string
+	Stage       int
+	Percent     int
+}
+
+func (r RampStageChanged) Type() string {
+	return "Normal"
+}
+
+func (r RampStageChanged) Reason() string {
+	return "RampStageChanged"
+}
+
+func (r RampStageChanged) Message() string {
+	return fmt.Sprintf("Ramp of selector %s goes to stage %d, %d%% of the targets will be injected", r.SelectorKey, r.Stage, r.Percent)
+}
+
+func init() {
+	register(RampStageChanged{})
+}
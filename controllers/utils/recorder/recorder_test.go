@@ -34,6 +34,7 @@ func TestGenerateAnnotations(t *testing.T) {
 		{map[string]string{"chaos-mesh.org/id": "", "chaos-mesh.org/type": "applied"}, Applied{}},
 		{map[string]string{"chaos-mesh.org/id": "test", "chaos-mesh.org/type": "applied"}, Applied{"test"}},
 		{map[string]string{"chaos-mesh.org/id": "test", "chaos-mesh.org/type": "recovered"}, Recovered{"test"}},
+		{map[string]string{"chaos-mesh.org/id": "test", "chaos-mesh.org/type": "would-inject"}, WouldInject{"test"}},
 
 		{map[string]string{"chaos-mesh.org/field": "test", "chaos-mesh.org/type": "updated"}, Updated{"test"}},
 
@@ -45,6 +46,10 @@ func TestGenerateAnnotations(t *testing.T) {
 		{map[string]string{"chaos-mesh.org/activity": "test1", "chaos-mesh.org/err": "test2", "chaos-mesh.org/type": "failed"}, Failed{"test1", "test2"}},
 		{map[string]string{"chaos-mesh.org/type": "not-supported", "chaos-mesh.org/activity": "pausing a workflow schedule"}, NotSupported{Activity: "pausing a workflow schedule"}},
 
+		{map[string]string{"chaos-mesh.org/selector-key": "target", "chaos-mesh.org/stage": "1", "chaos-mesh.org/percent": "25", "chaos-mesh.org/type": "ramp-stage-changed"}, RampStageChanged{"target", 1, 25}},
+
+		{map[string]string{"chaos-mesh.org/name": "example.com", "chaos-mesh.org/type": "external-target-resolved"}, ExternalTargetResolved{"example.com"}},
+
 		{map[string]string{"chaos-mesh.org/type": "finalizer-inited"}, FinalizerInited{}},
 		{map[string]string{"chaos-mesh.org/type": "finalizer-removed"}, FinalizerRemoved{}},
 
@@ -78,6 +83,7 @@ func TestParse(t *testing.T) {
 	testCases := []casePair{
 		{"Successfully apply chaos for test", Applied{"test"}},
 		{"Successfully recover chaos for test", Recovered{"test"}},
+		{"Chaos would be applied for test in dry-run mode", WouldInject{"test"}},
 
 		{"Successfully update test of resource", Updated{"test"}},
 
@@ -88,6 +94,13 @@ func TestParse(t *testing.T) {
 
 		{"Failed to test1: test2", Failed{"test1", "test2"}},
 
+		{"Abort condition test is breached: test2", AbortConditionBreached{"test", "test2"}},
+		{"Experiment has been aborted by the abort condition test", Aborted{"test"}},
+
+		{"Ramp of selector target goes to stage 1, 25% of the targets will be injected", RampStageChanged{"target", 1, 25}},
+
+		{"External target example.com has been resolved into new addresses", ExternalTargetResolved{"example.com"}},
+
 		{"Finalizer has been inited", FinalizerInited{}},
 		{"Finalizer has been removed", FinalizerRemoved{}},
 
@@ -0,0 +1,38 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recorder
+
+import (
+	"fmt"
+)
+
+type ExternalTargetResolved struct {
+	Name string
+}
+
+func (r ExternalTargetResolved) Type() string {
+	return "Normal"
+}
+
+func (r ExternalTargetResolved) Reason() string {
+	return "ExternalTargetResolved"
+}
+
+func (r ExternalTargetResolved) Message() string {
+	return fmt.Sprintf("External target %s has been resolved into new addresses", r.Name)
+}
+
+func init() {
+	register(ExternalTargetResolved{})
+}
@@ -23,7 +23,6 @@ type Config struct {
 	DaemonImage      string
 	DaemonTag        string
 	E2EImage         string
-	ChaosDNSImage    string
 	InstallChaosMesh bool
 	EnableDashboard  bool
 }
@@ -39,7 +38,6 @@ func NewDefaultConfig() *Config {
 		DaemonImage:      "localhost:5000/pingcap/chaos-daemon",
 		DaemonTag:        "latest",
 		E2EImage:         "localhost:5000/pingcap/e2e-helper:latest",
-		ChaosDNSImage:    "localhost:5000/pingcap/chaos-dns:latest",
 		InstallChaosMesh: false,
 		EnableDashboard:  false,
 	}
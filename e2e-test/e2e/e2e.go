@@ -143,7 +143,6 @@ var _ = ginkgo.SynchronizedBeforeSuite(func() []byte {
 		ocfg.Manager.Tag = e2econfig.TestConfig.ManagerTag
 		ocfg.Daemon.Image = e2econfig.TestConfig.DaemonImage
 		ocfg.Daemon.Tag = e2econfig.TestConfig.DaemonTag
-		ocfg.DNSImage = e2econfig.TestConfig.ChaosDNSImage
 		ocfg.EnableDashboard = e2econfig.TestConfig.EnableDashboard
 
 		oa.CleanCRDOrDie()
@@ -37,7 +37,6 @@ type OperatorConfig struct {
 	Manager         ManagerConfig
 	Daemon          DaemonConfig
 	Tag             string
-	DNSImage        string
 	EnableDashboard bool
 }
 
@@ -75,7 +74,6 @@ func NewDefaultOperatorConfig() OperatorConfig {
 			Runtime:         "containerd",
 			SocketPath:      "/run/containerd/containerd.sock",
 		},
-		DNSImage: "pingcap/coredns:v0.2.0",
 	}
 }
 
@@ -95,8 +93,6 @@ func (oi *OperatorConfig) operatorHelmSetValue() string {
 		"chaosDaemon.runtime":               oi.Daemon.Runtime,
 		"chaosDaemon.socketPath":            oi.Daemon.SocketPath,
 		"chaosDaemon.imagePullPolicy":       oi.Daemon.ImagePullPolicy,
-		"dnsServer.create":                  "true",
-		"dnsServer.image":                   oi.DNSImage,
 		"dashboard.create":                  fmt.Sprintf("%t", oi.EnableDashboard),
 	}
 	arr := make([]string, 0, len(set))
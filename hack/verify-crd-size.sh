@@ -0,0 +1,37 @@
+#!/usr/bin/env bash
+
+# Copyright 2021 Chaos Mesh Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# verify-crd-size.sh fails if any generated CRD is too large. An object stored in etcd can't be larger than
+# 1.5 MiB, so the CRDs are limited to 1 MiB to leave room for the fields added later.
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+ROOT=$(unset CDPATH && cd $(dirname "${BASH_SOURCE[0]}")/.. && pwd)
+cd $ROOT
+
+limit=${CRD_SIZE_LIMIT:-1048576}
+
+oversized=0
+for file in config/crd/bases/*.yaml helm/chaos-mesh/crds/*.yaml; do
+    size=$(wc -c < "${file}")
+    if [[ ${size} -gt ${limit} ]]; then
+        echo "CRD ${file} is ${size} bytes, which is larger than ${limit} bytes" >&2
+        oversized=1
+    fi
+done
+
+exit ${oversized}
@@ -986,6 +986,13 @@ rules:
   - apiGroups: [ "" ]
     resources: [ "configmaps" ]
     verbs: [ "*" ]
+  # services, endpoints and the ReplicaSets are used to resolve the pods of serviceSelectors and workloadSelectors
+  - apiGroups: [ "" ]
+    resources: [ "services", "endpoints" ]
+    verbs: [ "get", "list", "watch" ]
+  - apiGroups: [ "apps" ]
+    resources: [ "replicasets" ]
+    verbs: [ "get", "list", "watch" ]
   - apiGroups: [ "chaos-mesh.org" ]
     resources:
       - "*"
@@ -1013,6 +1020,10 @@ rules:
     resources:
       - subjectaccessreviews
     verbs: [ "create" ]
+  - apiGroups: [ "chaos-mesh.org" ]
+    resources:
+      - chaospolicies
+    verbs: [ "get", "list", "watch" ]
 ---
 # Source: chaos-mesh/templates/controller-manager-rbac.yaml
 # bindings cluster level
@@ -1316,8 +1327,6 @@ spec:
               value: "false"
             - name: SECURITY_MODE
               value: "false"
-            - name: DNS_SERVER_CREATE
-              value: "false"
           volumeMounts:
             - name: storage-volume
               mountPath: /data
@@ -1402,10 +1411,6 @@ spec:
             value: "false"
           - name: PPROF_ADDR
             value: ":10081"
-          - name: CHAOS_DNS_SERVICE_NAME
-            value: chaos-mesh-dns-server
-          - name: CHAOS_DNS_SERVICE_PORT
-            value: !!str 9288
           - name: SECURITY_MODE
             value: "false"
           - name: POD_FAILURE_PAUSE_IMAGE
@@ -1954,6 +1959,36 @@ webhooks:
           - CREATE
           - UPDATE
         resources: [ "*" ]
+---
+# Source: chaos-mesh/templates/secrets-configuration.yaml
+apiVersion: admissionregistration.k8s.io/v1beta1
+kind: ValidatingWebhookConfiguration
+metadata:
+  name: validate-policy
+  labels:
+    app.kubernetes.io/instance: chaos-mesh
+    app.kubernetes.io/name: chaos-mesh
+    app.kubernetes.io/part-of: chaos-mesh
+    app.kubernetes.io/version: v0.9.0
+    app.kubernetes.io/component: admission-webhook
+webhooks:
+  - clientConfig:
+      caBundle: "${CA_BUNDLE}"
+      service:
+        name: chaos-mesh-controller-manager
+        namespace: "chaos-testing"
+        path: /validate-policy
+    failurePolicy: Fail
+    name: vpolicy.kb.io
+    rules:
+      - apiGroups:
+          - chaos-mesh.org
+        apiVersions:
+          - v1alpha1
+        operations:
+          - CREATE
+          - UPDATE
+        resources: [ "*" ]
 EOF
     # chaos-mesh.yaml end
 }
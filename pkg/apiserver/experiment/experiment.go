@@ -111,6 +111,8 @@ type Experiment struct {
 // Detail represents an experiment instance.
 type Detail struct {
 	Experiment
+	// Records are the targets of the experiment, with their phases, errors and timestamps
+	Records    []*v1alpha1.Record  `json:"records,omitempty"`
 	KubeObject core.KubeObjectDesc `json:"kube_object"`
 }
 
@@ -489,6 +491,7 @@ func (s *Service) getPodChaosDetail(namespace string, name string, kubeCli clien
 			Created: chaos.GetChaos().StartTime.Format(time.RFC3339),
 			Status:  string(utils.GetChaosState(chaos)),
 		},
+		Records: chaos.Status.Experiment.Records,
 		KubeObject: core.KubeObjectDesc{
 			TypeMeta: metav1.TypeMeta{
 				APIVersion: gvk.GroupVersion().String(),
@@ -533,6 +536,7 @@ func (s *Service) getIOChaosDetail(namespace string, name string, kubeCli client
 			Created: chaos.GetChaos().StartTime.Format(time.RFC3339),
 			Status:  string(utils.GetChaosState(chaos)),
 		},
+		Records: chaos.Status.Experiment.Records,
 		KubeObject: core.KubeObjectDesc{
 			TypeMeta: metav1.TypeMeta{
 				APIVersion: gvk.GroupVersion().String(),
@@ -577,6 +581,7 @@ func (s *Service) getNetworkChaosDetail(namespace string, name string, kubeCli c
 			Created: chaos.GetChaos().StartTime.Format(time.RFC3339),
 			Status:  string(utils.GetChaosState(chaos)),
 		},
+		Records: chaos.Status.Experiment.Records,
 		KubeObject: core.KubeObjectDesc{
 			TypeMeta: metav1.TypeMeta{
 				APIVersion: gvk.GroupVersion().String(),
@@ -621,6 +626,7 @@ func (s *Service) getTimeChaosDetail(namespace string, name string, kubeCli clie
 			Status:  string(utils.GetChaosState(chaos)),
 			UID:     chaos.GetChaos().UID,
 		},
+		Records: chaos.Status.Experiment.Records,
 		KubeObject: core.KubeObjectDesc{
 			TypeMeta: metav1.TypeMeta{
 				APIVersion: gvk.GroupVersion().String(),
@@ -665,6 +671,7 @@ func (s *Service) getKernelChaosDetail(namespace string, name string, kubeCli cl
 			Status:  string(utils.GetChaosState(chaos)),
 			UID:     chaos.GetChaos().UID,
 		},
+		Records: chaos.Status.Experiment.Records,
 		KubeObject: core.KubeObjectDesc{
 			TypeMeta: metav1.TypeMeta{
 				APIVersion: gvk.GroupVersion().String(),
@@ -709,6 +716,7 @@ func (s *Service) getStressChaosDetail(namespace string, name string, kubeCli cl
 			Status:  string(utils.GetChaosState(chaos)),
 			UID:     chaos.GetChaos().UID,
 		},
+		Records: chaos.Status.Experiment.Records,
 		KubeObject: core.KubeObjectDesc{
 			TypeMeta: metav1.TypeMeta{
 				APIVersion: gvk.GroupVersion().String(),
@@ -753,6 +761,7 @@ func (s *Service) getDNSChaosDetail(namespace string, name string, kubeCli clien
 			Status:  string(utils.GetChaosState(chaos)),
 			UID:     chaos.GetChaos().UID,
 		},
+		Records: chaos.Status.Experiment.Records,
 		KubeObject: core.KubeObjectDesc{
 			TypeMeta: metav1.TypeMeta{
 				APIVersion: gvk.GroupVersion().String(),
@@ -797,6 +806,7 @@ func (s *Service) getAwsChaosDetail(namespace string, name string, kubeCli clien
 			Status:  string(utils.GetChaosState(chaos)),
 			UID:     chaos.GetChaos().UID,
 		},
+		Records: chaos.Status.Experiment.Records,
 		KubeObject: core.KubeObjectDesc{
 			TypeMeta: metav1.TypeMeta{
 				APIVersion: gvk.GroupVersion().String(),
@@ -841,6 +851,7 @@ func (s *Service) getGcpChaosDetail(namespace string, name string, kubeCli clien
 			Status:  string(utils.GetChaosState(chaos)),
 			UID:     chaos.GetChaos().UID,
 		},
+		Records: chaos.Status.Experiment.Records,
 		KubeObject: core.KubeObjectDesc{
 			TypeMeta: metav1.TypeMeta{
 				APIVersion: gvk.GroupVersion().String(),
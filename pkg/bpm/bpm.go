@@ -76,6 +76,9 @@ type BackgroundProcessManager struct {
 	deathSig    *sync.Map
 	identifiers *sync.Map
 	stdio       *sync.Map
+
+	// adopted are the processes started by a previous chaos-daemon, which can be killed by this manager
+	adopted *sync.Map
 }
 
 // NewBackgroundProcessManager creates a background process manager
@@ -84,9 +87,16 @@ func NewBackgroundProcessManager() BackgroundProcessManager {
 		deathSig:    &sync.Map{},
 		identifiers: &sync.Map{},
 		stdio:       &sync.Map{},
+		adopted:     &sync.Map{},
 	}
 }
 
+// Adopt allows the manager to kill the process started by a previous chaos-daemon, e.g. the stressors moved into the
+// cgroup of the target container, which survive the restart of chaos-daemon and are no longer its children
+func (m *BackgroundProcessManager) Adopt(pid int, startTime int64) {
+	m.adopted.Store(ProcessPair{Pid: pid, CreateTime: startTime}, struct{}{})
+}
+
 // StartProcess manages a process in manager
 func (m *BackgroundProcessManager) StartProcess(cmd *ManagedProcess) (*process.Process, error) {
 	var identifierLock *sync.Mutex
@@ -230,7 +240,12 @@ func (m *BackgroundProcessManager) KillBackgroundProcess(ctx context.Context, pi
 		// return successfully as the process has exited
 		return nil
 	}
-	if ppid != int32(os.Getpid()) {
+	pair := ProcessPair{
+		Pid:        pid,
+		CreateTime: startTime,
+	}
+	_, adopted := m.adopted.Load(pair)
+	if ppid != int32(os.Getpid()) && !adopted {
 		log.Info("process has already been killed", "ppid", ppid)
 		// return successfully as the process has exited
 		return nil
@@ -242,11 +257,8 @@ func (m *BackgroundProcessManager) KillBackgroundProcess(ctx context.Context, pi
 		log.Error(err, "error while killing process")
 		return err
 	}
+	m.adopted.Delete(pair)
 
-	pair := ProcessPair{
-		Pid:        pid,
-		CreateTime: startTime,
-	}
 	channel, ok := m.deathSig.Load(pair)
 	if ok {
 		deathChannel := channel.(chan bool)
@@ -33,6 +33,9 @@ Interacting with chaos mesh
   # show debug info
   chaosctl debug networkchaos
 
+  # show the status of the targets of chaos
+  chaosctl status networkchaos
+
   # show logs of all chaos-mesh components
   chaosctl logs`,
 }
@@ -73,6 +76,17 @@ func Execute() {
 	}
 
 	rootCmd.AddCommand(debugCommand)
+
+	statusCommand, err := NewStatusCommand(rootLogger.WithName("cmd-status"))
+	if err != nil {
+		rootLogger.Error(err, "failed to initialize cmd",
+			"cmd", "status",
+			"errorVerbose", fmt.Sprintf("%+v", err),
+		)
+		os.Exit(1)
+	}
+
+	rootCmd.AddCommand(statusCommand)
 	rootCmd.AddCommand(completionCmd)
 	if err := rootCmd.Execute(); err != nil {
 		rootLogger.Error(err, "failed to execute cmd",
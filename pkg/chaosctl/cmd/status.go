@@ -0,0 +1,127 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosctl/common"
+)
+
+type StatusOptions struct {
+	logger    logr.Logger
+	namespace string
+}
+
+func NewStatusCommand(logger logr.Logger) (*cobra.Command, error) {
+	o := &StatusOptions{
+		logger: logger,
+	}
+
+	statusCmd := &cobra.Command{
+		Use:   `status (CHAOSTYPE) [CHAOSNAME] [-n NAMESPACE]`,
+		Short: `Print the status of the targets of certain chaos`,
+		Long: `Print the status of the targets of certain chaos, including the phase,
+the count of failed attempts, the time of injection and recovery, and the last error of every target.
+
+Examples:
+  # Return the status of the targets of all networkchaos in default namespace
+  chaosctl status networkchaos
+
+  # Return the status of the targets of certain networkchaos
+  chaosctl status networkchaos CHAOSNAME -n NAMESPACE`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientset, err := common.InitClientSet()
+			if err != nil {
+				return err
+			}
+			return o.Run(args, clientset)
+		},
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) == 0 {
+				return listChaosTypes(toComplete)
+			}
+			if len(args) != 1 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			clientset, err := common.InitClientSet()
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveDefault
+			}
+			return listChaos(args[0], o.namespace, toComplete, clientset.CtrlCli)
+		},
+	}
+
+	statusCmd.Flags().StringVarP(&o.namespace, "namespace", "n", "default", "namespace to find chaos")
+	err := statusCmd.RegisterFlagCompletionFunc("namespace", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		clientset, err := common.InitClientSet()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveDefault
+		}
+		return listNamespace(toComplete, clientset.KubeCli)
+	})
+	return statusCmd, err
+}
+
+// Run status
+func (o *StatusOptions) Run(args []string, c *common.ClientSet) error {
+	if len(args) == 0 {
+		return fmt.Errorf("the chaos type should be specified")
+	}
+	if len(args) > 2 {
+		return fmt.Errorf("only one chaos could be specified")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	chaosName := ""
+	if len(args) == 2 {
+		chaosName = args[1]
+	}
+
+	chaosList, chaosNameList, err := common.GetChaosList(ctx, args[0], chaosName, o.namespace, c.CtrlCli)
+	if err != nil {
+		return err
+	}
+
+	for i, chaos := range chaosList {
+		innerObject, ok := chaos.(v1alpha1.InnerObject)
+		if !ok {
+			return fmt.Errorf("chaos %s doesn't have records", chaosNameList[i])
+		}
+		common.PrintRecords(chaosNameList[i], innerObject.GetStatus().Experiment.Records)
+	}
+	return nil
+}
+
+func listChaosTypes(toComplete string) ([]string, cobra.ShellCompDirective) {
+	var ret []string
+	for kind := range v1alpha1.AllKinds() {
+		chaosType := strings.ToLower(kind)
+		if strings.HasPrefix(chaosType, toComplete) {
+			ret = append(ret, chaosType)
+		}
+	}
+	sort.Strings(ret)
+	return ret, cobra.ShellCompDirectiveNoFileComp
+}
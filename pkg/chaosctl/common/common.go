@@ -21,6 +21,8 @@ import (
 	"io"
 	"regexp"
 	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/pkg/errors"
@@ -103,9 +105,14 @@ func init() {
 	_ = clientgoscheme.AddToScheme(scheme)
 }
 
-func upperCaseChaos(str string) string {
-	parts := regexp.MustCompile("(.*)(chaos)").FindStringSubmatch(str)
-	return strings.Title(parts[1]) + strings.Title(parts[2])
+// chaosKind returns the kind of chaos matching the chaos type case-insensitively, e.g. "networkchaos" for "NetworkChaos"
+func chaosKind(chaosType string) (string, error) {
+	for kind := range v1alpha1.AllKinds() {
+		if strings.EqualFold(kind, chaosType) {
+			return kind, nil
+		}
+	}
+	return "", fmt.Errorf("chaos type %s is not supported", chaosType)
 }
 
 // PrettyPrint print with tab number and color
@@ -149,6 +156,51 @@ func PrintResult(result []ChaosResult) {
 	}
 }
 
+// PrintRecords prints the records of the chaos to users in a table
+func PrintRecords(chaosName string, records []*v1alpha1.Record) {
+	PrettyPrint("[Chaos]: "+chaosName, 0, Blue)
+	if len(records) == 0 {
+		PrettyPrint("No target is selected", 1, NoColor)
+		return
+	}
+	PrettyPrint(FormatRecords(records), 1, NoColor)
+}
+
+// FormatRecords formats the records into a table, with the phase, the failed attempts, the timestamps and the last error of every target
+func FormatRecords(records []*v1alpha1.Record) string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSELECTOR\tPHASE\tFAILED ATTEMPTS\tINJECTED AT\tRECOVERED AT\tLAST ERROR")
+	for _, record := range records {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\t%s\n",
+			record.Id,
+			record.SelectorKey,
+			record.Phase,
+			record.FailedAttempts,
+			formatRecordTime(record.InjectedAt),
+			formatRecordTime(record.RecoveredAt),
+			orNone(record.LastError),
+		)
+	}
+	w.Flush()
+
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func formatRecordTime(t *metav1.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format(time.RFC3339)
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
 // MarshalChaos returns json in readable format
 func MarshalChaos(s interface{}) (string, error) {
 	b, err := json.MarshalIndent(s, "", "  ")
@@ -189,7 +241,7 @@ func GetPods(ctx context.Context, chaosName string, status v1alpha1.ChaosStatus,
 	}
 	L().WithName("GetPods").V(4).Info("select pods for chaos", "chaos", chaosName, "pods", pods)
 	if len(pods) == 0 {
-		return nil, nil, fmt.Errorf("no pods found for chaos %s, selector: %s", chaosName, selectorSpec)
+		return nil, nil, fmt.Errorf("no pods found for chaos %s, selector: %v", chaosName, selectorSpec)
 	}
 
 	var chaosDaemons []v1.Pod
@@ -205,7 +257,7 @@ func GetPods(ctx context.Context, chaosName string, status v1alpha1.ChaosStatus,
 			return nil, nil, errors.Wrap(err, fmt.Sprintf("failed to select daemon pod for pod %s", chaosPod.GetName()))
 		}
 		if len(daemons) == 0 {
-			return nil, nil, fmt.Errorf("no daemons found for pod %s with selector: %s", chaosPod.GetName(), daemonSelector)
+			return nil, nil, fmt.Errorf("no daemons found for pod %s with selector: %v", chaosPod.GetName(), daemonSelector)
 		}
 		chaosDaemons = append(chaosDaemons, daemons[0])
 	}
@@ -215,7 +267,10 @@ func GetPods(ctx context.Context, chaosName string, status v1alpha1.ChaosStatus,
 
 // GetChaosList returns chaos list limited by input
 func GetChaosList(ctx context.Context, chaosType string, chaosName string, ns string, c client.Client) ([]runtime.Object, []string, error) {
-	chaosType = upperCaseChaos(strings.ToLower(chaosType))
+	chaosType, err := chaosKind(chaosType)
+	if err != nil {
+		return nil, nil, err
+	}
 	allKinds := v1alpha1.AllKinds()
 	chaosListInterface := allKinds[chaosType].ChaosList
 
@@ -97,6 +97,20 @@ func TestGetChaosList(t *testing.T) {
 			ns:          "default",
 			expectedErr: true,
 		},
+		{
+			name:        "Specify chaos type in kind format",
+			chaosType:   "NetworkChaos",
+			chaosName:   "fakechaos-1",
+			ns:          "default",
+			expectedNum: 1,
+			expectedErr: false,
+		},
+		{
+			name:        "Specify unsupported chaos type",
+			chaosType:   "oopschaos",
+			ns:          "default",
+			expectedErr: true,
+		},
 		{
 			name:        "Specify non-exist namespace",
 			chaosType:   "networkchaos",
@@ -119,6 +133,21 @@ func TestGetChaosList(t *testing.T) {
 	}
 }
 
+func TestFormatRecords(t *testing.T) {
+	g := NewWithT(t)
+
+	injectedAt := metav1.NewTime(time.Date(2021, 5, 1, 8, 0, 0, 0, time.UTC))
+	records := []*v1alpha1.Record{
+		{Id: "default/pod-0", SelectorKey: ".", Phase: v1alpha1.Injected, InjectedAt: &injectedAt},
+		{Id: "default/pod-1", SelectorKey: ".", Phase: v1alpha1.NotInjected, FailedAttempts: 3, LastError: "pod not found"},
+	}
+
+	g.Expect(FormatRecords(records)).To(Equal(
+		`ID             SELECTOR  PHASE         FAILED ATTEMPTS  INJECTED AT           RECOVERED AT  LAST ERROR
+default/pod-0  .         Injected      0                2021-05-01T08:00:00Z  -             -
+default/pod-1  .         Not Injected  3                -                     -             pod not found`))
+}
+
 func TestGetPods(t *testing.T) {
 	logger, _, _ := NewStderrLogger()
 	SetupGlobalLogger(logger)
@@ -19,6 +19,10 @@ import (
 	chaosdaemon "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
 )
 
+// HostNetworkLease is the id of the lease held by chaos-daemon for the faults on the host network,
+// which are recovered once the lease isn't renewed in time
+const HostNetworkLease = "host-network"
+
 // ChaosDaemonClientInterface represents the ChaosDaemonClient, it's used to simply unit test
 type ChaosDaemonClientInterface interface {
 	chaosdaemon.ChaosDaemonClient
@@ -0,0 +1,41 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package command generates the arguments of the commands executed by chaos daemon.
+// It's shared by chaos daemon and the dry-run mode of the controllers, so the rendered
+// commands are the same as the executed ones.
+package command
+
+import (
+	"strings"
+)
+
+const (
+	// Tc is the command to operate the traffic control
+	Tc = "tc"
+	// Iptables is the command to operate the iptables
+	Iptables = "iptables"
+	// Ip6tables is the command to operate the iptables of IPv6
+	Ip6tables = "ip6tables"
+	// IPSet is the command to operate the ipset
+	IPSet = "ipset"
+	// IP is the command to operate the network devices
+	IP = "ip"
+	// StressNg is the command to generate the stress
+	StressNg = "stress-ng"
+)
+
+// Render joins the command and its arguments into a readable command line
+func Render(cmd string, args ...string) string {
+	return strings.Join(append([]string{cmd}, args...), " ")
+}
@@ -0,0 +1,422 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+)
+
+func TestNetemArgs(t *testing.T) {
+	g := NewWithT(t)
+
+	t.Run("convert network delay", func(t *testing.T) {
+		args := NetemArgs(&pb.Netem{
+			Time: 1000,
+		})
+		g.Expect(args).To(Equal("delay 1000"))
+
+		args = NetemArgs(&pb.Netem{
+			Time:      1000,
+			DelayCorr: 25,
+		})
+		g.Expect(args).To(Equal("delay 1000"))
+
+		args = NetemArgs(&pb.Netem{
+			Time:      1000,
+			Jitter:    10000,
+			DelayCorr: 25,
+		})
+		g.Expect(args).To(Equal("delay 1000 10000 25.000000"))
+	})
+
+	t.Run("convert packet limit", func(t *testing.T) {
+		args := NetemArgs(&pb.Netem{
+			Limit: 1000,
+		})
+		g.Expect(args).To(Equal("limit 1000"))
+	})
+
+	t.Run("convert packet loss", func(t *testing.T) {
+		args := NetemArgs(&pb.Netem{
+			Loss: 100,
+		})
+		g.Expect(args).To(Equal("loss 100.000000"))
+
+		args = NetemArgs(&pb.Netem{
+			Loss:     50,
+			LossCorr: 12,
+		})
+		g.Expect(args).To(Equal("loss 50.000000 12.000000"))
+	})
+
+	t.Run("convert packet reorder", func(t *testing.T) {
+		args := NetemArgs(&pb.Netem{
+			Reorder:     5,
+			ReorderCorr: 10,
+		})
+		g.Expect(args).To(Equal(""))
+
+		args = NetemArgs(&pb.Netem{
+			Time:        1000,
+			Jitter:      10000,
+			DelayCorr:   25,
+			Reorder:     5,
+			ReorderCorr: 10,
+			Gap:         10,
+		})
+		g.Expect(args).To(Equal("delay 1000 10000 25.000000 reorder 5.000000 10.000000 gap 10"))
+
+		args = NetemArgs(&pb.Netem{
+			Time:        1000,
+			Jitter:      10000,
+			DelayCorr:   25,
+			Reorder:     5,
+			ReorderCorr: 10,
+			Gap:         10,
+		})
+		g.Expect(args).To(Equal("delay 1000 10000 25.000000 reorder 5.000000 10.000000 gap 10"))
+
+		args = NetemArgs(&pb.Netem{
+			Time:      1000,
+			Jitter:    10000,
+			DelayCorr: 25,
+			Reorder:   5,
+			Gap:       10,
+		})
+		g.Expect(args).To(Equal("delay 1000 10000 25.000000 reorder 5.000000 gap 10"))
+	})
+
+	t.Run("convert packet duplication", func(t *testing.T) {
+		args := NetemArgs(&pb.Netem{
+			Duplicate: 10,
+		})
+		g.Expect(args).To(Equal("duplicate 10.000000"))
+
+		args = NetemArgs(&pb.Netem{
+			Duplicate:     10,
+			DuplicateCorr: 50,
+		})
+		g.Expect(args).To(Equal("duplicate 10.000000 50.000000"))
+	})
+
+	t.Run("convert packet corrupt", func(t *testing.T) {
+		args := NetemArgs(&pb.Netem{
+			Corrupt: 10,
+		})
+		g.Expect(args).To(Equal("corrupt 10.000000"))
+
+		args = NetemArgs(&pb.Netem{
+			Corrupt:     10,
+			CorruptCorr: 50,
+		})
+		g.Expect(args).To(Equal("corrupt 10.000000 50.000000"))
+	})
+
+	t.Run("complicate cases", func(t *testing.T) {
+		args := NetemArgs(&pb.Netem{
+			Time:        1000,
+			Jitter:      10000,
+			Reorder:     5,
+			Gap:         10,
+			Corrupt:     10,
+			CorruptCorr: 50,
+		})
+		g.Expect(args).To(Equal("delay 1000 10000 reorder 5.000000 gap 10 corrupt 10.000000 50.000000"))
+	})
+}
+
+func TestRenderTcs(t *testing.T) {
+	g := NewWithT(t)
+
+	t.Run("without filter", func(t *testing.T) {
+		commands, err := RenderTcs("eth0", []*pb.Tc{
+			{Type: pb.Tc_NETEM, Netem: &pb.Netem{Time: 50000}},
+			{Type: pb.Tc_BANDWIDTH, Tbf: &pb.Tbf{Rate: 1000, Buffer: 100}},
+		})
+		g.Expect(err).To(BeNil())
+		g.Expect(commands).To(Equal([]string{
+			"tc qdisc del dev eth0 root",
+			"tc qdisc add dev eth0 root handle 1: netem delay 50000",
+			"tc qdisc add dev eth0 parent 1: handle 2: tbf rate 1000 burst 100",
+		}))
+	})
+
+	t.Run("with filter", func(t *testing.T) {
+		commands, err := RenderTcs("eth0", []*pb.Tc{
+			{Type: pb.Tc_NETEM, Netem: &pb.Netem{Time: 50000}},
+			{Type: pb.Tc_NETEM, Netem: &pb.Netem{Time: 100000}, Ipset: "B"},
+			{Type: pb.Tc_NETEM, Netem: &pb.Netem{Time: 50000}, Ipset: "A"},
+		})
+		g.Expect(err).To(BeNil())
+		g.Expect(commands).To(Equal([]string{
+			"tc qdisc del dev eth0 root",
+			"tc qdisc add dev eth0 root handle 1: netem delay 50000",
+			"tc qdisc add dev eth0 parent 1: handle 2: prio bands 5 priomap 1 2 2 2 1 2 0 0 1 1 1 1 1 1 1 1",
+			"tc qdisc add dev eth0 parent 2:1 handle 3: sfq",
+			"tc qdisc add dev eth0 parent 2:2 handle 4: sfq",
+			"tc qdisc add dev eth0 parent 2:3 handle 5: sfq",
+			"tc qdisc add dev eth0 parent 2:4 handle 6: netem delay 100000",
+			"tc qdisc add dev eth0 parent 2:5 handle 7: netem delay 50000",
+			"iptables -w -N TC-TABLES-eth0-0",
+			"iptables -w -F TC-TABLES-eth0-0",
+			"iptables -w -A TC-TABLES-eth0-0 -m set --match-set B dst -j CLASSIFY --set-class 2:4 -w 5 --out-interface eth0",
+			"iptables -w -A CHAOS-OUTPUT -j TC-TABLES-eth0-0",
+			"iptables -w -N TC-TABLES-eth0-1",
+			"iptables -w -F TC-TABLES-eth0-1",
+			"iptables -w -A TC-TABLES-eth0-1 -m set --match-set A dst -j CLASSIFY --set-class 2:5 -w 5 --out-interface eth0",
+			"iptables -w -A CHAOS-OUTPUT -j TC-TABLES-eth0-1",
+			"ip6tables -w -N TC-TABLES-eth0-0",
+			"ip6tables -w -F TC-TABLES-eth0-0",
+			"ip6tables -w -A TC-TABLES-eth0-0 -m set --match-set B6 dst -j CLASSIFY --set-class 2:4 -w 5 --out-interface eth0",
+			"ip6tables -w -A CHAOS-OUTPUT -j TC-TABLES-eth0-0",
+			"ip6tables -w -N TC-TABLES-eth0-1",
+			"ip6tables -w -F TC-TABLES-eth0-1",
+			"ip6tables -w -A TC-TABLES-eth0-1 -m set --match-set A6 dst -j CLASSIFY --set-class 2:5 -w 5 --out-interface eth0",
+			"ip6tables -w -A CHAOS-OUTPUT -j TC-TABLES-eth0-1",
+		}))
+	})
+
+	t.Run("with protocol and ports", func(t *testing.T) {
+		commands, err := RenderTcs("eth0", []*pb.Tc{
+			{Type: pb.Tc_NETEM, Netem: &pb.Netem{Time: 50000}, Protocol: "udp", EgressPort: "53"},
+			{Type: pb.Tc_NETEM, Netem: &pb.Netem{Time: 100000}, Ipset: "A", Protocol: "tcp", SourcePort: "80,443"},
+			{Type: pb.Tc_NETEM, Netem: &pb.Netem{Time: 50000}, Ipset: "A", Protocol: "tcp", SourcePort: "8080"},
+		})
+		g.Expect(err).To(BeNil())
+		g.Expect(commands).To(Equal([]string{
+			"tc qdisc del dev eth0 root",
+			"tc qdisc add dev eth0 root handle 1: prio bands 6 priomap 1 2 2 2 1 2 0 0 1 1 1 1 1 1 1 1",
+			"tc qdisc add dev eth0 parent 1:1 handle 2: sfq",
+			"tc qdisc add dev eth0 parent 1:2 handle 3: sfq",
+			"tc qdisc add dev eth0 parent 1:3 handle 4: sfq",
+			"tc qdisc add dev eth0 parent 1:4 handle 5: netem delay 50000",
+			"tc qdisc add dev eth0 parent 1:5 handle 6: netem delay 100000",
+			"tc qdisc add dev eth0 parent 1:6 handle 7: netem delay 50000",
+			"iptables -w -N TC-TABLES-eth0-0",
+			"iptables -w -F TC-TABLES-eth0-0",
+			"iptables -w -A TC-TABLES-eth0-0 -j CLASSIFY --set-class 1:4 -w 5 --out-interface eth0 --protocol udp --destination-port 53",
+			"iptables -w -A CHAOS-OUTPUT -j TC-TABLES-eth0-0",
+			"iptables -w -N TC-TABLES-eth0-1",
+			"iptables -w -F TC-TABLES-eth0-1",
+			"iptables -w -A TC-TABLES-eth0-1 -m set --match-set A dst -j CLASSIFY --set-class 1:5 -w 5 --out-interface eth0 --protocol tcp -m multiport --source-ports 80,443",
+			"iptables -w -A CHAOS-OUTPUT -j TC-TABLES-eth0-1",
+			"iptables -w -N TC-TABLES-eth0-2",
+			"iptables -w -F TC-TABLES-eth0-2",
+			"iptables -w -A TC-TABLES-eth0-2 -m set --match-set A dst -j CLASSIFY --set-class 1:6 -w 5 --out-interface eth0 --protocol tcp --source-port 8080",
+			"iptables -w -A CHAOS-OUTPUT -j TC-TABLES-eth0-2",
+			"ip6tables -w -N TC-TABLES-eth0-0",
+			"ip6tables -w -F TC-TABLES-eth0-0",
+			"ip6tables -w -A TC-TABLES-eth0-0 -j CLASSIFY --set-class 1:4 -w 5 --out-interface eth0 --protocol udp --destination-port 53",
+			"ip6tables -w -A CHAOS-OUTPUT -j TC-TABLES-eth0-0",
+			"ip6tables -w -N TC-TABLES-eth0-1",
+			"ip6tables -w -F TC-TABLES-eth0-1",
+			"ip6tables -w -A TC-TABLES-eth0-1 -m set --match-set A6 dst -j CLASSIFY --set-class 1:5 -w 5 --out-interface eth0 --protocol tcp -m multiport --source-ports 80,443",
+			"ip6tables -w -A CHAOS-OUTPUT -j TC-TABLES-eth0-1",
+			"ip6tables -w -N TC-TABLES-eth0-2",
+			"ip6tables -w -F TC-TABLES-eth0-2",
+			"ip6tables -w -A TC-TABLES-eth0-2 -m set --match-set A6 dst -j CLASSIFY --set-class 1:6 -w 5 --out-interface eth0 --protocol tcp --source-port 8080",
+			"ip6tables -w -A CHAOS-OUTPUT -j TC-TABLES-eth0-2",
+		}))
+	})
+
+	t.Run("invalid tc", func(t *testing.T) {
+		_, err := RenderTcs("eth0", []*pb.Tc{{Type: pb.Tc_NETEM}})
+		g.Expect(err).NotTo(BeNil())
+	})
+}
+
+func TestPlanTcs(t *testing.T) {
+	g := NewWithT(t)
+
+	global := &pb.Tc{Type: pb.Tc_NETEM, Netem: &pb.Netem{Time: 50000}}
+	filtered := &pb.Tc{Type: pb.Tc_BANDWIDTH, Tbf: &pb.Tbf{Rate: 1000, Buffer: 100}, Ipset: "A"}
+	plan, err := PlanTcs("eth0", []*pb.Tc{filtered, global})
+	g.Expect(err).To(BeNil())
+
+	// the qdisc of the global tc is added before the prio qdisc of the filtered ones
+	g.Expect(plan.QdiscOf[global]).To(Equal(0))
+	g.Expect(plan.QdiscOf[filtered]).To(Equal(5))
+	g.Expect(Render(Tc, ChangeQdiscArgs(plan.Qdiscs[plan.QdiscOf[filtered]])...)).
+		To(Equal("tc qdisc change dev eth0 parent 2:4 handle 6: tbf rate 1000 burst 100"))
+	// the arguments to add the qdisc are not modified
+	g.Expect(plan.Qdiscs[plan.QdiscOf[filtered]][1]).To(Equal("add"))
+}
+
+func TestPlanTcsWithPorts(t *testing.T) {
+	g := NewWithT(t)
+
+	// the same port as the destination and as the source are two different filters
+	dport := &pb.Tc{Type: pb.Tc_NETEM, Netem: &pb.Netem{Time: 50000}, Protocol: "tcp", EgressPort: "80"}
+	sport := &pb.Tc{Type: pb.Tc_NETEM, Netem: &pb.Netem{Time: 100000}, Protocol: "tcp", SourcePort: "80"}
+	g.Expect(TcFilter(dport)).NotTo(Equal(TcFilter(sport)))
+
+	plan, err := PlanTcs("eth0", []*pb.Tc{dport, sport})
+	g.Expect(err).To(BeNil())
+
+	g.Expect(plan.Chains).To(HaveLen(2))
+	g.Expect(plan.Chains[0].DestinationPorts).To(Equal("80"))
+	g.Expect(plan.Chains[0].SourcePorts).To(BeEmpty())
+	g.Expect(plan.Chains[0].Target).To(Equal("CLASSIFY --set-class 1:4"))
+	g.Expect(plan.Chains[1].DestinationPorts).To(BeEmpty())
+	g.Expect(plan.Chains[1].SourcePorts).To(Equal("80"))
+	g.Expect(plan.Chains[1].Target).To(Equal("CLASSIFY --set-class 1:5"))
+}
+
+func TestPlanHostTcs(t *testing.T) {
+	g := NewWithT(t)
+
+	global := &pb.Tc{Type: pb.Tc_NETEM, Netem: &pb.Netem{Time: 50000}}
+	filtered := &pb.Tc{Type: pb.Tc_BANDWIDTH, Tbf: &pb.Tbf{Rate: 1000, Buffer: 100}, Ipset: "A"}
+	plan, err := PlanHostTcs("eth0", []*pb.Tc{filtered, global})
+	g.Expect(err).To(BeNil())
+
+	// the global tc is classified by the first chain matching all packets, instead of being set on root
+	g.Expect(Render(Tc, plan.Qdiscs[0]...)).
+		To(Equal("tc qdisc add dev eth0 root handle 1: prio bands 5 priomap 1 2 2 2 1 2 0 0 1 1 1 1 1 1 1 1"))
+	g.Expect(Render(Tc, plan.Qdiscs[plan.QdiscOf[global]]...)).
+		To(Equal("tc qdisc add dev eth0 parent 1:4 handle 5: netem delay 50000"))
+	g.Expect(Render(Tc, plan.Qdiscs[plan.QdiscOf[filtered]]...)).
+		To(Equal("tc qdisc add dev eth0 parent 1:5 handle 6: tbf rate 1000 burst 100"))
+
+	g.Expect(plan.Chains).To(HaveLen(2))
+	g.Expect(plan.Chains[0].Ipsets).To(BeEmpty())
+	g.Expect(plan.Chains[0].Target).To(Equal("CLASSIFY --set-class 1:4"))
+	g.Expect(plan.Chains[1].Ipsets).To(Equal([]string{"A"}))
+	g.Expect(plan.Chains[1].Target).To(Equal("CLASSIFY --set-class 1:5"))
+}
+
+func TestIptablesProtectRules(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(IptablesProtectRules("INPUT", []uint32{31767, 10250})).To(Equal([]string{
+		"-A CHAOS-INPUT -p tcp -m tcp --sport 31767 -j RETURN",
+		"-A CHAOS-INPUT -p tcp -m tcp --dport 31767 -j RETURN",
+		"-A CHAOS-INPUT -p tcp -m tcp --sport 10250 -j RETURN",
+		"-A CHAOS-INPUT -p tcp -m tcp --dport 10250 -j RETURN",
+	}))
+}
+
+func TestRenderIPSet(t *testing.T) {
+	g := NewWithT(t)
+
+	commands := RenderIPSet(&pb.IPSet{Name: "test", Cidrs: []string{"10.0.0.1/32", "fd00::1/128", "10.0.0.2/32"}})
+	g.Expect(commands).To(Equal([]string{
+		"ipset create testold hash:net",
+		"ipset add testold 10.0.0.1/32",
+		"ipset add testold 10.0.0.2/32",
+		"ipset rename testold test",
+		"ipset create test6old hash:net family inet6",
+		"ipset add test6old fd00::1/128",
+		"ipset rename test6old test6",
+	}))
+}
+
+func TestIPv6Chain(t *testing.T) {
+	g := NewWithT(t)
+
+	chain := &pb.Chain{Name: "test", Direction: pb.Chain_INPUT, Ipsets: []string{"A", "B"}, Protocol: "icmp"}
+	g.Expect(IPv6Chain(chain)).To(Equal(&pb.Chain{
+		Name:      "test",
+		Direction: pb.Chain_INPUT,
+		Ipsets:    []string{"A6", "B6"},
+		Protocol:  "icmpv6",
+	}))
+	// the original chain is not modified
+	g.Expect(chain.Ipsets).To(Equal([]string{"A", "B"}))
+	g.Expect(ChainFor(Iptables, chain)).To(BeIdenticalTo(chain))
+}
+
+func TestIfbDevice(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(IfbDevice("eth0")).To(Equal("ifbeth0"))
+	g.Expect(IfbDevice("enp0s31f6abc")).To(Equal("ifbenp0s31f6abc"))
+
+	long := IfbDevice("enp0s31f6abcd")
+	g.Expect(long).To(HaveLen(15))
+	g.Expect(long).To(HavePrefix("ifbenp0"))
+	g.Expect(long).To(Equal(IfbDevice("enp0s31f6abcd")))
+	g.Expect(long).NotTo(Equal(IfbDevice("enp0s31f6abce")))
+}
+
+func TestRenderIngressTcs(t *testing.T) {
+	g := NewWithT(t)
+
+	t.Run("remove the IFB device without tcs", func(t *testing.T) {
+		commands, err := RenderIngressTcs("eth0", nil)
+		g.Expect(err).To(BeNil())
+		g.Expect(commands).To(Equal([]string{
+			"tc filter del dev eth0 ingress pref 1",
+			"ip link del ifbeth0",
+		}))
+	})
+
+	t.Run("redirect the inbound traffic into the IFB device", func(t *testing.T) {
+		commands, err := RenderIngressTcs("eth0", []*pb.Tc{
+			{Type: pb.Tc_NETEM, Netem: &pb.Netem{Time: 50000}},
+			{Type: pb.Tc_NETEM, Netem: &pb.Netem{Time: 100000}, Ipset: "A", Protocol: "tcp", SourcePort: "80,8000:8080"},
+		})
+		g.Expect(err).To(BeNil())
+		g.Expect(commands).To(Equal([]string{
+			"tc filter del dev eth0 ingress pref 1",
+			"ip link del ifbeth0",
+			"ip link add ifbeth0 type ifb",
+			"ip link set ifbeth0 up",
+			"tc qdisc replace dev eth0 clsact",
+			"tc filter add dev eth0 ingress pref 1 protocol all u32 match u32 0 0 action mirred egress redirect dev ifbeth0",
+			"tc qdisc add dev ifbeth0 root handle 1: netem delay 50000",
+			"tc qdisc add dev ifbeth0 parent 1: handle 2: prio bands 4 priomap 1 2 2 2 1 2 0 0 1 1 1 1 1 1 1 1",
+			"tc qdisc add dev ifbeth0 parent 2:1 handle 3: sfq",
+			"tc qdisc add dev ifbeth0 parent 2:2 handle 4: sfq",
+			"tc qdisc add dev ifbeth0 parent 2:3 handle 5: sfq",
+			"tc qdisc add dev ifbeth0 parent 2:4 handle 6: netem delay 100000",
+			`tc filter add dev ifbeth0 parent 2: protocol ip prio 1 basic match "ipset(A src) and cmp(u8 at 9 layer network eq 6) and ` +
+				`(cmp(u16 at 20 layer network eq 80) or (cmp(u16 at 20 layer network gt 7999) and cmp(u16 at 20 layer network lt 8081)))" flowid 2:4`,
+			`tc filter add dev ifbeth0 parent 2: protocol ipv6 prio 1 basic match "ipset(A6 src) and cmp(u8 at 6 layer network eq 6) and ` +
+				`(cmp(u16 at 40 layer network eq 80) or (cmp(u16 at 40 layer network gt 7999) and cmp(u16 at 40 layer network lt 8081)))" flowid 2:4`,
+		}))
+	})
+}
+
+func TestIngressFilterArgs(t *testing.T) {
+	g := NewWithT(t)
+
+	filters, err := IngressFilterArgs(IfbDevice("eth0"), 1, 4, &pb.Tc{Protocol: "udp", EgressPort: "0:65535"})
+	g.Expect(err).To(BeNil())
+	g.Expect(filters).To(Equal([][]string{
+		{"filter", "add", "dev", "ifbeth0", "parent", "1:", "protocol", "ip", "prio", "1",
+			"basic", "match", "cmp(u8 at 9 layer network eq 17)", "flowid", "1:4"},
+		{"filter", "add", "dev", "ifbeth0", "parent", "1:", "protocol", "ipv6", "prio", "1",
+			"basic", "match", "cmp(u8 at 6 layer network eq 17)", "flowid", "1:4"},
+	}))
+
+	_, err = IngressFilterArgs(IfbDevice("eth0"), 1, 4, &pb.Tc{Protocol: "tcp", SourcePort: "90:80"})
+	g.Expect(err).NotTo(BeNil())
+}
+
+func TestRenderTproxy(t *testing.T) {
+	g := NewWithT(t)
+
+	commands, err := RenderTproxy(&TproxyConfig{ProxyPorts: []uint32{80}, Rules: []v1alpha1.PodHttpChaosBaseRule{}})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(commands).To(Equal([]string{
+		"/usr/local/bin/tproxy -i -vv",
+		`PUT / {"proxy_ports":[80],"rules":[]}`,
+	}))
+}
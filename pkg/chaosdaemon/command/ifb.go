@@ -0,0 +1,231 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+)
+
+// IfbDevice returns the name of the IFB device which the inbound traffic of the device is redirected
+// into, so the qdiscs on its egress shape the inbound traffic
+func IfbDevice(device string) string {
+	name := "ifb" + device
+	// the name of a network device cannot be longer than 15 bytes, so the long names are shortened into
+	// the prefix of the device with the hash of the whole name, which tells the devices sharing the prefix
+	if len(name) > 15 {
+		hash := fnv.New32a()
+		hash.Write([]byte(device))
+		return fmt.Sprintf("%s%08x", name[:7], hash.Sum32())
+	}
+
+	return name
+}
+
+// ingressPref is the priority of the filter redirecting the inbound packets on the clsact qdisc
+const ingressPref = "1"
+
+// IfbAddArgs returns the arguments of ip to create the IFB device of the device
+func IfbAddArgs(device string) []string {
+	return []string{"link", "add", IfbDevice(device), "type", "ifb"}
+}
+
+// IfbUpArgs returns the arguments of ip to bring the IFB device of the device up
+func IfbUpArgs(device string) []string {
+	return []string{"link", "set", IfbDevice(device), "up"}
+}
+
+// IfbDelArgs returns the arguments of ip to remove the IFB device of the device, together with all qdiscs on it
+func IfbDelArgs(device string) []string {
+	return []string{"link", "del", IfbDevice(device)}
+}
+
+// IngressQdiscArgs returns the arguments of tc to add the clsact qdisc on the device, which is shared by the
+// redirection of the inbound traffic and the eBPF program of the packet faults on the outbound traffic
+func IngressQdiscArgs(device string) []string {
+	return []string{"qdisc", "replace", "dev", device, "clsact"}
+}
+
+// IngressRedirectArgs returns the arguments of tc to redirect all inbound packets of the device into the IFB device
+func IngressRedirectArgs(device string) []string {
+	return []string{"filter", "add", "dev", device, "ingress", "pref", ingressPref, "protocol", "all",
+		"u32", "match", "u32", "0", "0", "action", "mirred", "egress", "redirect", "dev", IfbDevice(device)}
+}
+
+// FlushIngressArgs returns the arguments of tc to remove the redirection of the inbound traffic of the device.
+// The clsact qdisc is kept, as it may be used by the packet faults.
+func FlushIngressArgs(device string) []string {
+	return []string{"filter", "del", "dev", device, "ingress", "pref", ingressPref}
+}
+
+// ingressFamily describes how to match the packets of an IP family with ematch
+type ingressFamily struct {
+	// protocol is the ethernet protocol of the family
+	protocol string
+	// protocolOffset is the offset of the protocol field in the IP header
+	protocolOffset int
+	// transportOffset is the offset of the transport header. The transport header of the redirected
+	// packets isn't set, so it's located by the length of the IP header without options or extensions.
+	transportOffset int
+	// icmp is the protocol number of icmp in the family
+	icmp int
+	// ipset returns the name of the ipset holding the cidrs of the family
+	ipset func(string) string
+}
+
+var ingressFamilies = []ingressFamily{
+	{protocol: "ip", protocolOffset: 9, transportOffset: 20, icmp: 1, ipset: IPSetName},
+	{protocol: "ipv6", protocolOffset: 6, transportOffset: 40, icmp: 58, ipset: IPSet6Name},
+}
+
+// IngressFilterArgs returns the arguments of tc to classify the inbound packets matching the ipset, the protocol
+// and the ports of the tc into the band of the prio qdisc, one filter for each IP family
+func IngressFilterArgs(device string, prio int, band int, tc *pb.Tc) ([][]string, error) {
+	filters := [][]string{}
+	for _, family := range ingressFamilies {
+		matches := []string{}
+		if len(tc.Ipset) > 0 {
+			matches = append(matches, fmt.Sprintf("ipset(%s src)", family.ipset(tc.Ipset)))
+		}
+
+		if len(tc.Protocol) > 0 {
+			var number int
+			switch tc.Protocol {
+			case "tcp":
+				number = 6
+			case "udp":
+				number = 17
+			case "icmp":
+				number = family.icmp
+			default:
+				return nil, fmt.Errorf("unknown protocol %s", tc.Protocol)
+			}
+			matches = append(matches, fmt.Sprintf("cmp(u8 at %d layer network eq %d)", family.protocolOffset, number))
+		}
+
+		// the source port is the first field of the tcp and udp header, followed by the destination port
+		for i, ports := range []string{tc.SourcePort, tc.EgressPort} {
+			if len(ports) == 0 {
+				continue
+			}
+
+			match, err := portsMatch(ports, family.transportOffset+2*i)
+			if err != nil {
+				return nil, err
+			}
+			if len(match) > 0 {
+				matches = append(matches, match)
+			}
+		}
+
+		args := []string{"filter", "add", "dev", device, "parent", fmt.Sprintf("%d:", prio),
+			"protocol", family.protocol, "prio", "1"}
+		if len(matches) == 0 {
+			args = append(args, "matchall")
+		} else {
+			args = append(args, "basic", "match", strings.Join(matches, " and "))
+		}
+		filters = append(filters, append(args, "flowid", fmt.Sprintf("%d:%d", prio, band)))
+	}
+
+	return filters, nil
+}
+
+// portsMatch returns the ematch expression matching the ports at the offset of the IP header, or an empty
+// string if all ports are matched. The ports are separated by commas, and a range of ports is represented
+// as "start:end".
+func portsMatch(ports string, offset int) (string, error) {
+	cmp := func(op string, port int) string {
+		return fmt.Sprintf("cmp(u16 at %d layer network %s %d)", offset, op, port)
+	}
+
+	matches := []string{}
+	for _, part := range strings.Split(ports, ",") {
+		bounds := strings.SplitN(part, ":", 2)
+		start, err := strconv.ParseUint(bounds[0], 10, 16)
+		if err != nil {
+			return "", fmt.Errorf("invalid port %s: %v", part, err)
+		}
+		if len(bounds) == 1 {
+			matches = append(matches, cmp("eq", int(start)))
+			continue
+		}
+
+		end, err := strconv.ParseUint(bounds[1], 10, 16)
+		if err != nil {
+			return "", fmt.Errorf("invalid port range %s: %v", part, err)
+		}
+		if start > end {
+			return "", fmt.Errorf("invalid port range %s", part)
+		}
+
+		bound := []string{}
+		if start > 0 {
+			bound = append(bound, cmp("gt", int(start)-1))
+		}
+		if end < 65535 {
+			bound = append(bound, cmp("lt", int(end)+1))
+		}
+		if len(bound) == 0 {
+			// the range covers all ports
+			return "", nil
+		}
+		matches = append(matches, "("+strings.Join(bound, " and ")+")")
+	}
+
+	if len(matches) == 1 {
+		return matches[0], nil
+	}
+	return "(" + strings.Join(matches, " or ") + ")", nil
+}
+
+// RenderIngressTcs renders all commands to shape the inbound traffic of the device with the tc rules, including
+// the removal of the existing IFB device. The IFB device is not created if there is no tc rule.
+func RenderIngressTcs(device string, tcs []*pb.Tc) ([]string, error) {
+	commands := []string{Render(Tc, FlushIngressArgs(device)...), Render(IP, IfbDelArgs(device)...)}
+	if len(tcs) == 0 {
+		return commands, nil
+	}
+
+	plan, err := PlanIngressTcs(device, tcs)
+	if err != nil {
+		return nil, err
+	}
+
+	commands = append(commands,
+		Render(IP, IfbAddArgs(device)...),
+		Render(IP, IfbUpArgs(device)...),
+		Render(Tc, IngressQdiscArgs(device)...),
+		Render(Tc, IngressRedirectArgs(device)...),
+	)
+	for _, args := range plan.Qdiscs {
+		commands = append(commands, Render(Tc, args...))
+	}
+	for _, args := range plan.Filters {
+		quoted := make([]string, len(args))
+		for i, arg := range args {
+			quoted[i] = arg
+			if strings.Contains(arg, " ") {
+				quoted[i] = strconv.Quote(arg)
+			}
+		}
+		commands = append(commands, Render(Tc, quoted...))
+	}
+
+	return commands, nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"fmt"
+	"net"
+
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+)
+
+// ipsetMaxNameLength is the limit of the length of an ipset name
+const ipsetMaxNameLength = 31
+
+// IPSetTmpName returns the name of the temporary ipset, which will be swapped with the ipset
+func IPSetTmpName(name string) string {
+	return fmt.Sprintf("%sold", name)
+}
+
+// IPSetName truncates the name of the ipset to fit the limit
+func IPSetName(name string) string {
+	// ipset name cannot be longer than 31 bytes
+	if len(name) > ipsetMaxNameLength {
+		return name[:ipsetMaxNameLength]
+	}
+
+	return name
+}
+
+// IPSet6Name returns the name of the ipset holding the IPv6 cidrs of the ipset
+func IPSet6Name(name string) string {
+	return IPSetName(name + "6")
+}
+
+// IPSetCreateArgs returns the arguments of ipset to create an ipset of the cidrs in the family
+func IPSetCreateArgs(name string, ipv6 bool) []string {
+	args := []string{"create", IPSetName(name), "hash:net"}
+	if ipv6 {
+		args = append(args, "family", "inet6")
+	}
+
+	return args
+}
+
+// SplitIPSet splits the cidrs of the ipset by the family. The IPv4 cidrs are kept in the ipset with
+// the same name, and the IPv6 cidrs are moved into the ipset named by IPSet6Name. Both ipsets are
+// returned even if they are empty, because the iptables rules of both families refer to them.
+func SplitIPSet(set *pb.IPSet) (*pb.IPSet, *pb.IPSet) {
+	v4 := &pb.IPSet{Name: set.Name}
+	v6 := &pb.IPSet{Name: IPSet6Name(set.Name)}
+	for _, cidr := range set.Cidrs {
+		if IsIPv6Cidr(cidr) {
+			v6.Cidrs = append(v6.Cidrs, cidr)
+		} else {
+			v4.Cidrs = append(v4.Cidrs, cidr)
+		}
+	}
+
+	return v4, v6
+}
+
+// IsIPv6Cidr returns whether the cidr or ip is an IPv6 one
+func IsIPv6Cidr(cidr string) bool {
+	ip, _, err := net.ParseCIDR(cidr)
+	if err != nil {
+		ip = net.ParseIP(cidr)
+	}
+
+	return ip != nil && ip.To4() == nil
+}
+
+// RenderIPSet renders the commands to flush the ipsets of both families with the cidrs
+func RenderIPSet(set *pb.IPSet) []string {
+	v4, v6 := SplitIPSet(set)
+
+	return append(renderIPSet(v4, false), renderIPSet(v6, true)...)
+}
+
+func renderIPSet(set *pb.IPSet, ipv6 bool) []string {
+	tmpName := IPSetTmpName(set.Name)
+
+	commands := []string{Render(IPSet, IPSetCreateArgs(tmpName, ipv6)...)}
+	for _, cidr := range set.Cidrs {
+		commands = append(commands, Render(IPSet, "add", tmpName, cidr))
+	}
+
+	return append(commands, Render(IPSet, "rename", tmpName, set.Name))
+}
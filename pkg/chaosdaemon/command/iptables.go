@@ -0,0 +1,189 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+)
+
+// IptablesDirections are the builtin chains which jump to the chaos chains
+var IptablesDirections = []string{"INPUT", "OUTPUT"}
+
+// IptablesChaosChain returns the name of the chain holding all chaos chains of the direction
+func IptablesChaosChain(direction string) string {
+	return "CHAOS-" + direction
+}
+
+// IptablesRules generates the rules of the chain
+func IptablesRules(chain *pb.Chain) ([]string, error) {
+	var matchPart, deviceMatch string
+	if chain.Direction == pb.Chain_INPUT {
+		matchPart = "src"
+		deviceMatch = "--in-interface"
+	} else if chain.Direction == pb.Chain_OUTPUT {
+		matchPart = "dst"
+		deviceMatch = "--out-interface"
+	} else {
+		return nil, fmt.Errorf("unknown chain direction %d", chain.Direction)
+	}
+
+	protocolAndPort := ""
+	if len(chain.Device) > 0 {
+		protocolAndPort += fmt.Sprintf("%s %s ", deviceMatch, chain.Device)
+	}
+	if len(chain.Protocol) > 0 {
+		protocolAndPort += fmt.Sprintf("--protocol %s", chain.Protocol)
+
+		if len(chain.SourcePorts) > 0 {
+			if strings.Contains(chain.SourcePorts, ",") {
+				protocolAndPort += fmt.Sprintf(" -m multiport --source-ports %s", chain.SourcePorts)
+			} else {
+				protocolAndPort += fmt.Sprintf(" --source-port %s", chain.SourcePorts)
+			}
+		}
+
+		if len(chain.DestinationPorts) > 0 {
+			if strings.Contains(chain.DestinationPorts, ",") {
+				protocolAndPort += fmt.Sprintf(" -m multiport --destination-ports %s", chain.DestinationPorts)
+			} else {
+				protocolAndPort += fmt.Sprintf(" --destination-port %s", chain.DestinationPorts)
+			}
+		}
+
+		if len(chain.TcpFlags) > 0 {
+			protocolAndPort += fmt.Sprintf(" --tcp-flags %s", chain.TcpFlags)
+		}
+	}
+
+	rules := []string{}
+
+	if len(chain.Ipsets) == 0 {
+		rules = append(rules, strings.TrimSpace(fmt.Sprintf("-A %s -j %s -w 5 %s", chain.Name, chain.Target, protocolAndPort)))
+	}
+
+	for _, ipset := range chain.Ipsets {
+		rules = append(rules, strings.TrimSpace(fmt.Sprintf("-A %s -m set --match-set %s %s -j %s -w 5 %s",
+			chain.Name, ipset, matchPart, chain.Target, protocolAndPort)))
+	}
+
+	return rules, nil
+}
+
+// IptablesProtectRules generates the rules returning from the chaos chain of the direction for the tcp packets
+// from or to the ports, so they never go through the chains jumped into after them. The rules are in the same
+// form as the output of `iptables -S`.
+func IptablesProtectRules(direction string, ports []uint32) []string {
+	rules := []string{}
+	for _, port := range ports {
+		for _, match := range []string{"--sport", "--dport"} {
+			rules = append(rules, fmt.Sprintf("-A %s -p tcp -m tcp %s %d -j RETURN", IptablesChaosChain(direction), match, port))
+		}
+	}
+
+	return rules
+}
+
+// IptablesJumpRule generates the rule to jump from the chaos chain of its direction into the chain
+func IptablesJumpRule(chain *pb.Chain) (string, error) {
+	if chain.Direction == pb.Chain_INPUT {
+		return "-A " + IptablesChaosChain("INPUT") + " -j " + chain.Name, nil
+	} else if chain.Direction == pb.Chain_OUTPUT {
+		return "-A " + IptablesChaosChain("OUTPUT") + " -j " + chain.Name, nil
+	}
+
+	return "", fmt.Errorf("unknown direction %d", chain.Direction)
+}
+
+// IptablesCommands are the commands to operate the iptables of IPv4 and IPv6
+var IptablesCommands = []string{Iptables, Ip6tables}
+
+// IPv6Chain returns the chain matching the IPv6 packets with ip6tables, which refers to the
+// IPv6 ipsets and the protocol names of IPv6
+func IPv6Chain(chain *pb.Chain) *pb.Chain {
+	ch := &pb.Chain{
+		Name:             chain.Name,
+		Direction:        chain.Direction,
+		Target:           chain.Target,
+		Protocol:         chain.Protocol,
+		SourcePorts:      chain.SourcePorts,
+		DestinationPorts: chain.DestinationPorts,
+		TcpFlags:         chain.TcpFlags,
+		Device:           chain.Device,
+	}
+	for _, ipset := range chain.Ipsets {
+		ch.Ipsets = append(ch.Ipsets, IPSet6Name(ipset))
+	}
+	if ch.Protocol == "icmp" {
+		ch.Protocol = "icmpv6"
+	}
+
+	return ch
+}
+
+// ChainFor returns the chain to be set with the command of iptables or ip6tables
+func ChainFor(cmd string, chain *pb.Chain) *pb.Chain {
+	if cmd == Ip6tables {
+		return IPv6Chain(chain)
+	}
+
+	return chain
+}
+
+// RenderIptablesInit renders the commands to initialize the chaos chains of both families
+func RenderIptablesInit() []string {
+	commands := []string{}
+	for _, cmd := range IptablesCommands {
+		for _, direction := range IptablesDirections {
+			chainName := IptablesChaosChain(direction)
+			commands = append(commands,
+				Render(cmd, "-w", "-N", chainName),
+				Render(cmd, "-w", "-F", chainName),
+				Render(cmd, "-w", "-A", direction, "-j", chainName),
+			)
+		}
+	}
+
+	return commands
+}
+
+// RenderIptablesChains renders the commands to create the chains of both families, fill their rules and jump into them
+func RenderIptablesChains(chains []*pb.Chain) ([]string, error) {
+	commands := []string{}
+	for _, cmd := range IptablesCommands {
+		for _, chain := range chains {
+			chain := ChainFor(cmd, chain)
+			rules, err := IptablesRules(chain)
+			if err != nil {
+				return nil, err
+			}
+			jump, err := IptablesJumpRule(chain)
+			if err != nil {
+				return nil, err
+			}
+
+			commands = append(commands,
+				Render(cmd, "-w", "-N", chain.Name),
+				Render(cmd, "-w", "-F", chain.Name),
+			)
+			for _, rule := range append(rules, jump) {
+				commands = append(commands, Render(cmd, "-w", rule))
+			}
+		}
+	}
+
+	return commands, nil
+}
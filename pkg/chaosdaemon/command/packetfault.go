@@ -0,0 +1,51 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"fmt"
+
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+)
+
+// PacketFaultFilter is the name of the filter of the eBPF program injecting the packet faults,
+// which is attached to the egress of the clsact qdisc
+const PacketFaultFilter = "chaos-mesh-packet-fault"
+
+// PacketFaultFqMajor is the major of the handle of the fq qdisc holding the delayed packets, which tells it
+// from the fq qdiscs set by others
+const PacketFaultFqMajor = 0xca05
+
+// RenderPacketFaults renders the commands equivalent to attaching the eBPF program of the packet faults
+// to the device. Chaos daemon compiles the program from the faults, and attaches it through netlink.
+func RenderPacketFaults(device string, faults []*pb.PacketFault) []string {
+	if len(faults) == 0 {
+		return nil
+	}
+
+	var commands []string
+	for _, fault := range faults {
+		// the fq qdisc holds the delayed packets until their timestamps
+		if fault.Fault == pb.PacketFault_DELAY {
+			commands = append(commands, Render(Tc, "qdisc", "replace", "dev", device, "root", "handle", fmt.Sprintf("%x:", PacketFaultFqMajor), "fq"))
+			break
+		}
+	}
+
+	return append(commands,
+		Render(Tc, IngressQdiscArgs(device)...),
+		Render(Tc, "filter", "add", "dev", device, "egress", "pref", "1", "handle", "0x1",
+			"bpf", "direct-action", "name", PacketFaultFilter),
+	)
+}
@@ -0,0 +1,386 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+)
+
+// TcPlan is the list of operations to set the traffic control rules on a device
+type TcPlan struct {
+	// Qdiscs are the arguments of tc to add the qdiscs, in the order of execution
+	Qdiscs [][]string
+	// Chains are the iptables chains to classify the packets into the qdiscs with filter
+	Chains []*pb.Chain
+	// Filters are the arguments of tc to classify the packets into the qdiscs with filter. They are
+	// used instead of Chains on the IFB device, because the redirected packets don't traverse iptables.
+	Filters [][]string
+	// QdiscOf maps the tc to the index of its qdisc in Qdiscs
+	QdiscOf map[*pb.Tc]int
+}
+
+// FlushTcArgs returns the arguments of tc to remove all qdiscs on the device
+func FlushTcArgs(device string) []string {
+	return []string{"qdisc", "del", "dev", device, "root"}
+}
+
+// PlanTcs generates the operations to set the tc rules on the device
+func PlanTcs(device string, tcs []*pb.Tc) (*TcPlan, error) {
+	return planTcs(device, tcs, false, false)
+}
+
+// PlanHostTcs generates the operations to set the tc rules on the device in the network namespace of the host.
+// The tcs without filter are classified into the PRIO qdisc by a chain matching all packets instead of being
+// piped from root, so the packets returning from the chaos chain before the classification, e.g. the ones of
+// the protected ports, are never shaped. The chain is the first one, so the tcs with filter take precedence.
+func PlanHostTcs(device string, tcs []*pb.Tc) (*TcPlan, error) {
+	return planTcs(device, tcs, false, true)
+}
+
+// PlanIngressTcs generates the operations to set the tc rules on the IFB device of the device, which shape
+// the inbound traffic redirected into it. The ipsets of the tcs match the source address of the packets.
+func PlanIngressTcs(device string, tcs []*pb.Tc) (*TcPlan, error) {
+	return planTcs(IfbDevice(device), tcs, true, false)
+}
+
+func planTcs(device string, tcs []*pb.Tc, ingress bool, classifyAll bool) (*TcPlan, error) {
+	// tc rules are split into two different kinds according to whether it has filter.
+	// all tc rules without filter are called `globalTc` and the tc rules with filter will be called `filterTc`.
+	// the `globalTc` rules will be piped one by one from root, and the last `globalTc` will be connected with a PRIO
+	// qdisc, which has `3 + len(filterTc)` bands. Then the 4.. bands will be connected to `filterTc` and a filter will
+	// be setuped to flow packet from PRIO qdisc to it.
+
+	// for example, four tc rules:
+	// - NETEM: 50ms latency without filter
+	// - NETEM: 100ms latency without filter
+	// - NETEM: 50ms latency with filter ipset A
+	// - NETEM: 100ms latency with filter ipset B
+	// will generate tc rules:
+	//	tc qdisc del dev eth0 root
+	//  tc qdisc add dev eth0 root handle 1: netem delay 50000
+	//  tc qdisc add dev eth0 parent 1: handle 2: netem delay 100000
+	//  tc qdisc add dev eth0 parent 2: handle 3: prio bands 5 priomap 1 2 2 2 1 2 0 0 1 1 1 1 1 1 1 1
+	//  tc qdisc add dev eth0 parent 3:1 handle 4: sfq
+	//  tc qdisc add dev eth0 parent 3:2 handle 5: sfq
+	//  tc qdisc add dev eth0 parent 3:3 handle 6: sfq
+	//  tc qdisc add dev eth0 parent 3:4 handle 7: netem delay 50000
+	//  iptables -A TC-TABLES-eth0-0 -m set --match-set A dst -j CLASSIFY --set-class 3:4 -w 5 --out-interface eth0
+	//  tc qdisc add dev eth0 parent 3:5 handle 8: netem delay 100000
+	//  iptables -A TC-TABLES-eth0-1 -m set --match-set B dst -j CLASSIFY --set-class 3:5 -w 5 --out-interface eth0
+
+	globalTc := []*pb.Tc{}
+	// filters keeps the order of the filters, so the plan is stable for the same request
+	filters := []string{}
+	filterTc := make(map[string][]*pb.Tc)
+
+	for _, tc := range tcs {
+		filter := TcFilter(tc)
+		if len(filter) == 0 && classifyAll {
+			// the empty filter matches all packets, and it's always the first one
+			if _, ok := filterTc[filter]; !ok {
+				filters = append([]string{filter}, filters...)
+			}
+			filterTc[filter] = append(filterTc[filter], tc)
+			continue
+		}
+		if len(filter) > 0 {
+			if _, ok := filterTc[filter]; !ok {
+				filters = append(filters, filter)
+			}
+			filterTc[filter] = append(filterTc[filter], tc)
+			continue
+		}
+		globalTc = append(globalTc, tc)
+	}
+
+	plan := &TcPlan{QdiscOf: map[*pb.Tc]int{}}
+	for index, tc := range globalTc {
+		parentArg := "root"
+		if index > 0 {
+			parentArg = fmt.Sprintf("parent %d:", index)
+		}
+
+		handleArg := fmt.Sprintf("handle %d:", index+1)
+
+		args, err := qdiscArgs(device, parentArg, handleArg, tc)
+		if err != nil {
+			return nil, err
+		}
+		plan.QdiscOf[tc] = len(plan.Qdiscs)
+		plan.Qdiscs = append(plan.Qdiscs, args)
+	}
+
+	if len(filterTc) == 0 {
+		return plan, nil
+	}
+
+	parent := len(globalTc)
+	band := 3 + len(filterTc) // 3 handlers for normal sfq on prio qdisc
+	plan.Qdiscs = append(plan.Qdiscs, prioArgs(device, parent, band)...)
+
+	parent++
+	currentHandler := parent + 3 // 3 handlers for sfq on prio qdisc
+
+	for index, filter := range filters {
+		tcs := filterTc[filter]
+		for i, tc := range tcs {
+			parentArg := fmt.Sprintf("parent %d:%d", parent, index+4)
+			if i > 0 {
+				parentArg = fmt.Sprintf("parent %d:", currentHandler)
+			}
+
+			currentHandler++
+			handleArg := fmt.Sprintf("handle %d:", currentHandler)
+
+			args, err := qdiscArgs(device, parentArg, handleArg, tc)
+			if err != nil {
+				return nil, err
+			}
+			plan.QdiscOf[tc] = len(plan.Qdiscs)
+			plan.Qdiscs = append(plan.Qdiscs, args)
+		}
+
+		if ingress {
+			// every tc of the band matches with its own ports, the identical filters are added only once
+			seen := map[string]bool{}
+			for _, tc := range tcs {
+				filters, err := IngressFilterArgs(device, parent, index+4, tc)
+				if err != nil {
+					return nil, err
+				}
+				for _, filter := range filters {
+					key := strings.Join(filter, " ")
+					if seen[key] {
+						continue
+					}
+					seen[key] = true
+					plan.Filters = append(plan.Filters, filter)
+				}
+			}
+			continue
+		}
+
+		// the chains are named and matched by the device, because the same class may exist on other devices
+		ch := &pb.Chain{
+			Name:      TcChainName(device, index),
+			Direction: pb.Chain_OUTPUT,
+			Target:    fmt.Sprintf("CLASSIFY --set-class %d:%d", parent, index+4),
+			Device:    device,
+		}
+
+		// the tcs of the band share the same filter, so the chain rules are built from the ports of each of them
+		for _, tc := range tcs {
+			if len(tc.Ipset) > 0 && !containsString(ch.Ipsets, tc.Ipset) {
+				ch.Ipsets = append(ch.Ipsets, tc.Ipset)
+			}
+			if len(tc.Protocol) > 0 {
+				ch.Protocol = tc.Protocol
+			}
+			if len(tc.SourcePort) > 0 {
+				ch.SourcePorts = tc.SourcePort
+			}
+			if len(tc.EgressPort) > 0 {
+				ch.DestinationPorts = tc.EgressPort
+			}
+		}
+
+		plan.Chains = append(plan.Chains, ch)
+	}
+
+	return plan, nil
+}
+
+// TcChainName returns the name of the chain classifying the packets of the index-th filter on the device
+func TcChainName(device string, index int) string {
+	return fmt.Sprintf("TC-TABLES-%s-%d", device, index)
+}
+
+// RenderTcs renders all commands to set the tc rules on the device, including the flush of existing rules
+func RenderTcs(device string, tcs []*pb.Tc) ([]string, error) {
+	plan, err := PlanTcs(device, tcs)
+	if err != nil {
+		return nil, err
+	}
+
+	commands := []string{Render(Tc, FlushTcArgs(device)...)}
+	for _, args := range plan.Qdiscs {
+		commands = append(commands, Render(Tc, args...))
+	}
+
+	chains, err := RenderIptablesChains(plan.Chains)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(commands, chains...), nil
+}
+
+// ChangeQdiscArgs converts the arguments of tc to add the qdisc into the ones to change its parameters
+func ChangeQdiscArgs(args []string) []string {
+	changed := make([]string, len(args))
+	copy(changed, args)
+	if len(changed) > 1 && changed[1] == "add" {
+		changed[1] = "change"
+	}
+
+	return changed
+}
+
+func qdiscArgs(device string, parentArg string, handleArg string, tc *pb.Tc) ([]string, error) {
+	var args string
+	if tc.Type == pb.Tc_BANDWIDTH {
+		if tc.Tbf == nil {
+			return nil, fmt.Errorf("tbf is nil while type is BANDWIDTH")
+		}
+		args = fmt.Sprintf("qdisc add dev %s %s %s tbf %s", device, parentArg, handleArg, TbfArgs(tc.Tbf))
+	} else if tc.Type == pb.Tc_NETEM {
+		if tc.Netem == nil {
+			return nil, fmt.Errorf("netem is nil while type is NETEM")
+		}
+		args = fmt.Sprintf("qdisc add dev %s %s %s netem %s", device, parentArg, handleArg, NetemArgs(tc.Netem))
+	} else {
+		return nil, fmt.Errorf("unknown tc qdisc type")
+	}
+
+	// the arguments of netem may be empty, e.g. the delay of a profile is 0
+	return strings.Fields(args), nil
+}
+
+func prioArgs(device string, parent int, band int) [][]string {
+	parentArg := "root"
+	if parent > 0 {
+		parentArg = fmt.Sprintf("parent %d:", parent)
+	}
+	args := fmt.Sprintf("qdisc add dev %s %s handle %d: prio bands %d priomap 1 2 2 2 1 2 0 0 1 1 1 1 1 1 1 1", device, parentArg, parent+1, band)
+	qdiscs := [][]string{strings.Split(args, " ")}
+
+	for index := 1; index <= 3; index++ {
+		args := fmt.Sprintf("qdisc add dev %s parent %d:%d handle %d: sfq", device, parent+1, index, parent+1+index)
+		qdiscs = append(qdiscs, strings.Split(args, " "))
+	}
+
+	return qdiscs
+}
+
+// NetemArgs converts the netem into the arguments of tc
+func NetemArgs(netem *pb.Netem) string {
+	args := ""
+	if netem.Time > 0 {
+		args = fmt.Sprintf("delay %d", netem.Time)
+		if netem.Jitter > 0 {
+			args = fmt.Sprintf("%s %d", args, netem.Jitter)
+
+			if netem.DelayCorr > 0 {
+				args = fmt.Sprintf("%s %f", args, netem.DelayCorr)
+			}
+		}
+
+		// reordering not possible without specifying some delay
+		if netem.Reorder > 0 {
+			args = fmt.Sprintf("%s reorder %f", args, netem.Reorder)
+			if netem.ReorderCorr > 0 {
+				args = fmt.Sprintf("%s %f", args, netem.ReorderCorr)
+			}
+
+			if netem.Gap > 0 {
+				args = fmt.Sprintf("%s gap %d", args, netem.Gap)
+			}
+		}
+	}
+
+	if netem.Limit > 0 {
+		args = fmt.Sprintf("%s limit %d", args, netem.Limit)
+	}
+
+	if netem.Loss > 0 {
+		args = fmt.Sprintf("%s loss %f", args, netem.Loss)
+		if netem.LossCorr > 0 {
+			args = fmt.Sprintf("%s %f", args, netem.LossCorr)
+		}
+	}
+
+	if netem.Duplicate > 0 {
+		args = fmt.Sprintf("%s duplicate %f", args, netem.Duplicate)
+		if netem.DuplicateCorr > 0 {
+			args = fmt.Sprintf("%s %f", args, netem.DuplicateCorr)
+		}
+	}
+
+	if netem.Corrupt > 0 {
+		args = fmt.Sprintf("%s corrupt %f", args, netem.Corrupt)
+		if netem.CorruptCorr > 0 {
+			args = fmt.Sprintf("%s %f", args, netem.CorruptCorr)
+		}
+	}
+
+	trimedArgs := []string{}
+
+	for _, part := range strings.Split(args, " ") {
+		if len(part) > 0 {
+			trimedArgs = append(trimedArgs, part)
+		}
+	}
+
+	return strings.Join(trimedArgs, " ")
+}
+
+// TbfArgs converts the tbf into the arguments of tc
+func TbfArgs(tbf *pb.Tbf) string {
+	args := fmt.Sprintf("rate %d burst %d", tbf.Rate, tbf.Buffer)
+	if tbf.Limit > 0 {
+		args = fmt.Sprintf("%s limit %d", args, tbf.Limit)
+	}
+	if tbf.PeakRate > 0 {
+		args = fmt.Sprintf("%s peakrate %d mtu %d", args, tbf.PeakRate, tbf.MinBurst)
+	}
+
+	return args
+}
+
+// TcFilter returns the identity of the filter of the tc, the tcs with the same filter share the same band.
+// Every part is tagged, so a destination port never collides with the same source port.
+func TcFilter(tc *pb.Tc) string {
+	parts := []string{}
+
+	if len(tc.Ipset) > 0 {
+		parts = append(parts, "ipset="+tc.Ipset)
+	}
+
+	if len(tc.Protocol) > 0 {
+		parts = append(parts, "protocol="+tc.Protocol)
+	}
+
+	if len(tc.EgressPort) > 0 {
+		parts = append(parts, "dport="+tc.EgressPort)
+	}
+
+	if len(tc.SourcePort) > 0 {
+		parts = append(parts, "sport="+tc.SourcePort)
+	}
+
+	return strings.Join(parts, ",")
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,36 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Toda is the binary to inject the io chaos
+const Toda = "/usr/local/bin/toda"
+
+// TodaArgs returns the arguments of toda to inject the io chaos on the volume
+func TodaArgs(volume string) []string {
+	// TODO: make this log level configurable
+	return strings.Split(fmt.Sprintf("--path %s --verbose info", volume), " ")
+}
+
+// RenderToda renders the toda command and the json rpc call to update its actions
+func RenderToda(volume string, actions string) []string {
+	return []string{
+		Render(Toda, TodaArgs(volume)...),
+		"jsonrpc update " + actions,
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"encoding/json"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+)
+
+// Tproxy is the binary to inject the http chaos
+const Tproxy = "/usr/local/bin/tproxy"
+
+// TproxyConfig is the config sent to tproxy through its stdin
+type TproxyConfig struct {
+	ProxyPorts []uint32                        `json:"proxy_ports"`
+	Rules      []v1alpha1.PodHttpChaosBaseRule `json:"rules"`
+}
+
+// TproxyArgs returns the arguments of tproxy, which reads the config from its stdin
+func TproxyArgs() []string {
+	return []string{"-i", "-vv"}
+}
+
+// RenderTproxy renders the tproxy command and the request to update its config
+func RenderTproxy(config *TproxyConfig) ([]string, error) {
+	body, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{
+		Render(Tproxy, TproxyArgs()...),
+		"PUT / " + string(body),
+	}, nil
+}
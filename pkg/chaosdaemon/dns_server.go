@@ -16,83 +16,362 @@ package chaosdaemon
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/vishvananda/netns"
 
 	"github.com/chaos-mesh/chaos-mesh/pkg/bpm"
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/dnschaos"
 	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
 )
 
 const (
 	// DNSServerConfFile is the default config file for DNS server
 	DNSServerConfFile = "/etc/resolv.conf"
+
+	// dnsStateFile is the file in the state directory which keeps the rules of the dns servers across the restarts
+	// of chaos-daemon
+	dnsStateFile = "dnschaos.json"
 )
 
+// dnsServers keeps the dns servers embedded in chaos-daemon, one for each network namespace
+type dnsServers struct {
+	sync.Mutex
+
+	// servers are the dns servers by the id of the network namespace
+	servers map[string]*dnsServer
+	// containers are the ids of the network namespaces of the containers using the dns servers
+	containers map[string]string
+
+	// path is the state file keeping the requests and confs, nothing is kept if it's empty
+	path string
+	// requests are the requests setting the rules, by the container and the name of the rules
+	requests map[string]map[string]*pb.SetDNSServerRequest
+	// confs are the original resolv.conf of the containers using the rules
+	confs map[string]string
+}
+
+// dnsState is the content of the state file
+type dnsState struct {
+	Requests map[string]map[string]*pb.SetDNSServerRequest `json:"requests"`
+	Confs    map[string]string                             `json:"confs"`
+}
+
+// dnsServer is a dns server with the rules used by the containers in its network namespace
+type dnsServer struct {
+	*dnschaos.Server
+
+	// users are the names of the rules used by each container
+	users map[string]map[string]struct{}
+}
+
+func newDNSServers() *dnsServers {
+	return &dnsServers{
+		servers:    map[string]*dnsServer{},
+		containers: map[string]string{},
+		requests:   map[string]map[string]*pb.SetDNSServerRequest{},
+		confs:      map[string]string{},
+	}
+}
+
 func (s *DaemonServer) SetDNSServer(ctx context.Context,
 	req *pb.SetDNSServerRequest) (*empty.Empty, error) {
 	log.Info("SetDNSServer", "request", req)
+
+	if !req.Enable {
+		s.releaseLease(req.Lease)
+		if err := s.recoverDNSServer(ctx, req); err != nil {
+			log.Error(err, "recover dns server")
+			return nil, err
+		}
+		return &empty.Empty{}, nil
+	}
+
+	if len(req.Rules) == 0 {
+		return nil, fmt.Errorf("no rule of dns chaos %s", req.Name)
+	}
+	rules := make([]*dnschaos.Rule, 0, len(req.Rules))
+	for _, in := range req.Rules {
+		rule, err := dnschaos.NewRule(in)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
 	pid, err := s.crClient.GetPidFromContainerID(ctx, req.ContainerId)
 	if err != nil {
 		log.Error(err, "GetPidFromContainerID")
 		return nil, err
 	}
 
-	if req.Enable {
-		// set dns server to the chaos dns server's address
+	// the nameservers of the backup are the upstreams, which are the original ones even if the
+	// resolv.conf has been rewritten before
+	conf, err := runInMountNS(ctx, pid, req.EnterNS, "sh", "-c",
+		fmt.Sprintf("ls %s.chaos.bak >/dev/null 2>&1 || cp %s %s.chaos.bak; cat %s.chaos.bak",
+			DNSServerConfFile, DNSServerConfFile, DNSServerConfFile, DNSServerConfFile))
+	if err != nil {
+		return nil, err
+	}
+	upstreams := dnschaos.Nameservers(conf)
+	if len(upstreams) == 0 {
+		return nil, fmt.Errorf("no nameserver in %s of container %s", DNSServerConfFile, req.ContainerId)
+	}
 
-		if len(req.DnsServer) == 0 {
-			return &empty.Empty{}, fmt.Errorf("invalid set dns server request %v", req)
-		}
+	if err := s.dnsServers.use(pid, req.ContainerId, req.Name, rules, upstreams); err != nil {
+		log.Error(err, "start dns server")
+		return nil, err
+	}
 
-		// backup the /etc/resolv.conf
-		processBuilder := bpm.DefaultProcessBuilder("sh", "-c", fmt.Sprintf("ls %s.chaos.bak || cp %s %s.chaos.bak", DNSServerConfFile, DNSServerConfFile, DNSServerConfFile)).SetContext(ctx)
-		if req.EnterNS {
-			processBuilder = processBuilder.SetNS(pid, bpm.MountNS)
+	// Note: can not replace the /etc/resolv.conf like `mv temp resolv.conf`, will execute with error `Device or resource busy`
+	_, err = runInMountNS(ctx, pid, req.EnterNS, "sh", "-c",
+		fmt.Sprintf(`printf '%%s' "$1" > %s`, DNSServerConfFile), "sh", dnschaos.Rewrite(conf, dnschaos.Address))
+	if err != nil {
+		s.dnsServers.leave(req.ContainerId, req.Name)
+		return nil, err
+	}
+	s.dnsServers.remember(req, conf)
+
+	recovery := &pb.SetDNSServerRequest{
+		ContainerId: req.ContainerId,
+		EnterNS:     req.EnterNS,
+		Name:        req.Name,
+	}
+	s.holdLease(req.Lease, recoveryOf(methodSetDNSServer, recovery))
+
+	return &empty.Empty{}, nil
+}
+
+// recoverDNSServer removes the rules used by the container, and restores the resolv.conf of the container if
+// it doesn't use any rule anymore
+func (s *DaemonServer) recoverDNSServer(ctx context.Context, req *pb.SetDNSServerRequest) error {
+	if s.dnsServers.leave(req.ContainerId, req.Name) {
+		return nil
+	}
+
+	pid, err := s.crClient.GetPidFromContainerID(ctx, req.ContainerId)
+	if err != nil {
+		log.Error(err, "GetPidFromContainerID")
+		return err
+	}
+
+	// the backup is removed, so the resolv.conf is backed up again by the next chaos
+	_, err = runInMountNS(ctx, pid, req.EnterNS, "sh", "-c",
+		fmt.Sprintf("if [ -f %s.chaos.bak ]; then cat %s.chaos.bak > %s && rm %s.chaos.bak; fi",
+			DNSServerConfFile, DNSServerConfFile, DNSServerConfFile, DNSServerConfFile))
+	return err
+}
+
+// restoreDNSServers serves the rules kept by the previous chaos-daemon again, as the resolv.conf of the containers
+// still points to the dns servers. The original resolv.conf is restored if the rules can't be served anymore.
+func (s *DaemonServer) restoreDNSServers(ctx context.Context) error {
+	var state dnsState
+	found, err := readState(s.dnsServers.path, &state)
+	if err != nil || !found {
+		return err
+	}
+
+	// the rules are kept until they are served again or given up, even if chaos-daemon restarts meanwhile
+	s.dnsServers.Lock()
+	for containerID, requests := range state.Requests {
+		s.dnsServers.requests[containerID] = map[string]*pb.SetDNSServerRequest{}
+		for name, req := range requests {
+			s.dnsServers.requests[containerID][name] = req
 		}
+		s.dnsServers.confs[containerID] = state.Confs[containerID]
+	}
+	s.dnsServers.Unlock()
 
-		cmd := processBuilder.Build()
-		output, err := cmd.CombinedOutput()
-		if err != nil {
-			log.Error(err, "execute command error", "command", cmd.String(), "output", output)
-			return nil, encodeOutputToError(output, err)
+	for containerID, requests := range state.Requests {
+		conf := state.Confs[containerID]
+
+		var enterNS bool
+		var lastErr error
+		for name, req := range requests {
+			log.Info("restoring dns rules", "containerID", containerID, "name", name)
+			enterNS = req.EnterNS
+			if lastErr = s.restoreDNSRules(ctx, req, conf); lastErr != nil {
+				break
+			}
 		}
-		if len(output) != 0 {
-			log.Info("command output", "output", string(output))
+		if lastErr == nil {
+			continue
 		}
 
-		// add chaos dns server to the first line of /etc/resolv.conf
-		// Note: can not replace the /etc/resolv.conf like `mv temp resolv.conf`, will execute with error `Device or resource busy`
-		processBuilder = bpm.DefaultProcessBuilder("sh", "-c", fmt.Sprintf("cp %s temp && sed -i 's/.*nameserver.*/nameserver %s/' temp && cat temp > %s", DNSServerConfFile, req.DnsServer, DNSServerConfFile)).SetContext(ctx)
-		if req.EnterNS {
-			processBuilder = processBuilder.SetNS(pid, bpm.MountNS)
+		log.Error(lastErr, "error while restoring dns rules, restoring resolv.conf", "containerID", containerID)
+		for name := range requests {
+			s.dnsServers.leave(containerID, name)
 		}
+		if err := s.restoreResolvConf(ctx, containerID, enterNS, conf); err != nil {
+			log.Error(err, "error while restoring resolv.conf", "containerID", containerID)
+		}
+	}
 
-		cmd = processBuilder.Build()
-		output, err = cmd.CombinedOutput()
+	return nil
+}
+
+// restoreDNSRules sets the rules of the request again. The backup of the resolv.conf is recreated from the
+// original one if it's missing, so the dns server never forwards the queries to itself.
+func (s *DaemonServer) restoreDNSRules(ctx context.Context, req *pb.SetDNSServerRequest, conf string) error {
+	pid, err := s.crClient.GetPidFromContainerID(ctx, req.ContainerId)
+	if err != nil {
+		return err
+	}
+
+	if len(conf) > 0 {
+		_, err = runInMountNS(ctx, pid, req.EnterNS, "sh", "-c",
+			fmt.Sprintf(`[ -f %s.chaos.bak ] || printf '%%s' "$1" > %s.chaos.bak`, DNSServerConfFile, DNSServerConfFile), "sh", conf)
 		if err != nil {
-			log.Error(err, "execute command error", "command", cmd.String(), "output", output)
-			return nil, encodeOutputToError(output, err)
-		}
-		if len(output) != 0 {
-			log.Info("command output", "output", string(output))
+			return err
 		}
+	}
+
+	_, err = s.SetDNSServer(ctx, req)
+	return err
+}
+
+// restoreResolvConf restores the original resolv.conf of the container, which is read from the backup if it's unknown
+func (s *DaemonServer) restoreResolvConf(ctx context.Context, containerID string, enterNS bool, conf string) error {
+	if len(conf) == 0 {
+		return s.recoverDNSServer(ctx, &pb.SetDNSServerRequest{ContainerId: containerID, EnterNS: enterNS})
+	}
+
+	pid, err := s.crClient.GetPidFromContainerID(ctx, containerID)
+	if err != nil {
+		return err
+	}
+
+	_, err = runInMountNS(ctx, pid, enterNS, "sh", "-c",
+		fmt.Sprintf(`printf '%%s' "$1" > %s && rm -f %s.chaos.bak`, DNSServerConfFile, DNSServerConfFile), "sh", conf)
+	return err
+}
+
+// use sets the rules of the name in the dns server of the network namespace of the process for the container,
+// the dns server is started with the upstreams if it doesn't exist
+func (d *dnsServers) use(pid uint32, containerID string, name string, rules []*dnschaos.Rule, upstreams []string) error {
+	ns, err := netns.GetFromPid(int(pid))
+	if err != nil {
+		return err
+	}
+	id := ns.UniqueId()
+
+	d.Lock()
+	defer d.Unlock()
+
+	server, ok := d.servers[id]
+	if ok {
+		ns.Close()
 	} else {
-		// recover the dns server's address
-		processBuilder := bpm.DefaultProcessBuilder("sh", "-c", fmt.Sprintf("ls %s.chaos.bak && cat %s.chaos.bak > %s || true", DNSServerConfFile, DNSServerConfFile, DNSServerConfFile)).SetContext(ctx)
-		if req.EnterNS {
-			processBuilder = processBuilder.SetNS(pid, bpm.MountNS)
+		s, err := dnschaos.Start(ns, upstreams)
+		if err != nil {
+			return err
 		}
+		log.Info("started dns server", "netns", id, "upstreams", upstreams)
 
-		cmd := processBuilder.Build()
-		output, err := cmd.CombinedOutput()
-		if err != nil {
-			log.Error(err, "execute command error", "command", cmd.String(), "output", output)
-			return nil, encodeOutputToError(output, err)
+		server = &dnsServer{
+			Server: s,
+			users:  map[string]map[string]struct{}{},
+		}
+		d.servers[id] = server
+	}
+
+	server.SetRules(name, rules)
+	if server.users[containerID] == nil {
+		server.users[containerID] = map[string]struct{}{}
+	}
+	server.users[containerID][name] = struct{}{}
+	d.containers[containerID] = id
+
+	return nil
+}
+
+// leave removes the rules of the name used by the container, the rules are removed from the dns server if no
+// container uses them, and the dns server is stopped if it has no user. It returns whether the container still
+// uses the rules of other names.
+func (d *dnsServers) leave(containerID string, name string) bool {
+	d.Lock()
+	defer d.Unlock()
+
+	delete(d.requests[containerID], name)
+	if len(d.requests[containerID]) == 0 {
+		delete(d.requests, containerID)
+		delete(d.confs, containerID)
+	}
+	d.save()
+
+	id, ok := d.containers[containerID]
+	if !ok {
+		return false
+	}
+	server := d.servers[id]
+
+	delete(server.users[containerID], name)
+	used := false
+	for _, names := range server.users {
+		if _, ok := names[name]; ok {
+			used = true
 		}
-		if len(output) != 0 {
-			log.Info("command output", "output", string(output))
+	}
+	if !used {
+		server.RemoveRules(name)
+	}
+
+	if len(server.users[containerID]) > 0 {
+		return true
+	}
+	delete(server.users, containerID)
+	delete(d.containers, containerID)
+
+	if len(server.users) == 0 {
+		delete(d.servers, id)
+		if err := server.Close(); err != nil {
+			log.Error(err, "fail to stop dns server", "netns", id)
 		}
+		log.Info("stopped dns server", "netns", id)
 	}
 
-	return &empty.Empty{}, nil
+	return false
+}
+
+// remember keeps the request setting the rules and the original resolv.conf of the container in the state file
+func (d *dnsServers) remember(req *pb.SetDNSServerRequest, conf string) {
+	d.Lock()
+	defer d.Unlock()
+
+	if d.requests[req.ContainerId] == nil {
+		d.requests[req.ContainerId] = map[string]*pb.SetDNSServerRequest{}
+	}
+	d.requests[req.ContainerId][req.Name] = req
+	d.confs[req.ContainerId] = conf
+	d.save()
+}
+
+// save writes the requests and confs to the state file, the caller must hold the lock
+func (d *dnsServers) save() {
+	if len(d.path) == 0 {
+		return
+	}
+
+	err := writeState(d.path, dnsState{Requests: d.requests, Confs: d.confs})
+	if err != nil {
+		log.Error(err, "error while saving the dns rules", "path", d.path)
+	}
+}
+
+// runInMountNS runs the command in the mount namespace of the process, and returns its output
+func runInMountNS(ctx context.Context, pid uint32, enterNS bool, name string, args ...string) (string, error) {
+	processBuilder := bpm.DefaultProcessBuilder(name, args...).SetContext(ctx)
+	if enterNS {
+		processBuilder = processBuilder.SetNS(pid, bpm.MountNS)
+	}
+
+	cmd := processBuilder.Build()
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		log.Error(err, "execute command error", "command", cmd.String(), "output", output)
+		return "", encodeOutputToError(output, err)
+	}
+
+	return string(output), nil
 }
@@ -0,0 +1,96 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/dnschaos"
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+)
+
+func Test_dnsServers_leave(t *testing.T) {
+	g := NewWithT(t)
+
+	udp, err := net.ListenPacket("udp", "127.0.0.1:0")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	tcp, err := net.Listen("tcp", "127.0.0.1:0")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	server := &dnsServer{
+		Server: dnschaos.NewServer(udp, tcp, nil, net.Dial),
+		users: map[string]map[string]struct{}{
+			"c1": {"a": {}, "b": {}},
+			"c2": {"a": {}},
+		},
+	}
+	d := newDNSServers()
+	d.servers["ns"] = server
+	d.containers["c1"] = "ns"
+	d.containers["c2"] = "ns"
+
+	// the container still uses the other rule
+	g.Expect(d.leave("c1", "a")).To(BeTrue())
+	g.Expect(server.users).To(HaveLen(2))
+
+	g.Expect(d.leave("c1", "b")).To(BeFalse())
+	g.Expect(d.containers).NotTo(HaveKey("c1"))
+	g.Expect(d.servers).To(HaveKey("ns"))
+
+	// the server is stopped once it has no user
+	g.Expect(d.leave("c2", "a")).To(BeFalse())
+	g.Expect(d.servers).To(BeEmpty())
+	_, _, err = udp.ReadFrom(make([]byte, 1))
+	g.Expect(err).To(HaveOccurred())
+
+	// the unknown container doesn't use any rule
+	g.Expect(d.leave("c3", "a")).To(BeFalse())
+}
+
+func Test_dnsServers_state(t *testing.T) {
+	g := NewWithT(t)
+
+	dir, err := ioutil.TempDir("", "chaos-daemon")
+	g.Expect(err).To(BeNil())
+	defer os.RemoveAll(dir)
+
+	d := newDNSServers()
+	d.path = filepath.Join(dir, dnsStateFile)
+	conf := "nameserver 10.0.0.10\n"
+	d.remember(&pb.SetDNSServerRequest{ContainerId: "c1", Name: "a", Enable: true}, conf)
+	d.remember(&pb.SetDNSServerRequest{ContainerId: "c1", Name: "b", Enable: true}, conf)
+
+	// the rules and the original resolv.conf are kept until the container leaves all of them
+	d.leave("c1", "a")
+	var state dnsState
+	found, err := readState(d.path, &state)
+	g.Expect(err).To(BeNil())
+	g.Expect(found).To(BeTrue())
+	g.Expect(state.Requests).To(HaveKey("c1"))
+	g.Expect(state.Requests["c1"]).To(HaveLen(1))
+	g.Expect(state.Requests["c1"]["b"].Enable).To(BeTrue())
+	g.Expect(state.Confs).To(HaveKeyWithValue("c1", conf))
+
+	d.leave("c1", "b")
+	state = dnsState{}
+	_, err = readState(d.path, &state)
+	g.Expect(err).To(BeNil())
+	g.Expect(state.Requests).To(BeEmpty())
+	g.Expect(state.Confs).To(BeEmpty())
+}
@@ -0,0 +1,92 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnschaos
+
+import (
+	"net"
+	"runtime"
+
+	"github.com/vishvananda/netns"
+)
+
+// Start starts a server listening on the address in the network namespace, which forwards the queries to the
+// upstreams in the same network namespace. The server takes the ownership of the handle of the namespace.
+func Start(ns netns.NsHandle, upstreams []string) (*Server, error) {
+	address := net.JoinHostPort(Address, dnsPort)
+
+	var udp net.PacketConn
+	var tcp net.Listener
+	err := inNetns(ns, func() error {
+		var err error
+		udp, err = net.ListenPacket("udp", address)
+		if err != nil {
+			return err
+		}
+
+		tcp, err = net.Listen("tcp", address)
+		if err != nil {
+			udp.Close()
+		}
+		return err
+	})
+	if err != nil {
+		ns.Close()
+		return nil, err
+	}
+
+	server := NewServer(udp, tcp, upstreams, func(network, address string) (net.Conn, error) {
+		var conn net.Conn
+		err := inNetns(ns, func() error {
+			var err error
+			conn, err = net.DialTimeout(network, address, forwardTimeout)
+			return err
+		})
+		return conn, err
+	})
+	server.release = func() {
+		ns.Close()
+	}
+	server.Serve()
+
+	return server, nil
+}
+
+// inNetns calls the function in the network namespace. The sockets created by the function stay in the
+// namespace after it returns.
+func inNetns(ns netns.NsHandle, f func() error) error {
+	runtime.LockOSThread()
+
+	origin, err := netns.Get()
+	if err != nil {
+		runtime.UnlockOSThread()
+		return err
+	}
+	defer origin.Close()
+
+	if err := netns.Set(ns); err != nil {
+		runtime.UnlockOSThread()
+		return err
+	}
+
+	err = f()
+
+	// the thread is terminated together with the goroutine if it can't go back to the original namespace
+	if restoreErr := netns.Set(origin); restoreErr != nil {
+		log.Error(restoreErr, "fail to restore the network namespace of the thread")
+		return err
+	}
+	runtime.UnlockOSThread()
+
+	return err
+}
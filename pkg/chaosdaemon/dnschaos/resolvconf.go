@@ -0,0 +1,70 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnschaos
+
+import (
+	"net"
+	"strings"
+)
+
+// dnsPort is the port of the nameservers in resolv.conf, which can't be configured
+const dnsPort = "53"
+
+// Nameservers returns the addresses of the nameservers in the content of resolv.conf, with the port
+func Nameservers(conf string) []string {
+	nameservers := []string{}
+	for _, line := range strings.Split(conf, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != "nameserver" {
+			continue
+		}
+
+		// the zone of a link-local address is kept, e.g. fe80::1%eth0
+		ip := fields[1]
+		if i := strings.IndexByte(ip, '%'); i >= 0 {
+			ip = ip[:i]
+		}
+		if net.ParseIP(ip) == nil {
+			continue
+		}
+		nameservers = append(nameservers, net.JoinHostPort(fields[1], dnsPort))
+	}
+
+	return nameservers
+}
+
+// Rewrite replaces the nameservers in the content of resolv.conf with the nameserver, the search domains
+// and the options are kept
+func Rewrite(conf string, nameserver string) string {
+	lines := []string{}
+	replaced := false
+	for _, line := range strings.Split(conf, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || fields[0] != "nameserver" {
+			lines = append(lines, line)
+			continue
+		}
+
+		if !replaced {
+			lines = append(lines, "nameserver "+nameserver)
+			replaced = true
+		}
+	}
+
+	if !replaced {
+		lines = append([]string{"nameserver " + nameserver}, lines...)
+	}
+
+	return strings.Join(lines, "\n")
+}
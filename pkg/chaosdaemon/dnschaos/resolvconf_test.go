@@ -0,0 +1,44 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnschaos
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+const resolvConf = `# custom dns config
+nameserver 10.96.0.10
+nameserver fe80::1%eth0
+search default.svc.cluster.local svc.cluster.local cluster.local
+nameserver invalid
+options ndots:5`
+
+func TestNameservers(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(Nameservers(resolvConf)).Should(Equal([]string{"10.96.0.10:53", "[fe80::1%eth0]:53"}))
+	g.Expect(Nameservers("search cluster.local")).Should(BeEmpty())
+}
+
+func TestRewrite(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(Rewrite(resolvConf, Address)).Should(Equal(`# custom dns config
+nameserver 127.0.0.153
+search default.svc.cluster.local svc.cluster.local cluster.local
+options ndots:5`))
+	g.Expect(Rewrite("search cluster.local\n", Address)).Should(Equal("nameserver 127.0.0.153\nsearch cluster.local\n"))
+}
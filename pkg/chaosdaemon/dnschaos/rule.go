@@ -0,0 +1,206 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnschaos
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+)
+
+// Action is the fault of the queries matched by a rule
+type Action string
+
+const (
+	// ErrorAction answers the matched queries with SERVFAIL
+	ErrorAction Action = "error"
+	// RandomAction answers the matched queries with random addresses
+	RandomAction Action = "random"
+	// DelayAction forwards the matched queries to the upstreams after the delay
+	DelayAction Action = "delay"
+	// RcodeAction answers the matched queries with the response code
+	RcodeAction Action = "rcode"
+	// TruncateAction answers the matched queries over udp with truncated responses, and forwards the ones
+	// over tcp, so the clients fall back to tcp
+	TruncateAction Action = "truncate"
+	// DropAction doesn't answer the matched queries
+	DropAction Action = "drop"
+	// StaticAction answers the matched queries with the records
+	StaticAction Action = "static"
+)
+
+// rcodes are the response codes supported by the rcode action
+var rcodes = map[string]dnsmessage.RCode{
+	"NXDOMAIN": dnsmessage.RCodeNameError,
+	"SERVFAIL": dnsmessage.RCodeServerFailure,
+	"REFUSED":  dnsmessage.RCodeRefused,
+}
+
+// Rule is a dns chaos action on the queries matched by the patterns
+type Rule struct {
+	Action Action
+
+	// Delay is the added latency of the delay action
+	Delay time.Duration
+	// Rcode is the response code of the rcode action
+	Rcode dnsmessage.RCode
+
+	// A, AAAA and CNAME are the records of the static action, the addresses are the records of the
+	// canonical name if it's set
+	A     []dnsmessage.AResource
+	AAAA  []dnsmessage.AAAAResource
+	CNAME *dnsmessage.CNAMEResource
+
+	// patterns match the names of the queries, all names are matched if it's empty
+	patterns []*regexp.Regexp
+}
+
+// NewRule parses the rule of the request. The patterns support the placeholder ? for a character and the
+// wildcard * at the end of a pattern.
+func NewRule(in *pb.DNSRule) (*Rule, error) {
+	rule := &Rule{Action: Action(in.Action)}
+	switch rule.Action {
+	case ErrorAction, RandomAction, TruncateAction, DropAction:
+	case DelayAction:
+		if in.Delay <= 0 {
+			return nil, fmt.Errorf("the delay of the %s action should be positive", rule.Action)
+		}
+		rule.Delay = time.Duration(in.Delay)
+	case RcodeAction:
+		rcode, ok := rcodes[in.Rcode]
+		if !ok {
+			return nil, fmt.Errorf("unknown rcode %q", in.Rcode)
+		}
+		rule.Rcode = rcode
+	case StaticAction:
+		if err := rule.parseRecords(in); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown dns chaos action %q", in.Action)
+	}
+
+	for _, pattern := range in.Patterns {
+		if strings.Contains(strings.TrimSuffix(pattern, "*"), "*") {
+			return nil, fmt.Errorf("the wildcard * must be at the end of the pattern %q", pattern)
+		}
+
+		expr := regexp.QuoteMeta(strings.ToLower(strings.TrimSuffix(pattern, ".")))
+		expr = strings.ReplaceAll(expr, `\?`, ".")
+		expr = strings.ReplaceAll(expr, `\*`, ".*")
+		re, err := regexp.Compile("^" + expr + "$")
+		if err != nil {
+			return nil, err
+		}
+		rule.patterns = append(rule.patterns, re)
+	}
+
+	return rule, nil
+}
+
+func (r *Rule) parseRecords(in *pb.DNSRule) error {
+	for _, a := range in.A {
+		ip := net.ParseIP(a).To4()
+		if ip == nil {
+			return fmt.Errorf("invalid IPv4 address %q", a)
+		}
+		resource := dnsmessage.AResource{}
+		copy(resource.A[:], ip)
+		r.A = append(r.A, resource)
+	}
+
+	for _, aaaa := range in.Aaaa {
+		ip := net.ParseIP(aaaa)
+		if ip == nil || ip.To4() != nil {
+			return fmt.Errorf("invalid IPv6 address %q", aaaa)
+		}
+		resource := dnsmessage.AAAAResource{}
+		copy(resource.AAAA[:], ip)
+		r.AAAA = append(r.AAAA, resource)
+	}
+
+	if len(in.Cname) > 0 {
+		cname := in.Cname
+		if !strings.HasSuffix(cname, ".") {
+			cname += "."
+		}
+		name, err := dnsmessage.NewName(cname)
+		if err != nil {
+			return err
+		}
+		r.CNAME = &dnsmessage.CNAMEResource{CNAME: name}
+	}
+
+	if len(r.A) == 0 && len(r.AAAA) == 0 && r.CNAME == nil {
+		return fmt.Errorf("no record of the %s action", r.Action)
+	}
+
+	return nil
+}
+
+// Match returns whether the name of the query is matched by the rule, the name is matched
+// case-insensitively with or without the trailing dot
+func (r *Rule) Match(name string) bool {
+	if len(r.patterns) == 0 {
+		return true
+	}
+
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	for _, pattern := range r.patterns {
+		if pattern.MatchString(name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// records returns the records of the static action answering the question. The canonical name is
+// answered for the questions of any type, and the addresses are answered for the questions of their types.
+func (r *Rule) records(question dnsmessage.Question) []dnsmessage.Resource {
+	resources := []dnsmessage.Resource{}
+	name := question.Name
+	if r.CNAME != nil {
+		resources = append(resources, dnsmessage.Resource{
+			Header: dnsmessage.ResourceHeader{Name: name, Type: dnsmessage.TypeCNAME, Class: question.Class},
+			Body:   r.CNAME,
+		})
+		name = r.CNAME.CNAME
+	}
+
+	switch question.Type {
+	case dnsmessage.TypeA:
+		for i := range r.A {
+			resources = append(resources, dnsmessage.Resource{
+				Header: dnsmessage.ResourceHeader{Name: name, Type: dnsmessage.TypeA, Class: question.Class},
+				Body:   &r.A[i],
+			})
+		}
+	case dnsmessage.TypeAAAA:
+		for i := range r.AAAA {
+			resources = append(resources, dnsmessage.Resource{
+				Header: dnsmessage.ResourceHeader{Name: name, Type: dnsmessage.TypeAAAA, Class: question.Class},
+				Body:   &r.AAAA[i],
+			})
+		}
+	}
+
+	return resources
+}
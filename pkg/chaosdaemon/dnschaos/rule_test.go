@@ -0,0 +1,113 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnschaos
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"golang.org/x/net/dns/dnsmessage"
+
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+)
+
+func TestNewRule(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	invalid := []*pb.DNSRule{
+		{Action: "unknown"},
+		{Action: string(ErrorAction), Patterns: []string{"chaos-*.org"}},
+		{Action: string(DelayAction)},
+		{Action: string(RcodeAction), Rcode: "NOERROR"},
+		{Action: string(StaticAction)},
+		{Action: string(StaticAction), A: []string{"fd00::1"}},
+		{Action: string(StaticAction), Aaaa: []string{"10.0.0.1"}},
+	}
+	for _, in := range invalid {
+		_, err := NewRule(in)
+		g.Expect(err).Should(HaveOccurred(), "rule %v", in)
+	}
+
+	rule, err := NewRule(&pb.DNSRule{Action: string(RandomAction), Patterns: []string{"chaos-*"}})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(rule.Action).Should(Equal(RandomAction))
+
+	rule, err = NewRule(&pb.DNSRule{Action: string(DelayAction), Delay: int64(time.Second)})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(rule.Delay).Should(Equal(time.Second))
+
+	rule, err = NewRule(&pb.DNSRule{Action: string(RcodeAction), Rcode: "NXDOMAIN"})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(rule.Rcode).Should(Equal(dnsmessage.RCodeNameError))
+
+	rule, err = NewRule(&pb.DNSRule{Action: string(StaticAction), A: []string{"10.0.0.1"}, Aaaa: []string{"fd00::1"}, Cname: "chaos-mesh.org"})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(rule.A).Should(Equal([]dnsmessage.AResource{{A: [4]byte{10, 0, 0, 1}}}))
+	g.Expect(rule.AAAA).Should(HaveLen(1))
+	g.Expect(rule.CNAME.CNAME.String()).Should(Equal("chaos-mesh.org."))
+}
+
+func TestRuleMatch(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	cases := []struct {
+		patterns []string
+		name     string
+		matched  bool
+	}{
+		{nil, "example.com.", true},
+		{[]string{"google.com"}, "google.com.", true},
+		{[]string{"google.com"}, "Google.COM", true},
+		{[]string{"google.com"}, "www.google.com.", false},
+		{[]string{"github.*"}, "github.com.", true},
+		{[]string{"github.*"}, "github.io", true},
+		{[]string{"github.*"}, "gitlab.com.", false},
+		{[]string{"chaos-mes?.org"}, "chaos-mesh.org.", true},
+		{[]string{"chaos-mes?.org"}, "chaos-mes.org.", false},
+		{[]string{"a.b", "chaos-mes?.org"}, "chaos-mesh.org.", true},
+		// the dots are not wildcards
+		{[]string{"google.com"}, "googleXcom.", false},
+	}
+
+	for _, c := range cases {
+		rule, err := NewRule(&pb.DNSRule{Action: string(ErrorAction), Patterns: c.patterns})
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(rule.Match(c.name)).Should(Equal(c.matched), "patterns %v, name %s", c.patterns, c.name)
+	}
+}
+
+func TestRuleRecords(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	question := func(typ dnsmessage.Type) dnsmessage.Question {
+		return dnsmessage.Question{Name: dnsmessage.MustNewName("example.com."), Type: typ, Class: dnsmessage.ClassINET}
+	}
+
+	rule, err := NewRule(&pb.DNSRule{Action: string(StaticAction), A: []string{"10.0.0.1", "10.0.0.2"}})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	records := rule.records(question(dnsmessage.TypeA))
+	g.Expect(records).Should(HaveLen(2))
+	g.Expect(records[0].Header.Name.String()).Should(Equal("example.com."))
+	g.Expect(rule.records(question(dnsmessage.TypeAAAA))).Should(BeEmpty())
+
+	// the addresses are the records of the canonical name
+	rule, err = NewRule(&pb.DNSRule{Action: string(StaticAction), Aaaa: []string{"fd00::1"}, Cname: "chaos-mesh.org"})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	records = rule.records(question(dnsmessage.TypeAAAA))
+	g.Expect(records).Should(HaveLen(2))
+	g.Expect(records[0].Header.Type).Should(Equal(dnsmessage.TypeCNAME))
+	g.Expect(records[1].Header.Name.String()).Should(Equal("chaos-mesh.org."))
+	g.Expect(rule.records(question(dnsmessage.TypeTXT))).Should(HaveLen(1))
+}
@@ -0,0 +1,407 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnschaos
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+var log = ctrl.Log.WithName("dns-chaos-server")
+
+// Address is the address of the server in the network namespace. It's a loopback address rarely used by
+// other dns servers, as the port of the nameservers in resolv.conf is always 53.
+const Address = "127.0.0.153"
+
+const (
+	// forwardTimeout is the timeout of a query forwarded to an upstream
+	forwardTimeout = 2 * time.Second
+	// idleTimeout is the timeout of a tcp connection without queries
+	idleTimeout = 10 * time.Second
+
+	maxMessageSize = 65535
+)
+
+// DialFunc connects to the address of an upstream on the network, which is udp or tcp
+type DialFunc func(network, address string) (net.Conn, error)
+
+// Server answers the queries matched by the rules, and forwards the other queries to the upstreams
+type Server struct {
+	udp       net.PacketConn
+	tcp       net.Listener
+	upstreams []string
+	dial      DialFunc
+
+	// release is called after the server is closed
+	release func()
+
+	sync.RWMutex
+	rules map[string][]*Rule
+}
+
+// NewServer returns a server on the connections, which forwards the queries to the upstreams in order
+// until one of them answers
+func NewServer(udp net.PacketConn, tcp net.Listener, upstreams []string, dial DialFunc) *Server {
+	return &Server{
+		udp:       udp,
+		tcp:       tcp,
+		upstreams: upstreams,
+		dial:      dial,
+		rules:     map[string][]*Rule{},
+	}
+}
+
+// Upstreams returns the addresses of the upstreams
+func (s *Server) Upstreams() []string {
+	return s.upstreams
+}
+
+// SetRules sets the rules of the name, which are matched in order
+func (s *Server) SetRules(name string, rules []*Rule) {
+	s.Lock()
+	defer s.Unlock()
+
+	s.rules[name] = rules
+}
+
+// RemoveRules removes the rules of the name
+func (s *Server) RemoveRules(name string) {
+	s.Lock()
+	defer s.Unlock()
+
+	delete(s.rules, name)
+}
+
+// Serve serves the queries in the background until the server is closed
+func (s *Server) Serve() {
+	go s.serveUDP()
+	go s.serveTCP()
+}
+
+// Close stops serving the queries
+func (s *Server) Close() error {
+	errUDP := s.udp.Close()
+	errTCP := s.tcp.Close()
+	if s.release != nil {
+		s.release()
+	}
+
+	if errUDP != nil {
+		return errUDP
+	}
+	return errTCP
+}
+
+// match returns the rule matching the name, the rules of different names are matched in the order of
+// the names
+func (s *Server) match(name string) *Rule {
+	s.RLock()
+	defer s.RUnlock()
+
+	names := make([]string, 0, len(s.rules))
+	for name := range s.rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, n := range names {
+		for _, rule := range s.rules[n] {
+			if rule.Match(name) {
+				return rule
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Server) serveUDP() {
+	for {
+		buf := make([]byte, maxMessageSize)
+		n, addr, err := s.udp.ReadFrom(buf)
+		if err != nil {
+			if !isClosed(err) {
+				log.Error(err, "fail to read the query", "network", "udp")
+			}
+			return
+		}
+
+		go func() {
+			response := s.respond("udp", buf[:n])
+			if response == nil {
+				return
+			}
+			if _, err := s.udp.WriteTo(response, addr); err != nil {
+				log.Error(err, "fail to write the response", "network", "udp", "client", addr.String())
+			}
+		}()
+	}
+}
+
+func (s *Server) serveTCP() {
+	for {
+		conn, err := s.tcp.Accept()
+		if err != nil {
+			if !isClosed(err) {
+				log.Error(err, "fail to accept the connection", "network", "tcp")
+			}
+			return
+		}
+
+		go s.serveConn(conn)
+	}
+}
+
+func (s *Server) serveConn(conn net.Conn) {
+	defer conn.Close()
+
+	for {
+		if err := conn.SetDeadline(time.Now().Add(idleTimeout)); err != nil {
+			return
+		}
+
+		query, err := readTCPMessage(conn)
+		if err != nil {
+			return
+		}
+
+		// the query is dropped if it can't be answered, and the client waits for the response until timeout
+		response := s.respond("tcp", query)
+		if response == nil {
+			continue
+		}
+		if err := writeTCPMessage(conn, response); err != nil {
+			log.Error(err, "fail to write the response", "network", "tcp", "client", conn.RemoteAddr().String())
+			return
+		}
+	}
+}
+
+// respond answers the query if its question is matched by a rule, or forwards it to the upstreams.
+// It returns nil if the query can't be answered.
+func (s *Server) respond(network string, query []byte) []byte {
+	var parser dnsmessage.Parser
+	header, err := parser.Start(query)
+	if err != nil {
+		// the query is forwarded as it is, and the upstreams decide how to answer it
+		return s.forwardOrDrop(network, query)
+	}
+
+	question, err := parser.Question()
+	if err != nil {
+		return s.forwardOrDrop(network, query)
+	}
+
+	rule := s.match(question.Name.String())
+	if rule == nil {
+		return s.forwardOrFail(network, query, header, question)
+	}
+
+	var response []byte
+	switch rule.Action {
+	case RandomAction:
+		response, err = answer(header, question, dnsmessage.RCodeSuccess, false, randomResources(question))
+	case DelayAction:
+		time.Sleep(rule.Delay)
+		return s.forwardOrFail(network, query, header, question)
+	case RcodeAction:
+		response, err = answer(header, question, rule.Rcode, false, nil)
+	case TruncateAction:
+		if network == "tcp" {
+			return s.forwardOrFail(network, query, header, question)
+		}
+		response, err = answer(header, question, dnsmessage.RCodeSuccess, true, nil)
+	case DropAction:
+		return nil
+	case StaticAction:
+		response, err = answer(header, question, dnsmessage.RCodeSuccess, false, rule.records(question))
+	default:
+		response, err = answer(header, question, dnsmessage.RCodeServerFailure, false, nil)
+	}
+	if err != nil {
+		log.Error(err, "fail to build the response", "name", question.Name.String())
+		return nil
+	}
+
+	return response
+}
+
+// forwardOrFail forwards the query to the upstreams, and answers it with SERVFAIL if all of them fail
+func (s *Server) forwardOrFail(network string, query []byte, header dnsmessage.Header, question dnsmessage.Question) []byte {
+	response, err := s.forward(network, query)
+	if err != nil {
+		log.Error(err, "fail to forward the query", "name", question.Name.String())
+		response, _ = answer(header, question, dnsmessage.RCodeServerFailure, false, nil)
+	}
+
+	return response
+}
+
+func (s *Server) forwardOrDrop(network string, query []byte) []byte {
+	response, err := s.forward(network, query)
+	if err != nil {
+		log.Error(err, "fail to forward the query")
+		return nil
+	}
+
+	return response
+}
+
+// forward sends the query to the upstreams in order, and returns the first response
+func (s *Server) forward(network string, query []byte) ([]byte, error) {
+	err := errors.New("no upstream")
+	for _, upstream := range s.upstreams {
+		var response []byte
+		response, err = s.exchange(network, upstream, query)
+		if err == nil {
+			return response, nil
+		}
+	}
+
+	return nil, err
+}
+
+func (s *Server) exchange(network string, upstream string, query []byte) ([]byte, error) {
+	conn, err := s.dial(network, upstream)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(forwardTimeout)); err != nil {
+		return nil, err
+	}
+
+	if network == "tcp" {
+		if err := writeTCPMessage(conn, query); err != nil {
+			return nil, err
+		}
+		return readTCPMessage(conn)
+	}
+
+	if _, err := conn.Write(query); err != nil {
+		return nil, err
+	}
+	for {
+		buf := make([]byte, maxMessageSize)
+		n, err := conn.Read(buf)
+		if err != nil {
+			return nil, err
+		}
+		// the responses of other queries are ignored
+		if n >= 2 && len(query) >= 2 && buf[0] == query[0] && buf[1] == query[1] {
+			return buf[:n], nil
+		}
+	}
+}
+
+// answer builds the response of the question with the resources
+func answer(header dnsmessage.Header, question dnsmessage.Question, rcode dnsmessage.RCode, truncated bool, resources []dnsmessage.Resource) ([]byte, error) {
+	builder := dnsmessage.NewBuilder(nil, dnsmessage.Header{
+		ID:                 header.ID,
+		Response:           true,
+		OpCode:             header.OpCode,
+		RecursionDesired:   header.RecursionDesired,
+		RecursionAvailable: true,
+		Truncated:          truncated,
+		RCode:              rcode,
+	})
+	builder.EnableCompression()
+
+	if err := builder.StartQuestions(); err != nil {
+		return nil, err
+	}
+	if err := builder.Question(question); err != nil {
+		return nil, err
+	}
+	if err := builder.StartAnswers(); err != nil {
+		return nil, err
+	}
+
+	for _, resource := range resources {
+		var err error
+		switch body := resource.Body.(type) {
+		case *dnsmessage.AResource:
+			err = builder.AResource(resource.Header, *body)
+		case *dnsmessage.AAAAResource:
+			err = builder.AAAAResource(resource.Header, *body)
+		case *dnsmessage.CNAMEResource:
+			err = builder.CNAMEResource(resource.Header, *body)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return builder.Finish()
+}
+
+// randomResources returns a random address of the question, or nil if the question is not about addresses
+func randomResources(question dnsmessage.Question) []dnsmessage.Resource {
+	header := dnsmessage.ResourceHeader{
+		Name:  question.Name,
+		Type:  question.Type,
+		Class: question.Class,
+	}
+
+	switch question.Type {
+	case dnsmessage.TypeA:
+		body := &dnsmessage.AResource{}
+		rand.Read(body.A[:])
+		return []dnsmessage.Resource{{Header: header, Body: body}}
+	case dnsmessage.TypeAAAA:
+		body := &dnsmessage.AAAAResource{}
+		rand.Read(body.AAAA[:])
+		return []dnsmessage.Resource{{Header: header, Body: body}}
+	}
+
+	return nil
+}
+
+func readTCPMessage(conn net.Conn) ([]byte, error) {
+	var length uint16
+	if err := binary.Read(conn, binary.BigEndian, &length); err != nil {
+		return nil, err
+	}
+
+	message := make([]byte, length)
+	if _, err := io.ReadFull(conn, message); err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+func writeTCPMessage(conn net.Conn, message []byte) error {
+	buf := make([]byte, 2+len(message))
+	binary.BigEndian.PutUint16(buf, uint16(len(message)))
+	copy(buf[2:], message)
+
+	_, err := conn.Write(buf)
+	return err
+}
+
+func isClosed(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Err.Error() == "use of closed network connection"
+}
@@ -0,0 +1,263 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dnschaos
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"golang.org/x/net/dns/dnsmessage"
+
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+)
+
+var upstreamAddress = dnsmessage.AResource{A: [4]byte{10, 0, 0, 1}}
+
+// upstream answers all the queries of addresses with the upstream address
+type upstream struct {
+	udp net.PacketConn
+	tcp net.Listener
+}
+
+func startUpstream(g *WithT) *upstream {
+	udp, err := net.ListenPacket("udp", "127.0.0.1:0")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	tcp, err := net.Listen("tcp", udp.LocalAddr().String())
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	u := &upstream{udp: udp, tcp: tcp}
+	go func() {
+		for {
+			buf := make([]byte, maxMessageSize)
+			n, addr, err := udp.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			udp.WriteTo(u.respond(buf[:n]), addr)
+		}
+	}()
+	go func() {
+		for {
+			conn, err := tcp.Accept()
+			if err != nil {
+				return
+			}
+			query, err := readTCPMessage(conn)
+			if err == nil {
+				writeTCPMessage(conn, u.respond(query))
+			}
+			conn.Close()
+		}
+	}()
+
+	return u
+}
+
+func (u *upstream) respond(query []byte) []byte {
+	var parser dnsmessage.Parser
+	header, _ := parser.Start(query)
+	question, _ := parser.Question()
+	response, _ := answer(header, question, dnsmessage.RCodeSuccess, false, []dnsmessage.Resource{{
+		Header: dnsmessage.ResourceHeader{Name: question.Name, Type: dnsmessage.TypeA, Class: question.Class},
+		Body:   &upstreamAddress,
+	}})
+	return response
+}
+
+func (u *upstream) close() {
+	u.udp.Close()
+	u.tcp.Close()
+}
+
+func startServer(g *WithT, upstreams []string) *Server {
+	udp, err := net.ListenPacket("udp", "127.0.0.1:0")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	tcp, err := net.Listen("tcp", udp.LocalAddr().String())
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	server := NewServer(udp, tcp, upstreams, net.Dial)
+	server.Serve()
+	return server
+}
+
+func newRules(g *WithT, in ...*pb.DNSRule) []*Rule {
+	rules := []*Rule{}
+	for _, r := range in {
+		rule, err := NewRule(r)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+func query(g *WithT, network string, address string, name string, typ dnsmessage.Type) *dnsmessage.Message {
+	builder := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: 42, RecursionDesired: true})
+	g.Expect(builder.StartQuestions()).Should(Succeed())
+	g.Expect(builder.Question(dnsmessage.Question{
+		Name:  dnsmessage.MustNewName(name),
+		Type:  typ,
+		Class: dnsmessage.ClassINET,
+	})).Should(Succeed())
+	message, err := builder.Finish()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	conn, err := net.Dial(network, address)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	defer conn.Close()
+
+	var response []byte
+	if network == "tcp" {
+		g.Expect(writeTCPMessage(conn, message)).Should(Succeed())
+		response, err = readTCPMessage(conn)
+		g.Expect(err).ShouldNot(HaveOccurred())
+	} else {
+		_, err = conn.Write(message)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		response = make([]byte, maxMessageSize)
+		n, err := conn.Read(response)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		response = response[:n]
+	}
+
+	result := &dnsmessage.Message{}
+	g.Expect(result.Unpack(response)).Should(Succeed())
+	g.Expect(result.ID).Should(Equal(uint16(42)))
+	return result
+}
+
+func TestServer(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	upstream := startUpstream(g)
+	defer upstream.close()
+
+	// the first upstream is unreachable
+	closed := startServer(g, nil)
+	closedAddress := closed.udp.LocalAddr().String()
+	g.Expect(closed.Close()).Should(Succeed())
+
+	server := startServer(g, []string{closedAddress, upstream.udp.LocalAddr().String()})
+	defer server.Close()
+	address := server.udp.LocalAddr().String()
+
+	for _, network := range []string{"udp", "tcp"} {
+		response := query(g, network, address, "chaos-mesh.org.", dnsmessage.TypeA)
+		g.Expect(response.RCode).Should(Equal(dnsmessage.RCodeSuccess))
+		g.Expect(response.Answers).Should(HaveLen(1))
+		g.Expect(response.Answers[0].Body).Should(Equal(&upstreamAddress))
+	}
+
+	server.SetRules("error", newRules(g, &pb.DNSRule{Action: string(ErrorAction), Patterns: []string{"chaos-mesh.*"}}))
+	for _, network := range []string{"udp", "tcp"} {
+		response := query(g, network, address, "chaos-mesh.org.", dnsmessage.TypeA)
+		g.Expect(response.RCode).Should(Equal(dnsmessage.RCodeServerFailure))
+		g.Expect(response.Questions).Should(HaveLen(1))
+
+		// the other names are still forwarded
+		response = query(g, network, address, "example.com.", dnsmessage.TypeA)
+		g.Expect(response.Answers).Should(HaveLen(1))
+	}
+
+	server.RemoveRules("error")
+	server.SetRules("random", newRules(g, &pb.DNSRule{Action: string(RandomAction)}))
+	response := query(g, "udp", address, "chaos-mesh.org.", dnsmessage.TypeAAAA)
+	g.Expect(response.RCode).Should(Equal(dnsmessage.RCodeSuccess))
+	g.Expect(response.Answers).Should(HaveLen(1))
+	g.Expect(response.Answers[0].Body).Should(BeAssignableToTypeOf(&dnsmessage.AAAAResource{}))
+	response = query(g, "udp", address, "chaos-mesh.org.", dnsmessage.TypeTXT)
+	g.Expect(response.RCode).Should(Equal(dnsmessage.RCodeSuccess))
+	g.Expect(response.Answers).Should(BeEmpty())
+
+	server.RemoveRules("random")
+	response = query(g, "udp", address, "chaos-mesh.org.", dnsmessage.TypeA)
+	g.Expect(response.Answers[0].Body).Should(Equal(&upstreamAddress))
+}
+
+func TestServerWithoutUpstream(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	server := startServer(g, nil)
+	defer server.Close()
+
+	response := query(g, "udp", server.udp.LocalAddr().String(), "chaos-mesh.org.", dnsmessage.TypeA)
+	g.Expect(response.RCode).Should(Equal(dnsmessage.RCodeServerFailure))
+}
+
+func TestServerActions(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	upstream := startUpstream(g)
+	defer upstream.close()
+
+	server := startServer(g, []string{upstream.udp.LocalAddr().String()})
+	defer server.Close()
+	address := server.udp.LocalAddr().String()
+
+	// the rules are matched in order
+	server.SetRules("actions", newRules(g,
+		&pb.DNSRule{Action: string(DelayAction), Patterns: []string{"delay.*"}, Delay: int64(200 * time.Millisecond)},
+		&pb.DNSRule{Action: string(RcodeAction), Patterns: []string{"nxdomain.*"}, Rcode: "NXDOMAIN"},
+		&pb.DNSRule{Action: string(RcodeAction), Patterns: []string{"refused.*"}, Rcode: "REFUSED"},
+		&pb.DNSRule{Action: string(TruncateAction), Patterns: []string{"truncate.*"}},
+		&pb.DNSRule{Action: string(StaticAction), Patterns: []string{"static.*"}, A: []string{"10.0.0.2"}, Cname: "chaos-mesh.org"},
+		&pb.DNSRule{Action: string(DropAction), Patterns: []string{"drop.*", "static.*"}},
+	))
+
+	start := time.Now()
+	response := query(g, "udp", address, "delay.org.", dnsmessage.TypeA)
+	g.Expect(time.Since(start)).Should(BeNumerically(">=", 200*time.Millisecond))
+	g.Expect(response.Answers[0].Body).Should(Equal(&upstreamAddress))
+
+	response = query(g, "udp", address, "nxdomain.org.", dnsmessage.TypeA)
+	g.Expect(response.RCode).Should(Equal(dnsmessage.RCodeNameError))
+	response = query(g, "tcp", address, "refused.org.", dnsmessage.TypeA)
+	g.Expect(response.RCode).Should(Equal(dnsmessage.RCodeRefused))
+
+	// the truncated response makes the client retry over tcp, which is forwarded
+	response = query(g, "udp", address, "truncate.org.", dnsmessage.TypeA)
+	g.Expect(response.Truncated).Should(BeTrue())
+	g.Expect(response.Answers).Should(BeEmpty())
+	response = query(g, "tcp", address, "truncate.org.", dnsmessage.TypeA)
+	g.Expect(response.Truncated).Should(BeFalse())
+	g.Expect(response.Answers[0].Body).Should(Equal(&upstreamAddress))
+
+	response = query(g, "udp", address, "static.org.", dnsmessage.TypeA)
+	g.Expect(response.RCode).Should(Equal(dnsmessage.RCodeSuccess))
+	g.Expect(response.Answers).Should(HaveLen(2))
+	g.Expect(response.Answers[0].Body).Should(Equal(&dnsmessage.CNAMEResource{CNAME: dnsmessage.MustNewName("chaos-mesh.org.")}))
+	g.Expect(response.Answers[1].Body).Should(Equal(&dnsmessage.AResource{A: [4]byte{10, 0, 0, 2}}))
+
+	// the dropped query is never answered
+	for _, network := range []string{"udp", "tcp"} {
+		conn, err := net.Dial(network, address)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		message, err := (&dnsmessage.Message{
+			Header:    dnsmessage.Header{ID: 42},
+			Questions: []dnsmessage.Question{{Name: dnsmessage.MustNewName("drop.org."), Type: dnsmessage.TypeA, Class: dnsmessage.ClassINET}},
+		}).Pack()
+		g.Expect(err).ShouldNot(HaveOccurred())
+		if network == "tcp" {
+			g.Expect(writeTCPMessage(conn, message)).Should(Succeed())
+		} else {
+			_, err = conn.Write(message)
+			g.Expect(err).ShouldNot(HaveOccurred())
+		}
+		g.Expect(conn.SetReadDeadline(time.Now().Add(200 * time.Millisecond))).Should(Succeed())
+		_, err = conn.Read(make([]byte, maxMessageSize))
+		g.Expect(err).Should(HaveOccurred())
+		conn.Close()
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+)
+
+const (
+	// AutoFirewall chooses the firewall backend by what the host uses
+	AutoFirewall = "auto"
+	// IptablesFirewall sets the rules with the binaries of iptables and ipset
+	IptablesFirewall = "iptables"
+	// NftablesFirewall sets the rules with nftables through netlink
+	NftablesFirewall = "nftables"
+)
+
+// firewall sets the ipsets and the chains in the network namespace of a container.
+// The names of the chains and the ipsets are the same for all backends.
+type firewall interface {
+	// flushIPSet replaces the cidrs of the ipset, and creates it if it doesn't exist
+	flushIPSet(set *pb.IPSet) error
+	// initializeChains creates the chaos chains of all directions and removes the jump rules in them
+	initializeChains() error
+	// protectPorts makes the tcp packets from or to the ports return from the chaos chains before jumping into any
+	// chain, it must be called right after the chains are initialized
+	protectPorts(ports []uint32) error
+	// setChains replaces the rules of the chains and jumps into them from the chaos chain of their direction
+	setChains(chains []*pb.Chain) error
+	// close releases the resources held by the firewall
+	close() error
+}
+
+// resolveFirewall validates the firewall backend and resolves AutoFirewall to the backend used by the host
+func resolveFirewall(backend string) (string, error) {
+	switch backend {
+	case "", IptablesFirewall:
+		return IptablesFirewall, nil
+	case NftablesFirewall:
+		return NftablesFirewall, nil
+	case AutoFirewall:
+		detected := detectFirewall()
+		log.Info("detect firewall backend", "backend", detected)
+		return detected, nil
+	}
+
+	return "", fmt.Errorf("unknown firewall backend %s", backend)
+}
+
+func (s *DaemonServer) buildFirewall(ctx context.Context, enterNS bool, pid uint32) (firewall, error) {
+	if s.firewall == NftablesFirewall {
+		return buildNftablesFirewall(enterNS, pid)
+	}
+
+	return &iptablesFirewall{
+		ctx:     ctx,
+		enterNS: enterNS,
+		pid:     pid,
+	}, nil
+}
+
+// iptablesFirewall sets the rules by executing iptables, ip6tables and ipset
+type iptablesFirewall struct {
+	ctx     context.Context
+	enterNS bool
+	pid     uint32
+}
+
+func (fw *iptablesFirewall) flushIPSet(set *pb.IPSet) error {
+	return flushIPSet(fw.ctx, fw.enterNS, fw.pid, set)
+}
+
+func (fw *iptablesFirewall) initializeChains() error {
+	for _, iptables := range buildIptablesClients(fw.ctx, fw.enterNS, fw.pid) {
+		if err := iptables.initializeEnv(); err != nil {
+			log.Error(err, "error while initializing iptables", "command", iptables.cmd)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (fw *iptablesFirewall) protectPorts(ports []uint32) error {
+	for _, iptables := range buildIptablesClients(fw.ctx, fw.enterNS, fw.pid) {
+		if err := iptables.protectPorts(ports); err != nil {
+			log.Error(err, "error while protecting ports", "command", iptables.cmd)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (fw *iptablesFirewall) setChains(chains []*pb.Chain) error {
+	for _, iptables := range buildIptablesClients(fw.ctx, fw.enterNS, fw.pid) {
+		if err := iptables.setIptablesChains(chains); err != nil {
+			log.Error(err, "error while setting iptables chains", "command", iptables.cmd)
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (fw *iptablesFirewall) close() error {
+	return nil
+}
@@ -0,0 +1,583 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/google/nftables"
+	"github.com/google/nftables/binaryutil"
+	"github.com/google/nftables/expr"
+	"golang.org/x/sys/unix"
+
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/command"
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+)
+
+const (
+	// nftablesTable is the table holding all sets and chains of chaos mesh. The table of
+	// the inet family matches the packets of both IPv4 and IPv6.
+	nftablesTable = "chaos-mesh"
+
+	// legacyIptablesNames lists the tables of the legacy iptables in the network namespace of the host
+	legacyIptablesNames = "/proc/1/net/ip_tables_names"
+	hostNetNS           = "/proc/1/ns/net"
+)
+
+// detectFirewall returns IptablesFirewall if the host uses the legacy iptables, and returns
+// NftablesFirewall if the host has any nftables rule, which includes the rules set by iptables-nft.
+func detectFirewall() string {
+	names, err := ioutil.ReadFile(legacyIptablesNames)
+	if err == nil && len(strings.TrimSpace(string(names))) > 0 {
+		return IptablesFirewall
+	}
+
+	ns, err := os.Open(hostNetNS)
+	if err != nil {
+		log.Error(err, "fail to open the network namespace of the host")
+		return IptablesFirewall
+	}
+	defer ns.Close()
+
+	conn, err := nftables.New(nftables.WithNetNSFd(int(ns.Fd())))
+	if err != nil {
+		log.Error(err, "fail to connect to nftables")
+		return IptablesFirewall
+	}
+	tables, err := conn.ListTables()
+	if err != nil || len(tables) == 0 {
+		return IptablesFirewall
+	}
+
+	return NftablesFirewall
+}
+
+// nftablesFirewall sets the rules through the netlink interface of nftables. The ipsets are
+// the interval sets in the table, and the chains are the regular chains in the table, which
+// are jumped into from the base chains named by command.IptablesChaosChain.
+type nftablesFirewall struct {
+	conn  *nftables.Conn
+	table *nftables.Table
+
+	// netns is the network namespace to set the rules in, which is nil for the current one
+	netns *os.File
+}
+
+func buildNftablesFirewall(enterNS bool, pid uint32) (firewall, error) {
+	if !enterNS {
+		return newNftablesFirewall(nil)
+	}
+
+	netns, err := os.Open(fmt.Sprintf("/proc/%d/ns/net", pid))
+	if err != nil {
+		return nil, err
+	}
+
+	fw, err := newNftablesFirewall(netns)
+	if err != nil {
+		netns.Close()
+		return nil, err
+	}
+
+	return fw, nil
+}
+
+func newNftablesFirewall(netns *os.File) (*nftablesFirewall, error) {
+	var opts []nftables.ConnOption
+	if netns != nil {
+		opts = append(opts, nftables.WithNetNSFd(int(netns.Fd())))
+	}
+
+	conn, err := nftables.New(opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &nftablesFirewall{
+		conn: conn,
+		table: &nftables.Table{
+			Name:   nftablesTable,
+			Family: nftables.TableFamilyINet,
+		},
+		netns: netns,
+	}, nil
+}
+
+func (fw *nftablesFirewall) close() error {
+	if fw.netns == nil {
+		return nil
+	}
+
+	return fw.netns.Close()
+}
+
+// flushIPSet replaces the elements of the sets of both families in one transaction, so
+// there is no need to swap a temporary set like ipset
+func (fw *nftablesFirewall) flushIPSet(set *pb.IPSet) error {
+	fw.conn.AddTable(fw.table)
+
+	v4, v6 := command.SplitIPSet(set)
+	for _, family := range []struct {
+		set     *pb.IPSet
+		keyType nftables.SetDatatype
+	}{
+		{v4, nftables.TypeIPAddr},
+		{v6, nftables.TypeIP6Addr},
+	} {
+		elements, err := nftablesSetElements(family.set.Cidrs)
+		if err != nil {
+			return err
+		}
+
+		s := &nftables.Set{
+			Table:    fw.table,
+			Name:     family.set.Name,
+			Interval: true,
+			KeyType:  family.keyType,
+		}
+		if err := fw.conn.AddSet(s, nil); err != nil {
+			return err
+		}
+		fw.conn.FlushSet(s)
+		if len(elements) > 0 {
+			if err := fw.conn.SetAddElements(s, elements); err != nil {
+				return err
+			}
+		}
+	}
+
+	return fw.conn.Flush()
+}
+
+// initializeChains creates the base chains hooked on input and output, and removes the jump rules in them
+func (fw *nftablesFirewall) initializeChains() error {
+	for _, chain := range fw.addBaseChains() {
+		fw.conn.FlushChain(chain)
+	}
+
+	return fw.conn.Flush()
+}
+
+// protectPorts appends the rules returning from the base chains for the tcp packets of the ports, which are
+// before the jump rules as the base chains have just been initialized
+func (fw *nftablesFirewall) protectPorts(ports []uint32) error {
+	for _, chain := range fw.addBaseChains() {
+		for _, rule := range nftablesProtectRules(ports) {
+			fw.conn.AddRule(&nftables.Rule{
+				Table: fw.table,
+				Chain: chain,
+				Exprs: rule,
+			})
+		}
+	}
+
+	return fw.conn.Flush()
+}
+
+func (fw *nftablesFirewall) setChains(chains []*pb.Chain) error {
+	baseChains := fw.addBaseChains()
+	if err := fw.conn.Flush(); err != nil {
+		return err
+	}
+
+	for _, chain := range chains {
+		rules, err := nftablesRules(chain)
+		if err != nil {
+			return err
+		}
+		baseChain, ok := baseChains[chain.Direction]
+		if !ok {
+			return fmt.Errorf("unknown direction %d", chain.Direction)
+		}
+		jumped, err := fw.jumped(baseChain, chain.Name)
+		if err != nil {
+			return err
+		}
+
+		c := fw.conn.AddChain(&nftables.Chain{
+			Name:  chain.Name,
+			Table: fw.table,
+		})
+		fw.conn.FlushChain(c)
+		for _, rule := range rules {
+			fw.conn.AddRule(&nftables.Rule{
+				Table: fw.table,
+				Chain: c,
+				Exprs: rule,
+			})
+		}
+
+		if !jumped {
+			fw.conn.AddRule(&nftables.Rule{
+				Table: fw.table,
+				Chain: baseChain,
+				Exprs: []expr.Any{
+					&expr.Verdict{Kind: expr.VerdictJump, Chain: chain.Name},
+				},
+			})
+		}
+	}
+
+	return fw.conn.Flush()
+}
+
+// addBaseChains adds the table and the base chains of all directions into the batch
+func (fw *nftablesFirewall) addBaseChains() map[pb.Chain_Direction]*nftables.Chain {
+	fw.conn.AddTable(fw.table)
+
+	policy := nftables.ChainPolicyAccept
+	chains := map[pb.Chain_Direction]*nftables.Chain{}
+	for direction, hook := range map[pb.Chain_Direction]*nftables.ChainHook{
+		pb.Chain_INPUT:  nftables.ChainHookInput,
+		pb.Chain_OUTPUT: nftables.ChainHookOutput,
+	} {
+		chains[direction] = fw.conn.AddChain(&nftables.Chain{
+			Name:     command.IptablesChaosChain(direction.String()),
+			Table:    fw.table,
+			Type:     nftables.ChainTypeFilter,
+			Hooknum:  hook,
+			Priority: nftables.ChainPriorityFilter,
+			Policy:   &policy,
+		})
+	}
+
+	return chains
+}
+
+// jumped returns whether the base chain already jumps into the chain
+func (fw *nftablesFirewall) jumped(baseChain *nftables.Chain, name string) (bool, error) {
+	rules, err := fw.conn.GetRules(fw.table, baseChain)
+	if err != nil {
+		return false, err
+	}
+
+	for _, rule := range rules {
+		for _, e := range rule.Exprs {
+			if verdict, ok := e.(*expr.Verdict); ok && verdict.Kind == expr.VerdictJump && verdict.Chain == name {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// nftablesSetElements converts the cidrs into the elements of an interval set. Every cidr is
+// an interval starting from its first address and ending before the address next to its last one.
+func nftablesSetElements(cidrs []string) ([]nftables.SetElement, error) {
+	var elements []nftables.SetElement
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			ip := net.ParseIP(cidr)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid cidr %s", cidr)
+			}
+			ipNet = &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)}
+		}
+
+		start := ipNet.IP.To4()
+		if start == nil {
+			start = ipNet.IP.To16()
+		}
+		end := make(net.IP, len(start))
+		for i := range start {
+			end[i] = start[i] | ^ipNet.Mask[len(ipNet.Mask)-len(start)+i]
+		}
+
+		elements = append(elements, nftables.SetElement{Key: start})
+		// the interval ending with the last address of the family has no end element
+		if next := nextIP(end); next != nil {
+			elements = append(elements, nftables.SetElement{Key: next, IntervalEnd: true})
+		}
+	}
+
+	return elements, nil
+}
+
+// nextIP returns the address next to the ip, or nil if the ip is the last one
+func nextIP(ip net.IP) net.IP {
+	next := make(net.IP, len(ip))
+	copy(next, ip)
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			return next
+		}
+	}
+
+	return nil
+}
+
+// nftablesFamily describes how the rules match the packets of a family
+type nftablesFamily struct {
+	nfproto byte
+	// offsets of the source and destination addresses in the network header
+	saddrOffset uint32
+	daddrOffset uint32
+	addrLen     uint32
+	icmp        byte
+	setName     func(string) string
+}
+
+var nftablesFamilies = []nftablesFamily{
+	{
+		nfproto:     unix.NFPROTO_IPV4,
+		saddrOffset: 12,
+		daddrOffset: 16,
+		addrLen:     4,
+		icmp:        unix.IPPROTO_ICMP,
+		setName:     func(name string) string { return name },
+	},
+	{
+		nfproto:     unix.NFPROTO_IPV6,
+		saddrOffset: 8,
+		daddrOffset: 24,
+		addrLen:     16,
+		icmp:        unix.IPPROTO_ICMPV6,
+		setName:     command.IPSet6Name,
+	},
+}
+
+// nftablesRules generates the rules of the chain, which match the same packets as the ones
+// generated by command.IptablesRules. As nftables has no multiport match, a rule is generated
+// for every combination of the source and destination ports.
+func nftablesRules(chain *pb.Chain) ([][]expr.Any, error) {
+	var addrOffset func(nftablesFamily) uint32
+	var deviceKey expr.MetaKey
+	if chain.Direction == pb.Chain_INPUT {
+		addrOffset = func(family nftablesFamily) uint32 { return family.saddrOffset }
+		deviceKey = expr.MetaKeyIIFNAME
+	} else if chain.Direction == pb.Chain_OUTPUT {
+		addrOffset = func(family nftablesFamily) uint32 { return family.daddrOffset }
+		deviceKey = expr.MetaKeyOIFNAME
+	} else {
+		return nil, fmt.Errorf("unknown chain direction %d", chain.Direction)
+	}
+
+	if len(chain.TcpFlags) > 0 {
+		return nil, fmt.Errorf("tcp flags are not supported by nftables")
+	}
+
+	verdict, err := nftablesVerdict(chain.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	sourcePorts, destinationPorts := []*portRange{nil}, []*portRange{nil}
+	if len(chain.Protocol) > 0 {
+		if sourcePorts, err = parsePortRanges(chain.SourcePorts); err != nil {
+			return nil, err
+		}
+		if destinationPorts, err = parsePortRanges(chain.DestinationPorts); err != nil {
+			return nil, err
+		}
+	}
+
+	ipsets := chain.Ipsets
+	if len(ipsets) == 0 {
+		// match the packets to any address
+		ipsets = []string{""}
+	}
+
+	var rules [][]expr.Any
+	for _, family := range nftablesFamilies {
+		var protocol []expr.Any
+		if len(chain.Protocol) > 0 {
+			number, err := nftablesProtocol(chain.Protocol, family)
+			if err != nil {
+				return nil, err
+			}
+			protocol = []expr.Any{
+				&expr.Meta{Key: expr.MetaKeyL4PROTO, Register: 1},
+				&expr.Cmp{Op: expr.CmpOpEq, Register: 1, Data: []byte{number}},
+			}
+		}
+
+		for _, ipset := range ipsets {
+			for _, sourcePort := range sourcePorts {
+				for _, destinationPort := range destinationPorts {
+					rule := []expr.Any{
+						&expr.Meta{Key: expr.MetaKeyNFPROTO, Register: 1},
+						&expr.Cmp{Op: expr.CmpOpEq, Register: 1, Data: []byte{family.nfproto}},
+					}
+					if len(ipset) > 0 {
+						rule = append(rule,
+							&expr.Payload{
+								DestRegister: 1,
+								Base:         expr.PayloadBaseNetworkHeader,
+								Offset:       addrOffset(family),
+								Len:          family.addrLen,
+							},
+							&expr.Lookup{SourceRegister: 1, SetName: family.setName(ipset)},
+						)
+					}
+					if len(chain.Device) > 0 {
+						rule = append(rule,
+							&expr.Meta{Key: deviceKey, Register: 1},
+							&expr.Cmp{Op: expr.CmpOpEq, Register: 1, Data: nftablesIfname(chain.Device)},
+						)
+					}
+					rule = append(rule, protocol...)
+					rule = append(rule, sourcePort.match(0)...)
+					rule = append(rule, destinationPort.match(2)...)
+					rules = append(rules, append(rule, verdict...))
+				}
+			}
+		}
+	}
+
+	return rules, nil
+}
+
+// nftablesProtectRules generates the rules returning for the tcp packets from or to the ports, which match the
+// same packets as the ones generated by command.IptablesProtectRules
+func nftablesProtectRules(ports []uint32) [][]expr.Any {
+	var rules [][]expr.Any
+	for _, port := range ports {
+		// the source port is at the offset 0 of the tcp header, and the destination port is at 2
+		for _, offset := range []uint32{0, 2} {
+			rule := []expr.Any{
+				&expr.Meta{Key: expr.MetaKeyL4PROTO, Register: 1},
+				&expr.Cmp{Op: expr.CmpOpEq, Register: 1, Data: []byte{unix.IPPROTO_TCP}},
+			}
+			rule = append(rule, (&portRange{first: uint16(port), last: uint16(port)}).match(offset)...)
+			rules = append(rules, append(rule, &expr.Verdict{Kind: expr.VerdictReturn}))
+		}
+	}
+
+	return rules
+}
+
+// nftablesIfname pads the name of the device with zeros to the size of the interface name in the kernel
+func nftablesIfname(device string) []byte {
+	name := make([]byte, unix.IFNAMSIZ)
+	copy(name, device)
+
+	return name
+}
+
+// nftablesVerdict converts the target of iptables into the statements of nftables
+func nftablesVerdict(target string) ([]expr.Any, error) {
+	fields := strings.Fields(target)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("empty target")
+	}
+
+	switch fields[0] {
+	case "DROP":
+		return []expr.Any{&expr.Verdict{Kind: expr.VerdictDrop}}, nil
+	case "ACCEPT":
+		return []expr.Any{&expr.Verdict{Kind: expr.VerdictAccept}}, nil
+	case "CLASSIFY":
+		// CLASSIFY --set-class MAJOR:MINOR sets the priority of the packet to the class,
+		// whose numbers are hexadecimal like the ones of tc
+		if len(fields) != 3 || fields[1] != "--set-class" {
+			return nil, fmt.Errorf("invalid target %s", target)
+		}
+		class := strings.SplitN(fields[2], ":", 2)
+		if len(class) != 2 {
+			return nil, fmt.Errorf("invalid class %s", fields[2])
+		}
+		major, err := strconv.ParseUint(class[0], 16, 16)
+		if err != nil {
+			return nil, err
+		}
+		minor, err := strconv.ParseUint(class[1], 16, 16)
+		if err != nil {
+			return nil, err
+		}
+
+		return []expr.Any{
+			&expr.Immediate{Register: 1, Data: binaryutil.NativeEndian.PutUint32(uint32(major<<16 | minor))},
+			&expr.Meta{Key: expr.MetaKeyPRIORITY, SourceRegister: true, Register: 1},
+		}, nil
+	}
+
+	return nil, fmt.Errorf("target %s is not supported by nftables", target)
+}
+
+func nftablesProtocol(protocol string, family nftablesFamily) (byte, error) {
+	switch protocol {
+	case "tcp":
+		return unix.IPPROTO_TCP, nil
+	case "udp":
+		return unix.IPPROTO_UDP, nil
+	case "icmp":
+		return family.icmp, nil
+	}
+
+	return 0, fmt.Errorf("unknown protocol %s", protocol)
+}
+
+// portRange is a range of ports, whose first and last ports are included
+type portRange struct {
+	first uint16
+	last  uint16
+}
+
+// parsePortRanges parses the ports in the format of iptables, like "80,8000:8080". It
+// returns a nil range matching any port if the ports are empty.
+func parsePortRanges(ports string) ([]*portRange, error) {
+	if len(ports) == 0 {
+		return []*portRange{nil}, nil
+	}
+
+	var ranges []*portRange
+	for _, port := range strings.Split(ports, ",") {
+		bounds := strings.SplitN(port, ":", 2)
+		first, err := strconv.ParseUint(bounds[0], 10, 16)
+		if err != nil {
+			return nil, err
+		}
+		last := first
+		if len(bounds) == 2 {
+			if last, err = strconv.ParseUint(bounds[1], 10, 16); err != nil {
+				return nil, err
+			}
+		}
+		ranges = append(ranges, &portRange{first: uint16(first), last: uint16(last)})
+	}
+
+	return ranges, nil
+}
+
+// match returns the expressions to match the port at the offset of the transport header
+func (r *portRange) match(offset uint32) []expr.Any {
+	if r == nil {
+		return nil
+	}
+
+	load := &expr.Payload{
+		DestRegister: 1,
+		Base:         expr.PayloadBaseTransportHeader,
+		Offset:       offset,
+		Len:          2,
+	}
+	if r.first == r.last {
+		return []expr.Any{load, &expr.Cmp{Op: expr.CmpOpEq, Register: 1, Data: binaryutil.BigEndian.PutUint16(r.first)}}
+	}
+
+	return []expr.Any{load, &expr.Range{
+		Op:       expr.CmpOpEq,
+		Register: 1,
+		FromData: binaryutil.BigEndian.PutUint16(r.first),
+		ToData:   binaryutil.BigEndian.PutUint16(r.last),
+	}}
+}
@@ -0,0 +1,199 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+
+	"github.com/google/nftables"
+	"github.com/google/nftables/expr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"golang.org/x/sys/unix"
+
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+)
+
+// newNetNS creates a network namespace without switching the current one into it
+func newNetNS() (*os.File, error) {
+	runtime.LockOSThread()
+
+	origin, err := os.Open(fmt.Sprintf("/proc/self/task/%d/ns/net", unix.Gettid()))
+	if err != nil {
+		runtime.UnlockOSThread()
+		return nil, err
+	}
+	defer origin.Close()
+
+	if err := unix.Unshare(unix.CLONE_NEWNET); err != nil {
+		runtime.UnlockOSThread()
+		return nil, err
+	}
+	netns, err := os.Open(fmt.Sprintf("/proc/self/task/%d/ns/net", unix.Gettid()))
+
+	// the thread is left locked and will be terminated if it fails to switch back
+	if err := unix.Setns(int(origin.Fd()), unix.CLONE_NEWNET); err != nil {
+		return nil, err
+	}
+	runtime.UnlockOSThread()
+
+	return netns, err
+}
+
+var _ = Describe("nftables firewall", func() {
+	var fw *nftablesFirewall
+
+	BeforeEach(func() {
+		netns, err := newNetNS()
+		if err != nil {
+			Skip(fmt.Sprintf("fail to create network namespace: %v", err))
+		}
+
+		fw, err = newNftablesFirewall(netns)
+		Expect(err).ToNot(HaveOccurred())
+		if _, err := fw.conn.ListTables(); err != nil {
+			fw.close()
+			Skip(fmt.Sprintf("nftables is not available: %v", err))
+		}
+	})
+
+	AfterEach(func() {
+		if fw != nil {
+			Expect(fw.close()).To(Succeed())
+		}
+	})
+
+	It("should flush ipsets of both families", func() {
+		Expect(fw.flushIPSet(&pb.IPSet{
+			Name:  "set",
+			Cidrs: []string{"10.0.0.0/24", "192.168.1.1/32", "fd00::1/128"},
+		})).To(Succeed())
+
+		set, err := fw.conn.GetSetByName(fw.table, "set")
+		Expect(err).ToNot(HaveOccurred())
+		elements, err := fw.conn.GetSetElements(set)
+		Expect(err).ToNot(HaveOccurred())
+		// every cidr is an interval made of a start and an end element
+		Expect(elements).To(HaveLen(4))
+
+		set6, err := fw.conn.GetSetByName(fw.table, "set6")
+		Expect(err).ToNot(HaveOccurred())
+		elements, err = fw.conn.GetSetElements(set6)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(elements).To(HaveLen(2))
+
+		// flush again with other cidrs, the old ones are removed
+		Expect(fw.flushIPSet(&pb.IPSet{
+			Name:  "set",
+			Cidrs: []string{"172.16.0.1/32"},
+		})).To(Succeed())
+		elements, err = fw.conn.GetSetElements(set)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(elements).To(HaveLen(2))
+		elements, err = fw.conn.GetSetElements(set6)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(elements).To(BeEmpty())
+	})
+
+	It("should set chains", func() {
+		Expect(fw.flushIPSet(&pb.IPSet{Name: "set", Cidrs: []string{"10.0.0.1/32"}})).To(Succeed())
+		Expect(fw.initializeChains()).To(Succeed())
+
+		chains := []*pb.Chain{
+			{
+				Name:             "NETWORK-PARTITION-0",
+				Ipsets:           []string{"set"},
+				Direction:        pb.Chain_OUTPUT,
+				Target:           "DROP",
+				Protocol:         "tcp",
+				DestinationPorts: "80,8000:8080",
+			},
+			{
+				Name:      "TC-TABLES-0",
+				Ipsets:    []string{"set"},
+				Direction: pb.Chain_OUTPUT,
+				Target:    "CLASSIFY --set-class 1:4",
+			},
+		}
+		// setting the chains twice doesn't duplicate the jump rules
+		Expect(fw.setChains(chains)).To(Succeed())
+		Expect(fw.setChains(chains)).To(Succeed())
+
+		list, err := fw.conn.ListChains()
+		Expect(err).ToNot(HaveOccurred())
+		names := map[string]*nftables.Chain{}
+		for _, chain := range list {
+			if chain.Table.Name == nftablesTable {
+				names[chain.Name] = chain
+			}
+		}
+		Expect(names).To(HaveKey("CHAOS-INPUT"))
+		Expect(names).To(HaveKey("CHAOS-OUTPUT"))
+		Expect(names).To(HaveKey("NETWORK-PARTITION-0"))
+		Expect(names).To(HaveKey("TC-TABLES-0"))
+
+		rules, err := fw.conn.GetRules(fw.table, names["CHAOS-OUTPUT"])
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rules).To(HaveLen(2))
+
+		// a rule for every family and every destination port
+		rules, err = fw.conn.GetRules(fw.table, names["NETWORK-PARTITION-0"])
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rules).To(HaveLen(4))
+		verdict, ok := rules[0].Exprs[len(rules[0].Exprs)-1].(*expr.Verdict)
+		Expect(ok).To(BeTrue())
+		Expect(verdict.Kind).To(Equal(expr.VerdictDrop))
+
+		rules, err = fw.conn.GetRules(fw.table, names["TC-TABLES-0"])
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rules).To(HaveLen(2))
+
+		// initializing the chains removes the jump rules
+		Expect(fw.initializeChains()).To(Succeed())
+		rules, err = fw.conn.GetRules(fw.table, names["CHAOS-OUTPUT"])
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rules).To(BeEmpty())
+	})
+
+	It("should protect ports before the jump rules", func() {
+		Expect(fw.initializeChains()).To(Succeed())
+		Expect(fw.protectPorts([]uint32{31767})).To(Succeed())
+		Expect(fw.setChains([]*pb.Chain{{
+			Name:      "NETWORK-PARTITION-0",
+			Direction: pb.Chain_OUTPUT,
+			Target:    "DROP",
+		}})).To(Succeed())
+
+		chains := fw.addBaseChains()
+		rules, err := fw.conn.GetRules(fw.table, chains[pb.Chain_OUTPUT])
+		Expect(err).ToNot(HaveOccurred())
+		// the rules of the source and the destination port, followed by the jump rule
+		Expect(rules).To(HaveLen(3))
+		for i, kind := range []expr.VerdictKind{expr.VerdictReturn, expr.VerdictReturn, expr.VerdictJump} {
+			verdict, ok := rules[i].Exprs[len(rules[i].Exprs)-1].(*expr.Verdict)
+			Expect(ok).To(BeTrue())
+			Expect(verdict.Kind).To(Equal(kind))
+		}
+	})
+
+	It("should reject unsupported targets", func() {
+		Expect(fw.setChains([]*pb.Chain{{
+			Name:      "CHAIN",
+			Direction: pb.Chain_INPUT,
+			Target:    "REJECT",
+		}})).ToNot(Succeed())
+	})
+})
@@ -0,0 +1,140 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/client"
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+)
+
+// hostPid is the init process of the host, chaos-daemon shares the pid namespace with the host
+const hostPid = 1
+
+// hostNetwork keeps the devices in the network namespace of the host which have been set with tcs
+type hostNetwork struct {
+	sync.Mutex
+
+	devices map[string]struct{}
+}
+
+func newHostNetwork() *hostNetwork {
+	return &hostNetwork{
+		devices: map[string]struct{}{},
+	}
+}
+
+// networkTarget returns the pid whose network namespace is the target of the request, and whether to enter
+// the namespace. The container is ignored if the request targets the network namespace of the host.
+func (s *DaemonServer) networkTarget(ctx context.Context, containerID string, enterNS bool, hostNetwork bool) (uint32, bool, error) {
+	if hostNetwork {
+		return hostPid, true, nil
+	}
+
+	pid, err := s.crClient.GetPidFromContainerID(ctx, containerID)
+	return pid, enterNS, err
+}
+
+// holdHostNetwork starts or extends the lease of the faults in the network namespace of the host. The device
+// is flushed on recovery if it's not empty.
+func (s *DaemonServer) holdHostNetwork(device string) {
+	s.hostNetwork.Lock()
+	if len(device) > 0 {
+		s.hostNetwork.devices[device] = struct{}{}
+	}
+	devices := make([]string, 0, len(s.hostNetwork.devices))
+	for device := range s.hostNetwork.devices {
+		devices = append(devices, device)
+	}
+	s.hostNetwork.Unlock()
+
+	// the devices are kept in the recovery, so they are still flushed after chaos-daemon restarts
+	sort.Strings(devices)
+	s.watchdog.hold(client.HostNetworkLease, defaultLeaseTTL, recoveryOf(methodRecoverHostNetwork, devices))
+}
+
+// recoverHostNetwork removes the jump rules of the chaos chains and the tcs in the network namespace of the host
+func (s *DaemonServer) recoverHostNetwork(ctx context.Context) error {
+	s.hostNetwork.Lock()
+	devices := s.hostNetwork.devices
+	s.hostNetwork.devices = map[string]struct{}{}
+	s.hostNetwork.Unlock()
+
+	var lastErr error
+	fw, err := s.buildFirewall(ctx, true, hostPid)
+	if err != nil {
+		log.Error(err, "error while building firewall of the host")
+		lastErr = err
+	} else {
+		if err := fw.initializeChains(); err != nil {
+			log.Error(err, "error while initializing the chains of the host")
+			lastErr = err
+		}
+		fw.close()
+	}
+
+	tcCli := buildTcClient(ctx, true, hostPid)
+	recovered := []string{}
+	for device := range devices {
+		s.tcProfiles.stop(&pb.TcsRequest{Device: device})
+		if err := tcCli.flush(device); err != nil {
+			log.Error(err, "error while flushing the tcs of the host", "device", device)
+			lastErr = err
+			continue
+		}
+		recovered = append(recovered, device)
+	}
+
+	log.Info("recovered the host network", "devices", recovered)
+	return lastErr
+}
+
+// defaultRouteDevice returns the device of the default route in the network namespace of the process
+func defaultRouteDevice(pid uint32) (string, error) {
+	file, err := os.Open(fmt.Sprintf("/proc/%d/net/route", pid))
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	return parseDefaultRoute(file)
+}
+
+func parseDefaultRoute(reader io.Reader) (string, error) {
+	scanner := bufio.NewScanner(reader)
+	for line := 0; scanner.Scan(); line++ {
+		if line < 1 {
+			continue
+		}
+
+		// the fields are the device, destination, gateway and so on, the destination is 0 in the default route
+		fields := strings.Fields(scanner.Text())
+		if len(fields) > 1 && fields[1] == "00000000" {
+			return fields[0], nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+
+	return "", fmt.Errorf("default route not found")
+}
@@ -0,0 +1,36 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func Test_parseDefaultRoute(t *testing.T) {
+	g := NewWithT(t)
+
+	device, err := parseDefaultRoute(strings.NewReader(
+		`Iface	Destination	Gateway 	Flags	RefCnt	Use	Metric	Mask		MTU	Window	IRTT
+cni0	0001F40A	00000000	0001	0	0	0	00FFFFFF	0	0	0
+ens5	00000000	0100000A	0003	0	0	100	00000000	0	0	0
+`))
+	g.Expect(err).To(BeNil())
+	g.Expect(device).To(Equal("ens5"))
+
+	_, err = parseDefaultRoute(strings.NewReader("header\ncni0	0001F40A	00000000	0001	0	0	0	00FFFFFF	0	0	0\n"))
+	g.Expect(err).NotTo(BeNil())
+}
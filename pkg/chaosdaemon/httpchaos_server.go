@@ -25,23 +25,18 @@ import (
 
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
 	"github.com/chaos-mesh/chaos-mesh/pkg/bpm"
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/command"
 	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
 )
 
 const (
-	tproxyBin = "/usr/local/bin/tproxy"
-	pathEnv   = "PATH"
+	pathEnv = "PATH"
 )
 
 type stdioTransport struct {
 	stdio *bpm.Stdio
 }
 
-type tproxyConfig struct {
-	ProxyPorts []uint32                        `json:"proxy_ports"`
-	Rules      []v1alpha1.PodHttpChaosBaseRule `json:"rules"`
-}
-
 func (t stdioTransport) RoundTrip(req *http.Request) (resp *http.Response, err error) {
 	t.stdio.Lock()
 	defer t.stdio.Unlock()
@@ -88,7 +83,7 @@ func (s *DaemonServer) ApplyHttpChaos(ctx context.Context, in *pb.ApplyHttpChaos
 
 	log.Info("the length of actions", "length", len(rules))
 
-	httpChaosSpec := tproxyConfig{
+	httpChaosSpec := command.TproxyConfig{
 		ProxyPorts: append([]uint32{}, in.ProxyPorts...),
 		Rules:      rules,
 	}
@@ -110,13 +105,17 @@ func (s *DaemonServer) ApplyHttpChaos(ctx context.Context, in *pb.ApplyHttpChaos
 		return nil, err
 	}
 
-	log.Info("http chaos applied")
-
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
 		return nil, err
 	}
 
+	// the lease is only held for the config accepted by tproxy
+	if resp.StatusCode == http.StatusOK {
+		log.Info("http chaos applied")
+		s.holdHttpChaos(in, len(rules))
+	}
+
 	return &pb.ApplyHttpChaosResponse{
 		Instance:   int64(in.Instance),
 		StartTime:  in.StartTime,
@@ -125,13 +124,24 @@ func (s *DaemonServer) ApplyHttpChaos(ctx context.Context, in *pb.ApplyHttpChaos
 	}, nil
 }
 
+// holdHttpChaos holds the lease of the request, which kills tproxy once it expires. The lease is released
+// if there is no rule anymore.
+func (s *DaemonServer) holdHttpChaos(in *pb.ApplyHttpChaosRequest, rules int) {
+	if rules == 0 {
+		s.releaseLease(in.Lease)
+		return
+	}
+
+	s.holdLease(in.Lease, recoveryOf(methodKillBackgroundProcess, backgroundProcess{Pid: int(in.Instance), StartTime: in.StartTime}))
+}
+
 func (s *DaemonServer) createHttpChaos(ctx context.Context, in *pb.ApplyHttpChaosRequest) error {
 	pid, err := s.crClient.GetPidFromContainerID(ctx, in.ContainerId)
 	if err != nil {
 		log.Error(err, "error while getting PID")
 		return err
 	}
-	processBuilder := bpm.DefaultProcessBuilder(tproxyBin, "-i", "-vv").
+	processBuilder := bpm.DefaultProcessBuilder(command.Tproxy, command.TproxyArgs()...).
 		EnableLocalMnt().
 		SetIdentifier(in.ContainerId).
 		SetEnv(pathEnv, os.Getenv(pathEnv)).
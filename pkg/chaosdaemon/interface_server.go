@@ -0,0 +1,73 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+)
+
+// ListInterfaces lists the network interfaces in the network namespace of the container
+func (s *DaemonServer) ListInterfaces(ctx context.Context, req *pb.ListInterfacesRequest) (*pb.ListInterfacesResponse, error) {
+	log.Info("list interfaces", "request", req)
+
+	pid, _, err := s.networkTarget(ctx, req.ContainerId, true, req.HostNetwork)
+	if err != nil {
+		log.Error(err, "error while getting PID")
+		return nil, err
+	}
+
+	// the statistics of the devices in the proc filesystem are scoped by the network namespace of the process
+	file, err := os.Open(fmt.Sprintf("/proc/%d/net/dev", pid))
+	if err != nil {
+		log.Error(err, "error while opening the statistics of the devices")
+		return nil, err
+	}
+	defer file.Close()
+
+	interfaces, err := parseNetDev(file)
+	if err != nil {
+		log.Error(err, "error while parsing the statistics of the devices")
+		return nil, err
+	}
+
+	return &pb.ListInterfacesResponse{Interfaces: interfaces}, nil
+}
+
+// parseNetDev parses the names of the devices from the content of /proc/net/dev, which starts with
+// two lines of headers, and is followed by one line for each device like "  eth0: 1024 ..."
+func parseNetDev(reader io.Reader) ([]string, error) {
+	var interfaces []string
+
+	scanner := bufio.NewScanner(reader)
+	for line := 0; scanner.Scan(); line++ {
+		if line < 2 {
+			continue
+		}
+
+		fields := strings.SplitN(scanner.Text(), ":", 2)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("unexpected line %q", scanner.Text())
+		}
+		interfaces = append(interfaces, strings.TrimSpace(fields[0]))
+	}
+
+	return interfaces, scanner.Err()
+}
@@ -0,0 +1,38 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func Test_parseNetDev(t *testing.T) {
+	g := NewWithT(t)
+
+	interfaces, err := parseNetDev(strings.NewReader(
+		`Inter-|   Receive                                                |  Transmit
+ face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed
+    lo:     100       1    0    0    0     0          0         0      100       1    0    0    0     0       0          0
+  eth0:    2048      20    0    0    0     0          0         0     1024      10    0    0    0     0       0          0
+  net1:       0       0    0    0    0     0          0         0        0       0    0    0    0     0       0          0
+`))
+	g.Expect(err).To(BeNil())
+	g.Expect(interfaces).To(Equal([]string{"lo", "eth0", "net1"}))
+
+	_, err = parseNetDev(strings.NewReader("header\nheader\ninvalid\n"))
+	g.Expect(err).NotTo(BeNil())
+}
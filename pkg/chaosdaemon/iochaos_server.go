@@ -19,20 +19,16 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"strings"
 	"time"
 
 	jrpc "github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
 	"github.com/chaos-mesh/chaos-mesh/pkg/bpm"
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/command"
 	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
 )
 
-const (
-	todaBin = "/usr/local/bin/toda"
-)
-
 func (s *DaemonServer) ApplyIOChaos(ctx context.Context, in *pb.ApplyIOChaosRequest) (*pb.ApplyIOChaosResponse, error) {
 	log.Info("applying io chaos", "Request", in)
 
@@ -52,6 +48,7 @@ func (s *DaemonServer) ApplyIOChaos(ctx context.Context, in *pb.ApplyIOChaosRequ
 
 	log.Info("the length of actions", "length", len(actions))
 	if len(actions) == 0 {
+		s.releaseLease(in.Lease)
 		return &pb.ApplyIOChaosResponse{
 			Instance:  0,
 			StartTime: 0,
@@ -64,11 +61,10 @@ func (s *DaemonServer) ApplyIOChaos(ctx context.Context, in *pb.ApplyIOChaosRequ
 		return nil, err
 	}
 
-	// TODO: make this log level configurable
-	args := fmt.Sprintf("--path %s --verbose info", in.Volume)
-	log.Info("executing", "cmd", todaBin+" "+args)
+	args := command.TodaArgs(in.Volume)
+	log.Info("executing", "cmd", command.Render(command.Toda, args...))
 
-	processBuilder := bpm.DefaultProcessBuilder(todaBin, strings.Split(args, " ")...).
+	processBuilder := bpm.DefaultProcessBuilder(command.Toda, args...).
 		EnableLocalMnt().
 		SetIdentifier(in.ContainerId)
 
@@ -121,6 +117,9 @@ func (s *DaemonServer) ApplyIOChaos(ctx context.Context, in *pb.ApplyIOChaosRequ
 		return nil, fmt.Errorf("toda startup takes too long or an error occurs: %s", ret)
 	}
 
+	// toda is killed once the lease expires, which restores the volume
+	s.holdLease(in.Lease, recoveryOf(methodKillBackgroundProcess, backgroundProcess{Pid: cmd.Process.Pid, StartTime: ct}))
+
 	return &pb.ApplyIOChaosResponse{
 		Instance:  int64(cmd.Process.Pid),
 		StartTime: ct,
@@ -15,12 +15,12 @@ package chaosdaemon
 
 import (
 	"context"
-	"fmt"
 	"strings"
 
 	"github.com/golang/protobuf/ptypes/empty"
 
 	"github.com/chaos-mesh/chaos-mesh/pkg/bpm"
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/command"
 	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
 )
 
@@ -33,12 +33,19 @@ const (
 func (s *DaemonServer) FlushIPSets(ctx context.Context, req *pb.IPSetsRequest) (*empty.Empty, error) {
 	log.Info("flush ipset", "request", req)
 
-	pid, err := s.crClient.GetPidFromContainerID(ctx, req.ContainerId)
+	pid, enterNS, err := s.networkTarget(ctx, req.ContainerId, req.EnterNS, req.HostNetwork)
 	if err != nil {
 		log.Error(err, "error while getting PID")
 		return nil, err
 	}
 
+	fw, err := s.buildFirewall(ctx, enterNS, pid)
+	if err != nil {
+		log.Error(err, "error while building firewall")
+		return nil, err
+	}
+	defer fw.close()
+
 	for _, ipset := range req.Ipsets {
 		// All operations on the ipset with the same name should be serialized,
 		// because ipset is not isolated with namespace in linux < 3.12
@@ -50,7 +57,7 @@ func (s *DaemonServer) FlushIPSets(ctx context.Context, req *pb.IPSetsRequest) (
 		// their linux version to 3.12 :(
 		ipset := ipset
 		s.IPSetLocker.Lock(ipset.Name)
-		err := flushIPSet(ctx, req.EnterNS, pid, ipset)
+		err := fw.flushIPSet(ipset)
 		s.IPSetLocker.Unlock(ipset.Name)
 		if err != nil {
 			return nil, err
@@ -60,15 +67,25 @@ func (s *DaemonServer) FlushIPSets(ctx context.Context, req *pb.IPSetsRequest) (
 	return &empty.Empty{}, nil
 }
 
+// flushIPSet flushes the ipsets of both IPv4 and IPv6 with the cidrs of the families
 func flushIPSet(ctx context.Context, enterNS bool, pid uint32, set *pb.IPSet) error {
+	v4, v6 := command.SplitIPSet(set)
+	if err := flushIPSetOfFamily(ctx, enterNS, pid, v4, false); err != nil {
+		return err
+	}
+
+	return flushIPSetOfFamily(ctx, enterNS, pid, v6, true)
+}
+
+func flushIPSetOfFamily(ctx context.Context, enterNS bool, pid uint32, set *pb.IPSet, ipv6 bool) error {
 	name := set.Name
 
 	// If the ipset already exists, the ipset will be renamed to this temp name.
-	tmpName := fmt.Sprintf("%sold", name)
+	tmpName := command.IPSetTmpName(name)
 
 	// the ipset while existing iptables rules are using them can not be deleted,.
 	// so we creates an temp ipset and swap it with existing one.
-	if err := createIPSet(ctx, enterNS, pid, tmpName); err != nil {
+	if err := createIPSet(ctx, enterNS, pid, tmpName, ipv6); err != nil {
 		return err
 	}
 
@@ -84,13 +101,8 @@ func flushIPSet(ctx context.Context, enterNS bool, pid uint32, set *pb.IPSet) er
 	return err
 }
 
-func createIPSet(ctx context.Context, enterNS bool, pid uint32, name string) error {
-	// ipset name cannot be longer than 31 bytes
-	if len(name) > 31 {
-		name = name[:31]
-	}
-
-	processBuilder := bpm.DefaultProcessBuilder("ipset", "create", name, "hash:net").SetContext(ctx)
+func createIPSet(ctx context.Context, enterNS bool, pid uint32, name string, ipv6 bool) error {
+	processBuilder := bpm.DefaultProcessBuilder(command.IPSet, command.IPSetCreateArgs(name, ipv6)...).SetContext(ctx)
 	if enterNS {
 		processBuilder = processBuilder.SetNS(pid, bpm.NetNS)
 	}
@@ -106,7 +118,7 @@ func createIPSet(ctx context.Context, enterNS bool, pid uint32, name string) err
 			return encodeOutputToError(out, err)
 		}
 
-		processBuilder = bpm.DefaultProcessBuilder("ipset", "flush", name).SetContext(ctx)
+		processBuilder = bpm.DefaultProcessBuilder(command.IPSet, "flush", command.IPSetName(name)).SetContext(ctx)
 		if enterNS {
 			processBuilder = processBuilder.SetNS(pid, bpm.NetNS)
 		}
@@ -126,7 +138,7 @@ func createIPSet(ctx context.Context, enterNS bool, pid uint32, name string) err
 
 func addCIDRsToIPSet(ctx context.Context, enterNS bool, pid uint32, name string, cidrs []string) error {
 	for _, cidr := range cidrs {
-		processBuilder := bpm.DefaultProcessBuilder("ipset", "add", name, cidr).SetContext(ctx)
+		processBuilder := bpm.DefaultProcessBuilder(command.IPSet, "add", name, cidr).SetContext(ctx)
 		if enterNS {
 			processBuilder = processBuilder.SetNS(pid, bpm.NetNS)
 		}
@@ -147,7 +159,7 @@ func addCIDRsToIPSet(ctx context.Context, enterNS bool, pid uint32, name string,
 }
 
 func renameIPSet(ctx context.Context, enterNS bool, pid uint32, oldName string, newName string) error {
-	processBuilder := bpm.DefaultProcessBuilder("ipset", "rename", oldName, newName).SetContext(ctx)
+	processBuilder := bpm.DefaultProcessBuilder(command.IPSet, "rename", oldName, newName).SetContext(ctx)
 	if enterNS {
 		processBuilder = processBuilder.SetNS(pid, bpm.NetNS)
 	}
@@ -164,7 +176,7 @@ func renameIPSet(ctx context.Context, enterNS bool, pid uint32, oldName string,
 		}
 
 		// swap the old ipset and the new ipset if the new ipset already exist.
-		processBuilder = bpm.DefaultProcessBuilder("ipset", "swap", oldName, newName).SetContext(ctx)
+		processBuilder = bpm.DefaultProcessBuilder(command.IPSet, "swap", oldName, newName).SetContext(ctx)
 		if enterNS {
 			processBuilder = processBuilder.SetNS(pid, bpm.NetNS)
 		}
@@ -46,7 +46,16 @@ var _ = Describe("ipset server", func() {
 				Expect(args[6]).To(Equal("hash:net"))
 				return exec.Command("echo", "mock command")
 			})()
-			err := createIPSet(context.TODO(), true, 1, "name")
+			err := createIPSet(context.TODO(), true, 1, "name", false)
+			Expect(err).To(BeNil())
+		})
+
+		It("should create the ipset of IPv6", func() {
+			defer mock.With("MockProcessBuild", func(ctx context.Context, cmd string, args ...string) *exec.Cmd {
+				Expect(args[3:]).To(Equal([]string{"ipset", "create", "name6", "hash:net", "family", "inet6"}))
+				return exec.Command("echo", "mock command")
+			})()
+			err := createIPSet(context.TODO(), true, 1, "name6", true)
 			Expect(err).To(BeNil())
 		})
 
@@ -65,7 +74,7 @@ exit 1
 			defer mock.With("MockProcessBuild", func(ctx context.Context, cmd string, args ...string) *exec.Cmd {
 				return exec.Command("/tmp/mockfail.sh", ipsetExistErr)
 			})()
-			err = createIPSet(context.TODO(), true, 1, "name")
+			err = createIPSet(context.TODO(), true, 1, "name", false)
 			Expect(err).To(BeNil())
 		})
 
@@ -80,7 +89,7 @@ exit 1
 			defer mock.With("MockProcessBuild", func(context.Context, string, ...string) *exec.Cmd {
 				return exec.Command("/tmp/mockfail.sh", "fail msg")
 			})()
-			err = createIPSet(context.TODO(), true, 1, "name")
+			err = createIPSet(context.TODO(), true, 1, "name", false)
 			Expect(err).ToNot(BeNil())
 		})
 
@@ -95,7 +104,7 @@ exit 1
 			defer mock.With("MockProcessBuild", func(context.Context, string, ...string) *exec.Cmd {
 				return exec.Command("/tmp/mockfail.sh", ipsetExistErr)
 			})()
-			err = createIPSet(context.TODO(), true, 1, "name")
+			err = createIPSet(context.TODO(), true, 1, "name", false)
 			Expect(err).ToNot(BeNil())
 		})
 	})
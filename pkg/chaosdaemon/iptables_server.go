@@ -15,50 +15,85 @@ package chaosdaemon
 
 import (
 	"context"
-	"fmt"
 	"strings"
 
 	"github.com/golang/protobuf/ptypes/empty"
 
 	"github.com/chaos-mesh/chaos-mesh/pkg/bpm"
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/command"
 	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
 )
 
 const (
-	iptablesCmd = "iptables"
-
 	iptablesChainAlreadyExistErr = "iptables: Chain already exists."
 )
 
 func (s *DaemonServer) SetIptablesChains(ctx context.Context, req *pb.IptablesChainsRequest) (*empty.Empty, error) {
 	log.Info("Set iptables chains", "request", req)
 
-	pid, err := s.crClient.GetPidFromContainerID(ctx, req.ContainerId)
+	pid, enterNS, err := s.networkTarget(ctx, req.ContainerId, req.EnterNS, req.HostNetwork)
 	if err != nil {
 		log.Error(err, "error while getting PID")
 		return nil, err
 	}
+	if req.HostNetwork {
+		s.holdHostNetwork("")
+	} else {
+		s.holdIptablesChains(req)
+	}
 
-	iptables := buildIptablesClient(ctx, req.EnterNS, pid)
-	err = iptables.initializeEnv()
+	fw, err := s.buildFirewall(ctx, enterNS, pid)
 	if err != nil {
-		log.Error(err, "error while initializing iptables")
+		log.Error(err, "error while building firewall")
 		return nil, err
 	}
+	defer fw.close()
 
-	err = iptables.setIptablesChains(req.Chains)
+	err = fw.initializeChains()
 	if err != nil {
-		log.Error(err, "error while setting iptables chains")
+		log.Error(err, "error while initializing chains")
+		return nil, err
+	}
+
+	if req.HostNetwork {
+		err = fw.protectPorts(s.protectedPorts)
+		if err != nil {
+			log.Error(err, "error while protecting ports")
+			return nil, err
+		}
+	}
+
+	err = fw.setChains(req.Chains)
+	if err != nil {
+		log.Error(err, "error while setting chains")
 		return nil, err
 	}
 
 	return &empty.Empty{}, nil
 }
 
+// holdIptablesChains holds the lease of the request, which removes the chains once it expires. The lease
+// is released if the request removes all the chains itself.
+func (s *DaemonServer) holdIptablesChains(req *pb.IptablesChainsRequest) {
+	if len(req.Chains) == 0 {
+		s.releaseLease(req.Lease)
+		return
+	}
+
+	recovery := &pb.IptablesChainsRequest{
+		ContainerId: req.ContainerId,
+		EnterNS:     req.EnterNS,
+	}
+	s.holdLease(req.Lease, recoveryOf(methodSetIptablesChains, recovery))
+}
+
 type iptablesClient struct {
 	ctx     context.Context
 	enterNS bool
 	pid     uint32
+
+	// cmd is the command to operate the iptables, which is iptables or ip6tables
+	cmd string
 }
 
 type iptablesChain struct {
@@ -66,14 +101,43 @@ type iptablesChain struct {
 	Rules []string
 }
 
-func buildIptablesClient(ctx context.Context, enterNS bool, pid uint32) iptablesClient {
+func buildIptablesClient(ctx context.Context, enterNS bool, pid uint32, cmd string) iptablesClient {
 	return iptablesClient{
 		ctx,
 		enterNS,
 		pid,
+		cmd,
 	}
 }
 
+// buildIptablesClients builds the clients of IPv4 and IPv6. The client of IPv6 is omitted if
+// ip6tables is not available in the network namespace.
+func buildIptablesClients(ctx context.Context, enterNS bool, pid uint32) []iptablesClient {
+	clients := []iptablesClient{buildIptablesClient(ctx, enterNS, pid, command.Iptables)}
+
+	ip6tables := buildIptablesClient(ctx, enterNS, pid, command.Ip6tables)
+	if err := ip6tables.probe(); err != nil {
+		log.Info("skip ip6tables because it's not available", "error", err)
+		return clients
+	}
+
+	return append(clients, ip6tables)
+}
+
+// probe checks whether the command is available
+func (iptables *iptablesClient) probe() error {
+	processBuilder := bpm.DefaultProcessBuilder(iptables.cmd, "-w", "-S").SetContext(iptables.ctx)
+	if iptables.enterNS {
+		processBuilder = processBuilder.SetNS(iptables.pid, bpm.NetNS)
+	}
+	out, err := processBuilder.Build().CombinedOutput()
+	if err != nil {
+		return encodeOutputToError(out, err)
+	}
+
+	return nil
+}
+
 func (iptables *iptablesClient) setIptablesChains(chains []*pb.Chain) error {
 	for _, chain := range chains {
 		err := iptables.setIptablesChain(chain)
@@ -86,51 +150,18 @@ func (iptables *iptablesClient) setIptablesChains(chains []*pb.Chain) error {
 }
 
 func (iptables *iptablesClient) setIptablesChain(chain *pb.Chain) error {
-	var matchPart string
-	if chain.Direction == pb.Chain_INPUT {
-		matchPart = "src"
-	} else if chain.Direction == pb.Chain_OUTPUT {
-		matchPart = "dst"
-	} else {
-		return fmt.Errorf("unknown chain direction %d", chain.Direction)
-	}
-
-	protocolAndPort := ""
-	if len(chain.Protocol) > 0 {
-		protocolAndPort += fmt.Sprintf("--protocol %s", chain.Protocol)
-
-		if len(chain.SourcePorts) > 0 {
-			if strings.Contains(chain.SourcePorts, ",") {
-				protocolAndPort += fmt.Sprintf(" -m multiport --source-ports %s", chain.SourcePorts)
-			} else {
-				protocolAndPort += fmt.Sprintf(" --source-port %s", chain.SourcePorts)
-			}
-		}
+	chain = command.ChainFor(iptables.cmd, chain)
 
-		if len(chain.DestinationPorts) > 0 {
-			if strings.Contains(chain.DestinationPorts, ",") {
-				protocolAndPort += fmt.Sprintf(" -m multiport --destination-ports %s", chain.DestinationPorts)
-			} else {
-				protocolAndPort += fmt.Sprintf(" --destination-port %s", chain.DestinationPorts)
-			}
-		}
-
-		if len(chain.TcpFlags) > 0 {
-			protocolAndPort += fmt.Sprintf(" --tcp-flags %s", chain.TcpFlags)
-		}
+	rules, err := command.IptablesRules(chain)
+	if err != nil {
+		return err
 	}
-
-	rules := []string{}
-
-	if len(chain.Ipsets) == 0 {
-		rules = append(rules, strings.TrimSpace(fmt.Sprintf("-A %s -j %s -w 5 %s", chain.Name, chain.Target, protocolAndPort)))
+	jump, err := command.IptablesJumpRule(chain)
+	if err != nil {
+		return err
 	}
 
-	for _, ipset := range chain.Ipsets {
-		rules = append(rules, strings.TrimSpace(fmt.Sprintf("-A %s -m set --match-set %s %s -j %s -w 5 %s",
-			chain.Name, ipset, matchPart, chain.Target, protocolAndPort)))
-	}
-	err := iptables.createNewChain(&iptablesChain{
+	err = iptables.createNewChain(&iptablesChain{
 		Name:  chain.Name,
 		Rules: rules,
 	})
@@ -139,28 +170,19 @@ func (iptables *iptablesClient) setIptablesChain(chain *pb.Chain) error {
 	}
 
 	if chain.Direction == pb.Chain_INPUT {
-		err := iptables.ensureRule(&iptablesChain{
-			Name: "CHAOS-INPUT",
-		}, "-A CHAOS-INPUT -j "+chain.Name)
-		if err != nil {
-			return err
-		}
-	} else if chain.Direction == pb.Chain_OUTPUT {
-		iptables.ensureRule(&iptablesChain{
-			Name: "CHAOS-OUTPUT",
-		}, "-A CHAOS-OUTPUT -j "+chain.Name)
-		if err != nil {
-			return err
-		}
-	} else {
-		return fmt.Errorf("unknown direction %d", chain.Direction)
+		return iptables.ensureRule(&iptablesChain{
+			Name: command.IptablesChaosChain("INPUT"),
+		}, jump)
 	}
-	return nil
+
+	return iptables.ensureRule(&iptablesChain{
+		Name: command.IptablesChaosChain("OUTPUT"),
+	}, jump)
 }
 
 func (iptables *iptablesClient) initializeEnv() error {
-	for _, direction := range []string{"INPUT", "OUTPUT"} {
-		chainName := "CHAOS-" + direction
+	for _, direction := range command.IptablesDirections {
+		chainName := command.IptablesChaosChain(direction)
 
 		err := iptables.createNewChain(&iptablesChain{
 			Name:  chainName,
@@ -179,9 +201,26 @@ func (iptables *iptablesClient) initializeEnv() error {
 	return nil
 }
 
+// protectPorts appends the rules returning from the chaos chains for the packets of the ports, which
+// are before the jump rules as the chaos chains have just been initialized
+func (iptables *iptablesClient) protectPorts(ports []uint32) error {
+	for _, direction := range command.IptablesDirections {
+		chain := &iptablesChain{
+			Name: command.IptablesChaosChain(direction),
+		}
+		for _, rule := range command.IptablesProtectRules(direction, ports) {
+			if err := iptables.ensureRule(chain, rule); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 // createNewChain will cover existing chain
 func (iptables *iptablesClient) createNewChain(chain *iptablesChain) error {
-	processBuilder := bpm.DefaultProcessBuilder(iptablesCmd, "-w", "-N", chain.Name).SetContext(iptables.ctx)
+	processBuilder := bpm.DefaultProcessBuilder(iptables.cmd, "-w", "-N", chain.Name).SetContext(iptables.ctx)
 	if iptables.enterNS {
 		processBuilder = processBuilder.SetNS(iptables.pid, bpm.NetNS)
 	}
@@ -218,7 +257,7 @@ func (iptables *iptablesClient) deleteAndWriteRules(chain *iptablesChain) error
 }
 
 func (iptables *iptablesClient) ensureRule(chain *iptablesChain, rule string) error {
-	processBuilder := bpm.DefaultProcessBuilder(iptablesCmd, "-w", "-S", chain.Name).SetContext(iptables.ctx)
+	processBuilder := bpm.DefaultProcessBuilder(iptables.cmd, "-w", "-S", chain.Name).SetContext(iptables.ctx)
 	if iptables.enterNS {
 		processBuilder = processBuilder.SetNS(iptables.pid, bpm.NetNS)
 	}
@@ -234,7 +273,7 @@ func (iptables *iptablesClient) ensureRule(chain *iptablesChain, rule string) er
 	}
 
 	// TODO: lock on every container but not on chaos-daemon's `/run/xtables.lock`
-	processBuilder = bpm.DefaultProcessBuilder(iptablesCmd, strings.Split("-w "+rule, " ")...).SetContext(iptables.ctx)
+	processBuilder = bpm.DefaultProcessBuilder(iptables.cmd, strings.Split("-w "+rule, " ")...).SetContext(iptables.ctx)
 	if iptables.enterNS {
 		processBuilder = processBuilder.SetNS(iptables.pid, bpm.NetNS)
 	}
@@ -248,7 +287,7 @@ func (iptables *iptablesClient) ensureRule(chain *iptablesChain, rule string) er
 }
 
 func (iptables *iptablesClient) flushIptablesChain(chain *iptablesChain) error {
-	processBuilder := bpm.DefaultProcessBuilder(iptablesCmd, "-w", "-F", chain.Name).SetContext(iptables.ctx)
+	processBuilder := bpm.DefaultProcessBuilder(iptables.cmd, "-w", "-F", chain.Name).SetContext(iptables.ctx)
 	if iptables.enterNS {
 		processBuilder = processBuilder.SetNS(iptables.pid, bpm.NetNS)
 	}
@@ -23,6 +23,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/command"
 	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/crclients"
 	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/crclients/test"
 	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
@@ -41,7 +42,7 @@ var _ = Describe("iptables server", func() {
 				Expect(args[0]).To(Equal("-n"))
 				Expect(args[1]).To(Equal("/proc/9527/ns/net"))
 				Expect(args[2]).To(Equal("--"))
-				Expect(args[3]).To(Equal(iptablesCmd))
+				Expect(args[3]).To(BeElementOf(command.Iptables, command.Ip6tables))
 				return exec.Command("echo", "-n")
 			})()
 			_, err := s.SetIptablesChains(context.TODO(), &pb.IptablesChainsRequest{
@@ -79,7 +80,7 @@ var _ = Describe("iptables server", func() {
 				Expect(args[0]).To(Equal("-n"))
 				Expect(args[1]).To(Equal("/proc/9527/ns/net"))
 				Expect(args[2]).To(Equal("--"))
-				Expect(args[3]).To(Equal(iptablesCmd))
+				Expect(args[3]).To(BeElementOf(command.Iptables, command.Ip6tables))
 				return exec.Command("echo", "-n")
 			})()
 
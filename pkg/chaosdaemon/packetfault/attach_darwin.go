@@ -0,0 +1,26 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packetfault
+
+import (
+	"fmt"
+)
+
+func Attach(netnsPath string, device string, rules []Rule) error {
+	return fmt.Errorf("packet fault is not supported")
+}
+
+func Delayed(netnsPath string, device string) (bool, error) {
+	return false, nil
+}
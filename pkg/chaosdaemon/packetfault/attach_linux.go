@@ -0,0 +1,247 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packetfault
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+	"golang.org/x/sys/unix"
+
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/command"
+)
+
+// filterPriority is the priority of the filter of the program on the egress of the clsact qdisc
+const filterPriority = 1
+
+// Attach attaches the program injecting the faults of the rules to the outbound traffic of the device,
+// and detaches it if there is no rule. The device is in the network namespace of the path, or the current
+// one if the path is empty.
+func Attach(netnsPath string, device string, rules []Rule) error {
+	handle, err := newHandle(netnsPath)
+	if err != nil {
+		return err
+	}
+	defer handle.Delete()
+
+	link, err := handle.LinkByName(device)
+	if err != nil {
+		return err
+	}
+
+	hasClsact, err := detach(handle, link)
+	if err != nil {
+		return err
+	}
+	if err := setupFq(handle, link, netnsPath+"/"+device, HasDelay(rules)); err != nil {
+		return err
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+
+	var l3Offset int32
+	if link.Attrs().EncapType == "ether" {
+		l3Offset = ethHeaderLength
+	}
+	program, err := Load(rules, l3Offset)
+	if err != nil {
+		return err
+	}
+	// the program is kept by the filter after the file descriptors are closed
+	defer program.Close()
+
+	// the clsact qdisc cannot be replaced, as it doesn't support being changed
+	if !hasClsact {
+		err = handle.QdiscAdd(&netlink.GenericQdisc{
+			QdiscAttrs: netlink.QdiscAttrs{
+				LinkIndex: link.Attrs().Index,
+				Handle:    netlink.MakeHandle(0xffff, 0),
+				Parent:    netlink.HANDLE_CLSACT,
+			},
+			QdiscType: "clsact",
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return handle.FilterAdd(&netlink.BpfFilter{
+		FilterAttrs: netlink.FilterAttrs{
+			LinkIndex: link.Attrs().Index,
+			Parent:    netlink.HANDLE_MIN_EGRESS,
+			Handle:    netlink.MakeHandle(0, 1),
+			Protocol:  unix.ETH_P_ALL,
+			Priority:  filterPriority,
+		},
+		Fd:           program.FD(),
+		Name:         command.PacketFaultFilter,
+		DirectAction: true,
+	})
+}
+
+func newHandle(netnsPath string) (*netlink.Handle, error) {
+	if len(netnsPath) == 0 {
+		return netlink.NewHandle()
+	}
+
+	ns, err := netns.GetFromPath(netnsPath)
+	if err != nil {
+		return nil, err
+	}
+	defer ns.Close()
+
+	return netlink.NewHandleAt(ns)
+}
+
+// detach removes the filter of the program, and keeps the clsact qdisc which may be used by the
+// redirection of the inbound traffic. It returns whether the clsact qdisc exists.
+func detach(handle *netlink.Handle, link netlink.Link) (bool, error) {
+	qdiscs, err := handle.QdiscList(link)
+	if err != nil {
+		return false, err
+	}
+
+	hasClsact := false
+	for _, qdisc := range qdiscs {
+		if qdisc.Type() == "clsact" {
+			hasClsact = true
+		}
+	}
+	if !hasClsact {
+		return false, nil
+	}
+
+	filters, err := handle.FilterList(link, netlink.HANDLE_MIN_EGRESS)
+	if err != nil {
+		return true, err
+	}
+	for _, filter := range filters {
+		if bpf, ok := filter.(*netlink.BpfFilter); ok && bpf.Name == command.PacketFaultFilter {
+			if err := handle.FilterDel(filter); err != nil {
+				return true, err
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// trafficControlQdiscs are the types of the root qdiscs set by the traffic control of chaos-daemon
+var trafficControlQdiscs = map[string]bool{
+	"netem": true,
+	"tbf":   true,
+	"prio":  true,
+}
+
+// originalRoots are the root qdiscs replaced by the fq qdisc, which are restored once the packets are not
+// delayed anymore. The roots created by the kernel are not kept, as they are recreated by deleting the fq qdisc.
+var originalRoots = struct {
+	sync.Mutex
+	qdiscs map[string]netlink.Qdisc
+}{qdiscs: map[string]netlink.Qdisc{}}
+
+// setupFq replaces the root qdisc with the fq qdisc if the packets are delayed, as the fq qdisc holds the
+// packets until their timestamps, and restores the original root qdisc otherwise. It fails rather than
+// replacing the root qdisc set by the traffic control. The key identifies the device for the original root.
+func setupFq(handle *netlink.Handle, link netlink.Link, key string, delay bool) error {
+	root, err := rootQdisc(handle, link)
+	if err != nil {
+		return err
+	}
+
+	originalRoots.Lock()
+	defer originalRoots.Unlock()
+
+	if delay {
+		// the fq qdisc set by others holds the delayed packets as well
+		if root != nil && root.Type() == "fq" {
+			return nil
+		}
+		// the traffic control on the outbound traffic would be wiped out by replacing its root
+		if root != nil && trafficControlQdiscs[root.Type()] {
+			return fmt.Errorf("the packets can't be delayed on %s, whose outbound traffic is controlled by %s qdisc",
+				link.Attrs().Name, root.Type())
+		}
+		err := handle.QdiscReplace(netlink.NewFq(netlink.QdiscAttrs{
+			LinkIndex: link.Attrs().Index,
+			Handle:    netlink.MakeHandle(command.PacketFaultFqMajor, 0),
+			Parent:    netlink.HANDLE_ROOT,
+		}))
+		if err != nil {
+			return err
+		}
+		if root != nil && root.Attrs().Handle != 0 {
+			originalRoots.qdiscs[key] = root
+		}
+		return nil
+	}
+
+	if !isDelayFq(root) {
+		return nil
+	}
+	original, ok := originalRoots.qdiscs[key]
+	delete(originalRoots.qdiscs, key)
+	if ok {
+		// the kernel creates its default root once the fq qdisc is deleted, if the original one can't be restored
+		if err := handle.QdiscReplace(original); err == nil {
+			return nil
+		}
+	}
+	return handle.QdiscDel(root)
+}
+
+// Delayed returns whether the root qdisc of the device is the fq qdisc holding the delayed packets. The device
+// is in the network namespace of the path, or the current one if the path is empty.
+func Delayed(netnsPath string, device string) (bool, error) {
+	handle, err := newHandle(netnsPath)
+	if err != nil {
+		return false, err
+	}
+	defer handle.Delete()
+
+	link, err := handle.LinkByName(device)
+	if err != nil {
+		if _, ok := err.(netlink.LinkNotFoundError); ok {
+			return false, nil
+		}
+		return false, err
+	}
+
+	root, err := rootQdisc(handle, link)
+	if err != nil {
+		return false, err
+	}
+	return isDelayFq(root), nil
+}
+
+func rootQdisc(handle *netlink.Handle, link netlink.Link) (netlink.Qdisc, error) {
+	qdiscs, err := handle.QdiscList(link)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, qdisc := range qdiscs {
+		if qdisc.Attrs().Parent == netlink.HANDLE_ROOT {
+			return qdisc, nil
+		}
+	}
+	return nil, nil
+}
+
+func isDelayFq(qdisc netlink.Qdisc) bool {
+	return qdisc != nil && qdisc.Type() == "fq" && qdisc.Attrs().Handle == netlink.MakeHandle(command.PacketFaultFqMajor, 0)
+}
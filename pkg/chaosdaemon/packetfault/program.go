@@ -0,0 +1,594 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packetfault
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/cilium/ebpf"
+	"github.com/cilium/ebpf/asm"
+
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+)
+
+// The program is a classifier of tc in the direct action mode, which returns one of the actions
+const (
+	actionOK   = 0
+	actionShot = 2
+)
+
+// The offsets of the fields in struct __sk_buff
+const (
+	skbProtocol = 16
+	skbTstamp   = 152
+)
+
+const (
+	ethHeaderLength = 14
+	ethProtocolIPv4 = 0x0800
+	ethProtocolIPv6 = 0x86dd
+
+	tcpFlagsOffset    = 13
+	tcpChecksumOffset = 16
+	udpChecksumOffset = 6
+	udpHeaderLength   = 8
+
+	tcpFlagRST = 0x04
+	tcpFlagACK = 0x10
+
+	// bpfMarkMangled0 is BPF_F_MARK_MANGLED_0, which keeps the udp checksum from becoming zero
+	bpfMarkMangled0 = 0x20
+	// bpfNoPrealloc is BPF_F_NO_PREALLOC, which is required by the LPM tries
+	bpfNoPrealloc = 0x1
+)
+
+// The layout of the stack, relative to the frame pointer
+const (
+	// stackHeader holds the ip header, which is 20 bytes for IPv4 and 40 bytes for IPv6
+	stackHeader = -48
+	// stackKey holds the key to look up the ipsets, which is the prefix length followed by the address
+	stackKey = -72
+	// stackPorts holds the source port and the destination port
+	stackPorts = -80
+	// stackTCP holds the 12th and 13th bytes of the tcp header, which are the data offset and the flags
+	stackTCP = -88
+	// stackPayload holds the beginning of the payload
+	stackPayload = -104
+	// stackOld and stackNew hold the corrupted bytes before and after the corruption
+	stackOld = -120
+	stackNew = -136
+)
+
+// The registers kept across the calls of the helpers
+const (
+	regCtx = asm.R6
+	// regL4 is the offset of the transport header in the packet
+	regL4 = asm.R7
+	// regProtocol is the transport protocol of the packet
+	regProtocol = asm.R8
+	// regMask is the bitmask of the rules whose ipset has the destination address of the packet
+	regMask = asm.R9
+)
+
+const (
+	labelIPv4  = "ipv4"
+	labelIPv6  = "ipv6"
+	labelRules = "rules"
+	labelPass  = "pass"
+)
+
+// lpmKey4 and lpmKey6 are the keys of the LPM tries of IPv4 and IPv6 addresses
+type lpmKey4 struct {
+	Prefixlen uint32
+	Addr      [net.IPv4len]byte
+}
+
+type lpmKey6 struct {
+	Prefixlen uint32
+	Addr      [net.IPv6len]byte
+}
+
+// Program is the loaded program injecting the packet faults, and the maps referred by it
+type Program struct {
+	*ebpf.Program
+
+	maps []*ebpf.Map
+}
+
+// Close releases the program and the maps, which are kept by the kernel as long as the program is attached
+func (p *Program) Close() error {
+	for _, m := range p.maps {
+		m.Close()
+	}
+	return p.Program.Close()
+}
+
+// Load builds and loads the program injecting the faults of the rules into the packets, whose network
+// header starts at l3Offset, i.e. the length of the ethernet header on the ethernet devices, and 0 on the devices without link layer header
+func Load(rules []Rule, l3Offset int32) (*Program, error) {
+	if len(rules) > MaxRules {
+		return nil, fmt.Errorf("at most %d packet faults are allowed on a device", MaxRules)
+	}
+
+	program := &Program{}
+	v4, v6, err := loadIPSets(rules)
+	if err != nil {
+		return nil, err
+	}
+	if v4 != nil {
+		program.maps = []*ebpf.Map{v4, v6}
+	}
+
+	spec := &ebpf.ProgramSpec{
+		Name:         "chaos_packet",
+		Type:         ebpf.SchedCLS,
+		Instructions: buildInstructions(rules, l3Offset, v4, v6),
+		License:      "Apache-2.0",
+	}
+	program.Program, err = ebpf.NewProgram(spec)
+	if err != nil {
+		for _, m := range program.maps {
+			m.Close()
+		}
+		return nil, err
+	}
+
+	return program, nil
+}
+
+// loadIPSets creates the LPM tries of the ipsets of the rules. The value of a cidr is the bitmask of the
+// rules having it or any cidr containing it, so that the longest match of an address has the bits of all
+// the rules having it. The maps are nil if none of the rules has an ipset.
+func loadIPSets(rules []Rule) (*ebpf.Map, *ebpf.Map, error) {
+	values := map[string]uint32{}
+	var cidrs []*net.IPNet
+	for _, rule := range rules {
+		if rule.Cidrs == nil {
+			continue
+		}
+		for _, cidr := range rule.Cidrs {
+			if _, ok := values[cidr.String()]; !ok {
+				values[cidr.String()] = 0
+				cidrs = append(cidrs, cidr)
+			}
+		}
+	}
+	hasIPSet := false
+	for i, rule := range rules {
+		if rule.Cidrs == nil {
+			continue
+		}
+		hasIPSet = true
+		for _, cidr := range cidrs {
+			for _, superset := range rule.Cidrs {
+				if containsCidr(superset, cidr) {
+					values[cidr.String()] |= 1 << uint(i)
+					break
+				}
+			}
+		}
+	}
+	if !hasIPSet {
+		return nil, nil, nil
+	}
+
+	v4, err := newLPMTrie(4+net.IPv4len, len(cidrs))
+	if err != nil {
+		return nil, nil, err
+	}
+	v6, err := newLPMTrie(4+net.IPv6len, len(cidrs))
+	if err != nil {
+		v4.Close()
+		return nil, nil, err
+	}
+
+	for _, cidr := range cidrs {
+		ones, _ := cidr.Mask.Size()
+		value := values[cidr.String()]
+		if ip := cidr.IP.To4(); ip != nil {
+			key := lpmKey4{Prefixlen: uint32(ones)}
+			copy(key.Addr[:], ip)
+			err = v4.Put(key, value)
+		} else {
+			key := lpmKey6{Prefixlen: uint32(ones)}
+			copy(key.Addr[:], cidr.IP.To16())
+			err = v6.Put(key, value)
+		}
+		if err != nil {
+			v4.Close()
+			v6.Close()
+			return nil, nil, err
+		}
+	}
+
+	return v4, v6, nil
+}
+
+func newLPMTrie(keySize uint32, maxEntries int) (*ebpf.Map, error) {
+	if maxEntries == 0 {
+		maxEntries = 1
+	}
+	return ebpf.NewMap(&ebpf.MapSpec{
+		Type:       ebpf.LPMTrie,
+		KeySize:    keySize,
+		ValueSize:  4,
+		MaxEntries: uint32(maxEntries),
+		Flags:      bpfNoPrealloc,
+	})
+}
+
+// containsCidr returns whether the cidr a contains the cidr b
+func containsCidr(a, b *net.IPNet) bool {
+	aOnes, aBits := a.Mask.Size()
+	bOnes, bBits := b.Mask.Size()
+	return aBits == bBits && aOnes <= bOnes && a.Contains(b.IP)
+}
+
+// buildInstructions builds the program, which parses the ip header of the packet, looks up the ipsets with
+// the destination address, and checks the rules one by one. The fault of the first matched rule is injected.
+func buildInstructions(rules []Rule, l3Offset int32, v4, v6 *ebpf.Map) asm.Instructions {
+	b := &builder{}
+	b.emit(
+		asm.Mov.Reg(regCtx, asm.R1),
+		asm.Mov.Imm(regMask, 0),
+		asm.LoadMem(asm.R0, regCtx, skbProtocol, asm.Word),
+		asm.HostTo(asm.BE, asm.R0, asm.Half),
+		asm.JEq.Imm(asm.R0, ethProtocolIPv4, labelIPv4),
+		asm.JEq.Imm(asm.R0, ethProtocolIPv6, labelIPv6),
+		asm.Ja.Label(labelPass),
+	)
+
+	// the length of the IPv4 header is in the lower 4 bits of the first byte, and the fragments
+	// except the first one have no transport header
+	b.mark(labelIPv4)
+	b.emit(asm.Mov.Imm(asm.R2, l3Offset))
+	b.loadBytes(stackHeader, 20, labelPass)
+	lookup := b.newLabel()
+	b.emit(
+		asm.LoadMem(regL4, asm.RFP, stackHeader, asm.Byte),
+		asm.And.Imm(regL4, 0x0f),
+		asm.LSh.Imm(regL4, 2),
+		asm.Add.Imm(regL4, l3Offset),
+		asm.LoadMem(regProtocol, asm.RFP, stackHeader+9, asm.Byte),
+		asm.LoadMem(asm.R1, asm.RFP, stackHeader+6, asm.Half),
+		asm.HostTo(asm.BE, asm.R1, asm.Half),
+		asm.And.Imm(asm.R1, 0x1fff),
+		asm.JEq.Imm(asm.R1, 0, lookup),
+		asm.Mov.Imm(regProtocol, 0),
+	)
+	b.mark(lookup)
+	if v4 != nil {
+		b.emit(
+			asm.StoreImm(asm.RFP, stackKey, 32, asm.Word),
+			asm.LoadMem(asm.R1, asm.RFP, stackHeader+16, asm.Word),
+			asm.StoreMem(asm.RFP, stackKey+4, asm.R1, asm.Word),
+		)
+		b.lookup(v4)
+	}
+	b.emit(asm.Ja.Label(labelRules))
+
+	// the extension headers of IPv6 are not parsed, so the transport protocol of the packets with
+	// them is unknown
+	b.mark(labelIPv6)
+	b.emit(asm.Mov.Imm(asm.R2, l3Offset))
+	b.loadBytes(stackHeader, 40, labelPass)
+	b.emit(
+		asm.Mov.Imm(regL4, l3Offset+40),
+		asm.LoadMem(regProtocol, asm.RFP, stackHeader+6, asm.Byte),
+	)
+	if v6 != nil {
+		b.emit(asm.StoreImm(asm.RFP, stackKey, 128, asm.Word))
+		for i := int16(0); i < net.IPv6len; i += 4 {
+			b.emit(
+				asm.LoadMem(asm.R1, asm.RFP, stackHeader+24+i, asm.Word),
+				asm.StoreMem(asm.RFP, stackKey+4+i, asm.R1, asm.Word),
+			)
+		}
+		b.lookup(v6)
+	}
+
+	b.mark(labelRules)
+	for i, rule := range rules {
+		next := labelPass
+		if i+1 < len(rules) {
+			next = b.newLabel()
+		}
+		b.rule(i, &rule, next)
+		b.mark(next)
+	}
+	if len(rules) == 0 {
+		b.mark(labelPass)
+	}
+	b.emit(asm.Mov.Imm(asm.R0, actionOK), asm.Return())
+
+	return b.insns
+}
+
+// builder appends the instructions, and marks the next instruction with the label
+type builder struct {
+	insns  asm.Instructions
+	label  string
+	labels int
+}
+
+func (b *builder) emit(insns ...asm.Instruction) {
+	for _, ins := range insns {
+		if len(b.label) > 0 {
+			ins = ins.Sym(b.label)
+			b.label = ""
+		}
+		b.insns = append(b.insns, ins)
+	}
+}
+
+// mark marks the next instruction with the label
+func (b *builder) mark(label string) {
+	if len(b.label) > 0 {
+		// an instruction has only one label, so the pending one jumps to the next
+		b.emit(asm.Ja.Label(label))
+	}
+	b.label = label
+}
+
+func (b *builder) newLabel() string {
+	b.labels++
+	return fmt.Sprintf("l%d", b.labels)
+}
+
+// loadBytes loads size bytes at the offset in R2 of the packet onto the stack, and jumps to
+// fail if the packet is too short
+func (b *builder) loadBytes(stack int16, size int32, fail string) {
+	b.emit(
+		asm.Mov.Reg(asm.R1, regCtx),
+		asm.Mov.Reg(asm.R3, asm.RFP),
+		asm.Add.Imm(asm.R3, int32(stack)),
+		asm.Mov.Imm(asm.R4, size),
+		asm.FnSkbLoadBytes.Call(),
+		asm.JNE.Imm(asm.R0, 0, fail),
+	)
+}
+
+// lookup looks up the key on the stack in the map, and sets the mask to the value if it's found
+func (b *builder) lookup(m *ebpf.Map) {
+	b.emit(
+		asm.LoadMapPtr(asm.R1, m.FD()),
+		asm.Mov.Reg(asm.R2, asm.RFP),
+		asm.Add.Imm(asm.R2, stackKey),
+		asm.FnMapLookupElem.Call(),
+		asm.JEq.Imm(asm.R0, 0, labelRules),
+		asm.LoadMem(regMask, asm.R0, 0, asm.Word),
+	)
+}
+
+// payloadOffset sets R2 to the offset of the payload, which follows the tcp header with the length
+// in the upper 4 bits of its 12th byte, or the udp header
+func (b *builder) payloadOffset(rule *Rule, fail string) {
+	if rule.Protocol == protocolUDP {
+		b.emit(
+			asm.Mov.Reg(asm.R2, regL4),
+			asm.Add.Imm(asm.R2, udpHeaderLength),
+		)
+		return
+	}
+
+	b.emit(
+		asm.Mov.Reg(asm.R2, regL4),
+		asm.Add.Imm(asm.R2, tcpFlagsOffset-1),
+	)
+	b.loadBytes(stackTCP, 2, fail)
+	b.emit(
+		asm.LoadMem(asm.R2, asm.RFP, stackTCP, asm.Byte),
+		asm.RSh.Imm(asm.R2, 4),
+		asm.LSh.Imm(asm.R2, 2),
+		asm.Add.Reg(asm.R2, regL4),
+	)
+}
+
+// ports jumps to fail unless the port at the offset of the stack is in any of the ranges
+func (b *builder) ports(stack int16, ranges []PortRange, fail string) {
+	if len(ranges) == 0 {
+		return
+	}
+
+	matched := b.newLabel()
+	b.emit(
+		asm.LoadMem(asm.R1, asm.RFP, stack, asm.Half),
+		asm.HostTo(asm.BE, asm.R1, asm.Half),
+	)
+	for _, r := range ranges {
+		skip := b.newLabel()
+		b.emit(
+			asm.JLT.Imm(asm.R1, int32(r.Start), skip),
+			asm.JLE.Imm(asm.R1, int32(r.End), matched),
+		)
+		b.mark(skip)
+	}
+	b.emit(asm.Ja.Label(fail))
+	b.mark(matched)
+}
+
+// rule checks the conditions of the rule, and injects the fault if all of them are met, otherwise
+// it jumps to next
+func (b *builder) rule(index int, rule *Rule, next string) {
+	if rule.Cidrs != nil {
+		b.emit(
+			asm.Mov.Reg(asm.R1, regMask),
+			asm.And.Imm(asm.R1, int32(uint32(1)<<uint(index))),
+			asm.JEq.Imm(asm.R1, 0, next),
+		)
+	}
+
+	switch rule.Protocol {
+	case 0:
+	case protocolICMP:
+		matched := b.newLabel()
+		b.emit(
+			asm.JEq.Imm(regProtocol, protocolICMP, matched),
+			asm.JNE.Imm(regProtocol, protocolICMPv6, next),
+		)
+		b.mark(matched)
+	default:
+		b.emit(asm.JNE.Imm(regProtocol, int32(rule.Protocol), next))
+	}
+
+	if len(rule.SourcePorts) > 0 || len(rule.DestinationPorts) > 0 {
+		b.emit(asm.Mov.Reg(asm.R2, regL4))
+		b.loadBytes(stackPorts, 4, next)
+		b.ports(stackPorts, rule.SourcePorts, next)
+		b.ports(stackPorts+2, rule.DestinationPorts, next)
+	}
+
+	if rule.TCPFlagsMask != 0 {
+		b.emit(
+			asm.Mov.Reg(asm.R2, regL4),
+			asm.Add.Imm(asm.R2, tcpFlagsOffset-1),
+		)
+		b.loadBytes(stackTCP, 2, next)
+		b.emit(
+			asm.LoadMem(asm.R1, asm.RFP, stackTCP+1, asm.Byte),
+			asm.And.Imm(asm.R1, int32(rule.TCPFlagsMask)),
+			asm.JNE.Imm(asm.R1, int32(rule.TCPFlagsValue), next),
+		)
+	}
+
+	if len(rule.PayloadPrefix) > 0 {
+		b.payloadOffset(rule, next)
+		b.loadBytes(stackPayload, int32(len(rule.PayloadPrefix)), next)
+		for i, value := range rule.PayloadPrefix {
+			b.emit(
+				asm.LoadMem(asm.R1, asm.RFP, stackPayload+int16(i), asm.Byte),
+				asm.JNE.Imm(asm.R1, int32(value), next),
+			)
+		}
+	}
+
+	if rule.Threshold < 10000 {
+		b.emit(
+			asm.FnGetPrandomU32.Call(),
+			asm.Mod.Imm(asm.R0, 10000),
+			asm.JGE.Imm(asm.R0, int32(rule.Threshold), next),
+		)
+	}
+
+	switch rule.Fault {
+	case pb.PacketFault_DROP:
+		b.emit(asm.Mov.Imm(asm.R0, actionShot), asm.Return())
+		return
+	case pb.PacketFault_DELAY:
+		// the fq qdisc holds the packets until their timestamps
+		b.emit(
+			asm.FnKtimeGetNs.Call(),
+			asm.LoadImm(asm.R1, int64(rule.Delay), asm.DWord),
+			asm.Add.Reg(asm.R0, asm.R1),
+			asm.StoreMem(regCtx, skbTstamp, asm.R0, asm.DWord),
+		)
+	case pb.PacketFault_RESET:
+		b.reset()
+	case pb.PacketFault_CORRUPT:
+		b.corrupt(rule)
+	}
+	b.emit(asm.Mov.Imm(asm.R0, actionOK), asm.Return())
+}
+
+// reset replaces the flags of the tcp segment with RST, and keeps the ACK flag to make the segment
+// acceptable to the peer. The checksum is updated with the difference of the flags.
+func (b *builder) reset() {
+	b.emit(
+		asm.Mov.Reg(asm.R2, regL4),
+		asm.Add.Imm(asm.R2, tcpFlagsOffset-1),
+	)
+	b.loadBytes(stackTCP, 2, labelPass)
+	b.emit(
+		asm.LoadMem(regMask, asm.RFP, stackTCP, asm.Half),
+		asm.LoadMem(asm.R1, asm.RFP, stackTCP+1, asm.Byte),
+		asm.And.Imm(asm.R1, tcpFlagACK),
+		asm.Or.Imm(asm.R1, tcpFlagRST),
+		asm.StoreMem(asm.RFP, stackTCP+1, asm.R1, asm.Byte),
+
+		asm.Mov.Reg(asm.R1, regCtx),
+		asm.Mov.Reg(asm.R2, regL4),
+		asm.Add.Imm(asm.R2, tcpFlagsOffset),
+		asm.Mov.Reg(asm.R3, asm.RFP),
+		asm.Add.Imm(asm.R3, stackTCP+1),
+		asm.Mov.Imm(asm.R4, 1),
+		asm.Mov.Imm(asm.R5, 0),
+		asm.FnSkbStoreBytes.Call(),
+		asm.JNE.Imm(asm.R0, 0, labelPass),
+
+		asm.Mov.Reg(asm.R1, regCtx),
+		asm.Mov.Reg(asm.R2, regL4),
+		asm.Add.Imm(asm.R2, tcpChecksumOffset),
+		asm.Mov.Reg(asm.R3, regMask),
+		asm.LoadMem(asm.R4, asm.RFP, stackTCP, asm.Half),
+		asm.Mov.Imm(asm.R5, 2),
+		asm.FnL4CsumReplace.Call(),
+	)
+}
+
+// corrupt overwrites the bytes at the offset of the payload, and updates the checksum with the
+// difference of the bytes, which are padded with zeros to 4 bytes. The packets shorter than the
+// offset of the bytes are not changed.
+func (b *builder) corrupt(rule *Rule) {
+	size := int32(len(rule.CorruptValue))
+	padded := (size + 3) / 4 * 4
+
+	b.payloadOffset(rule, labelPass)
+	b.emit(
+		asm.Add.Imm(asm.R2, int32(rule.CorruptOffset)),
+		asm.Mov.Reg(regMask, asm.R2),
+	)
+	for i := int16(0); i < maxBytes; i += 8 {
+		b.emit(
+			asm.StoreImm(asm.RFP, stackOld+i, 0, asm.DWord),
+			asm.StoreImm(asm.RFP, stackNew+i, 0, asm.DWord),
+		)
+	}
+	b.loadBytes(stackOld, size, labelPass)
+	for i, value := range rule.CorruptValue {
+		b.emit(asm.StoreImm(asm.RFP, stackNew+int16(i), int64(value), asm.Byte))
+	}
+
+	checksumOffset, flags := int32(tcpChecksumOffset), int32(0)
+	if rule.Protocol == protocolUDP {
+		checksumOffset, flags = udpChecksumOffset, bpfMarkMangled0
+	}
+	b.emit(
+		asm.Mov.Reg(asm.R1, regCtx),
+		asm.Mov.Reg(asm.R2, regMask),
+		asm.Mov.Reg(asm.R3, asm.RFP),
+		asm.Add.Imm(asm.R3, stackNew),
+		asm.Mov.Imm(asm.R4, size),
+		asm.Mov.Imm(asm.R5, 0),
+		asm.FnSkbStoreBytes.Call(),
+		asm.JNE.Imm(asm.R0, 0, labelPass),
+
+		asm.Mov.Reg(asm.R1, asm.RFP),
+		asm.Add.Imm(asm.R1, stackOld),
+		asm.Mov.Imm(asm.R2, padded),
+		asm.Mov.Reg(asm.R3, asm.RFP),
+		asm.Add.Imm(asm.R3, stackNew),
+		asm.Mov.Imm(asm.R4, padded),
+		asm.Mov.Imm(asm.R5, 0),
+		asm.FnCsumDiff.Call(),
+
+		asm.Mov.Reg(asm.R4, asm.R0),
+		asm.Mov.Reg(asm.R1, regCtx),
+		asm.Mov.Reg(asm.R2, regL4),
+		asm.Add.Imm(asm.R2, checksumOffset),
+		asm.Mov.Imm(asm.R3, 0),
+		asm.Mov.Imm(asm.R5, flags),
+		asm.FnL4CsumReplace.Call(),
+	)
+}
@@ -0,0 +1,292 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packetfault
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+)
+
+// buildPacket builds an ethernet frame of the transport segment with valid checksums
+func buildPacket(src, dst net.IP, protocol uint8, segment []byte) []byte {
+	var frame, pseudo []byte
+	if ip := dst.To4(); ip != nil {
+		frame = make([]byte, ethHeaderLength+20)
+		binary.BigEndian.PutUint16(frame[12:], ethProtocolIPv4)
+		header := frame[ethHeaderLength:]
+		header[0] = 0x45
+		binary.BigEndian.PutUint16(header[2:], uint16(20+len(segment)))
+		header[8] = 64
+		header[9] = protocol
+		copy(header[12:], src.To4())
+		copy(header[16:], ip)
+		binary.BigEndian.PutUint16(header[10:], checksum(header))
+
+		pseudo = append(pseudo, header[12:20]...)
+		pseudo = append(pseudo, 0, protocol)
+		pseudo = append(pseudo, byte(len(segment)>>8), byte(len(segment)))
+	} else {
+		frame = make([]byte, ethHeaderLength+40)
+		binary.BigEndian.PutUint16(frame[12:], ethProtocolIPv6)
+		header := frame[ethHeaderLength:]
+		header[0] = 0x60
+		binary.BigEndian.PutUint16(header[4:], uint16(len(segment)))
+		header[6] = protocol
+		header[7] = 64
+		copy(header[8:], src.To16())
+		copy(header[24:], dst.To16())
+
+		pseudo = append(pseudo, header[8:40]...)
+		pseudo = append(pseudo, 0, 0, byte(len(segment)>>8), byte(len(segment)), 0, 0, 0, protocol)
+	}
+
+	segment = append([]byte{}, segment...)
+	offset := checksumOffset(protocol)
+	binary.BigEndian.PutUint16(segment[offset:], checksum(append(pseudo, segment...)))
+
+	return append(frame, segment...)
+}
+
+func checksumOffset(protocol uint8) int {
+	switch protocol {
+	case protocolTCP:
+		return tcpChecksumOffset
+	case protocolUDP:
+		return udpChecksumOffset
+	default:
+		return 2
+	}
+}
+
+// checksum computes the internet checksum, which is zero over the data with a valid checksum
+func checksum(data []byte) uint16 {
+	var sum uint32
+	for i := 0; i < len(data); i += 2 {
+		word := uint32(data[i]) << 8
+		if i+1 < len(data) {
+			word |= uint32(data[i+1])
+		}
+		sum += word
+	}
+	for sum > 0xffff {
+		sum = sum&0xffff + sum>>16
+	}
+	return ^uint16(sum)
+}
+
+// verifyChecksum verifies the checksum of the IPv4 tcp or udp segment in the frame
+func verifyChecksum(frame []byte) uint16 {
+	header := frame[ethHeaderLength : ethHeaderLength+20]
+	segment := frame[ethHeaderLength+20:]
+
+	var pseudo []byte
+	pseudo = append(pseudo, header[12:20]...)
+	pseudo = append(pseudo, 0, header[9], byte(len(segment)>>8), byte(len(segment)))
+	return checksum(append(pseudo, segment...))
+}
+
+func tcpSegment(srcPort, dstPort uint16, flags uint8, payload string) []byte {
+	segment := make([]byte, 20)
+	binary.BigEndian.PutUint16(segment[0:], srcPort)
+	binary.BigEndian.PutUint16(segment[2:], dstPort)
+	binary.BigEndian.PutUint32(segment[4:], 1000)
+	segment[12] = 5 << 4
+	segment[13] = flags
+	binary.BigEndian.PutUint16(segment[14:], 65535)
+	return append(segment, payload...)
+}
+
+func udpSegment(srcPort, dstPort uint16, payload string) []byte {
+	segment := make([]byte, 8)
+	binary.BigEndian.PutUint16(segment[0:], srcPort)
+	binary.BigEndian.PutUint16(segment[2:], dstPort)
+	binary.BigEndian.PutUint16(segment[4:], uint16(8+len(payload)))
+	return append(segment, payload...)
+}
+
+func mustCidrs(cidrs ...string) []*net.IPNet {
+	var ipNets []*net.IPNet
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		ipNets = append(ipNets, ipNet)
+	}
+	return ipNets
+}
+
+// loadProgram loads the program of the rules, and skips the test if the program cannot be loaded
+// without privileges
+func loadProgram(t *testing.T, rules []Rule) *Program {
+	program, err := Load(rules, ethHeaderLength)
+	if err != nil {
+		t.Skipf("fail to load the program: %s", err)
+	}
+	t.Cleanup(func() { program.Close() })
+	return program
+}
+
+var (
+	source = net.ParseIP("192.168.0.1")
+	target = net.ParseIP("10.0.0.1")
+	other  = net.ParseIP("10.0.1.1")
+)
+
+func TestDrop(t *testing.T) {
+	g := NewWithT(t)
+
+	program := loadProgram(t, []Rule{{
+		Fault:            pb.PacketFault_DROP,
+		Cidrs:            mustCidrs("10.0.0.0/8"),
+		Protocol:         protocolTCP,
+		DestinationPorts: []PortRange{{Start: 80, End: 80}},
+		Threshold:        10000,
+	}, {
+		// the address matches the longest prefix of the second rule, with the bit of the first rule
+		Fault:     pb.PacketFault_DELAY,
+		Cidrs:     mustCidrs("10.0.0.0/24"),
+		Threshold: 10000,
+		Delay:     1000,
+	}})
+
+	action, _, err := program.Test(buildPacket(source, target, protocolTCP, tcpSegment(1234, 80, 0x18, "")))
+	g.Expect(err).To(BeNil())
+	g.Expect(action).To(Equal(uint32(actionShot)))
+
+	action, _, err = program.Test(buildPacket(source, other, protocolTCP, tcpSegment(1234, 80, 0x18, "")))
+	g.Expect(err).To(BeNil())
+	g.Expect(action).To(Equal(uint32(actionShot)))
+
+	action, _, err = program.Test(buildPacket(source, target, protocolTCP, tcpSegment(1234, 81, 0x18, "")))
+	g.Expect(err).To(BeNil())
+	g.Expect(action).To(Equal(uint32(actionOK)))
+
+	action, _, err = program.Test(buildPacket(source, net.ParseIP("172.16.0.1"), protocolTCP, tcpSegment(1234, 80, 0x18, "")))
+	g.Expect(err).To(BeNil())
+	g.Expect(action).To(Equal(uint32(actionOK)))
+}
+
+func TestDropIPv6(t *testing.T) {
+	g := NewWithT(t)
+
+	program := loadProgram(t, []Rule{{
+		Fault:     pb.PacketFault_DROP,
+		Cidrs:     mustCidrs("fd00::/64"),
+		Protocol:  protocolICMP,
+		Threshold: 10000,
+	}})
+
+	echo := []byte{128, 0, 0, 0, 0, 1, 0, 1}
+	action, _, err := program.Test(buildPacket(net.ParseIP("fd00::1"), net.ParseIP("fd00::2"), protocolICMPv6, echo))
+	g.Expect(err).To(BeNil())
+	g.Expect(action).To(Equal(uint32(actionShot)))
+
+	action, _, err = program.Test(buildPacket(net.ParseIP("fd00::1"), net.ParseIP("fd01::2"), protocolICMPv6, echo))
+	g.Expect(err).To(BeNil())
+	g.Expect(action).To(Equal(uint32(actionOK)))
+}
+
+func TestReset(t *testing.T) {
+	g := NewWithT(t)
+
+	program := loadProgram(t, []Rule{{
+		Fault:         pb.PacketFault_RESET,
+		Protocol:      protocolTCP,
+		SourcePorts:   []PortRange{{Start: 1000, End: 2000}},
+		TCPFlagsMask:  0x0a,
+		TCPFlagsValue: 0x08,
+		Threshold:     10000,
+	}})
+
+	action, out, err := program.Test(buildPacket(source, target, protocolTCP, tcpSegment(1234, 80, 0x18, "hello")))
+	g.Expect(err).To(BeNil())
+	g.Expect(action).To(Equal(uint32(actionOK)))
+	g.Expect(out[ethHeaderLength+20+tcpFlagsOffset]).To(Equal(byte(tcpFlagRST | tcpFlagACK)))
+	g.Expect(verifyChecksum(out)).To(Equal(uint16(0)))
+
+	// the SYN segment is not matched by the flags
+	in := buildPacket(source, target, protocolTCP, tcpSegment(1234, 80, 0x0a, ""))
+	action, out, err = program.Test(in)
+	g.Expect(err).To(BeNil())
+	g.Expect(action).To(Equal(uint32(actionOK)))
+	g.Expect(out).To(Equal(in))
+}
+
+func TestCorrupt(t *testing.T) {
+	g := NewWithT(t)
+
+	program := loadProgram(t, []Rule{{
+		Fault:         pb.PacketFault_CORRUPT,
+		Protocol:      protocolUDP,
+		PayloadPrefix: []byte("GET"),
+		Threshold:     10000,
+		CorruptOffset: 4,
+		CorruptValue:  []byte("XYZ"),
+	}})
+
+	action, out, err := program.Test(buildPacket(source, target, protocolUDP, udpSegment(1234, 80, "GET /index")))
+	g.Expect(err).To(BeNil())
+	g.Expect(action).To(Equal(uint32(actionOK)))
+	g.Expect(string(out[ethHeaderLength+20+udpHeaderLength:])).To(Equal("GET XYZdex"))
+	g.Expect(verifyChecksum(out)).To(Equal(uint16(0)))
+
+	in := buildPacket(source, target, protocolUDP, udpSegment(1234, 80, "PUT /index"))
+	_, out, err = program.Test(in)
+	g.Expect(err).To(BeNil())
+	g.Expect(out).To(Equal(in))
+}
+
+func TestPercent(t *testing.T) {
+	g := NewWithT(t)
+
+	program := loadProgram(t, []Rule{{
+		Fault:     pb.PacketFault_DROP,
+		Threshold: 0,
+	}})
+
+	action, _, err := program.Test(buildPacket(source, target, protocolTCP, tcpSegment(1234, 80, 0x18, "")))
+	g.Expect(err).To(BeNil())
+	g.Expect(action).To(Equal(uint32(actionOK)))
+}
+
+func TestParseRules(t *testing.T) {
+	g := NewWithT(t)
+
+	rules, err := ParseRules([]*pb.PacketFault{{
+		Fault:            pb.PacketFault_DELAY,
+		Ipset:            &pb.IPSet{Name: "chaos", Cidrs: []string{"10.0.0.1/32"}},
+		Protocol:         "tcp",
+		DestinationPorts: "80,8000:8080",
+		Percent:          50,
+		Delay:            1000000,
+	}})
+	g.Expect(err).To(BeNil())
+	g.Expect(rules).To(HaveLen(1))
+	g.Expect(rules[0].Cidrs).To(Equal(mustCidrs("10.0.0.1/32")))
+	g.Expect(rules[0].DestinationPorts).To(Equal([]PortRange{{Start: 80, End: 80}, {Start: 8000, End: 8080}}))
+	g.Expect(rules[0].Threshold).To(Equal(uint32(5000)))
+	g.Expect(HasDelay(rules)).To(BeTrue())
+
+	_, err = ParseRules([]*pb.PacketFault{{Fault: pb.PacketFault_RESET, Protocol: "udp"}})
+	g.Expect(err).NotTo(BeNil())
+
+	_, err = ParseRules([]*pb.PacketFault{{Fault: pb.PacketFault_CORRUPT, Protocol: "udp", CorruptOffset: 1, CorruptValue: []byte{0}}})
+	g.Expect(err).NotTo(BeNil())
+}
@@ -0,0 +1,222 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package packetfault
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+)
+
+// MaxRules is the limit of the count of rules on one device, as the ipsets of the rules are
+// looked up in the maps at once, whose values are the bitmasks of the matched rules
+const MaxRules = 32
+
+// maxBytes is the limit of the count of bytes of the payload prefix and the corrupted bytes
+const maxBytes = 16
+
+const (
+	protocolICMP   = 1
+	protocolTCP    = 6
+	protocolUDP    = 17
+	protocolICMPv6 = 58
+)
+
+// PortRange is a range of ports, both ends included
+type PortRange struct {
+	Start uint16
+	End   uint16
+}
+
+// Rule is a packet fault parsed from the request. A packet is matched by a rule if its destination
+// address is in the cidrs, and it matches all the other conditions set in the rule.
+type Rule struct {
+	Fault pb.PacketFault_Fault
+
+	// Cidrs are the cidrs of the ipset, all addresses are matched if it's nil
+	Cidrs []*net.IPNet
+
+	// Protocol is the number of the transport protocol, all protocols are matched if it's 0.
+	// The icmp protocol matches the icmpv6 packets too.
+	Protocol uint8
+
+	SourcePorts      []PortRange
+	DestinationPorts []PortRange
+
+	TCPFlagsMask  uint8
+	TCPFlagsValue uint8
+
+	PayloadPrefix []byte
+
+	// Threshold is the percentage of the matched packets to inject the fault into, in hundredths of
+	// a percent, and the fault is always injected if it's 10000
+	Threshold uint32
+
+	// Delay is the delay of the packets in nanoseconds
+	Delay uint64
+
+	CorruptOffset uint16
+	CorruptValue  []byte
+}
+
+// hasTransport returns whether the rule only matches the packets of the tcp and udp protocol,
+// whose ports and payloads are accessible
+func (r *Rule) hasTransport() bool {
+	return r.Protocol == protocolTCP || r.Protocol == protocolUDP
+}
+
+// ParseRules parses the packet faults of the request into rules
+func ParseRules(faults []*pb.PacketFault) ([]Rule, error) {
+	if len(faults) > MaxRules {
+		return nil, fmt.Errorf("at most %d packet faults are allowed on a device", MaxRules)
+	}
+
+	rules := make([]Rule, 0, len(faults))
+	for _, fault := range faults {
+		rule, err := parseRule(fault)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, *rule)
+	}
+
+	return rules, nil
+}
+
+func parseRule(fault *pb.PacketFault) (*Rule, error) {
+	rule := &Rule{
+		Fault:         fault.Fault,
+		TCPFlagsMask:  uint8(fault.TcpFlagsMask),
+		TCPFlagsValue: uint8(fault.TcpFlagsValue) & uint8(fault.TcpFlagsMask),
+		PayloadPrefix: fault.PayloadPrefix,
+		CorruptValue:  fault.CorruptValue,
+	}
+
+	if fault.Ipset != nil {
+		// an ipset without cidrs matches nothing rather than everything
+		rule.Cidrs = []*net.IPNet{}
+		for _, cidr := range fault.Ipset.Cidrs {
+			_, ipNet, err := net.ParseCIDR(cidr)
+			if err != nil {
+				return nil, err
+			}
+			rule.Cidrs = append(rule.Cidrs, ipNet)
+		}
+	}
+
+	switch fault.Protocol {
+	case "":
+	case "tcp":
+		rule.Protocol = protocolTCP
+	case "udp":
+		rule.Protocol = protocolUDP
+	case "icmp":
+		rule.Protocol = protocolICMP
+	default:
+		return nil, fmt.Errorf("unsupported protocol %s", fault.Protocol)
+	}
+
+	var err error
+	if rule.SourcePorts, err = parsePorts(fault.SourcePorts); err != nil {
+		return nil, err
+	}
+	if rule.DestinationPorts, err = parsePorts(fault.DestinationPorts); err != nil {
+		return nil, err
+	}
+	if (len(rule.SourcePorts) > 0 || len(rule.DestinationPorts) > 0 || len(rule.PayloadPrefix) > 0) && !rule.hasTransport() {
+		return nil, fmt.Errorf("ports and payload prefix require protocol tcp or udp")
+	}
+	if rule.TCPFlagsMask != 0 && rule.Protocol != protocolTCP {
+		return nil, fmt.Errorf("tcp flags require protocol tcp")
+	}
+	if len(rule.PayloadPrefix) > maxBytes {
+		return nil, fmt.Errorf("the payload prefix is longer than %d bytes", maxBytes)
+	}
+
+	if fault.Percent < 0 || fault.Percent > 100 {
+		return nil, fmt.Errorf("invalid percent %f", fault.Percent)
+	}
+	rule.Threshold = uint32(fault.Percent * 100)
+
+	switch fault.Fault {
+	case pb.PacketFault_DROP:
+	case pb.PacketFault_RESET:
+		if rule.Protocol != protocolTCP {
+			return nil, fmt.Errorf("reset fault requires protocol tcp")
+		}
+	case pb.PacketFault_DELAY:
+		if fault.Delay <= 0 {
+			return nil, fmt.Errorf("invalid delay %d", fault.Delay)
+		}
+		rule.Delay = uint64(fault.Delay)
+	case pb.PacketFault_CORRUPT:
+		if !rule.hasTransport() {
+			return nil, fmt.Errorf("corrupt fault requires protocol tcp or udp")
+		}
+		if len(rule.CorruptValue) == 0 || len(rule.CorruptValue) > maxBytes {
+			return nil, fmt.Errorf("the corrupted bytes should be between 1 and %d bytes", maxBytes)
+		}
+		// the checksum is updated by 16-bit words
+		if fault.CorruptOffset%2 != 0 || fault.CorruptOffset > 0xffff {
+			return nil, fmt.Errorf("invalid corrupt offset %d", fault.CorruptOffset)
+		}
+		rule.CorruptOffset = uint16(fault.CorruptOffset)
+	default:
+		return nil, fmt.Errorf("unknown fault %s", fault.Fault)
+	}
+
+	return rule, nil
+}
+
+// parsePorts parses the ports separated by commas, and the ranges of ports in the format of "start:end"
+func parsePorts(value string) ([]PortRange, error) {
+	if len(value) == 0 {
+		return nil, nil
+	}
+
+	var ranges []PortRange
+	for _, part := range strings.Split(value, ",") {
+		bounds := strings.SplitN(part, ":", 2)
+		start, err := strconv.ParseUint(bounds[0], 10, 16)
+		if err != nil {
+			return nil, err
+		}
+		end := start
+		if len(bounds) == 2 {
+			if end, err = strconv.ParseUint(bounds[1], 10, 16); err != nil {
+				return nil, err
+			}
+		}
+		if start > end {
+			return nil, fmt.Errorf("invalid port range %s", part)
+		}
+		ranges = append(ranges, PortRange{Start: uint16(start), End: uint16(end)})
+	}
+
+	return ranges, nil
+}
+
+// HasDelay returns whether any of the rules delays the packets, which requires the fq qdisc on the
+// device to hold the packets until their timestamps
+func HasDelay(rules []Rule) bool {
+	for _, rule := range rules {
+		if rule.Fault == pb.PacketFault_DELAY {
+			return true
+		}
+	}
+	return false
+}
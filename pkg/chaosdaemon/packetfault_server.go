@@ -0,0 +1,57 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/packetfault"
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+)
+
+// SetPacketFaults replaces the packet faults on the outbound traffic of the device with the ones in the
+// request, which are injected by an eBPF program attached to the clsact qdisc of the device
+func (s *DaemonServer) SetPacketFaults(ctx context.Context, in *pb.PacketFaultsRequest) (*empty.Empty, error) {
+	log.Info("handling packet faults request", "request", in)
+
+	pid, err := s.crClient.GetPidFromContainerID(ctx, in.ContainerId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "get pid from containerID error: %v", err)
+	}
+
+	if len(in.Device) == 0 {
+		in.Device = defaultDevice
+	}
+
+	rules, err := packetfault.ParseRules(in.Faults)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "parse packet faults error: %v", err)
+	}
+
+	netnsPath := ""
+	if in.EnterNS {
+		netnsPath = fmt.Sprintf("/proc/%d/ns/net", pid)
+	}
+	if err := packetfault.Attach(netnsPath, in.Device, rules); err != nil {
+		log.Error(err, "error while attaching the packet faults")
+		return nil, err
+	}
+
+	return &empty.Empty{}, nil
+}
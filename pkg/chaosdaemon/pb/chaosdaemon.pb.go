@@ -215,6 +215,110 @@ func (Tc_Type) EnumDescriptor() ([]byte, []int) {
 	return file_chaosdaemon_proto_rawDescGZIP(), []int{27, 0}
 }
 
+type TcProfile_Waveform int32
+
+const (
+	TcProfile_STEPS       TcProfile_Waveform = 0
+	TcProfile_SINE        TcProfile_Waveform = 1
+	TcProfile_SQUARE      TcProfile_Waveform = 2
+	TcProfile_RANDOM_WALK TcProfile_Waveform = 3
+)
+
+// Enum value maps for TcProfile_Waveform.
+var (
+	TcProfile_Waveform_name = map[int32]string{
+		0: "STEPS",
+		1: "SINE",
+		2: "SQUARE",
+		3: "RANDOM_WALK",
+	}
+	TcProfile_Waveform_value = map[string]int32{
+		"STEPS":       0,
+		"SINE":        1,
+		"SQUARE":      2,
+		"RANDOM_WALK": 3,
+	}
+)
+
+func (x TcProfile_Waveform) Enum() *TcProfile_Waveform {
+	p := new(TcProfile_Waveform)
+	*p = x
+	return p
+}
+
+func (x TcProfile_Waveform) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TcProfile_Waveform) Descriptor() protoreflect.EnumDescriptor {
+	return file_chaosdaemon_proto_enumTypes[4].Descriptor()
+}
+
+func (TcProfile_Waveform) Type() protoreflect.EnumType {
+	return &file_chaosdaemon_proto_enumTypes[4]
+}
+
+func (x TcProfile_Waveform) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TcProfile_Waveform.Descriptor instead.
+func (TcProfile_Waveform) EnumDescriptor() ([]byte, []int) {
+	return file_chaosdaemon_proto_rawDescGZIP(), []int{28, 0}
+}
+
+type PacketFault_Fault int32
+
+const (
+	PacketFault_DROP    PacketFault_Fault = 0
+	PacketFault_RESET   PacketFault_Fault = 1
+	PacketFault_DELAY   PacketFault_Fault = 2
+	PacketFault_CORRUPT PacketFault_Fault = 3
+)
+
+// Enum value maps for PacketFault_Fault.
+var (
+	PacketFault_Fault_name = map[int32]string{
+		0: "DROP",
+		1: "RESET",
+		2: "DELAY",
+		3: "CORRUPT",
+	}
+	PacketFault_Fault_value = map[string]int32{
+		"DROP":    0,
+		"RESET":   1,
+		"DELAY":   2,
+		"CORRUPT": 3,
+	}
+)
+
+func (x PacketFault_Fault) Enum() *PacketFault_Fault {
+	p := new(PacketFault_Fault)
+	*p = x
+	return p
+}
+
+func (x PacketFault_Fault) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PacketFault_Fault) Descriptor() protoreflect.EnumDescriptor {
+	return file_chaosdaemon_proto_enumTypes[5].Descriptor()
+}
+
+func (PacketFault_Fault) Type() protoreflect.EnumType {
+	return &file_chaosdaemon_proto_enumTypes[5]
+}
+
+func (x PacketFault_Fault) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PacketFault_Fault.Descriptor instead.
+func (PacketFault_Fault) EnumDescriptor() ([]byte, []int) {
+	return file_chaosdaemon_proto_rawDescGZIP(), []int{35, 0}
+}
+
 type TcHandle struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1090,6 +1194,8 @@ type IPSetsRequest struct {
 	Ipsets      []*IPSet `protobuf:"bytes,1,rep,name=ipsets,proto3" json:"ipsets,omitempty"`
 	ContainerId string   `protobuf:"bytes,2,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
 	EnterNS     bool     `protobuf:"varint,3,opt,name=enterNS,proto3" json:"enterNS,omitempty"`
+	// host_network sets the ipsets in the network namespace of the host, and the container_id is ignored
+	HostNetwork bool `protobuf:"varint,4,opt,name=host_network,json=hostNetwork,proto3" json:"host_network,omitempty"`
 }
 
 func (x *IPSetsRequest) Reset() {
@@ -1145,6 +1251,13 @@ func (x *IPSetsRequest) GetEnterNS() bool {
 	return false
 }
 
+func (x *IPSetsRequest) GetHostNetwork() bool {
+	if x != nil {
+		return x.HostNetwork
+	}
+	return false
+}
+
 type IPSet struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1208,6 +1321,12 @@ type IptablesChainsRequest struct {
 	Chains      []*Chain `protobuf:"bytes,1,rep,name=chains,proto3" json:"chains,omitempty"`
 	ContainerId string   `protobuf:"bytes,2,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
 	EnterNS     bool     `protobuf:"varint,3,opt,name=enterNS,proto3" json:"enterNS,omitempty"`
+	// host_network sets the chains in the network namespace of the host, and the container_id is ignored.
+	// The packets of the protected ports of the node are never matched by the chains.
+	HostNetwork bool `protobuf:"varint,4,opt,name=host_network,json=hostNetwork,proto3" json:"host_network,omitempty"`
+	// lease holds the faults, which are recovered once the lease isn't renewed in time. The faults are
+	// never recovered by chaos-daemon itself if it's not set.
+	Lease *LeaseRequest `protobuf:"bytes,5,opt,name=lease,proto3" json:"lease,omitempty"`
 }
 
 func (x *IptablesChainsRequest) Reset() {
@@ -1263,6 +1382,20 @@ func (x *IptablesChainsRequest) GetEnterNS() bool {
 	return false
 }
 
+func (x *IptablesChainsRequest) GetHostNetwork() bool {
+	if x != nil {
+		return x.HostNetwork
+	}
+	return false
+}
+
+func (x *IptablesChainsRequest) GetLease() *LeaseRequest {
+	if x != nil {
+		return x.Lease
+	}
+	return nil
+}
+
 type Chain struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1276,6 +1409,8 @@ type Chain struct {
 	SourcePorts      string          `protobuf:"bytes,6,opt,name=source_ports,json=sourcePorts,proto3" json:"source_ports,omitempty"`
 	DestinationPorts string          `protobuf:"bytes,7,opt,name=destination_ports,json=destinationPorts,proto3" json:"destination_ports,omitempty"`
 	TcpFlags         string          `protobuf:"bytes,8,opt,name=tcp_flags,json=tcpFlags,proto3" json:"tcp_flags,omitempty"`
+	// device limits the chain to the packets coming in (INPUT) or going out (OUTPUT) of the network device
+	Device string `protobuf:"bytes,9,opt,name=device,proto3" json:"device,omitempty"`
 }
 
 func (x *Chain) Reset() {
@@ -1366,6 +1501,13 @@ func (x *Chain) GetTcpFlags() string {
 	return ""
 }
 
+func (x *Chain) GetDevice() string {
+	if x != nil {
+		return x.Device
+	}
+	return ""
+}
+
 type TimeRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1375,6 +1517,9 @@ type TimeRequest struct {
 	Sec         int64  `protobuf:"varint,2,opt,name=sec,proto3" json:"sec,omitempty"`
 	Nsec        int64  `protobuf:"varint,3,opt,name=nsec,proto3" json:"nsec,omitempty"`
 	ClkIdsMask  uint64 `protobuf:"varint,4,opt,name=clk_ids_mask,json=clkIdsMask,proto3" json:"clk_ids_mask,omitempty"`
+	// lease holds the faults, which are recovered once the lease isn't renewed in time. The faults are
+	// never recovered by chaos-daemon itself if it's not set.
+	Lease *LeaseRequest `protobuf:"bytes,5,opt,name=lease,proto3" json:"lease,omitempty"`
 }
 
 func (x *TimeRequest) Reset() {
@@ -1437,6 +1582,13 @@ func (x *TimeRequest) GetClkIdsMask() uint64 {
 	return 0
 }
 
+func (x *TimeRequest) GetLease() *LeaseRequest {
+	if x != nil {
+		return x.Lease
+	}
+	return nil
+}
+
 type ContainerAction struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1493,6 +1645,9 @@ type ExecStressRequest struct {
 	Target    string                  `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
 	Stressors string                  `protobuf:"bytes,3,opt,name=stressors,proto3" json:"stressors,omitempty"`
 	EnterNS   bool                    `protobuf:"varint,4,opt,name=enterNS,proto3" json:"enterNS,omitempty"`
+	// lease holds the faults, which are recovered once the lease isn't renewed in time. The faults are
+	// never recovered by chaos-daemon itself if it's not set.
+	Lease *LeaseRequest `protobuf:"bytes,5,opt,name=lease,proto3" json:"lease,omitempty"`
 }
 
 func (x *ExecStressRequest) Reset() {
@@ -1555,6 +1710,13 @@ func (x *ExecStressRequest) GetEnterNS() bool {
 	return false
 }
 
+func (x *ExecStressRequest) GetLease() *LeaseRequest {
+	if x != nil {
+		return x.Lease
+	}
+	return nil
+}
+
 type ExecStressResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1617,6 +1779,8 @@ type CancelStressRequest struct {
 
 	Instance  string `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
 	StartTime int64  `protobuf:"varint,2,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	// lease is released as the stressors are canceled
+	Lease *LeaseRequest `protobuf:"bytes,3,opt,name=lease,proto3" json:"lease,omitempty"`
 }
 
 func (x *CancelStressRequest) Reset() {
@@ -1665,6 +1829,13 @@ func (x *CancelStressRequest) GetStartTime() int64 {
 	return 0
 }
 
+func (x *CancelStressRequest) GetLease() *LeaseRequest {
+	if x != nil {
+		return x.Lease
+	}
+	return nil
+}
+
 type ApplyIOChaosRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1676,6 +1847,9 @@ type ApplyIOChaosRequest struct {
 	Instance    int64  `protobuf:"varint,4,opt,name=instance,proto3" json:"instance,omitempty"`
 	StartTime   int64  `protobuf:"varint,5,opt,name=startTime,proto3" json:"startTime,omitempty"`
 	EnterNS     bool   `protobuf:"varint,6,opt,name=enterNS,proto3" json:"enterNS,omitempty"`
+	// lease holds the faults, which are recovered once the lease isn't renewed in time. The faults are
+	// never recovered by chaos-daemon itself if it's not set.
+	Lease *LeaseRequest `protobuf:"bytes,7,opt,name=lease,proto3" json:"lease,omitempty"`
 }
 
 func (x *ApplyIOChaosRequest) Reset() {
@@ -1752,6 +1926,13 @@ func (x *ApplyIOChaosRequest) GetEnterNS() bool {
 	return false
 }
 
+func (x *ApplyIOChaosRequest) GetLease() *LeaseRequest {
+	if x != nil {
+		return x.Lease
+	}
+	return nil
+}
+
 type ApplyIOChaosResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1818,6 +1999,9 @@ type ApplyHttpChaosRequest struct {
 	Instance    int64    `protobuf:"varint,4,opt,name=instance,proto3" json:"instance,omitempty"`
 	StartTime   int64    `protobuf:"varint,5,opt,name=startTime,proto3" json:"startTime,omitempty"`
 	EnterNS     bool     `protobuf:"varint,6,opt,name=enterNS,proto3" json:"enterNS,omitempty"`
+	// lease holds the faults, which are recovered once the lease isn't renewed in time. The faults are
+	// never recovered by chaos-daemon itself if it's not set.
+	Lease *LeaseRequest `protobuf:"bytes,7,opt,name=lease,proto3" json:"lease,omitempty"`
 }
 
 func (x *ApplyHttpChaosRequest) Reset() {
@@ -1894,6 +2078,13 @@ func (x *ApplyHttpChaosRequest) GetEnterNS() bool {
 	return false
 }
 
+func (x *ApplyHttpChaosRequest) GetLease() *LeaseRequest {
+	if x != nil {
+		return x.Lease
+	}
+	return nil
+}
+
 type ApplyHttpChaosResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1974,6 +2165,15 @@ type TcsRequest struct {
 	ContainerId string `protobuf:"bytes,2,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
 	Device      string `protobuf:"bytes,3,opt,name=device,proto3" json:"device,omitempty"`
 	EnterNS     bool   `protobuf:"varint,4,opt,name=enterNS,proto3" json:"enterNS,omitempty"`
+	// ingress sets the tcs on the inbound traffic of the device by redirecting it into an IFB device,
+	// and the ipsets of the tcs match the source address of the packets
+	Ingress bool `protobuf:"varint,5,opt,name=ingress,proto3" json:"ingress,omitempty"`
+	// host_network sets the tcs in the network namespace of the host, and the container_id is ignored.
+	// The packets of the protected ports of the node are never shaped by the tcs.
+	HostNetwork bool `protobuf:"varint,6,opt,name=host_network,json=hostNetwork,proto3" json:"host_network,omitempty"`
+	// lease holds the faults, which are recovered once the lease isn't renewed in time. The faults are
+	// never recovered by chaos-daemon itself if it's not set.
+	Lease *LeaseRequest `protobuf:"bytes,7,opt,name=lease,proto3" json:"lease,omitempty"`
 }
 
 func (x *TcsRequest) Reset() {
@@ -2036,6 +2236,27 @@ func (x *TcsRequest) GetEnterNS() bool {
 	return false
 }
 
+func (x *TcsRequest) GetIngress() bool {
+	if x != nil {
+		return x.Ingress
+	}
+	return false
+}
+
+func (x *TcsRequest) GetHostNetwork() bool {
+	if x != nil {
+		return x.HostNetwork
+	}
+	return false
+}
+
+func (x *TcsRequest) GetLease() *LeaseRequest {
+	if x != nil {
+		return x.Lease
+	}
+	return nil
+}
+
 type Tc struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -2048,6 +2269,11 @@ type Tc struct {
 	Protocol   string  `protobuf:"bytes,5,opt,name=protocol,proto3" json:"protocol,omitempty"`
 	SourcePort string  `protobuf:"bytes,6,opt,name=source_port,json=sourcePort,proto3" json:"source_port,omitempty"`
 	EgressPort string  `protobuf:"bytes,7,opt,name=egress_port,json=egressPort,proto3" json:"egress_port,omitempty"`
+	// the profiles vary the delay (in microseconds), the loss (in percentage) of the netem,
+	// and the rate (in bytes per second) of the tbf over time
+	DelayProfile *TcProfile `protobuf:"bytes,8,opt,name=delay_profile,json=delayProfile,proto3" json:"delay_profile,omitempty"`
+	LossProfile  *TcProfile `protobuf:"bytes,9,opt,name=loss_profile,json=lossProfile,proto3" json:"loss_profile,omitempty"`
+	RateProfile  *TcProfile `protobuf:"bytes,10,opt,name=rate_profile,json=rateProfile,proto3" json:"rate_profile,omitempty"`
 }
 
 func (x *Tc) Reset() {
@@ -2131,21 +2357,200 @@ func (x *Tc) GetEgressPort() string {
 	return ""
 }
 
+func (x *Tc) GetDelayProfile() *TcProfile {
+	if x != nil {
+		return x.DelayProfile
+	}
+	return nil
+}
+
+func (x *Tc) GetLossProfile() *TcProfile {
+	if x != nil {
+		return x.LossProfile
+	}
+	return nil
+}
+
+func (x *Tc) GetRateProfile() *TcProfile {
+	if x != nil {
+		return x.RateProfile
+	}
+	return nil
+}
+
+type TcProfile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Waveform TcProfile_Waveform `protobuf:"varint,1,opt,name=waveform,proto3,enum=pb.TcProfile_Waveform" json:"waveform,omitempty"`
+	Steps    []*TcProfileStep   `protobuf:"bytes,2,rep,name=steps,proto3" json:"steps,omitempty"`
+	Repeat   bool               `protobuf:"varint,3,opt,name=repeat,proto3" json:"repeat,omitempty"`
+	Min      float64            `protobuf:"fixed64,4,opt,name=min,proto3" json:"min,omitempty"`
+	Max      float64            `protobuf:"fixed64,5,opt,name=max,proto3" json:"max,omitempty"`
+	// period and interval are in nanoseconds
+	Period   int64 `protobuf:"varint,6,opt,name=period,proto3" json:"period,omitempty"`
+	Interval int64 `protobuf:"varint,7,opt,name=interval,proto3" json:"interval,omitempty"`
+}
+
+func (x *TcProfile) Reset() {
+	*x = TcProfile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_chaosdaemon_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TcProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TcProfile) ProtoMessage() {}
+
+func (x *TcProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_chaosdaemon_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TcProfile.ProtoReflect.Descriptor instead.
+func (*TcProfile) Descriptor() ([]byte, []int) {
+	return file_chaosdaemon_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *TcProfile) GetWaveform() TcProfile_Waveform {
+	if x != nil {
+		return x.Waveform
+	}
+	return TcProfile_STEPS
+}
+
+func (x *TcProfile) GetSteps() []*TcProfileStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *TcProfile) GetRepeat() bool {
+	if x != nil {
+		return x.Repeat
+	}
+	return false
+}
+
+func (x *TcProfile) GetMin() float64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *TcProfile) GetMax() float64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+func (x *TcProfile) GetPeriod() int64 {
+	if x != nil {
+		return x.Period
+	}
+	return 0
+}
+
+func (x *TcProfile) GetInterval() int64 {
+	if x != nil {
+		return x.Interval
+	}
+	return 0
+}
+
+type TcProfileStep struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value float64 `protobuf:"fixed64,1,opt,name=value,proto3" json:"value,omitempty"`
+	// duration is in nanoseconds
+	Duration int64 `protobuf:"varint,2,opt,name=duration,proto3" json:"duration,omitempty"`
+}
+
+func (x *TcProfileStep) Reset() {
+	*x = TcProfileStep{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_chaosdaemon_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TcProfileStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TcProfileStep) ProtoMessage() {}
+
+func (x *TcProfileStep) ProtoReflect() protoreflect.Message {
+	mi := &file_chaosdaemon_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TcProfileStep.ProtoReflect.Descriptor instead.
+func (*TcProfileStep) Descriptor() ([]byte, []int) {
+	return file_chaosdaemon_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *TcProfileStep) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *TcProfileStep) GetDuration() int64 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
 type SetDNSServerRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	ContainerId string `protobuf:"bytes,1,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
-	DnsServer   string `protobuf:"bytes,2,opt,name=dns_server,json=dnsServer,proto3" json:"dns_server,omitempty"`
-	Enable      bool   `protobuf:"varint,3,opt,name=enable,proto3" json:"enable,omitempty"`
-	EnterNS     bool   `protobuf:"varint,4,opt,name=enterNS,proto3" json:"enterNS,omitempty"`
+	// dns_server is deprecated, the queries are answered by the dns server embedded in chaos-daemon
+	DnsServer string `protobuf:"bytes,2,opt,name=dns_server,json=dnsServer,proto3" json:"dns_server,omitempty"`
+	Enable    bool   `protobuf:"varint,3,opt,name=enable,proto3" json:"enable,omitempty"`
+	EnterNS   bool   `protobuf:"varint,4,opt,name=enterNS,proto3" json:"enterNS,omitempty"`
+	// name is the name of the rules, which are set or removed in the network namespace of the container
+	Name string `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	// rules are matched in order, the queries not matched by any rule are forwarded to the original nameservers
+	Rules []*DNSRule    `protobuf:"bytes,6,rep,name=rules,proto3" json:"rules,omitempty"`
+	Lease *LeaseRequest `protobuf:"bytes,8,opt,name=lease,proto3" json:"lease,omitempty"`
 }
 
 func (x *SetDNSServerRequest) Reset() {
 	*x = SetDNSServerRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_chaosdaemon_proto_msgTypes[28]
+		mi := &file_chaosdaemon_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2158,7 +2563,7 @@ func (x *SetDNSServerRequest) String() string {
 func (*SetDNSServerRequest) ProtoMessage() {}
 
 func (x *SetDNSServerRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_chaosdaemon_proto_msgTypes[28]
+	mi := &file_chaosdaemon_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2171,7 +2576,7 @@ func (x *SetDNSServerRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetDNSServerRequest.ProtoReflect.Descriptor instead.
 func (*SetDNSServerRequest) Descriptor() ([]byte, []int) {
-	return file_chaosdaemon_proto_rawDescGZIP(), []int{28}
+	return file_chaosdaemon_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *SetDNSServerRequest) GetContainerId() string {
@@ -2202,27 +2607,517 @@ func (x *SetDNSServerRequest) GetEnterNS() bool {
 	return false
 }
 
-var File_chaosdaemon_proto protoreflect.FileDescriptor
+func (x *SetDNSServerRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
 
-var file_chaosdaemon_proto_rawDesc = []byte{
-	0x0a, 0x11, 0x63, 0x68, 0x61, 0x6f, 0x73, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x36, 0x0a, 0x08, 0x54, 0x63, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65,
-	0x12, 0x14, 0x0a, 0x05, 0x6d, 0x61, 0x6a, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
-	0x05, 0x6d, 0x61, 0x6a, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x69, 0x6e, 0x6f, 0x72, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6d, 0x69, 0x6e, 0x6f, 0x72, 0x22, 0x62, 0x0a, 0x10,
-	0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x2b, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x41,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a,
-	0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x64,
-	0x22, 0x25, 0x0a, 0x11, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x03, 0x70, 0x69, 0x64, 0x22, 0x9e, 0x01, 0x0a, 0x0c, 0x4e, 0x65, 0x74, 0x65,
-	0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x05, 0x6e, 0x65, 0x74, 0x65,
-	0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74,
+func (x *SetDNSServerRequest) GetRules() []*DNSRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *SetDNSServerRequest) GetLease() *LeaseRequest {
+	if x != nil {
+		return x.Lease
+	}
+	return nil
+}
+
+type DNSRule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// action is the fault of the queries matched by the patterns, which is error, random, delay, rcode,
+	// truncate, drop or static
+	Action   string   `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
+	Patterns []string `protobuf:"bytes,2,rep,name=patterns,proto3" json:"patterns,omitempty"`
+	// delay is the added latency of the delay action in nanoseconds
+	Delay int64 `protobuf:"varint,3,opt,name=delay,proto3" json:"delay,omitempty"`
+	// rcode is the response code of the rcode action, which is NXDOMAIN, SERVFAIL or REFUSED
+	Rcode string `protobuf:"bytes,4,opt,name=rcode,proto3" json:"rcode,omitempty"`
+	// a, aaaa and cname are the records of the static action
+	A     []string `protobuf:"bytes,5,rep,name=a,proto3" json:"a,omitempty"`
+	Aaaa  []string `protobuf:"bytes,6,rep,name=aaaa,proto3" json:"aaaa,omitempty"`
+	Cname string   `protobuf:"bytes,7,opt,name=cname,proto3" json:"cname,omitempty"`
+}
+
+func (x *DNSRule) Reset() {
+	*x = DNSRule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_chaosdaemon_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DNSRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DNSRule) ProtoMessage() {}
+
+func (x *DNSRule) ProtoReflect() protoreflect.Message {
+	mi := &file_chaosdaemon_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DNSRule.ProtoReflect.Descriptor instead.
+func (*DNSRule) Descriptor() ([]byte, []int) {
+	return file_chaosdaemon_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DNSRule) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *DNSRule) GetPatterns() []string {
+	if x != nil {
+		return x.Patterns
+	}
+	return nil
+}
+
+func (x *DNSRule) GetDelay() int64 {
+	if x != nil {
+		return x.Delay
+	}
+	return 0
+}
+
+func (x *DNSRule) GetRcode() string {
+	if x != nil {
+		return x.Rcode
+	}
+	return ""
+}
+
+func (x *DNSRule) GetA() []string {
+	if x != nil {
+		return x.A
+	}
+	return nil
+}
+
+func (x *DNSRule) GetAaaa() []string {
+	if x != nil {
+		return x.Aaaa
+	}
+	return nil
+}
+
+func (x *DNSRule) GetCname() string {
+	if x != nil {
+		return x.Cname
+	}
+	return ""
+}
+
+type ListInterfacesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ContainerId string `protobuf:"bytes,1,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	// host_network lists the interfaces in the network namespace of the host, and the container_id is ignored
+	HostNetwork bool `protobuf:"varint,2,opt,name=host_network,json=hostNetwork,proto3" json:"host_network,omitempty"`
+}
+
+func (x *ListInterfacesRequest) Reset() {
+	*x = ListInterfacesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_chaosdaemon_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListInterfacesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInterfacesRequest) ProtoMessage() {}
+
+func (x *ListInterfacesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chaosdaemon_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInterfacesRequest.ProtoReflect.Descriptor instead.
+func (*ListInterfacesRequest) Descriptor() ([]byte, []int) {
+	return file_chaosdaemon_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListInterfacesRequest) GetContainerId() string {
+	if x != nil {
+		return x.ContainerId
+	}
+	return ""
+}
+
+func (x *ListInterfacesRequest) GetHostNetwork() bool {
+	if x != nil {
+		return x.HostNetwork
+	}
+	return false
+}
+
+type ListInterfacesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// interfaces are the names of the network interfaces in the network namespace of the container
+	Interfaces []string `protobuf:"bytes,1,rep,name=interfaces,proto3" json:"interfaces,omitempty"`
+}
+
+func (x *ListInterfacesResponse) Reset() {
+	*x = ListInterfacesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_chaosdaemon_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListInterfacesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInterfacesResponse) ProtoMessage() {}
+
+func (x *ListInterfacesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_chaosdaemon_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInterfacesResponse.ProtoReflect.Descriptor instead.
+func (*ListInterfacesResponse) Descriptor() ([]byte, []int) {
+	return file_chaosdaemon_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListInterfacesResponse) GetInterfaces() []string {
+	if x != nil {
+		return x.Interfaces
+	}
+	return nil
+}
+
+type PacketFaultsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Faults      []*PacketFault `protobuf:"bytes,1,rep,name=faults,proto3" json:"faults,omitempty"`
+	ContainerId string         `protobuf:"bytes,2,opt,name=container_id,json=containerId,proto3" json:"container_id,omitempty"`
+	Device      string         `protobuf:"bytes,3,opt,name=device,proto3" json:"device,omitempty"`
+	EnterNS     bool           `protobuf:"varint,4,opt,name=enterNS,proto3" json:"enterNS,omitempty"`
+}
+
+func (x *PacketFaultsRequest) Reset() {
+	*x = PacketFaultsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_chaosdaemon_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PacketFaultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PacketFaultsRequest) ProtoMessage() {}
+
+func (x *PacketFaultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chaosdaemon_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PacketFaultsRequest.ProtoReflect.Descriptor instead.
+func (*PacketFaultsRequest) Descriptor() ([]byte, []int) {
+	return file_chaosdaemon_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *PacketFaultsRequest) GetFaults() []*PacketFault {
+	if x != nil {
+		return x.Faults
+	}
+	return nil
+}
+
+func (x *PacketFaultsRequest) GetContainerId() string {
+	if x != nil {
+		return x.ContainerId
+	}
+	return ""
+}
+
+func (x *PacketFaultsRequest) GetDevice() string {
+	if x != nil {
+		return x.Device
+	}
+	return ""
+}
+
+func (x *PacketFaultsRequest) GetEnterNS() bool {
+	if x != nil {
+		return x.EnterNS
+	}
+	return false
+}
+
+type PacketFault struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Fault PacketFault_Fault `protobuf:"varint,1,opt,name=fault,proto3,enum=pb.PacketFault_Fault" json:"fault,omitempty"`
+	// the ipset matches the destination address of the packets, all packets are matched if it's empty
+	Ipset            *IPSet  `protobuf:"bytes,2,opt,name=ipset,proto3" json:"ipset,omitempty"`
+	Protocol         string  `protobuf:"bytes,3,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	SourcePorts      string  `protobuf:"bytes,4,opt,name=source_ports,json=sourcePorts,proto3" json:"source_ports,omitempty"`
+	DestinationPorts string  `protobuf:"bytes,5,opt,name=destination_ports,json=destinationPorts,proto3" json:"destination_ports,omitempty"`
+	TcpFlagsMask     uint32  `protobuf:"varint,6,opt,name=tcp_flags_mask,json=tcpFlagsMask,proto3" json:"tcp_flags_mask,omitempty"`
+	TcpFlagsValue    uint32  `protobuf:"varint,7,opt,name=tcp_flags_value,json=tcpFlagsValue,proto3" json:"tcp_flags_value,omitempty"`
+	PayloadPrefix    []byte  `protobuf:"bytes,8,opt,name=payload_prefix,json=payloadPrefix,proto3" json:"payload_prefix,omitempty"`
+	Percent          float64 `protobuf:"fixed64,9,opt,name=percent,proto3" json:"percent,omitempty"`
+	// the delay in nanoseconds
+	Delay         int64  `protobuf:"varint,10,opt,name=delay,proto3" json:"delay,omitempty"`
+	CorruptOffset uint32 `protobuf:"varint,11,opt,name=corrupt_offset,json=corruptOffset,proto3" json:"corrupt_offset,omitempty"`
+	CorruptValue  []byte `protobuf:"bytes,12,opt,name=corrupt_value,json=corruptValue,proto3" json:"corrupt_value,omitempty"`
+}
+
+func (x *PacketFault) Reset() {
+	*x = PacketFault{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_chaosdaemon_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PacketFault) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PacketFault) ProtoMessage() {}
+
+func (x *PacketFault) ProtoReflect() protoreflect.Message {
+	mi := &file_chaosdaemon_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PacketFault.ProtoReflect.Descriptor instead.
+func (*PacketFault) Descriptor() ([]byte, []int) {
+	return file_chaosdaemon_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *PacketFault) GetFault() PacketFault_Fault {
+	if x != nil {
+		return x.Fault
+	}
+	return PacketFault_DROP
+}
+
+func (x *PacketFault) GetIpset() *IPSet {
+	if x != nil {
+		return x.Ipset
+	}
+	return nil
+}
+
+func (x *PacketFault) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *PacketFault) GetSourcePorts() string {
+	if x != nil {
+		return x.SourcePorts
+	}
+	return ""
+}
+
+func (x *PacketFault) GetDestinationPorts() string {
+	if x != nil {
+		return x.DestinationPorts
+	}
+	return ""
+}
+
+func (x *PacketFault) GetTcpFlagsMask() uint32 {
+	if x != nil {
+		return x.TcpFlagsMask
+	}
+	return 0
+}
+
+func (x *PacketFault) GetTcpFlagsValue() uint32 {
+	if x != nil {
+		return x.TcpFlagsValue
+	}
+	return 0
+}
+
+func (x *PacketFault) GetPayloadPrefix() []byte {
+	if x != nil {
+		return x.PayloadPrefix
+	}
+	return nil
+}
+
+func (x *PacketFault) GetPercent() float64 {
+	if x != nil {
+		return x.Percent
+	}
+	return 0
+}
+
+func (x *PacketFault) GetDelay() int64 {
+	if x != nil {
+		return x.Delay
+	}
+	return 0
+}
+
+func (x *PacketFault) GetCorruptOffset() uint32 {
+	if x != nil {
+		return x.CorruptOffset
+	}
+	return 0
+}
+
+func (x *PacketFault) GetCorruptValue() []byte {
+	if x != nil {
+		return x.CorruptValue
+	}
+	return nil
+}
+
+type LeaseRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// id identifies the faults held by the lease, e.g. "host-network"
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// ttl is the duration in seconds before the faults are recovered if the lease isn't renewed again
+	Ttl int64 `protobuf:"varint,2,opt,name=ttl,proto3" json:"ttl,omitempty"`
+}
+
+func (x *LeaseRequest) Reset() {
+	*x = LeaseRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_chaosdaemon_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LeaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseRequest) ProtoMessage() {}
+
+func (x *LeaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_chaosdaemon_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseRequest.ProtoReflect.Descriptor instead.
+func (*LeaseRequest) Descriptor() ([]byte, []int) {
+	return file_chaosdaemon_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *LeaseRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LeaseRequest) GetTtl() int64 {
+	if x != nil {
+		return x.Ttl
+	}
+	return 0
+}
+
+var File_chaosdaemon_proto protoreflect.FileDescriptor
+
+var file_chaosdaemon_proto_rawDesc = []byte{
+	0x0a, 0x11, 0x63, 0x68, 0x61, 0x6f, 0x73, 0x64, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x36, 0x0a, 0x08, 0x54, 0x63, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x6d, 0x61, 0x6a, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x05, 0x6d, 0x61, 0x6a, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x69, 0x6e, 0x6f, 0x72, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6d, 0x69, 0x6e, 0x6f, 0x72, 0x22, 0x62, 0x0a, 0x10,
+	0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x2b, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x41,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a,
+	0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x64,
+	0x22, 0x25, 0x0a, 0x11, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x03, 0x70, 0x69, 0x64, 0x22, 0x9e, 0x01, 0x0a, 0x0c, 0x4e, 0x65, 0x74, 0x65,
+	0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x05, 0x6e, 0x65, 0x74, 0x65,
+	0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74,
 	0x65, 0x6d, 0x52, 0x05, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e,
 	0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
 	0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x12, 0x24, 0x0a, 0x06,
@@ -2306,25 +3201,32 @@ var file_chaosdaemon_proto_rawDesc = []byte{
 	0x69, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x22, 0x30, 0x0a, 0x08, 0x54, 0x63, 0x46, 0x69, 0x6c, 0x74,
 	0x65, 0x72, 0x12, 0x24, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x63, 0x48, 0x61, 0x6e, 0x64, 0x6c, 0x65,
-	0x52, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x22, 0x6f, 0x0a, 0x0d, 0x49, 0x50, 0x53, 0x65,
-	0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x06, 0x69, 0x70, 0x73,
-	0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x70, 0x62, 0x2e, 0x49,
-	0x50, 0x53, 0x65, 0x74, 0x52, 0x06, 0x69, 0x70, 0x73, 0x65, 0x74, 0x73, 0x12, 0x21, 0x0a, 0x0c,
-	0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x12,
-	0x18, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x4e, 0x53, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x07, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x4e, 0x53, 0x22, 0x31, 0x0a, 0x05, 0x49, 0x50, 0x53,
-	0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x69, 0x64, 0x72, 0x73, 0x18,
-	0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x63, 0x69, 0x64, 0x72, 0x73, 0x22, 0x77, 0x0a, 0x15,
-	0x49, 0x70, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x06, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e,
-	0x52, 0x06, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74,
-	0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
-	0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x65,
-	0x6e, 0x74, 0x65, 0x72, 0x4e, 0x53, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e,
-	0x74, 0x65, 0x72, 0x4e, 0x53, 0x22, 0xab, 0x02, 0x0a, 0x05, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x12,
+	0x52, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x22, 0x92, 0x01, 0x0a, 0x0d, 0x49, 0x50, 0x53,
+	0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x06, 0x69, 0x70,
+	0x73, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x70, 0x62, 0x2e,
+	0x49, 0x50, 0x53, 0x65, 0x74, 0x52, 0x06, 0x69, 0x70, 0x73, 0x65, 0x74, 0x73, 0x12, 0x21, 0x0a,
+	0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x4e, 0x53, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x4e, 0x53, 0x12, 0x21, 0x0a, 0x0c, 0x68, 0x6f,
+	0x73, 0x74, 0x5f, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0b, 0x68, 0x6f, 0x73, 0x74, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x22, 0x31, 0x0a,
+	0x05, 0x49, 0x50, 0x53, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x69,
+	0x64, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x63, 0x69, 0x64, 0x72, 0x73,
+	0x22, 0xc2, 0x01, 0x0a, 0x15, 0x49, 0x70, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x43, 0x68, 0x61,
+	0x69, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x06, 0x63, 0x68,
+	0x61, 0x69, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x70, 0x62, 0x2e,
+	0x43, 0x68, 0x61, 0x69, 0x6e, 0x52, 0x06, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x73, 0x12, 0x21, 0x0a,
+	0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x64,
+	0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x4e, 0x53, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x4e, 0x53, 0x12, 0x21, 0x0a, 0x0c, 0x68, 0x6f,
+	0x73, 0x74, 0x5f, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0b, 0x68, 0x6f, 0x73, 0x74, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x26, 0x0a,
+	0x05, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70,
+	0x62, 0x2e, 0x4c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x05,
+	0x6c, 0x65, 0x61, 0x73, 0x65, 0x22, 0xc3, 0x02, 0x0a, 0x05, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x12,
 	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
 	0x61, 0x6d, 0x65, 0x12, 0x31, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
 	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x68, 0x61, 0x69,
@@ -2340,116 +3242,237 @@ var file_chaosdaemon_proto_rawDesc = []byte{
 	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
 	0x52, 0x10, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6f, 0x72,
 	0x74, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x63, 0x70, 0x5f, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18,
-	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x63, 0x70, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x22,
-	0x22, 0x0a, 0x09, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x09, 0x0a, 0x05,
-	0x49, 0x4e, 0x50, 0x55, 0x54, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x4f, 0x55, 0x54, 0x50, 0x55,
-	0x54, 0x10, 0x01, 0x22, 0x78, 0x0a, 0x0b, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69,
-	0x6e, 0x65, 0x72, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x63, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x03, 0x73, 0x65, 0x63, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x73, 0x65, 0x63, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x6e, 0x73, 0x65, 0x63, 0x12, 0x20, 0x0a, 0x0c, 0x63,
-	0x6c, 0x6b, 0x5f, 0x69, 0x64, 0x73, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x0a, 0x63, 0x6c, 0x6b, 0x49, 0x64, 0x73, 0x4d, 0x61, 0x73, 0x6b, 0x22, 0x65, 0x0a,
-	0x0f, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x12, 0x32, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
-	0x32, 0x1a, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x41,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x22, 0x1e, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x08,
-	0x0a, 0x04, 0x4b, 0x49, 0x4c, 0x4c, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x47, 0x45, 0x54, 0x50,
-	0x49, 0x44, 0x10, 0x01, 0x22, 0xb7, 0x01, 0x0a, 0x11, 0x45, 0x78, 0x65, 0x63, 0x53, 0x74, 0x72,
-	0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x31, 0x0a, 0x05, 0x73, 0x63,
-	0x6f, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x70, 0x62, 0x2e, 0x45,
-	0x78, 0x65, 0x63, 0x53, 0x74, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x2e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x16, 0x0a,
-	0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74,
-	0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x72, 0x65, 0x73, 0x73, 0x6f,
-	0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x72, 0x65, 0x73, 0x73,
-	0x6f, 0x72, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x4e, 0x53, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x4e, 0x53, 0x22, 0x1f, 0x0a,
-	0x05, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x4f, 0x4e, 0x54, 0x41, 0x49,
-	0x4e, 0x45, 0x52, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x50, 0x4f, 0x44, 0x10, 0x01, 0x22, 0x4e,
-	0x0a, 0x12, 0x45, 0x78, 0x65, 0x63, 0x53, 0x74, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
-	0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x4f,
-	0x0a, 0x13, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x53, 0x74, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
-	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x22,
-	0xbe, 0x01, 0x0a, 0x13, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x49, 0x6f, 0x43, 0x68, 0x61, 0x6f, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e,
-	0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08,
-	0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08,
-	0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72,
-	0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74, 0x61,
-	0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x4e,
-	0x53, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x4e, 0x53,
-	0x22, 0x50, 0x0a, 0x14, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x49, 0x6f, 0x43, 0x68, 0x61, 0x6f, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x73, 0x74,
-	0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x69, 0x6e, 0x73, 0x74,
-	0x61, 0x6e, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69,
-	0x6d, 0x65, 0x22, 0xc5, 0x01, 0x0a, 0x15, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x48, 0x74, 0x74, 0x70,
-	0x43, 0x68, 0x61, 0x6f, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05,
-	0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x72, 0x75, 0x6c,
-	0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x5f, 0x70, 0x6f, 0x72, 0x74,
-	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x50, 0x6f,
-	0x72, 0x74, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72,
-	0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x61,
-	0x69, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e,
-	0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x63, 0x70, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x12,
+	0x16, 0x0a, 0x06, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x22, 0x22, 0x0a, 0x09, 0x44, 0x69, 0x72, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x09, 0x0a, 0x05, 0x49, 0x4e, 0x50, 0x55, 0x54, 0x10, 0x00, 0x12,
+	0x0a, 0x0a, 0x06, 0x4f, 0x55, 0x54, 0x50, 0x55, 0x54, 0x10, 0x01, 0x22, 0xa0, 0x01, 0x0a, 0x0b,
+	0x54, 0x69, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x63,
+	0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x12, 0x10,
+	0x0a, 0x03, 0x73, 0x65, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x73, 0x65, 0x63,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x73, 0x65, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04,
+	0x6e, 0x73, 0x65, 0x63, 0x12, 0x20, 0x0a, 0x0c, 0x63, 0x6c, 0x6b, 0x5f, 0x69, 0x64, 0x73, 0x5f,
+	0x6d, 0x61, 0x73, 0x6b, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x63, 0x6c, 0x6b, 0x49,
+	0x64, 0x73, 0x4d, 0x61, 0x73, 0x6b, 0x12, 0x26, 0x0a, 0x05, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x65, 0x61, 0x73, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x05, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x22, 0x65,
+	0x0a, 0x0f, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x41, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x32, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x1a, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72,
+	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x1e, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x08, 0x0a, 0x04, 0x4b, 0x49, 0x4c, 0x4c, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x47, 0x45, 0x54,
+	0x50, 0x49, 0x44, 0x10, 0x01, 0x22, 0xdf, 0x01, 0x0a, 0x11, 0x45, 0x78, 0x65, 0x63, 0x53, 0x74,
+	0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x31, 0x0a, 0x05, 0x73,
+	0x63, 0x6f, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x70, 0x62, 0x2e,
+	0x45, 0x78, 0x65, 0x63, 0x53, 0x74, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x2e, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x72, 0x65, 0x73, 0x73,
+	0x6f, 0x72, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x72, 0x65, 0x73,
+	0x73, 0x6f, 0x72, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x4e, 0x53, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x4e, 0x53, 0x12, 0x26,
+	0x0a, 0x05, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x70, 0x62, 0x2e, 0x4c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52,
+	0x05, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x22, 0x1f, 0x0a, 0x05, 0x53, 0x63, 0x6f, 0x70, 0x65, 0x12,
+	0x0d, 0x0a, 0x09, 0x43, 0x4f, 0x4e, 0x54, 0x41, 0x49, 0x4e, 0x45, 0x52, 0x10, 0x00, 0x12, 0x07,
+	0x0a, 0x03, 0x50, 0x4f, 0x44, 0x10, 0x01, 0x22, 0x4e, 0x0a, 0x12, 0x45, 0x78, 0x65, 0x63, 0x53,
+	0x74, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x77, 0x0a, 0x13, 0x43, 0x61, 0x6e, 0x63, 0x65,
+	0x6c, 0x53, 0x74, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a,
+	0x0a, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x05, 0x6c, 0x65, 0x61, 0x73,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x65, 0x61,
+	0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x05, 0x6c, 0x65, 0x61, 0x73, 0x65,
+	0x22, 0xe6, 0x01, 0x0a, 0x13, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x49, 0x4f, 0x43, 0x68, 0x61, 0x6f,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1a, 0x0a,
+	0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x65, 0x72,
+	0x4e, 0x53, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x4e,
+	0x53, 0x12, 0x26, 0x0a, 0x05, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x52, 0x05, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x22, 0x50, 0x0a, 0x14, 0x41, 0x70, 0x70,
+	0x6c, 0x79, 0x49, 0x4f, 0x43, 0x68, 0x61, 0x6f, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1c, 0x0a,
+	0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x22, 0xed, 0x01, 0x0a, 0x15,
+	0x41, 0x70, 0x70, 0x6c, 0x79, 0x48, 0x74, 0x74, 0x70, 0x43, 0x68, 0x61, 0x6f, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x70,
+	0x72, 0x6f, 0x78, 0x79, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0d,
+	0x52, 0x0a, 0x70, 0x72, 0x6f, 0x78, 0x79, 0x50, 0x6f, 0x72, 0x74, 0x73, 0x12, 0x21, 0x0a, 0x0c,
+	0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x74,
+	0x65, 0x72, 0x4e, 0x53, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x65,
+	0x72, 0x4e, 0x53, 0x12, 0x26, 0x0a, 0x05, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x52, 0x05, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x22, 0x88, 0x01, 0x0a, 0x16,
+	0x41, 0x70, 0x70, 0x6c, 0x79, 0x48, 0x74, 0x74, 0x70, 0x43, 0x68, 0x61, 0x6f, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e,
 	0x63, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65,
-	0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x4e, 0x53, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x4e, 0x53, 0x22, 0x88, 0x01, 0x0a, 0x16, 0x41,
-	0x70, 0x70, 0x6c, 0x79, 0x48, 0x74, 0x74, 0x70, 0x43, 0x68, 0x61, 0x6f, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
-	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12,
-	0x1e, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12,
-	0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x7b, 0x0a, 0x0a, 0x54, 0x63, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x03, 0x74, 0x63, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x06, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x63, 0x52, 0x03, 0x74, 0x63, 0x73, 0x12, 0x21, 0x0a,
-	0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x64,
-	0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x74, 0x65,
-	0x72, 0x4e, 0x53, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x65, 0x72,
-	0x4e, 0x53, 0x22, 0xf7, 0x01, 0x0a, 0x02, 0x54, 0x63, 0x12, 0x1f, 0x0a, 0x04, 0x74, 0x79, 0x70,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0b, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x63, 0x2e,
-	0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1f, 0x0a, 0x05, 0x6e, 0x65,
-	0x74, 0x65, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x70, 0x62, 0x2e, 0x4e,
-	0x65, 0x74, 0x65, 0x6d, 0x52, 0x05, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x12, 0x19, 0x0a, 0x03, 0x74,
-	0x62, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x07, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x62,
-	0x66, 0x52, 0x03, 0x74, 0x62, 0x66, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x70, 0x73, 0x65, 0x74, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x70, 0x73, 0x65, 0x74, 0x12, 0x1a, 0x0a, 0x08,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73,
-	0x6f, 0x75, 0x72, 0x63, 0x65, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x67, 0x72,
-	0x65, 0x73, 0x73, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
-	0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x50, 0x6f, 0x72, 0x74, 0x22, 0x20, 0x0a, 0x04, 0x54, 0x79,
-	0x70, 0x65, 0x12, 0x09, 0x0a, 0x05, 0x4e, 0x45, 0x54, 0x45, 0x4d, 0x10, 0x00, 0x12, 0x0d, 0x0a,
-	0x09, 0x42, 0x41, 0x4e, 0x44, 0x57, 0x49, 0x44, 0x54, 0x48, 0x10, 0x01, 0x22, 0x89, 0x01, 0x0a,
-	0x13, 0x53, 0x65, 0x74, 0x44, 0x4e, 0x53, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65,
-	0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74,
-	0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x6e, 0x73, 0x5f, 0x73,
-	0x65, 0x72, 0x76, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x6e, 0x73,
-	0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x18,
-	0x0a, 0x07, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x4e, 0x53, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x07, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x4e, 0x53, 0x32, 0xa1, 0x06, 0x0a, 0x0b, 0x43, 0x68, 0x61,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65,
+	0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xe0, 0x01, 0x0a, 0x0a, 0x54, 0x63, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x03, 0x74, 0x63, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x06, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x63, 0x52, 0x03, 0x74, 0x63, 0x73, 0x12,
+	0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72,
+	0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e,
+	0x74, 0x65, 0x72, 0x4e, 0x53, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x74,
+	0x65, 0x72, 0x4e, 0x53, 0x12, 0x18, 0x0a, 0x07, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x6e, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x21,
+	0x0a, 0x0c, 0x68, 0x6f, 0x73, 0x74, 0x5f, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x68, 0x6f, 0x73, 0x74, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72,
+	0x6b, 0x12, 0x26, 0x0a, 0x05, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x52, 0x05, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x22, 0x8f, 0x03, 0x0a, 0x02, 0x54, 0x63,
+	0x12, 0x1f, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0b,
+	0x2e, 0x70, 0x62, 0x2e, 0x54, 0x63, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x12, 0x1f, 0x0a, 0x05, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x09, 0x2e, 0x70, 0x62, 0x2e, 0x4e, 0x65, 0x74, 0x65, 0x6d, 0x52, 0x05, 0x6e, 0x65, 0x74,
+	0x65, 0x6d, 0x12, 0x19, 0x0a, 0x03, 0x74, 0x62, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x07, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x62, 0x66, 0x52, 0x03, 0x74, 0x62, 0x66, 0x12, 0x14, 0x0a,
+	0x05, 0x69, 0x70, 0x73, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x70,
+	0x73, 0x65, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12,
+	0x1f, 0x0a, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x50, 0x6f, 0x72, 0x74,
+	0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x67, 0x72, 0x65, 0x73, 0x73, 0x50, 0x6f, 0x72,
+	0x74, 0x12, 0x32, 0x0a, 0x0d, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x5f, 0x70, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x63,
+	0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x0c, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x50, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x30, 0x0a, 0x0c, 0x6c, 0x6f, 0x73, 0x73, 0x5f, 0x70, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x62,
+	0x2e, 0x54, 0x63, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x0b, 0x6c, 0x6f, 0x73, 0x73,
+	0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x30, 0x0a, 0x0c, 0x72, 0x61, 0x74, 0x65, 0x5f,
+	0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e,
+	0x70, 0x62, 0x2e, 0x54, 0x63, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x0b, 0x72, 0x61,
+	0x74, 0x65, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x22, 0x20, 0x0a, 0x04, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x09, 0x0a, 0x05, 0x4e, 0x45, 0x54, 0x45, 0x4d, 0x10, 0x00, 0x12, 0x0d, 0x0a, 0x09,
+	0x42, 0x41, 0x4e, 0x44, 0x57, 0x49, 0x44, 0x54, 0x48, 0x10, 0x01, 0x22, 0x96, 0x02, 0x0a, 0x09,
+	0x54, 0x63, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x32, 0x0a, 0x08, 0x77, 0x61, 0x76,
+	0x65, 0x66, 0x6f, 0x72, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x70, 0x62,
+	0x2e, 0x54, 0x63, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x2e, 0x57, 0x61, 0x76, 0x65, 0x66,
+	0x6f, 0x72, 0x6d, 0x52, 0x08, 0x77, 0x61, 0x76, 0x65, 0x66, 0x6f, 0x72, 0x6d, 0x12, 0x27, 0x0a,
+	0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70,
+	0x62, 0x2e, 0x54, 0x63, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x74, 0x65, 0x70, 0x52,
+	0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x70, 0x65, 0x61, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x72, 0x65, 0x70, 0x65, 0x61, 0x74, 0x12, 0x10,
+	0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6d, 0x69, 0x6e,
+	0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6d,
+	0x61, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x22, 0x3c, 0x0a, 0x08, 0x57, 0x61, 0x76, 0x65, 0x66, 0x6f,
+	0x72, 0x6d, 0x12, 0x09, 0x0a, 0x05, 0x53, 0x54, 0x45, 0x50, 0x53, 0x10, 0x00, 0x12, 0x08, 0x0a,
+	0x04, 0x53, 0x49, 0x4e, 0x45, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x51, 0x55, 0x41, 0x52,
+	0x45, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x52, 0x41, 0x4e, 0x44, 0x4f, 0x4d, 0x5f, 0x57, 0x41,
+	0x4c, 0x4b, 0x10, 0x03, 0x22, 0x41, 0x0a, 0x0d, 0x54, 0x63, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c,
+	0x65, 0x53, 0x74, 0x65, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xe8, 0x01, 0x0a, 0x13, 0x53, 0x65, 0x74, 0x44,
+	0x4e, 0x53, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72,
+	0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x6e, 0x73, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x6e, 0x73, 0x53, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x06, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x74,
+	0x65, 0x72, 0x4e, 0x53, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x74, 0x65,
+	0x72, 0x4e, 0x53, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73,
+	0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x4e, 0x53, 0x52,
+	0x75, 0x6c, 0x65, 0x52, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x05, 0x6c, 0x65,
+	0x61, 0x73, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x4c,
+	0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x05, 0x6c, 0x65, 0x61,
+	0x73, 0x65, 0x22, 0xa1, 0x01, 0x0a, 0x07, 0x44, 0x4e, 0x53, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72,
+	0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72,
+	0x6e, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x63, 0x6f, 0x64,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x72, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x0c,
+	0x0a, 0x01, 0x61, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x01, 0x61, 0x12, 0x12, 0x0a, 0x04,
+	0x61, 0x61, 0x61, 0x61, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x61, 0x61, 0x61,
+	0x12, 0x14, 0x0a, 0x05, 0x63, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x63, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x5d, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e,
+	0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72,
+	0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x68, 0x6f, 0x73, 0x74, 0x5f, 0x6e, 0x65, 0x74, 0x77, 0x6f,
+	0x72, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x68, 0x6f, 0x73, 0x74, 0x4e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x22, 0x38, 0x0a, 0x16, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x74,
+	0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x1e, 0x0a, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x22,
+	0x93, 0x01, 0x0a, 0x13, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x06, 0x66, 0x61, 0x75, 0x6c, 0x74,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x62, 0x2e, 0x50, 0x61, 0x63,
+	0x6b, 0x65, 0x74, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x73,
+	0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65,
+	0x6e, 0x74, 0x65, 0x72, 0x4e, 0x53, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e,
+	0x74, 0x65, 0x72, 0x4e, 0x53, 0x22, 0xee, 0x03, 0x0a, 0x0b, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x46, 0x61, 0x75, 0x6c, 0x74, 0x12, 0x2b, 0x0a, 0x05, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x70, 0x62, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x46, 0x61, 0x75, 0x6c, 0x74, 0x2e, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x52, 0x05, 0x66, 0x61, 0x75,
+	0x6c, 0x74, 0x12, 0x1f, 0x0a, 0x05, 0x69, 0x70, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x09, 0x2e, 0x70, 0x62, 0x2e, 0x49, 0x50, 0x53, 0x65, 0x74, 0x52, 0x05, 0x69, 0x70,
+	0x73, 0x65, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12,
+	0x21, 0x0a, 0x0c, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x50, 0x6f, 0x72,
+	0x74, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x64,
+	0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6f, 0x72, 0x74, 0x73, 0x12,
+	0x24, 0x0a, 0x0e, 0x74, 0x63, 0x70, 0x5f, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x5f, 0x6d, 0x61, 0x73,
+	0x6b, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x74, 0x63, 0x70, 0x46, 0x6c, 0x61, 0x67,
+	0x73, 0x4d, 0x61, 0x73, 0x6b, 0x12, 0x26, 0x0a, 0x0f, 0x74, 0x63, 0x70, 0x5f, 0x66, 0x6c, 0x61,
+	0x67, 0x73, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d,
+	0x74, 0x63, 0x70, 0x46, 0x6c, 0x61, 0x67, 0x73, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x25, 0x0a,
+	0x0e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x50, 0x72,
+	0x65, 0x66, 0x69, 0x78, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x64,
+	0x65, 0x6c, 0x61, 0x79, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x72, 0x72, 0x75, 0x70, 0x74, 0x5f,
+	0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x63, 0x6f,
+	0x72, 0x72, 0x75, 0x70, 0x74, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x63,
+	0x6f, 0x72, 0x72, 0x75, 0x70, 0x74, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f, 0x72, 0x72, 0x75, 0x70, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x22, 0x34, 0x0a, 0x05, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x12, 0x08, 0x0a, 0x04, 0x44, 0x52, 0x4f,
+	0x50, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x52, 0x45, 0x53, 0x45, 0x54, 0x10, 0x01, 0x12, 0x09,
+	0x0a, 0x05, 0x44, 0x45, 0x4c, 0x41, 0x59, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x4f, 0x52,
+	0x52, 0x55, 0x50, 0x54, 0x10, 0x03, 0x22, 0x30, 0x0a, 0x0c, 0x4c, 0x65, 0x61, 0x73, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x74, 0x6c, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x03, 0x74, 0x74, 0x6c, 0x32, 0xec, 0x07, 0x0a, 0x0b, 0x43, 0x68, 0x61,
 	0x6f, 0x73, 0x44, 0x61, 0x65, 0x6d, 0x6f, 0x6e, 0x12, 0x32, 0x0a, 0x06, 0x53, 0x65, 0x74, 0x54,
 	0x63, 0x73, 0x12, 0x0e, 0x2e, 0x70, 0x62, 0x2e, 0x54, 0x63, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
 	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
@@ -2487,9 +3510,9 @@ var file_chaosdaemon_proto_rawDesc = []byte{
 	0x65, 0x6c, 0x53, 0x74, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
 	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
 	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0c, 0x41, 0x70, 0x70,
-	0x6c, 0x79, 0x49, 0x6f, 0x43, 0x68, 0x61, 0x6f, 0x73, 0x12, 0x17, 0x2e, 0x70, 0x62, 0x2e, 0x41,
-	0x70, 0x70, 0x6c, 0x79, 0x49, 0x6f, 0x43, 0x68, 0x61, 0x6f, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x18, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x49, 0x6f, 0x43,
+	0x6c, 0x79, 0x49, 0x4f, 0x43, 0x68, 0x61, 0x6f, 0x73, 0x12, 0x17, 0x2e, 0x70, 0x62, 0x2e, 0x41,
+	0x70, 0x70, 0x6c, 0x79, 0x49, 0x4f, 0x43, 0x68, 0x61, 0x6f, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x18, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x49, 0x4f, 0x43,
 	0x68, 0x61, 0x6f, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x49,
 	0x0a, 0x0e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x48, 0x74, 0x74, 0x70, 0x43, 0x68, 0x61, 0x6f, 0x73,
 	0x12, 0x19, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x48, 0x74, 0x74, 0x70, 0x43,
@@ -2499,8 +3522,20 @@ var file_chaosdaemon_proto_rawDesc = []byte{
 	0x44, 0x4e, 0x53, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x12, 0x17, 0x2e, 0x70, 0x62, 0x2e, 0x53,
 	0x65, 0x74, 0x44, 0x4e, 0x53, 0x53, 0x65, 0x72, 0x76, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
 	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x0e,
+	0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x12, 0x19,
+	0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x70, 0x62, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x44, 0x0a, 0x0f, 0x53, 0x65, 0x74, 0x50, 0x61,
+	0x63, 0x6b, 0x65, 0x74, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x17, 0x2e, 0x70, 0x62, 0x2e,
+	0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x38, 0x0a,
+	0x0a, 0x52, 0x65, 0x6e, 0x65, 0x77, 0x4c, 0x65, 0x61, 0x73, 0x65, 0x12, 0x10, 0x2e, 0x70, 0x62,
+	0x2e, 0x4c, 0x65, 0x61, 0x73, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -2515,98 +3550,131 @@ func file_chaosdaemon_proto_rawDescGZIP() []byte {
 	return file_chaosdaemon_proto_rawDescData
 }
 
-var file_chaosdaemon_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_chaosdaemon_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
+var file_chaosdaemon_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_chaosdaemon_proto_msgTypes = make([]protoimpl.MessageInfo, 37)
 var file_chaosdaemon_proto_goTypes = []interface{}{
 	(Chain_Direction)(0),           // 0: pb.Chain.Direction
 	(ContainerAction_Action)(0),    // 1: pb.ContainerAction.Action
 	(ExecStressRequest_Scope)(0),   // 2: pb.ExecStressRequest.Scope
 	(Tc_Type)(0),                   // 3: pb.Tc.Type
-	(*TcHandle)(nil),               // 4: pb.TcHandle
-	(*ContainerRequest)(nil),       // 5: pb.ContainerRequest
-	(*ContainerResponse)(nil),      // 6: pb.ContainerResponse
-	(*NetemRequest)(nil),           // 7: pb.NetemRequest
-	(*Netem)(nil),                  // 8: pb.Netem
-	(*TbfRequest)(nil),             // 9: pb.TbfRequest
-	(*Tbf)(nil),                    // 10: pb.Tbf
-	(*QdiscRequest)(nil),           // 11: pb.QdiscRequest
-	(*Qdisc)(nil),                  // 12: pb.Qdisc
-	(*EmatchFilterRequest)(nil),    // 13: pb.EmatchFilterRequest
-	(*EmatchFilter)(nil),           // 14: pb.EmatchFilter
-	(*TcFilterRequest)(nil),        // 15: pb.TcFilterRequest
-	(*TcFilter)(nil),               // 16: pb.TcFilter
-	(*IPSetsRequest)(nil),          // 17: pb.IPSetsRequest
-	(*IPSet)(nil),                  // 18: pb.IPSet
-	(*IptablesChainsRequest)(nil),  // 19: pb.IptablesChainsRequest
-	(*Chain)(nil),                  // 20: pb.Chain
-	(*TimeRequest)(nil),            // 21: pb.TimeRequest
-	(*ContainerAction)(nil),        // 22: pb.ContainerAction
-	(*ExecStressRequest)(nil),      // 23: pb.ExecStressRequest
-	(*ExecStressResponse)(nil),     // 24: pb.ExecStressResponse
-	(*CancelStressRequest)(nil),    // 25: pb.CancelStressRequest
-	(*ApplyIOChaosRequest)(nil),    // 26: pb.ApplyIOChaosRequest
-	(*ApplyIOChaosResponse)(nil),   // 27: pb.ApplyIOChaosResponse
-	(*ApplyHttpChaosRequest)(nil),  // 28: pb.ApplyHttpChaosRequest
-	(*ApplyHttpChaosResponse)(nil), // 29: pb.ApplyHttpChaosResponse
-	(*TcsRequest)(nil),             // 30: pb.TcsRequest
-	(*Tc)(nil),                     // 31: pb.Tc
-	(*SetDNSServerRequest)(nil),    // 32: pb.SetDNSServerRequest
-	(*empty.Empty)(nil),            // 33: google.protobuf.Empty
+	(TcProfile_Waveform)(0),        // 4: pb.TcProfile.Waveform
+	(PacketFault_Fault)(0),         // 5: pb.PacketFault.Fault
+	(*TcHandle)(nil),               // 6: pb.TcHandle
+	(*ContainerRequest)(nil),       // 7: pb.ContainerRequest
+	(*ContainerResponse)(nil),      // 8: pb.ContainerResponse
+	(*NetemRequest)(nil),           // 9: pb.NetemRequest
+	(*Netem)(nil),                  // 10: pb.Netem
+	(*TbfRequest)(nil),             // 11: pb.TbfRequest
+	(*Tbf)(nil),                    // 12: pb.Tbf
+	(*QdiscRequest)(nil),           // 13: pb.QdiscRequest
+	(*Qdisc)(nil),                  // 14: pb.Qdisc
+	(*EmatchFilterRequest)(nil),    // 15: pb.EmatchFilterRequest
+	(*EmatchFilter)(nil),           // 16: pb.EmatchFilter
+	(*TcFilterRequest)(nil),        // 17: pb.TcFilterRequest
+	(*TcFilter)(nil),               // 18: pb.TcFilter
+	(*IPSetsRequest)(nil),          // 19: pb.IPSetsRequest
+	(*IPSet)(nil),                  // 20: pb.IPSet
+	(*IptablesChainsRequest)(nil),  // 21: pb.IptablesChainsRequest
+	(*Chain)(nil),                  // 22: pb.Chain
+	(*TimeRequest)(nil),            // 23: pb.TimeRequest
+	(*ContainerAction)(nil),        // 24: pb.ContainerAction
+	(*ExecStressRequest)(nil),      // 25: pb.ExecStressRequest
+	(*ExecStressResponse)(nil),     // 26: pb.ExecStressResponse
+	(*CancelStressRequest)(nil),    // 27: pb.CancelStressRequest
+	(*ApplyIOChaosRequest)(nil),    // 28: pb.ApplyIOChaosRequest
+	(*ApplyIOChaosResponse)(nil),   // 29: pb.ApplyIOChaosResponse
+	(*ApplyHttpChaosRequest)(nil),  // 30: pb.ApplyHttpChaosRequest
+	(*ApplyHttpChaosResponse)(nil), // 31: pb.ApplyHttpChaosResponse
+	(*TcsRequest)(nil),             // 32: pb.TcsRequest
+	(*Tc)(nil),                     // 33: pb.Tc
+	(*TcProfile)(nil),              // 34: pb.TcProfile
+	(*TcProfileStep)(nil),          // 35: pb.TcProfileStep
+	(*SetDNSServerRequest)(nil),    // 36: pb.SetDNSServerRequest
+	(*DNSRule)(nil),                // 37: pb.DNSRule
+	(*ListInterfacesRequest)(nil),  // 38: pb.ListInterfacesRequest
+	(*ListInterfacesResponse)(nil), // 39: pb.ListInterfacesResponse
+	(*PacketFaultsRequest)(nil),    // 40: pb.PacketFaultsRequest
+	(*PacketFault)(nil),            // 41: pb.PacketFault
+	(*LeaseRequest)(nil),           // 42: pb.LeaseRequest
+	(*empty.Empty)(nil),            // 43: google.protobuf.Empty
 }
 var file_chaosdaemon_proto_depIdxs = []int32{
-	22, // 0: pb.ContainerRequest.action:type_name -> pb.ContainerAction
-	8,  // 1: pb.NetemRequest.netem:type_name -> pb.Netem
-	4,  // 2: pb.NetemRequest.handle:type_name -> pb.TcHandle
-	4,  // 3: pb.NetemRequest.parent:type_name -> pb.TcHandle
-	4,  // 4: pb.Netem.parent:type_name -> pb.TcHandle
-	4,  // 5: pb.Netem.handle:type_name -> pb.TcHandle
-	10, // 6: pb.TbfRequest.tbf:type_name -> pb.Tbf
-	12, // 7: pb.QdiscRequest.qdisc:type_name -> pb.Qdisc
-	4,  // 8: pb.Qdisc.parent:type_name -> pb.TcHandle
-	4,  // 9: pb.Qdisc.handle:type_name -> pb.TcHandle
-	14, // 10: pb.EmatchFilterRequest.filter:type_name -> pb.EmatchFilter
-	4,  // 11: pb.EmatchFilter.parent:type_name -> pb.TcHandle
-	4,  // 12: pb.EmatchFilter.classid:type_name -> pb.TcHandle
-	16, // 13: pb.TcFilterRequest.filter:type_name -> pb.TcFilter
-	4,  // 14: pb.TcFilter.parent:type_name -> pb.TcHandle
-	18, // 15: pb.IPSetsRequest.ipsets:type_name -> pb.IPSet
-	20, // 16: pb.IptablesChainsRequest.chains:type_name -> pb.Chain
-	0,  // 17: pb.Chain.direction:type_name -> pb.Chain.Direction
-	1,  // 18: pb.ContainerAction.action:type_name -> pb.ContainerAction.Action
-	2,  // 19: pb.ExecStressRequest.scope:type_name -> pb.ExecStressRequest.Scope
-	31, // 20: pb.TcsRequest.tcs:type_name -> pb.Tc
-	3,  // 21: pb.Tc.type:type_name -> pb.Tc.Type
-	8,  // 22: pb.Tc.netem:type_name -> pb.Netem
-	10, // 23: pb.Tc.tbf:type_name -> pb.Tbf
-	30, // 24: pb.ChaosDaemon.SetTcs:input_type -> pb.TcsRequest
-	17, // 25: pb.ChaosDaemon.FlushIPSets:input_type -> pb.IPSetsRequest
-	19, // 26: pb.ChaosDaemon.SetIptablesChains:input_type -> pb.IptablesChainsRequest
-	21, // 27: pb.ChaosDaemon.SetTimeOffset:input_type -> pb.TimeRequest
-	21, // 28: pb.ChaosDaemon.RecoverTimeOffset:input_type -> pb.TimeRequest
-	5,  // 29: pb.ChaosDaemon.ContainerKill:input_type -> pb.ContainerRequest
-	5,  // 30: pb.ChaosDaemon.ContainerGetPid:input_type -> pb.ContainerRequest
-	23, // 31: pb.ChaosDaemon.ExecStressors:input_type -> pb.ExecStressRequest
-	25, // 32: pb.ChaosDaemon.CancelStressors:input_type -> pb.CancelStressRequest
-	26, // 33: pb.ChaosDaemon.ApplyIOChaos:input_type -> pb.ApplyIOChaosRequest
-	28, // 34: pb.ChaosDaemon.ApplyHttpChaos:input_type -> pb.ApplyHttpChaosRequest
-	32, // 35: pb.ChaosDaemon.SetDNSServer:input_type -> pb.SetDNSServerRequest
-	33, // 36: pb.ChaosDaemon.SetTcs:output_type -> google.protobuf.Empty
-	33, // 37: pb.ChaosDaemon.FlushIPSets:output_type -> google.protobuf.Empty
-	33, // 38: pb.ChaosDaemon.SetIptablesChains:output_type -> google.protobuf.Empty
-	33, // 39: pb.ChaosDaemon.SetTimeOffset:output_type -> google.protobuf.Empty
-	33, // 40: pb.ChaosDaemon.RecoverTimeOffset:output_type -> google.protobuf.Empty
-	33, // 41: pb.ChaosDaemon.ContainerKill:output_type -> google.protobuf.Empty
-	6,  // 42: pb.ChaosDaemon.ContainerGetPid:output_type -> pb.ContainerResponse
-	24, // 43: pb.ChaosDaemon.ExecStressors:output_type -> pb.ExecStressResponse
-	33, // 44: pb.ChaosDaemon.CancelStressors:output_type -> google.protobuf.Empty
-	27, // 45: pb.ChaosDaemon.ApplyIOChaos:output_type -> pb.ApplyIOChaosResponse
-	29, // 46: pb.ChaosDaemon.ApplyHttpChaos:output_type -> pb.ApplyHttpChaosResponse
-	33, // 47: pb.ChaosDaemon.SetDNSServer:output_type -> google.protobuf.Empty
-	36, // [36:48] is the sub-list for method output_type
-	24, // [24:36] is the sub-list for method input_type
-	24, // [24:24] is the sub-list for extension type_name
-	24, // [24:24] is the sub-list for extension extendee
-	0,  // [0:24] is the sub-list for field type_name
+	24, // 0: pb.ContainerRequest.action:type_name -> pb.ContainerAction
+	10, // 1: pb.NetemRequest.netem:type_name -> pb.Netem
+	6,  // 2: pb.NetemRequest.handle:type_name -> pb.TcHandle
+	6,  // 3: pb.NetemRequest.parent:type_name -> pb.TcHandle
+	6,  // 4: pb.Netem.parent:type_name -> pb.TcHandle
+	6,  // 5: pb.Netem.handle:type_name -> pb.TcHandle
+	12, // 6: pb.TbfRequest.tbf:type_name -> pb.Tbf
+	14, // 7: pb.QdiscRequest.qdisc:type_name -> pb.Qdisc
+	6,  // 8: pb.Qdisc.parent:type_name -> pb.TcHandle
+	6,  // 9: pb.Qdisc.handle:type_name -> pb.TcHandle
+	16, // 10: pb.EmatchFilterRequest.filter:type_name -> pb.EmatchFilter
+	6,  // 11: pb.EmatchFilter.parent:type_name -> pb.TcHandle
+	6,  // 12: pb.EmatchFilter.classid:type_name -> pb.TcHandle
+	18, // 13: pb.TcFilterRequest.filter:type_name -> pb.TcFilter
+	6,  // 14: pb.TcFilter.parent:type_name -> pb.TcHandle
+	20, // 15: pb.IPSetsRequest.ipsets:type_name -> pb.IPSet
+	22, // 16: pb.IptablesChainsRequest.chains:type_name -> pb.Chain
+	42, // 17: pb.IptablesChainsRequest.lease:type_name -> pb.LeaseRequest
+	0,  // 18: pb.Chain.direction:type_name -> pb.Chain.Direction
+	42, // 19: pb.TimeRequest.lease:type_name -> pb.LeaseRequest
+	1,  // 20: pb.ContainerAction.action:type_name -> pb.ContainerAction.Action
+	2,  // 21: pb.ExecStressRequest.scope:type_name -> pb.ExecStressRequest.Scope
+	42, // 22: pb.ExecStressRequest.lease:type_name -> pb.LeaseRequest
+	42, // 23: pb.CancelStressRequest.lease:type_name -> pb.LeaseRequest
+	42, // 24: pb.ApplyIOChaosRequest.lease:type_name -> pb.LeaseRequest
+	42, // 25: pb.ApplyHttpChaosRequest.lease:type_name -> pb.LeaseRequest
+	33, // 26: pb.TcsRequest.tcs:type_name -> pb.Tc
+	42, // 27: pb.TcsRequest.lease:type_name -> pb.LeaseRequest
+	3,  // 28: pb.Tc.type:type_name -> pb.Tc.Type
+	10, // 29: pb.Tc.netem:type_name -> pb.Netem
+	12, // 30: pb.Tc.tbf:type_name -> pb.Tbf
+	34, // 31: pb.Tc.delay_profile:type_name -> pb.TcProfile
+	34, // 32: pb.Tc.loss_profile:type_name -> pb.TcProfile
+	34, // 33: pb.Tc.rate_profile:type_name -> pb.TcProfile
+	4,  // 34: pb.TcProfile.waveform:type_name -> pb.TcProfile.Waveform
+	35, // 35: pb.TcProfile.steps:type_name -> pb.TcProfileStep
+	37, // 36: pb.SetDNSServerRequest.rules:type_name -> pb.DNSRule
+	42, // 37: pb.SetDNSServerRequest.lease:type_name -> pb.LeaseRequest
+	41, // 38: pb.PacketFaultsRequest.faults:type_name -> pb.PacketFault
+	5,  // 39: pb.PacketFault.fault:type_name -> pb.PacketFault.Fault
+	20, // 40: pb.PacketFault.ipset:type_name -> pb.IPSet
+	32, // 41: pb.ChaosDaemon.SetTcs:input_type -> pb.TcsRequest
+	19, // 42: pb.ChaosDaemon.FlushIPSets:input_type -> pb.IPSetsRequest
+	21, // 43: pb.ChaosDaemon.SetIptablesChains:input_type -> pb.IptablesChainsRequest
+	23, // 44: pb.ChaosDaemon.SetTimeOffset:input_type -> pb.TimeRequest
+	23, // 45: pb.ChaosDaemon.RecoverTimeOffset:input_type -> pb.TimeRequest
+	7,  // 46: pb.ChaosDaemon.ContainerKill:input_type -> pb.ContainerRequest
+	7,  // 47: pb.ChaosDaemon.ContainerGetPid:input_type -> pb.ContainerRequest
+	25, // 48: pb.ChaosDaemon.ExecStressors:input_type -> pb.ExecStressRequest
+	27, // 49: pb.ChaosDaemon.CancelStressors:input_type -> pb.CancelStressRequest
+	28, // 50: pb.ChaosDaemon.ApplyIOChaos:input_type -> pb.ApplyIOChaosRequest
+	30, // 51: pb.ChaosDaemon.ApplyHttpChaos:input_type -> pb.ApplyHttpChaosRequest
+	36, // 52: pb.ChaosDaemon.SetDNSServer:input_type -> pb.SetDNSServerRequest
+	38, // 53: pb.ChaosDaemon.ListInterfaces:input_type -> pb.ListInterfacesRequest
+	40, // 54: pb.ChaosDaemon.SetPacketFaults:input_type -> pb.PacketFaultsRequest
+	42, // 55: pb.ChaosDaemon.RenewLease:input_type -> pb.LeaseRequest
+	43, // 56: pb.ChaosDaemon.SetTcs:output_type -> google.protobuf.Empty
+	43, // 57: pb.ChaosDaemon.FlushIPSets:output_type -> google.protobuf.Empty
+	43, // 58: pb.ChaosDaemon.SetIptablesChains:output_type -> google.protobuf.Empty
+	43, // 59: pb.ChaosDaemon.SetTimeOffset:output_type -> google.protobuf.Empty
+	43, // 60: pb.ChaosDaemon.RecoverTimeOffset:output_type -> google.protobuf.Empty
+	43, // 61: pb.ChaosDaemon.ContainerKill:output_type -> google.protobuf.Empty
+	8,  // 62: pb.ChaosDaemon.ContainerGetPid:output_type -> pb.ContainerResponse
+	26, // 63: pb.ChaosDaemon.ExecStressors:output_type -> pb.ExecStressResponse
+	43, // 64: pb.ChaosDaemon.CancelStressors:output_type -> google.protobuf.Empty
+	29, // 65: pb.ChaosDaemon.ApplyIOChaos:output_type -> pb.ApplyIOChaosResponse
+	31, // 66: pb.ChaosDaemon.ApplyHttpChaos:output_type -> pb.ApplyHttpChaosResponse
+	43, // 67: pb.ChaosDaemon.SetDNSServer:output_type -> google.protobuf.Empty
+	39, // 68: pb.ChaosDaemon.ListInterfaces:output_type -> pb.ListInterfacesResponse
+	43, // 69: pb.ChaosDaemon.SetPacketFaults:output_type -> google.protobuf.Empty
+	43, // 70: pb.ChaosDaemon.RenewLease:output_type -> google.protobuf.Empty
+	56, // [56:71] is the sub-list for method output_type
+	41, // [41:56] is the sub-list for method input_type
+	41, // [41:41] is the sub-list for extension type_name
+	41, // [41:41] is the sub-list for extension extendee
+	0,  // [0:41] is the sub-list for field type_name
 }
 
 func init() { file_chaosdaemon_proto_init() }
@@ -2952,6 +4020,30 @@ func file_chaosdaemon_proto_init() {
 			}
 		}
 		file_chaosdaemon_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TcProfile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_chaosdaemon_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TcProfileStep); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_chaosdaemon_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SetDNSServerRequest); i {
 			case 0:
 				return &v.state
@@ -2963,14 +4055,86 @@ func file_chaosdaemon_proto_init() {
 				return nil
 			}
 		}
+		file_chaosdaemon_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DNSRule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_chaosdaemon_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListInterfacesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_chaosdaemon_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListInterfacesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_chaosdaemon_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PacketFaultsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_chaosdaemon_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PacketFault); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_chaosdaemon_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LeaseRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_chaosdaemon_proto_rawDesc,
-			NumEnums:      4,
-			NumMessages:   29,
+			NumEnums:      6,
+			NumMessages:   37,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -3009,6 +4173,9 @@ type ChaosDaemonClient interface {
 	ApplyIOChaos(ctx context.Context, in *ApplyIOChaosRequest, opts ...grpc.CallOption) (*ApplyIOChaosResponse, error)
 	ApplyHttpChaos(ctx context.Context, in *ApplyHttpChaosRequest, opts ...grpc.CallOption) (*ApplyHttpChaosResponse, error)
 	SetDNSServer(ctx context.Context, in *SetDNSServerRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	ListInterfaces(ctx context.Context, in *ListInterfacesRequest, opts ...grpc.CallOption) (*ListInterfacesResponse, error)
+	SetPacketFaults(ctx context.Context, in *PacketFaultsRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	RenewLease(ctx context.Context, in *LeaseRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 }
 
 type chaosDaemonClient struct {
@@ -3127,6 +4294,33 @@ func (c *chaosDaemonClient) SetDNSServer(ctx context.Context, in *SetDNSServerRe
 	return out, nil
 }
 
+func (c *chaosDaemonClient) ListInterfaces(ctx context.Context, in *ListInterfacesRequest, opts ...grpc.CallOption) (*ListInterfacesResponse, error) {
+	out := new(ListInterfacesResponse)
+	err := c.cc.Invoke(ctx, "/pb.ChaosDaemon/ListInterfaces", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chaosDaemonClient) SetPacketFaults(ctx context.Context, in *PacketFaultsRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/pb.ChaosDaemon/SetPacketFaults", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chaosDaemonClient) RenewLease(ctx context.Context, in *LeaseRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/pb.ChaosDaemon/RenewLease", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ChaosDaemonServer is the server API for ChaosDaemon service.
 type ChaosDaemonServer interface {
 	SetTcs(context.Context, *TcsRequest) (*empty.Empty, error)
@@ -3141,6 +4335,9 @@ type ChaosDaemonServer interface {
 	ApplyIOChaos(context.Context, *ApplyIOChaosRequest) (*ApplyIOChaosResponse, error)
 	ApplyHttpChaos(context.Context, *ApplyHttpChaosRequest) (*ApplyHttpChaosResponse, error)
 	SetDNSServer(context.Context, *SetDNSServerRequest) (*empty.Empty, error)
+	ListInterfaces(context.Context, *ListInterfacesRequest) (*ListInterfacesResponse, error)
+	SetPacketFaults(context.Context, *PacketFaultsRequest) (*empty.Empty, error)
+	RenewLease(context.Context, *LeaseRequest) (*empty.Empty, error)
 }
 
 // UnimplementedChaosDaemonServer can be embedded to have forward compatible implementations.
@@ -3183,6 +4380,15 @@ func (*UnimplementedChaosDaemonServer) ApplyHttpChaos(context.Context, *ApplyHtt
 func (*UnimplementedChaosDaemonServer) SetDNSServer(context.Context, *SetDNSServerRequest) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetDNSServer not implemented")
 }
+func (*UnimplementedChaosDaemonServer) ListInterfaces(context.Context, *ListInterfacesRequest) (*ListInterfacesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInterfaces not implemented")
+}
+func (*UnimplementedChaosDaemonServer) SetPacketFaults(context.Context, *PacketFaultsRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPacketFaults not implemented")
+}
+func (*UnimplementedChaosDaemonServer) RenewLease(context.Context, *LeaseRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenewLease not implemented")
+}
 
 func RegisterChaosDaemonServer(s *grpc.Server, srv ChaosDaemonServer) {
 	s.RegisterService(&_ChaosDaemon_serviceDesc, srv)
@@ -3404,6 +4610,60 @@ func _ChaosDaemon_SetDNSServer_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ChaosDaemon_ListInterfaces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInterfacesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChaosDaemonServer).ListInterfaces(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ChaosDaemon/ListInterfaces",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChaosDaemonServer).ListInterfaces(ctx, req.(*ListInterfacesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChaosDaemon_SetPacketFaults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PacketFaultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChaosDaemonServer).SetPacketFaults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ChaosDaemon/SetPacketFaults",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChaosDaemonServer).SetPacketFaults(ctx, req.(*PacketFaultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChaosDaemon_RenewLease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChaosDaemonServer).RenewLease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.ChaosDaemon/RenewLease",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChaosDaemonServer).RenewLease(ctx, req.(*LeaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ChaosDaemon_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "pb.ChaosDaemon",
 	HandlerType: (*ChaosDaemonServer)(nil),
@@ -3456,6 +4716,18 @@ var _ChaosDaemon_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SetDNSServer",
 			Handler:    _ChaosDaemon_SetDNSServer_Handler,
 		},
+		{
+			MethodName: "ListInterfaces",
+			Handler:    _ChaosDaemon_ListInterfaces_Handler,
+		},
+		{
+			MethodName: "SetPacketFaults",
+			Handler:    _ChaosDaemon_SetPacketFaults_Handler,
+		},
+		{
+			MethodName: "RenewLease",
+			Handler:    _ChaosDaemon_RenewLease_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "chaosdaemon.proto",
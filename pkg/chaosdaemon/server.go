@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net"
+	"path/filepath"
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
@@ -48,6 +49,14 @@ type Config struct {
 	Host      string
 	Runtime   string
 	Profiling bool
+	// Firewall is the backend to set the network rules, which is auto, iptables or nftables
+	Firewall string
+	// KubeletPort is the port of kubelet, which is protected from the network chaos on the host network
+	// together with the grpc port
+	KubeletPort int
+	// StateDir is the directory on the host to keep the leases of the faults and the rules of dns chaos across the
+	// restarts of chaos-daemon
+	StateDir string
 
 	tlsConfig
 }
@@ -75,6 +84,21 @@ type DaemonServer struct {
 	backgroundProcessManager bpm.BackgroundProcessManager
 
 	IPSetLocker *locker.Locker
+
+	tcProfiles *tcProfileRunners
+
+	// firewall is the resolved firewall backend, which is iptables or nftables
+	firewall string
+
+	// protectedPorts are the tcp ports in the network namespace of the host which are never affected by the
+	// network chaos on the host network
+	protectedPorts []uint32
+	hostNetwork    *hostNetwork
+
+	// dnsServers are the dns servers answering the queries of the dns chaos
+	dnsServers *dnsServers
+
+	watchdog *watchdog
 }
 
 func newDaemonServer(containerRuntime string) (*DaemonServer, error) {
@@ -88,24 +112,50 @@ func newDaemonServer(containerRuntime string) (*DaemonServer, error) {
 
 // NewDaemonServerWithCRClient returns DaemonServer with container runtime client
 func NewDaemonServerWithCRClient(crClient crclients.ContainerRuntimeInfoClient) *DaemonServer {
-	return &DaemonServer{
+	s := &DaemonServer{
 		IPSetLocker:              locker.New(),
+		tcProfiles:               newTcProfileRunners(),
 		crClient:                 crClient,
 		backgroundProcessManager: bpm.NewBackgroundProcessManager(),
+		hostNetwork:              newHostNetwork(),
+		dnsServers:               newDNSServers(),
 	}
+	s.watchdog = newWatchdog("", s.recoverLease)
+	return s
 }
 
-func newGRPCServer(containerRuntime string, reg prometheus.Registerer, tlsConf tlsConfig) (*grpc.Server, error) {
+func newGRPCServer(containerRuntime string, firewall string, protectedPorts []uint32, stateDir string, reg prometheus.Registerer, tlsConf tlsConfig) (*grpc.Server, error) {
 	ds, err := newDaemonServer(containerRuntime)
 	if err != nil {
 		return nil, err
 	}
+	ds.firewall, err = resolveFirewall(firewall)
+	if err != nil {
+		return nil, err
+	}
+	ds.protectedPorts = protectedPorts
+
+	// the leases and dns rules are kept in the state directory, so the faults are still recovered or served after
+	// chaos-daemon restarts
+	if len(stateDir) > 0 {
+		ds.watchdog.path = filepath.Join(stateDir, leaseStateFile)
+		if err := ds.restoreLeases(); err != nil {
+			return nil, err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), recoverTimeout)
+		defer cancel()
+		ds.dnsServers.path = filepath.Join(stateDir, dnsStateFile)
+		if err := ds.restoreDNSServers(ctx); err != nil {
+			return nil, err
+		}
+	}
 
 	grpcMetrics := grpc_prometheus.NewServerMetrics()
 	grpcMetrics.EnableHandlingTimeHistogram(
 		grpc_prometheus.WithHistogramBuckets([]float64{0.001, 0.01, 0.1, 0.3, 0.6, 1, 3, 6, 10}),
 	)
-	reg.MustRegister(grpcMetrics)
+	reg.MustRegister(grpcMetrics, ds.watchdog.recoveredLeases)
 
 	grpcOpts := []grpc.ServerOption{
 		grpc_middleware.WithUnaryServerChain(
@@ -165,7 +215,11 @@ func StartServer(conf *Config, reg RegisterGatherer) error {
 		return err
 	}
 
-	grpcServer, err := newGRPCServer(conf.Runtime, reg, conf.tlsConfig)
+	protectedPorts := []uint32{uint32(conf.GRPCPort)}
+	if conf.KubeletPort > 0 {
+		protectedPorts = append(protectedPorts, uint32(conf.KubeletPort))
+	}
+	grpcServer, err := newGRPCServer(conf.Runtime, conf.Firewall, protectedPorts, conf.StateDir, reg, conf.tlsConfig)
 	if err != nil {
 		log.Error(err, "failed to create grpc server")
 		return err
@@ -40,7 +40,7 @@ var _ = Describe("netem server", func() {
 	Context("newGRPCServer", func() {
 		It("should work", func() {
 			defer mock.With("MockContainerdClient", &test.MockClient{})()
-			_, err := newGRPCServer(crclients.ContainerRuntimeContainerd, &MockRegisterer{}, tlsConfig{})
+			_, err := newGRPCServer(crclients.ContainerRuntimeContainerd, IptablesFirewall, nil, "", &MockRegisterer{}, tlsConfig{})
 			Expect(err).To(BeNil())
 		})
 
@@ -48,7 +48,7 @@ var _ = Describe("netem server", func() {
 			Ω(func() {
 				defer mock.With("MockContainerdClient", &test.MockClient{})()
 				defer mock.With("PanicOnMustRegister", "mock panic")()
-				_, err := newGRPCServer(crclients.ContainerRuntimeContainerd, &MockRegisterer{}, tlsConfig{})
+				_, err := newGRPCServer(crclients.ContainerRuntimeContainerd, IptablesFirewall, nil, "", &MockRegisterer{}, tlsConfig{})
 				Expect(err).To(BeNil())
 			}).Should(Panic())
 		})
@@ -30,6 +30,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/chaos-mesh/chaos-mesh/pkg/bpm"
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/command"
 	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
 )
 
@@ -64,7 +65,7 @@ func (s *DaemonServer) ExecStressors(ctx context.Context,
 		return nil, err
 	}
 
-	processBuilder := bpm.DefaultProcessBuilder("stress-ng", strings.Fields(req.Stressors)...).
+	processBuilder := bpm.DefaultProcessBuilder(command.StressNg, strings.Fields(req.Stressors)...).
 		EnablePause()
 	if req.EnterNS {
 		processBuilder = processBuilder.SetNS(pid, bpm.PidNS)
@@ -108,6 +109,13 @@ func (s *DaemonServer) ExecStressors(ctx context.Context,
 		log.Info("the process hasn't resumed, step into the following loop", "comm", comm)
 	}
 
+	// the stressors are killed once the lease expires
+	recovery := &pb.CancelStressRequest{
+		Instance:  strconv.Itoa(cmd.Process.Pid),
+		StartTime: ct,
+	}
+	s.holdLease(req.Lease, recoveryOf(methodCancelStressors, recovery))
+
 	return &pb.ExecStressResponse{
 		Instance:  strconv.Itoa(cmd.Process.Pid),
 		StartTime: ct,
@@ -121,6 +129,7 @@ func (s *DaemonServer) CancelStressors(ctx context.Context,
 		return nil, err
 	}
 	log.Info("Canceling stressors", "request", req)
+	s.releaseLease(req.Lease)
 
 	err = s.backgroundProcessManager.KillBackgroundProcess(ctx, pid, req.StartTime)
 	if err != nil {
@@ -0,0 +1,319 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/command"
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+)
+
+// randomWalkSteps is the number of steps of the random walk from the min value to the max value
+const randomWalkSteps = 10
+
+// tcProfile computes the value of a profile over time
+type tcProfile struct {
+	*pb.TcProfile
+
+	rand *rand.Rand
+	// walked is the value of the random walk after the walkedSteps-th step
+	walked      float64
+	walkedSteps int64
+}
+
+func newTcProfile(profile *pb.TcProfile) *tcProfile {
+	if profile == nil {
+		return nil
+	}
+
+	return &tcProfile{
+		TcProfile: profile,
+		rand:      rand.New(rand.NewSource(time.Now().UnixNano())),
+		walked:    profile.Min,
+	}
+}
+
+// stepsDuration returns the duration of all steps
+func (p *tcProfile) stepsDuration() time.Duration {
+	var total time.Duration
+	for _, step := range p.Steps {
+		total += time.Duration(step.Duration)
+	}
+
+	return total
+}
+
+// valueAt returns the value of the profile at the elapsed time since it starts. The random walk
+// can only move forward, so the elapsed time should not decrease between the calls.
+func (p *tcProfile) valueAt(elapsed time.Duration) float64 {
+	if p.Waveform == pb.TcProfile_STEPS {
+		total := p.stepsDuration()
+		if len(p.Steps) == 0 || total <= 0 {
+			return 0
+		}
+		if elapsed >= total {
+			if !p.Repeat {
+				return p.Steps[len(p.Steps)-1].Value
+			}
+			elapsed %= total
+		}
+
+		for _, step := range p.Steps {
+			if elapsed < time.Duration(step.Duration) {
+				return step.Value
+			}
+			elapsed -= time.Duration(step.Duration)
+		}
+
+		return p.Steps[len(p.Steps)-1].Value
+	}
+
+	// the value of the waveform only changes at the multiples of the interval
+	steps := int64(elapsed) / p.Interval
+	at := time.Duration(steps * p.Interval)
+
+	switch p.Waveform {
+	case pb.TcProfile_SINE:
+		phase := 2 * math.Pi * float64(at%time.Duration(p.Period)) / float64(p.Period)
+		return p.Min + (p.Max-p.Min)*(1-math.Cos(phase))/2
+	case pb.TcProfile_SQUARE:
+		if at%time.Duration(p.Period) < time.Duration(p.Period)/2 {
+			return p.Min
+		}
+		return p.Max
+	case pb.TcProfile_RANDOM_WALK:
+		stride := (p.Max - p.Min) / randomWalkSteps
+		for ; p.walkedSteps < steps; p.walkedSteps++ {
+			if p.rand.Intn(2) == 0 {
+				p.walked = math.Max(p.Min, p.walked-stride)
+			} else {
+				p.walked = math.Min(p.Max, p.walked+stride)
+			}
+		}
+		return p.walked
+	}
+
+	return p.Min
+}
+
+// nextUpdate returns the elapsed time when the value of the profile may change next time,
+// or a negative duration if the value never changes again
+func (p *tcProfile) nextUpdate(elapsed time.Duration) time.Duration {
+	if p.Waveform != pb.TcProfile_STEPS {
+		if p.Interval <= 0 || (p.Waveform != pb.TcProfile_RANDOM_WALK && p.Period <= 0) {
+			return -1
+		}
+		return time.Duration((int64(elapsed)/p.Interval + 1) * p.Interval)
+	}
+
+	total := p.stepsDuration()
+	if total <= 0 || (!p.Repeat && elapsed >= total) {
+		return -1
+	}
+
+	round := elapsed / total * total
+	next := round
+	for _, step := range p.Steps {
+		next += time.Duration(step.Duration)
+		if next > elapsed {
+			return next
+		}
+	}
+
+	return round + total
+}
+
+// profiledTc is a tc whose parameters are varied by the profiles
+type profiledTc struct {
+	tc *pb.Tc
+
+	delay *tcProfile
+	loss  *tcProfile
+	rate  *tcProfile
+}
+
+// buildProfiledTcs returns the tcs with any profile
+func buildProfiledTcs(tcs []*pb.Tc) []*profiledTc {
+	var profiled []*profiledTc
+	for _, tc := range tcs {
+		if tc.DelayProfile == nil && tc.LossProfile == nil && tc.RateProfile == nil {
+			continue
+		}
+
+		profiled = append(profiled, &profiledTc{
+			tc:    tc,
+			delay: newTcProfile(tc.DelayProfile),
+			loss:  newTcProfile(tc.LossProfile),
+			rate:  newTcProfile(tc.RateProfile),
+		})
+	}
+
+	return profiled
+}
+
+func (p *profiledTc) profiles() []*tcProfile {
+	var profiles []*tcProfile
+	for _, profile := range []*tcProfile{p.delay, p.loss, p.rate} {
+		if profile != nil {
+			profiles = append(profiles, profile)
+		}
+	}
+
+	return profiles
+}
+
+// apply sets the parameters of the tc to the values of the profiles at the elapsed time
+func (p *profiledTc) apply(elapsed time.Duration) {
+	if p.delay != nil && p.tc.Netem != nil {
+		p.tc.Netem.Time = uint32(p.delay.valueAt(elapsed))
+	}
+	if p.loss != nil && p.tc.Netem != nil {
+		p.tc.Netem.Loss = float32(p.loss.valueAt(elapsed))
+	}
+	if p.rate != nil && p.tc.Tbf != nil {
+		p.tc.Tbf.Rate = uint64(p.rate.valueAt(elapsed))
+	}
+}
+
+// nextUpdate returns the earliest elapsed time when any profile of the tcs may change, or a
+// negative duration if none of them changes again
+func nextUpdate(profiled []*profiledTc, elapsed time.Duration) time.Duration {
+	next := time.Duration(-1)
+	for _, p := range profiled {
+		for _, profile := range p.profiles() {
+			update := profile.nextUpdate(elapsed)
+			if update >= 0 && (next < 0 || update < next) {
+				next = update
+			}
+		}
+	}
+
+	return next
+}
+
+// tcProfileRunner updates the qdiscs of a tcs request by the profiles in background
+type tcProfileRunner struct {
+	request *pb.TcsRequest
+	start   time.Time
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// tcProfileRunners keeps the running runners by the container, the device and whether it's on the inbound traffic
+type tcProfileRunners struct {
+	sync.Mutex
+
+	runners map[string]*tcProfileRunner
+}
+
+func newTcProfileRunners() *tcProfileRunners {
+	return &tcProfileRunners{
+		runners: map[string]*tcProfileRunner{},
+	}
+}
+
+func tcProfileKey(in *pb.TcsRequest) string {
+	if in.Ingress {
+		return in.ContainerId + "/" + in.Device + "/ingress"
+	}
+	return in.ContainerId + "/" + in.Device
+}
+
+// stop stops the runner of the container and the device. It returns the start time of the
+// stopped runner if the request is the same as the one of the runner, so the profiles can
+// continue from where they are, otherwise returns the current time.
+func (r *tcProfileRunners) stop(in *pb.TcsRequest) time.Time {
+	r.Lock()
+	runner, ok := r.runners[tcProfileKey(in)]
+	delete(r.runners, tcProfileKey(in))
+	r.Unlock()
+
+	if !ok {
+		return time.Now()
+	}
+
+	runner.cancel()
+	<-runner.done
+
+	if proto.Equal(runner.request, in) {
+		return runner.start
+	}
+	return time.Now()
+}
+
+// start runs the profiles of the tcs in the request, which have been set with the values at the start
+// time. The original request is kept for comparison, because the runner changes the parameters of the tcs.
+func (r *tcProfileRunners) start(in *pb.TcsRequest, request *pb.TcsRequest, profiled []*profiledTc, start time.Time, pid uint32) {
+	if len(profiled) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	runner := &tcProfileRunner{
+		request: request,
+		start:   start,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+	}
+
+	r.Lock()
+	r.runners[tcProfileKey(in)] = runner
+	r.Unlock()
+
+	go func() {
+		defer close(runner.done)
+
+		tcCli := buildTcClient(ctx, in.EnterNS, pid)
+		for {
+			next := nextUpdate(profiled, time.Since(start))
+			if next < 0 {
+				log.Info("tc profiles end", "containerID", in.ContainerId, "device", in.Device)
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Until(start.Add(next))):
+			}
+
+			elapsed := time.Since(start)
+			for _, p := range profiled {
+				p.apply(elapsed)
+			}
+			plan, err := planTcs(in)
+			if err != nil {
+				log.Error(err, "error while planning tc")
+				return
+			}
+			for _, p := range profiled {
+				if err := tcCli.changeQdisc(command.ChangeQdiscArgs(plan.Qdiscs[plan.QdiscOf[p.tc]])); err != nil {
+					// the container may have been removed, or the runner has been stopped
+					if ctx.Err() == nil {
+						log.Error(err, "error while changing qdisc by the profile, stop the tc profiles")
+					}
+					return
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,138 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+)
+
+var _ = Describe("tc profile", func() {
+	Context("steps", func() {
+		steps := []*pb.TcProfileStep{
+			{Value: 10, Duration: int64(time.Minute)},
+			{Value: 20, Duration: int64(2 * time.Minute)},
+		}
+
+		It("should keep the last value", func() {
+			profile := newTcProfile(&pb.TcProfile{Steps: steps})
+			Expect(profile.valueAt(0)).To(Equal(float64(10)))
+			Expect(profile.valueAt(time.Minute)).To(Equal(float64(20)))
+			Expect(profile.valueAt(10 * time.Minute)).To(Equal(float64(20)))
+
+			Expect(profile.nextUpdate(0)).To(Equal(time.Minute))
+			Expect(profile.nextUpdate(time.Minute)).To(Equal(3 * time.Minute))
+			Expect(profile.nextUpdate(3 * time.Minute)).To(BeNumerically("<", 0))
+		})
+
+		It("should repeat", func() {
+			profile := newTcProfile(&pb.TcProfile{Steps: steps, Repeat: true})
+			Expect(profile.valueAt(3 * time.Minute)).To(Equal(float64(10)))
+			Expect(profile.valueAt(4 * time.Minute)).To(Equal(float64(20)))
+			Expect(profile.nextUpdate(3 * time.Minute)).To(Equal(4 * time.Minute))
+			Expect(profile.nextUpdate(5 * time.Minute)).To(Equal(6 * time.Minute))
+		})
+	})
+
+	Context("waveform", func() {
+		It("should vary by sine", func() {
+			profile := newTcProfile(&pb.TcProfile{
+				Waveform: pb.TcProfile_SINE,
+				Min:      100,
+				Max:      200,
+				Period:   int64(time.Minute),
+				Interval: int64(time.Second),
+			})
+			Expect(profile.valueAt(0)).To(Equal(float64(100)))
+			Expect(profile.valueAt(30 * time.Second)).To(BeNumerically("~", 200, 0.001))
+			Expect(profile.valueAt(15*time.Second + 500*time.Millisecond)).To(BeNumerically("~", 150, 0.001))
+			Expect(profile.nextUpdate(1500 * time.Millisecond)).To(Equal(2 * time.Second))
+		})
+
+		It("should vary by square", func() {
+			profile := newTcProfile(&pb.TcProfile{
+				Waveform: pb.TcProfile_SQUARE,
+				Min:      0,
+				Max:      50,
+				Period:   int64(time.Minute),
+				Interval: int64(time.Second),
+			})
+			Expect(profile.valueAt(29 * time.Second)).To(Equal(float64(0)))
+			Expect(profile.valueAt(30 * time.Second)).To(Equal(float64(50)))
+			Expect(profile.valueAt(time.Minute)).To(Equal(float64(0)))
+		})
+
+		It("should walk randomly between the min and max value", func() {
+			profile := newTcProfile(&pb.TcProfile{
+				Waveform: pb.TcProfile_RANDOM_WALK,
+				Min:      0,
+				Max:      100,
+				Interval: int64(time.Second),
+			})
+			Expect(profile.valueAt(0)).To(Equal(float64(0)))
+
+			last := float64(0)
+			for i := 1; i <= 100; i++ {
+				value := profile.valueAt(time.Duration(i) * time.Second)
+				Expect(value).To(BeNumerically(">=", 0))
+				Expect(value).To(BeNumerically("<=", 100))
+				Expect(value - last).To(BeNumerically("~", 0, 10))
+				last = value
+			}
+		})
+	})
+
+	Context("profiled tc", func() {
+		It("should apply the values of the profiles", func() {
+			tcs := []*pb.Tc{
+				{Type: pb.Tc_NETEM, Netem: &pb.Netem{Time: 1000}},
+				{
+					Type:  pb.Tc_NETEM,
+					Netem: &pb.Netem{Time: 1000, Loss: 10},
+					LossProfile: &pb.TcProfile{Steps: []*pb.TcProfileStep{
+						{Value: 10, Duration: int64(time.Minute)},
+						{Value: 30, Duration: int64(time.Minute)},
+					}},
+				},
+				{
+					Type: pb.Tc_BANDWIDTH,
+					Tbf:  &pb.Tbf{Rate: 1000},
+					RateProfile: &pb.TcProfile{
+						Waveform: pb.TcProfile_SQUARE,
+						Min:      1000,
+						Max:      2000,
+						Period:   int64(2 * time.Minute),
+						Interval: int64(time.Second),
+					},
+				},
+			}
+
+			profiled := buildProfiledTcs(tcs)
+			Expect(profiled).To(HaveLen(2))
+			Expect(nextUpdate(profiled, 0)).To(Equal(time.Second))
+
+			for _, p := range profiled {
+				p.apply(time.Minute)
+			}
+			Expect(tcs[0].Netem.Time).To(Equal(uint32(1000)))
+			Expect(tcs[1].Netem.Time).To(Equal(uint32(1000)))
+			Expect(tcs[1].Netem.Loss).To(Equal(float32(30)))
+			Expect(tcs[2].Tbf.Rate).To(Equal(uint64(2000)))
+		})
+	})
+})
@@ -17,217 +17,193 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/chaos-mesh/chaos-mesh/pkg/bpm"
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/command"
+	"github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/packetfault"
 	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/empty"
 )
 
 const (
 	ruleNotExist             = "Cannot delete qdisc with handle of zero."
 	ruleNotExistLowerVersion = "RTNETLINK answers: No such file or directory"
+	// the messages of tc when the clsact qdisc or the redirection on it doesn't exist
+	ingressNotExist            = "Cannot find specified filter chain"
+	clsactNotExist             = "Parent Qdisc doesn't exists"
+	clsactNotExistLowerVersion = "RTNETLINK answers: Invalid argument"
+	deviceNotExist             = "Cannot find device"
 
 	defaultDevice = "eth0"
 )
 
-func generateQdiscArgs(action string, qdisc *pb.Qdisc) ([]string, error) {
-	if qdisc == nil {
-		return nil, fmt.Errorf("qdisc is required")
+func setDefaultTcsRequest(in *pb.TcsRequest) {
+	if len(in.Device) == 0 {
+		in.Device = defaultDevice
 	}
+}
+
+func (s *DaemonServer) SetTcs(ctx context.Context, in *pb.TcsRequest) (*empty.Empty, error) {
+	log.Info("handling tc request", "tcs", in)
 
-	if qdisc.Type == "" {
-		return nil, fmt.Errorf("qdisc.Type is required")
+	pid, enterNS, err := s.networkTarget(ctx, in.ContainerId, in.EnterNS, in.HostNetwork)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "get pid from containerID error: %v", err)
 	}
 
-	args := []string{"qdisc", action, "dev", "eth0"}
+	if in.HostNetwork && len(in.Device) == 0 {
+		in.Device, err = defaultRouteDevice(pid)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "get the device of the default route error: %v", err)
+		}
+	}
+	setDefaultTcsRequest(in)
 
-	if qdisc.Parent == nil {
-		args = append(args, "root")
-	} else if qdisc.Parent.Major == 1 && qdisc.Parent.Minor == 0 {
-		args = append(args, "root")
-	} else {
-		args = append(args, "parent", fmt.Sprintf("%d:%d", qdisc.Parent.Major, qdisc.Parent.Minor))
+	// the fq qdisc holding the packets delayed by the packet faults is the root of the outbound traffic
+	delayed := false
+	if !in.Ingress {
+		netnsPath := ""
+		if enterNS {
+			netnsPath = fmt.Sprintf("/proc/%d/ns/net", pid)
+		}
+		delayed, err = packetfault.Delayed(netnsPath, in.Device)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "get the root qdisc of %s error: %v", in.Device, err)
+		}
+		if delayed && len(in.Tcs) > 0 {
+			return nil, status.Errorf(codes.FailedPrecondition,
+				"the outbound traffic of %s can't be controlled, whose packets are delayed by the packet faults", in.Device)
+		}
 	}
 
-	if qdisc.Handle == nil {
-		args = append(args, "handle", fmt.Sprintf("%d:%d", 1, 0))
+	if in.HostNetwork {
+		// the inbound packets are classified by tc filters on the IFB device, which don't skip the protected ports
+		if in.Ingress && len(in.Tcs) > 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "the inbound traffic of the host network can't be shaped")
+		}
+		in.EnterNS = enterNS
+		s.holdHostNetwork(in.Device)
 	} else {
-		args = append(args, "handle", fmt.Sprintf("%d:%d", qdisc.Handle.Major, qdisc.Handle.Minor))
+		s.holdTcs(in)
 	}
 
-	args = append(args, qdisc.Type)
-
-	if qdisc.Args != nil {
-		args = append(args, qdisc.Args...)
+	// the profiles continue from where they are if the same request is sent again
+	start := s.tcProfiles.stop(in)
+	request := proto.Clone(in).(*pb.TcsRequest)
+	profiled := buildProfiledTcs(in.Tcs)
+	elapsed := time.Since(start)
+	for _, p := range profiled {
+		p.apply(elapsed)
 	}
 
-	return args, nil
-}
-
-func setDefaultTcsRequest(in *pb.TcsRequest) {
-	if len(in.Device) == 0 {
-		in.Device = defaultDevice
+	tcCli := buildTcClient(ctx, enterNS, pid)
+	if in.Ingress {
+		err = tcCli.flushIngress(in.Device)
+	} else if !delayed {
+		err = tcCli.flush(in.Device)
 	}
-}
-
-func (s *DaemonServer) SetTcs(ctx context.Context, in *pb.TcsRequest) (*empty.Empty, error) {
-	log.Info("handling tc request", "tcs", in)
-
-	pid, err := s.crClient.GetPidFromContainerID(ctx, in.ContainerId)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "get pid from containerID error: %v", err)
+		log.Error(err, "error while flushing client")
+		return &empty.Empty{}, err
 	}
 
-	setDefaultTcsRequest(in)
+	// the IFB device is only kept while there are tcs on the inbound traffic
+	if in.Ingress && len(in.Tcs) == 0 {
+		return &empty.Empty{}, nil
+	}
 
-	tcCli := buildTcClient(ctx, in.EnterNS, pid)
-	err = tcCli.flush(in.Device)
+	plan, err := planTcs(in)
 	if err != nil {
-		log.Error(err, "error while flushing client")
+		log.Error(err, "error while planning tc")
 		return &empty.Empty{}, err
 	}
 
-	// tc rules are split into two different kinds according to whether it has filter.
-	// all tc rules without filter are called `globalTc` and the tc rules with filter will be called `filterTc`.
-	// the `globalTc` rules will be piped one by one from root, and the last `globalTc` will be connected with a PRIO
-	// qdisc, which has `3 + len(filterTc)` bands. Then the 4.. bands will be connected to `filterTc` and a filter will
-	// be setuped to flow packet from PRIO qdisc to it.
-
-	// for example, four tc rules:
-	// - NETEM: 50ms latency without filter
-	// - NETEM: 100ms latency without filter
-	// - NETEM: 50ms latency with filter ipset A
-	// - NETEM: 100ms latency with filter ipset B
-	// will generate tc rules:
-	//	tc qdisc del dev eth0 root
-	//  tc qdisc add dev eth0 root handle 1: netem delay 50000
-	//  tc qdisc add dev eth0 parent 1: handle 2: netem delay 100000
-	//  tc qdisc add dev eth0 parent 2: handle 3: prio bands 5 priomap 1 2 2 2 1 2 0 0 1 1 1 1 1 1 1 1
-	//  tc qdisc add dev eth0 parent 3:1 handle 4: sfq
-	//  tc qdisc add dev eth0 parent 3:2 handle 5: sfq
-	//  tc qdisc add dev eth0 parent 3:3 handle 6: sfq
-	//  tc qdisc add dev eth0 parent 3:4 handle 7: netem delay 50000
-	//  iptables -A TC-TABLES-0 -m set --match-set A dst -j CLASSIFY --set-class 3:4 -w 5
-	//  tc qdisc add dev eth0 parent 3:5 handle 8: netem delay 100000
-	//  iptables -A TC-TABLES-1 -m set --match-set B dst -j CLASSIFY --set-class 3:5 -w 5
-
-	globalTc := []*pb.Tc{}
-	filterTc := make(map[string][]*pb.Tc)
-
-	for _, tc := range in.Tcs {
-		filter := abstractTcFilter(tc)
-		if len(filter) > 0 {
-			filterTc[filter] = append(filterTc[filter], tc)
-			continue
+	if len(plan.Filters) > 0 && s.firewall == NftablesFirewall {
+		for _, tc := range in.Tcs {
+			if len(tc.Ipset) > 0 {
+				err := fmt.Errorf("the inbound traffic can't be filtered by the ipsets of the %s firewall", NftablesFirewall)
+				log.Error(err, "error while planning tc")
+				return &empty.Empty{}, err
+			}
 		}
-		globalTc = append(globalTc, tc)
 	}
 
-	if len(globalTc) > 0 {
-		if err := s.setGlobalTcs(tcCli, globalTc, in.Device); err != nil {
-			log.Error(err, "error while setting global tc")
+	if in.Ingress {
+		if err := tcCli.setupIngress(in.Device); err != nil {
+			log.Error(err, "error while redirecting the inbound traffic")
 			return &empty.Empty{}, err
 		}
 	}
 
-	if len(filterTc) > 0 {
-		iptablesCli := buildIptablesClient(ctx, in.EnterNS, pid)
-		if err := s.setFilterTcs(tcCli, iptablesCli, filterTc, in.Device, len(globalTc)); err != nil {
-			log.Error(err, "error while setting filter tc")
+	for _, args := range plan.Qdiscs {
+		if err := tcCli.addQdisc(args); err != nil {
+			log.Error(err, "error while adding qdisc")
 			return &empty.Empty{}, err
 		}
 	}
 
-	return &empty.Empty{}, nil
-}
-
-func (s *DaemonServer) setGlobalTcs(cli tcClient, tcs []*pb.Tc, device string) error {
-	for index, tc := range tcs {
-		parentArg := "root"
-		if index > 0 {
-			parentArg = fmt.Sprintf("parent %d:", index)
-		}
-
-		handleArg := fmt.Sprintf("handle %d:", index+1)
-
-		err := cli.addTc(device, parentArg, handleArg, tc)
-		if err != nil {
-			log.Error(err, "error while adding tc")
-			return err
+	for _, args := range plan.Filters {
+		if err := tcCli.addFilter(args); err != nil {
+			log.Error(err, "error while adding filter")
+			return &empty.Empty{}, err
 		}
 	}
 
-	return nil
-}
-
-func (s *DaemonServer) setFilterTcs(
-	tcCli tcClient,
-	iptablesCli iptablesClient,
-	filterTc map[string][]*pb.Tc,
-	device string,
-	baseIndex int,
-) error {
-	parent := baseIndex
-	band := 3 + len(filterTc) // 3 handlers for normal sfq on prio qdisc
-	if err := tcCli.addPrio(device, parent, band); err != nil {
-		log.Error(err, "error while adding prio")
-		return err
-	}
-
-	parent++
-	index := 0
-	currentHandler := parent + 3 // 3 handlers for sfq on prio qdisc
-
-	// iptables chain has been initialized by previous grpc request to set iptables
+	// the chains have been initialized by previous grpc request to set iptables
 	// and iptables rules are recovered by previous call too, so there is no need
 	// to remove these rules here
-	chains := []*pb.Chain{}
-	for _, tcs := range filterTc {
-		for i, tc := range tcs {
-			parentArg := fmt.Sprintf("parent %d:%d", parent, index+4)
-			if i > 0 {
-				parentArg = fmt.Sprintf("parent %d:", currentHandler)
-			}
-
-			currentHandler++
-			handleArg := fmt.Sprintf("handle %d:", currentHandler)
-
-			err := tcCli.addTc(device, parentArg, handleArg, tc)
-			if err != nil {
-				log.Error(err, "error while adding tc")
-				return err
-			}
+	if len(plan.Chains) > 0 {
+		fw, err := s.buildFirewall(ctx, enterNS, pid)
+		if err != nil {
+			log.Error(err, "error while building firewall")
+			return &empty.Empty{}, err
 		}
+		defer fw.close()
 
-		ch := &pb.Chain{
-			Name:      fmt.Sprintf("TC-TABLES-%d", index),
-			Direction: pb.Chain_OUTPUT,
-			Target:    fmt.Sprintf("CLASSIFY --set-class %d:%d", parent, index+4),
+		if err := fw.setChains(plan.Chains); err != nil {
+			log.Error(err, "error while setting chains")
+			return &empty.Empty{}, err
 		}
+	}
 
-		tc := tcs[0]
-		if len(tc.Ipset) > 0 {
-			ch.Ipsets = []string{tc.Ipset}
-		}
+	s.tcProfiles.start(in, request, profiled, start, pid)
+
+	return &empty.Empty{}, nil
+}
 
-		ch.Protocol = tc.Protocol
-		ch.SourcePorts = tc.SourcePort
-		ch.DestinationPorts = tc.EgressPort
+// holdTcs holds the lease of the request, which flushes the tcs on the device once it expires. The lease
+// is released if the request flushes the tcs itself.
+func (s *DaemonServer) holdTcs(in *pb.TcsRequest) {
+	if len(in.Tcs) == 0 {
+		s.releaseLease(in.Lease)
+		return
+	}
 
-		chains = append(chains, ch)
+	recovery := &pb.TcsRequest{
+		ContainerId: in.ContainerId,
+		Device:      in.Device,
+		EnterNS:     in.EnterNS,
+		Ingress:     in.Ingress,
+	}
+	s.holdLease(in.Lease, recoveryOf(methodSetTcs, recovery))
+}
 
-		index++
+// planTcs plans the tcs of the request on the device, or on the IFB device for the inbound traffic
+func planTcs(in *pb.TcsRequest) (*command.TcPlan, error) {
+	if in.Ingress {
+		return command.PlanIngressTcs(in.Device, in.Tcs)
 	}
-	if err := iptablesCli.setIptablesChains(chains); err != nil {
-		log.Error(err, "error while setting iptables")
-		return err
+	if in.HostNetwork {
+		return command.PlanHostTcs(in.Device, in.Tcs)
 	}
 
-	return nil
+	return command.PlanTcs(in.Device, in.Tcs)
 }
 
 type tcClient struct {
@@ -245,7 +221,7 @@ func buildTcClient(ctx context.Context, enterNS bool, pid uint32) tcClient {
 }
 
 func (c *tcClient) flush(device string) error {
-	processBuilder := bpm.DefaultProcessBuilder("tc", "qdisc", "del", "dev", device, "root").SetContext(c.ctx)
+	processBuilder := bpm.DefaultProcessBuilder(command.Tc, command.FlushTcArgs(device)...).SetContext(c.ctx)
 	if c.enterNS {
 		processBuilder = processBuilder.SetNS(c.pid, bpm.NetNS)
 	}
@@ -259,46 +235,10 @@ func (c *tcClient) flush(device string) error {
 	return nil
 }
 
-func (c *tcClient) addTc(device string, parentArg string, handleArg string, tc *pb.Tc) error {
-	log.Info("add tc", "tc", tc)
+func (c *tcClient) addQdisc(args []string) error {
+	log.Info("adding qdisc", "args", args)
 
-	if tc.Type == pb.Tc_BANDWIDTH {
-
-		if tc.Tbf == nil {
-			return fmt.Errorf("tbf is nil while type is BANDWIDTH")
-		}
-		err := c.addTbf(device, parentArg, handleArg, tc.Tbf)
-		if err != nil {
-			return err
-		}
-
-	} else if tc.Type == pb.Tc_NETEM {
-
-		if tc.Netem == nil {
-			return fmt.Errorf("netem is nil while type is NETEM")
-		}
-		err := c.addNetem(device, parentArg, handleArg, tc.Netem)
-		if err != nil {
-			return err
-		}
-
-	} else {
-		return fmt.Errorf("unknown tc qdisc type")
-	}
-
-	return nil
-}
-
-func (c *tcClient) addPrio(device string, parent int, band int) error {
-	log.Info("adding prio", "parent", parent)
-
-	parentArg := "root"
-	if parent > 0 {
-		parentArg = fmt.Sprintf("parent %d:", parent)
-	}
-	args := fmt.Sprintf("qdisc add dev %s %s handle %d: prio bands %d priomap 1 2 2 2 1 2 0 0 1 1 1 1 1 1 1 1", device, parentArg, parent+1, band)
-
-	processBuilder := bpm.DefaultProcessBuilder("tc", strings.Split(args, " ")...).SetContext(c.ctx)
+	processBuilder := bpm.DefaultProcessBuilder(command.Tc, args...).SetContext(c.ctx)
 	if c.enterNS {
 		processBuilder = processBuilder.SetNS(c.pid, bpm.NetNS)
 	}
@@ -307,29 +247,13 @@ func (c *tcClient) addPrio(device string, parent int, band int) error {
 	if err != nil {
 		return encodeOutputToError(output, err)
 	}
-
-	for index := 1; index <= 3; index++ {
-		args := fmt.Sprintf("qdisc add dev %s parent %d:%d handle %d: sfq", device, parent+1, index, parent+1+index)
-
-		processBuilder := bpm.DefaultProcessBuilder("tc", strings.Split(args, " ")...).SetContext(c.ctx)
-		if c.enterNS {
-			processBuilder = processBuilder.SetNS(c.pid, bpm.NetNS)
-		}
-		cmd := processBuilder.Build()
-		output, err := cmd.CombinedOutput()
-		if err != nil {
-			return encodeOutputToError(output, err)
-		}
-	}
-
 	return nil
 }
 
-func (c *tcClient) addNetem(device string, parent string, handle string, netem *pb.Netem) error {
-	log.Info("adding netem", "device", device, "parent", parent, "handle", handle)
+func (c *tcClient) changeQdisc(args []string) error {
+	log.Info("changing qdisc", "args", args)
 
-	args := fmt.Sprintf("qdisc add dev %s %s %s netem %s", device, parent, handle, convertNetemToArgs(netem))
-	processBuilder := bpm.DefaultProcessBuilder("tc", strings.Split(args, " ")...).SetContext(c.ctx)
+	processBuilder := bpm.DefaultProcessBuilder(command.Tc, args...).SetContext(c.ctx)
 	if c.enterNS {
 		processBuilder = processBuilder.SetNS(c.pid, bpm.NetNS)
 	}
@@ -341,109 +265,52 @@ func (c *tcClient) addNetem(device string, parent string, handle string, netem *
 	return nil
 }
 
-func (c *tcClient) addTbf(device string, parent string, handle string, tbf *pb.Tbf) error {
-	log.Info("adding tbf", "device", device, "parent", parent, "handle", handle)
+func (c *tcClient) addFilter(args []string) error {
+	log.Info("adding filter", "args", args)
 
-	args := fmt.Sprintf("qdisc add dev %s %s %s tbf %s", device, parent, handle, convertTbfToArgs(tbf))
-	processBuilder := bpm.DefaultProcessBuilder("tc", strings.Split(args, " ")...).SetContext(c.ctx)
-	if c.enterNS {
-		processBuilder = processBuilder.SetNS(c.pid, bpm.NetNS)
-	}
-	cmd := processBuilder.Build()
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return encodeOutputToError(output, err)
-	}
-	return nil
+	return c.run(command.Tc, args...)
 }
 
-func convertNetemToArgs(netem *pb.Netem) string {
-	args := ""
-	if netem.Time > 0 {
-		args = fmt.Sprintf("delay %d", netem.Time)
-		if netem.Jitter > 0 {
-			args = fmt.Sprintf("%s %d", args, netem.Jitter)
-
-			if netem.DelayCorr > 0 {
-				args = fmt.Sprintf("%s %f", args, netem.DelayCorr)
-			}
-		}
-
-		// reordering not possible without specifying some delay
-		if netem.Reorder > 0 {
-			args = fmt.Sprintf("%s reorder %f", args, netem.Reorder)
-			if netem.ReorderCorr > 0 {
-				args = fmt.Sprintf("%s %f", args, netem.ReorderCorr)
-			}
-
-			if netem.Gap > 0 {
-				args = fmt.Sprintf("%s gap %d", args, netem.Gap)
-			}
-		}
-	}
-
-	if netem.Limit > 0 {
-		args = fmt.Sprintf("%s limit %d", args, netem.Limit)
-	}
-
-	if netem.Loss > 0 {
-		args = fmt.Sprintf("%s loss %f", args, netem.Loss)
-		if netem.LossCorr > 0 {
-			args = fmt.Sprintf("%s %f", args, netem.LossCorr)
-		}
-	}
-
-	if netem.Duplicate > 0 {
-		args = fmt.Sprintf("%s duplicate %f", args, netem.Duplicate)
-		if netem.DuplicateCorr > 0 {
-			args = fmt.Sprintf("%s %f", args, netem.DuplicateCorr)
-		}
+// setupIngress creates the IFB device and redirects the inbound traffic of the device into it
+func (c *tcClient) setupIngress(device string) error {
+	if err := c.run(command.IP, command.IfbAddArgs(device)...); err != nil {
+		return err
 	}
-
-	if netem.Corrupt > 0 {
-		args = fmt.Sprintf("%s corrupt %f", args, netem.Corrupt)
-		if netem.CorruptCorr > 0 {
-			args = fmt.Sprintf("%s %f", args, netem.CorruptCorr)
-		}
+	if err := c.run(command.IP, command.IfbUpArgs(device)...); err != nil {
+		return err
 	}
-
-	trimedArgs := []string{}
-
-	for _, part := range strings.Split(args, " ") {
-		if len(part) > 0 {
-			trimedArgs = append(trimedArgs, part)
-		}
+	if err := c.run(command.Tc, command.IngressQdiscArgs(device)...); err != nil {
+		return err
 	}
 
-	return strings.Join(trimedArgs, " ")
+	return c.run(command.Tc, command.IngressRedirectArgs(device)...)
 }
 
-func convertTbfToArgs(tbf *pb.Tbf) string {
-	args := fmt.Sprintf("rate %d burst %d", tbf.Rate, tbf.Buffer)
-	if tbf.Limit > 0 {
-		args = fmt.Sprintf("%s limit %d", args, tbf.Limit)
+// flushIngress removes the redirection of the inbound traffic of the device and the IFB device
+func (c *tcClient) flushIngress(device string) error {
+	err := c.run(command.Tc, command.FlushIngressArgs(device)...)
+	if err != nil && !strings.Contains(err.Error(), ingressNotExist) && !strings.Contains(err.Error(), clsactNotExist) &&
+		!strings.Contains(err.Error(), clsactNotExistLowerVersion) {
+		return err
 	}
-	if tbf.PeakRate > 0 {
-		args = fmt.Sprintf("%s peakrate %d mtu %d", args, tbf.PeakRate, tbf.MinBurst)
+
+	err = c.run(command.IP, command.IfbDelArgs(device)...)
+	if err != nil && !strings.Contains(err.Error(), deviceNotExist) {
+		return err
 	}
 
-	return args
+	return nil
 }
 
-func abstractTcFilter(tc *pb.Tc) string {
-	filter := tc.Ipset
-
-	if len(tc.Protocol) > 0 {
-		filter += "-" + tc.Protocol
-	}
-
-	if len(tc.EgressPort) > 0 {
-		filter += "-" + tc.EgressPort
+func (c *tcClient) run(name string, args ...string) error {
+	processBuilder := bpm.DefaultProcessBuilder(name, args...).SetContext(c.ctx)
+	if c.enterNS {
+		processBuilder = processBuilder.SetNS(c.pid, bpm.NetNS)
 	}
-
-	if len(tc.SourcePort) > 0 {
-		filter += "-" + tc.EgressPort
+	cmd := processBuilder.Build()
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return encodeOutputToError(output, err)
 	}
-
-	return filter
+	return nil
 }
@@ -39,6 +39,12 @@ func (s *DaemonServer) SetTimeOffset(ctx context.Context, req *pb.TimeRequest) (
 	allPids := append(childPids, pid)
 	log.Info("all related processes found", "pids", allPids)
 
+	// the time of the processes is recovered once the lease expires
+	recovery := &pb.TimeRequest{
+		ContainerId: req.ContainerId,
+	}
+	s.holdLease(req.Lease, recoveryOf(methodRecoverTimeOffset, recovery))
+
 	for _, pid := range allPids {
 		err = time.ModifyTime(int(pid), req.Sec, req.Nsec, req.ClkIdsMask)
 		if err != nil {
@@ -52,6 +58,7 @@ func (s *DaemonServer) SetTimeOffset(ctx context.Context, req *pb.TimeRequest) (
 
 func (s *DaemonServer) RecoverTimeOffset(ctx context.Context, req *pb.TimeRequest) (*empty.Empty, error) {
 	log.Info("Recover time", "Request", req)
+	s.releaseLease(req.Lease)
 
 	pid, err := s.crClient.GetPidFromContainerID(ctx, req.ContainerId)
 	if err != nil {
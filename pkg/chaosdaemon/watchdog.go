@@ -0,0 +1,400 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+)
+
+const (
+	// defaultLeaseTTL is the duration of a lease before it's renewed for the first time
+	defaultLeaseTTL = time.Minute
+
+	// recoverTimeout is the timeout to recover the faults of an expired lease
+	recoverTimeout = time.Minute
+
+	// recoveredLeaseRetention is how long an expired lease is remembered, so renewing it is told apart from renewing
+	// a lease which chaos-daemon has never heard of
+	recoveredLeaseRetention = time.Hour
+
+	// leaseStateFile is the file in the state directory which keeps the leases across the restarts of chaos-daemon
+	leaseStateFile = "leases.json"
+)
+
+// The methods to recover the faults of a lease
+const (
+	methodSetTcs                = "SetTcs"
+	methodSetIptablesChains     = "SetIptablesChains"
+	methodRecoverTimeOffset     = "RecoverTimeOffset"
+	methodCancelStressors       = "CancelStressors"
+	methodSetDNSServer          = "SetDNSServer"
+	methodKillBackgroundProcess = "KillBackgroundProcess"
+	methodRecoverHostNetwork    = "RecoverHostNetwork"
+)
+
+// leaseRecovery is the request to recover the faults of a lease. It's kept in the state file with the lease, so the
+// faults are still recovered once the lease expires after chaos-daemon restarts.
+type leaseRecovery struct {
+	Method  string          `json:"method"`
+	Request json.RawMessage `json:"request"`
+}
+
+// recoveryOf returns the recovery calling the method with the request
+func recoveryOf(method string, req interface{}) leaseRecovery {
+	body, err := json.Marshal(req)
+	if err != nil {
+		log.Error(err, "error while encoding the recovery of a lease", "method", method)
+	}
+	return leaseRecovery{Method: method, Request: body}
+}
+
+// backgroundProcess is a process started by chaos-daemon, which is killed to recover the faults
+type backgroundProcess struct {
+	Pid       int   `json:"pid"`
+	StartTime int64 `json:"startTime"`
+}
+
+// watchdog recovers the faults held by a lease once the lease expires, so the faults are not left
+// behind if chaos-daemon loses contact with the controller. Every lease is recovered on its own.
+type watchdog struct {
+	sync.Mutex
+
+	leases map[string]*lease
+
+	// recovered are the expired leases and the time when they expired
+	recovered map[string]time.Time
+
+	// path is the state file keeping the leases, nothing is kept if it's empty
+	path string
+
+	// recover recovers the faults of an expired lease
+	recover func(recovery leaseRecovery) error
+
+	// recoveredLeases counts the expired leases by the kind of their faults and the result of the recovery
+	recoveredLeases *prometheus.CounterVec
+}
+
+type lease struct {
+	timer    *time.Timer
+	ttl      time.Duration
+	recovery leaseRecovery
+}
+
+// leaseState is the content of the state file
+type leaseState struct {
+	Leases    map[string]persistedLease `json:"leases"`
+	Recovered map[string]time.Time      `json:"recovered"`
+}
+
+type persistedLease struct {
+	TTL      time.Duration `json:"ttl"`
+	Recovery leaseRecovery `json:"recovery"`
+}
+
+func newWatchdog(path string, recover func(recovery leaseRecovery) error) *watchdog {
+	return &watchdog{
+		leases:    map[string]*lease{},
+		recovered: map[string]time.Time{},
+		path:      path,
+		recover:   recover,
+		recoveredLeases: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "chaos_daemon_recovered_leases_total",
+			Help: "Total number of the expired leases whose faults are recovered by chaos-daemon",
+		}, []string{"kind", "result"}),
+	}
+}
+
+// hold starts the lease of the id, or extends it if it exists. The recovery replaces the previous one,
+// and it will be called if the lease isn't renewed within the ttl.
+func (w *watchdog) hold(id string, ttl time.Duration, recovery leaseRecovery) {
+	w.Lock()
+	defer w.Unlock()
+
+	w.start(id, ttl, recovery)
+	w.save()
+}
+
+// start starts or extends the lease without saving it, the caller must hold the lock
+func (w *watchdog) start(id string, ttl time.Duration, recovery leaseRecovery) {
+	delete(w.recovered, id)
+	if l, ok := w.leases[id]; ok {
+		l.ttl = ttl
+		l.recovery = recovery
+		l.timer.Reset(ttl)
+		return
+	}
+
+	l := &lease{ttl: ttl, recovery: recovery}
+	l.timer = time.AfterFunc(ttl, func() {
+		w.Lock()
+		if w.leases[id] != l {
+			// the lease has been released
+			w.Unlock()
+			return
+		}
+		delete(w.leases, id)
+		w.recovered[id] = time.Now()
+		recovery := l.recovery
+		w.save()
+		w.Unlock()
+
+		log.Info("lease expired, recovering the faults", "id", id)
+		if err := w.recover(recovery); err != nil {
+			log.Error(err, "error while recovering the faults of the lease", "id", id)
+			w.recoveredLeases.WithLabelValues(leaseKind(id), "failure").Inc()
+			return
+		}
+		log.Info("recovered the faults of the lease", "id", id)
+		w.recoveredLeases.WithLabelValues(leaseKind(id), "success").Inc()
+	})
+	w.leases[id] = l
+}
+
+// renew extends the lease of the id. It returns false if there is no such lease, e.g. it has expired
+// and the faults have been recovered.
+func (w *watchdog) renew(id string, ttl time.Duration) bool {
+	w.Lock()
+	defer w.Unlock()
+
+	l, ok := w.leases[id]
+	if !ok {
+		return false
+	}
+
+	l.timer.Reset(ttl)
+	return true
+}
+
+// isRecovered returns whether the lease of the id has expired recently, and its faults have been recovered
+func (w *watchdog) isRecovered(id string) bool {
+	w.Lock()
+	defer w.Unlock()
+
+	_, ok := w.recovered[id]
+	return ok
+}
+
+// release stops the lease of the id without recovering the faults
+func (w *watchdog) release(id string) {
+	w.Lock()
+	defer w.Unlock()
+
+	if l, ok := w.leases[id]; ok {
+		l.timer.Stop()
+		delete(w.leases, id)
+		w.save()
+	}
+}
+
+// save forgets the leases expired long ago, and writes the leases to the state file. The caller must hold the lock. The state file is replaced
+// as a whole, so it's never left half written.
+func (w *watchdog) save() {
+	for id, at := range w.recovered {
+		if time.Since(at) > recoveredLeaseRetention {
+			delete(w.recovered, id)
+		}
+	}
+	if len(w.path) == 0 {
+		return
+	}
+
+	state := leaseState{
+		Leases:    map[string]persistedLease{},
+		Recovered: w.recovered,
+	}
+	for id, l := range w.leases {
+		state.Leases[id] = persistedLease{TTL: l.ttl, Recovery: l.recovery}
+	}
+
+	err := writeState(w.path, state)
+	if err != nil {
+		log.Error(err, "error while saving the leases", "path", w.path)
+	}
+}
+
+// restore restarts the leases in the state file with their full ttl, and returns their recoveries
+func (w *watchdog) restore() ([]leaseRecovery, error) {
+	if len(w.path) == 0 {
+		return nil, nil
+	}
+
+	var state leaseState
+	found, err := readState(w.path, &state)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	w.Lock()
+	defer w.Unlock()
+
+	var recoveries []leaseRecovery
+	for id, at := range state.Recovered {
+		w.recovered[id] = at
+	}
+	for id, l := range state.Leases {
+		log.Info("restoring lease", "id", id)
+		w.start(id, l.TTL, l.Recovery)
+		recoveries = append(recoveries, l.Recovery)
+	}
+	w.save()
+
+	return recoveries, nil
+}
+
+// leaseKind returns the kind of the faults held by the lease, which is the first segment of the id,
+// e.g. "podnetworkchaos" of "podnetworkchaos/default/nginx/iptables"
+func leaseKind(id string) string {
+	return strings.SplitN(id, "/", 2)[0]
+}
+
+// leaseTTL returns the ttl of the lease request, or the default one if it's not set
+func leaseTTL(req *pb.LeaseRequest) time.Duration {
+	ttl := time.Duration(req.Ttl) * time.Second
+	if ttl <= 0 {
+		ttl = defaultLeaseTTL
+	}
+	return ttl
+}
+
+// holdLease starts or extends the lease of a request, and the faults injected by the request are recovered by the
+// recovery once the lease expires. Nothing is held if the request doesn't have a lease.
+func (s *DaemonServer) holdLease(req *pb.LeaseRequest, recovery leaseRecovery) {
+	if req == nil || len(req.Id) == 0 {
+		return
+	}
+
+	s.watchdog.hold(req.Id, leaseTTL(req), recovery)
+}
+
+// releaseLease stops the lease of a request which recovers the faults itself
+func (s *DaemonServer) releaseLease(req *pb.LeaseRequest) {
+	if req == nil || len(req.Id) == 0 {
+		return
+	}
+
+	s.watchdog.release(req.Id)
+}
+
+// restoreLeases restores the leases kept by the previous chaos-daemon, and takes over the processes and devices
+// their faults depend on, so they are still recovered once the leases expire
+func (s *DaemonServer) restoreLeases() error {
+	recoveries, err := s.watchdog.restore()
+	if err != nil {
+		return err
+	}
+
+	for _, recovery := range recoveries {
+		switch recovery.Method {
+		case methodCancelStressors:
+			req := &pb.CancelStressRequest{}
+			if err := json.Unmarshal(recovery.Request, req); err != nil {
+				return err
+			}
+			pid, err := strconv.Atoi(req.Instance)
+			if err != nil {
+				return err
+			}
+			s.backgroundProcessManager.Adopt(pid, req.StartTime)
+		case methodKillBackgroundProcess:
+			var process backgroundProcess
+			if err := json.Unmarshal(recovery.Request, &process); err != nil {
+				return err
+			}
+			s.backgroundProcessManager.Adopt(process.Pid, process.StartTime)
+		case methodRecoverHostNetwork:
+			var devices []string
+			if err := json.Unmarshal(recovery.Request, &devices); err != nil {
+				return err
+			}
+			s.hostNetwork.Lock()
+			for _, device := range devices {
+				s.hostNetwork.devices[device] = struct{}{}
+			}
+			s.hostNetwork.Unlock()
+		}
+	}
+
+	return nil
+}
+
+// recoverLease recovers the faults of an expired lease by calling the method of the recovery
+func (s *DaemonServer) recoverLease(recovery leaseRecovery) error {
+	ctx, cancel := context.WithTimeout(context.Background(), recoverTimeout)
+	defer cancel()
+
+	var err error
+	switch recovery.Method {
+	case methodSetTcs:
+		req := &pb.TcsRequest{}
+		if err = json.Unmarshal(recovery.Request, req); err == nil {
+			_, err = s.SetTcs(ctx, req)
+		}
+	case methodSetIptablesChains:
+		req := &pb.IptablesChainsRequest{}
+		if err = json.Unmarshal(recovery.Request, req); err == nil {
+			_, err = s.SetIptablesChains(ctx, req)
+		}
+	case methodRecoverTimeOffset:
+		req := &pb.TimeRequest{}
+		if err = json.Unmarshal(recovery.Request, req); err == nil {
+			_, err = s.RecoverTimeOffset(ctx, req)
+		}
+	case methodCancelStressors:
+		req := &pb.CancelStressRequest{}
+		if err = json.Unmarshal(recovery.Request, req); err == nil {
+			_, err = s.CancelStressors(ctx, req)
+		}
+	case methodSetDNSServer:
+		req := &pb.SetDNSServerRequest{}
+		if err = json.Unmarshal(recovery.Request, req); err == nil {
+			_, err = s.SetDNSServer(ctx, req)
+		}
+	case methodKillBackgroundProcess:
+		var process backgroundProcess
+		if err = json.Unmarshal(recovery.Request, &process); err == nil {
+			err = s.backgroundProcessManager.KillBackgroundProcess(ctx, process.Pid, process.StartTime)
+		}
+	case methodRecoverHostNetwork:
+		err = s.recoverHostNetwork(ctx)
+	default:
+		err = errors.Errorf("unknown method %s to recover the lease", recovery.Method)
+	}
+	return err
+}
+
+// RenewLease extends the lease of the faults. It returns NotFound if the lease has expired and the faults have been
+// recovered, or FailedPrecondition if the lease is unknown, e.g. the state of chaos-daemon has been lost.
+func (s *DaemonServer) RenewLease(ctx context.Context, req *pb.LeaseRequest) (*empty.Empty, error) {
+	if s.watchdog.renew(req.Id, leaseTTL(req)) {
+		return &empty.Empty{}, nil
+	}
+
+	if s.watchdog.isRecovered(req.Id) {
+		return nil, status.Errorf(codes.NotFound, "lease %s has expired", req.Id)
+	}
+	return nil, status.Errorf(codes.FailedPrecondition, "lease %s is unknown to chaos-daemon", req.Id)
+}
@@ -0,0 +1,138 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaosdaemon
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/chaos-mesh/chaos-mesh/pkg/chaosdaemon/pb"
+)
+
+// recordRecoveries returns the recover function sending the methods of the recoveries to the channel
+func recordRecoveries(recovered chan string, failed string) func(recovery leaseRecovery) error {
+	return func(recovery leaseRecovery) error {
+		recovered <- recovery.Method
+		if recovery.Method == failed {
+			return fmt.Errorf("device not found")
+		}
+		return nil
+	}
+}
+
+func Test_watchdog(t *testing.T) {
+	g := NewWithT(t)
+
+	recovered := make(chan string, 2)
+	w := newWatchdog("", recordRecoveries(recovered, ""))
+
+	// the lease expires without being renewed
+	w.hold("a", 50*time.Millisecond, leaseRecovery{Method: "a"})
+	g.Eventually(recovered).Should(Receive(Equal("a")))
+	g.Expect(w.renew("a", time.Second)).To(BeFalse())
+	g.Expect(w.isRecovered("a")).To(BeTrue())
+
+	// the lease is kept by renewing
+	w.hold("b", 100*time.Millisecond, leaseRecovery{Method: "b"})
+	for i := 0; i < 3; i++ {
+		time.Sleep(50 * time.Millisecond)
+		g.Expect(w.renew("b", 100*time.Millisecond)).To(BeTrue())
+	}
+	g.Consistently(recovered, 50*time.Millisecond).ShouldNot(Receive())
+
+	// the faults are not recovered once the lease is released
+	w.release("b")
+	g.Consistently(recovered, 200*time.Millisecond).ShouldNot(Receive())
+	g.Expect(w.isRecovered("b")).To(BeFalse())
+}
+
+func Test_restoreWatchdog(t *testing.T) {
+	g := NewWithT(t)
+
+	dir, err := ioutil.TempDir("", "chaos-daemon")
+	g.Expect(err).To(BeNil())
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, leaseStateFile)
+
+	recovered := make(chan string, 3)
+	w := newWatchdog(path, recordRecoveries(recovered, ""))
+	w.hold("held", time.Hour, recoveryOf(methodCancelStressors, &pb.CancelStressRequest{Instance: "42", StartTime: 1}))
+	w.hold("released", time.Hour, leaseRecovery{Method: "released"})
+	w.release("released")
+	w.hold("expired", 10*time.Millisecond, leaseRecovery{Method: "expired"})
+	g.Eventually(recovered).Should(Receive(Equal("expired")))
+
+	// chaos-daemon restarts
+	restored := newWatchdog(path, recordRecoveries(recovered, ""))
+	recoveries, err := restored.restore()
+	g.Expect(err).To(BeNil())
+	g.Expect(recoveries).To(HaveLen(1))
+	g.Expect(recoveries[0].Method).To(Equal(methodCancelStressors))
+	g.Expect(string(recoveries[0].Request)).To(MatchJSON(`{"instance":"42","startTime":1}`))
+
+	g.Expect(restored.renew("held", time.Hour)).To(BeTrue())
+	g.Expect(restored.renew("released", time.Hour)).To(BeFalse())
+	g.Expect(restored.isRecovered("released")).To(BeFalse())
+	g.Expect(restored.renew("expired", time.Hour)).To(BeFalse())
+	g.Expect(restored.isRecovered("expired")).To(BeTrue())
+
+	// the restored lease is recovered once it expires
+	restored.hold("held", 10*time.Millisecond, recoveries[0])
+	g.Eventually(recovered).Should(Receive(Equal(methodCancelStressors)))
+}
+
+func Test_holdLease(t *testing.T) {
+	g := NewWithT(t)
+
+	recovered := make(chan string, 3)
+	s := &DaemonServer{watchdog: newWatchdog("", recordRecoveries(recovered, "tc"))}
+
+	// the request without a lease is never recovered
+	s.holdLease(nil, leaseRecovery{Method: "none"})
+	s.holdLease(&pb.LeaseRequest{}, leaseRecovery{Method: "none"})
+
+	// every lease is recovered on its own
+	s.holdLease(&pb.LeaseRequest{Id: "podnetworkchaos/default/nginx/iptables", Ttl: 1}, leaseRecovery{Method: "iptables"})
+	s.holdLease(&pb.LeaseRequest{Id: "podnetworkchaos/default/nginx/tc/eth0", Ttl: 1}, leaseRecovery{Method: "tc"})
+	s.holdLease(&pb.LeaseRequest{Id: "timechaos/default/time/default/nginx/nginx", Ttl: 1}, leaseRecovery{Method: "time"})
+	s.releaseLease(&pb.LeaseRequest{Id: "timechaos/default/time/default/nginx/nginx"})
+
+	_, err := s.RenewLease(context.TODO(), &pb.LeaseRequest{Id: "podnetworkchaos/default/nginx/iptables", Ttl: 2})
+	g.Expect(err).To(BeNil())
+	g.Eventually(recovered, 2*time.Second).Should(Receive(Equal("tc")))
+	g.Consistently(recovered, 500*time.Millisecond).ShouldNot(Receive())
+	g.Eventually(recovered, 2*time.Second).Should(Receive(Equal("iptables")))
+
+	// the expired lease is told apart from the unknown one
+	_, err = s.RenewLease(context.TODO(), &pb.LeaseRequest{Id: "podnetworkchaos/default/nginx/iptables"})
+	g.Expect(status.Code(err)).To(Equal(codes.NotFound))
+	_, err = s.RenewLease(context.TODO(), &pb.LeaseRequest{Id: "timechaos/default/time/default/nginx/nginx"})
+	g.Expect(status.Code(err)).To(Equal(codes.FailedPrecondition))
+
+	g.Eventually(func() float64 {
+		return testutil.ToFloat64(s.watchdog.recoveredLeases.WithLabelValues("podnetworkchaos", "success"))
+	}).Should(Equal(float64(1)))
+	g.Expect(testutil.ToFloat64(s.watchdog.recoveredLeases.WithLabelValues("podnetworkchaos", "failure"))).To(Equal(float64(1)))
+	g.Expect(testutil.ToFloat64(s.watchdog.recoveredLeases.WithLabelValues("timechaos", "success"))).To(Equal(float64(0)))
+}
@@ -66,10 +66,6 @@ type ChaosControllerConfig struct {
 	// It only works with ClusterScoped is false;
 	TargetNamespace string `envconfig:"TARGET_NAMESPACE" default:""`
 
-	// DNSServiceName is the name of DNS service, which is used for DNS chaos
-	DNSServiceName string `envconfig:"CHAOS_DNS_SERVICE_NAME" default:""`
-	DNSServicePort int    `envconfig:"CHAOS_DNS_SERVICE_PORT" default:""`
-
 	// SecurityMode is used for enable authority validation in admission webhook
 	SecurityMode bool `envconfig:"SECURITY_MODE" default:"true" json:"security_mode"`
 
@@ -81,6 +77,20 @@ type ChaosControllerConfig struct {
 
 	// PodFailurePauseImage is used to set a custom image for pod failure
 	PodFailurePauseImage string `envconfig:"POD_FAILURE_PAUSE_IMAGE" default:"gcr.io/google-containers/pause:latest"`
+
+	// RecordRetryBaseDelay is the delay before retrying a record whose apply or recover failed for the first time.
+	// The delay doubles after every failed attempt.
+	RecordRetryBaseDelay time.Duration `envconfig:"RECORD_RETRY_BASE_DELAY" default:"1s"`
+	// RecordRetryMaxDelay is the upper bound of the delay between two attempts on the same record
+	RecordRetryMaxDelay time.Duration `envconfig:"RECORD_RETRY_MAX_DELAY" default:"5m"`
+	// RecordRetryLimit is the number of failed attempts after which a record is marked as failed
+	RecordRetryLimit int `envconfig:"RECORD_RETRY_LIMIT" default:"10"`
+
+	// PrometheusAddress is the address of the prometheus server used by the abort conditions, e.g. "http://prometheus.monitoring:9090"
+	PrometheusAddress string `envconfig:"PROMETHEUS_ADDRESS" default:""`
+	// AbortHTTPAllowedHosts is the list of hosts which the http abort conditions are allowed to request,
+	// e.g. "frontend.default.svc:8080,example.com". The http abort conditions can't be evaluated if it's empty.
+	AbortHTTPAllowedHosts []string `envconfig:"ABORT_HTTP_ALLOWED_HOSTS" default:""`
 }
 
 // EnvironChaosController returns the settings from the environment.
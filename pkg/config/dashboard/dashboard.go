@@ -38,9 +38,8 @@ type ChaosDashboardConfig struct {
 	// annotated with `chaos-mesh.org/inject=enabled` will be injected
 	EnableFilterNamespace bool `envconfig:"ENABLE_FILTER_NAMESPACE" default:"false"`
 	// SecurityMode will use the token login by the user if set to true
-	SecurityMode    bool   `envconfig:"SECURITY_MODE" default:"true" json:"security_mode"`
-	DNSServerCreate bool   `envconfig:"DNS_SERVER_CREATE" default:"false" json:"dns_server_create"`
-	Version         string `json:"version"`
+	SecurityMode bool   `envconfig:"SECURITY_MODE" default:"true" json:"security_mode"`
+	Version      string `json:"version"`
 }
 
 // PersistTTLConfig defines the configuration of ttl
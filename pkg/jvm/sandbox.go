@@ -47,6 +47,14 @@ func RecoverChaos(host string, port int, body []byte) error {
 	return httpPost(url, body)
 }
 
+// RenderInjection renders the requests which would be sent to the sandbox to inject the chaos
+func RenderInjection(host string, port int, body []byte) []string {
+	return []string{
+		http.MethodGet + " " + fmt.Sprintf(ActiveURL, host, port),
+		http.MethodPost + " " + fmt.Sprintf(InjectURL, host, port) + " " + string(body),
+	}
+}
+
 func httpPost(url string, body []byte) error {
 	client := &http.Client{}
 	reqBody := bytes.NewBuffer([]byte(body))
@@ -14,6 +14,7 @@
 package netem
 
 import (
+	"fmt"
 	"strconv"
 	"time"
 
@@ -23,9 +24,19 @@ import (
 
 // FromDelay convert delay to netem
 func FromDelay(in *v1alpha1.DelaySpec) (*chaosdaemonpb.Netem, error) {
-	delayTime, err := time.ParseDuration(in.Latency)
-	if err != nil {
-		return nil, err
+	var delayTime time.Duration
+	if in.Profile != nil {
+		profile, err := FromProfile(in.Profile, parseLatency)
+		if err != nil {
+			return nil, err
+		}
+		delayTime = time.Duration(InitialValue(profile)) * time.Microsecond
+	} else {
+		var err error
+		delayTime, err = time.ParseDuration(in.Latency)
+		if err != nil {
+			return nil, err
+		}
 	}
 	jitter, err := time.ParseDuration(in.Jitter)
 	if err != nil {
@@ -64,9 +75,19 @@ func FromDelay(in *v1alpha1.DelaySpec) (*chaosdaemonpb.Netem, error) {
 
 // FromLoss convert loss to netem
 func FromLoss(in *v1alpha1.LossSpec) (*chaosdaemonpb.Netem, error) {
-	lossPercentage, err := strconv.ParseFloat(in.Loss, 32)
-	if err != nil {
-		return nil, err
+	var lossPercentage float64
+	if in.Profile != nil {
+		profile, err := FromProfile(in.Profile, parseLoss)
+		if err != nil {
+			return nil, err
+		}
+		lossPercentage = InitialValue(profile)
+	} else {
+		var err error
+		lossPercentage, err = strconv.ParseFloat(in.Loss, 32)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	corr, err := strconv.ParseFloat(in.Correlation, 32)
@@ -122,10 +143,20 @@ func FromCorrupt(in *v1alpha1.CorruptSpec) (*chaosdaemonpb.Netem, error) {
 // for traffic control with the tc command.
 // http://man7.org/linux/man-pages/man8/tc-tbf.8.html
 func FromBandwidth(in *v1alpha1.BandwidthSpec) (*chaosdaemonpb.Tbf, error) {
-	rate, err := v1alpha1.ConvertUnitToBytes(in.Rate)
+	var rate uint64
+	if in.Profile != nil {
+		profile, err := FromProfile(in.Profile, parseRate)
+		if err != nil {
+			return nil, err
+		}
+		rate = uint64(InitialValue(profile))
+	} else {
+		var err error
+		rate, err = v1alpha1.ConvertUnitToBytes(in.Rate)
 
-	if err != nil {
-		return nil, err
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	tbf := &chaosdaemonpb.Tbf{
@@ -141,3 +172,133 @@ func FromBandwidth(in *v1alpha1.BandwidthSpec) (*chaosdaemonpb.Tbf, error) {
 
 	return tbf, nil
 }
+
+// FromDelayProfile converts the profile of the delay into *chaosdaemonpb.TcProfile, whose values are in microseconds
+func FromDelayProfile(in *v1alpha1.DelaySpec) (*chaosdaemonpb.TcProfile, error) {
+	if in == nil || in.Profile == nil {
+		return nil, nil
+	}
+
+	return FromProfile(in.Profile, parseLatency)
+}
+
+// FromLossProfile converts the profile of the loss into *chaosdaemonpb.TcProfile, whose values are in percentage
+func FromLossProfile(in *v1alpha1.LossSpec) (*chaosdaemonpb.TcProfile, error) {
+	if in == nil || in.Profile == nil {
+		return nil, nil
+	}
+
+	return FromProfile(in.Profile, parseLoss)
+}
+
+// FromRateProfile converts the profile of the bandwidth into *chaosdaemonpb.TcProfile, whose values are in bytes per second
+func FromRateProfile(in *v1alpha1.BandwidthSpec) (*chaosdaemonpb.TcProfile, error) {
+	if in == nil || in.Profile == nil {
+		return nil, nil
+	}
+
+	return FromProfile(in.Profile, parseRate)
+}
+
+// FromProfile converts the profile into *chaosdaemonpb.TcProfile, whose values are parsed by parse
+func FromProfile(in *v1alpha1.ProfileSpec, parse func(string) (float64, error)) (*chaosdaemonpb.TcProfile, error) {
+	profile := &chaosdaemonpb.TcProfile{
+		Repeat: in.Repeat,
+	}
+
+	if in.Waveform == nil {
+		if len(in.Steps) == 0 {
+			return nil, fmt.Errorf("either steps or waveform should be set in the profile")
+		}
+
+		profile.Waveform = chaosdaemonpb.TcProfile_STEPS
+		for _, step := range in.Steps {
+			value, err := parse(step.Value)
+			if err != nil {
+				return nil, err
+			}
+			duration, err := time.ParseDuration(step.Duration)
+			if err != nil {
+				return nil, err
+			}
+			profile.Steps = append(profile.Steps, &chaosdaemonpb.TcProfileStep{
+				Value:    value,
+				Duration: int64(duration),
+			})
+		}
+
+		return profile, nil
+	}
+
+	switch in.Waveform.Type {
+	case v1alpha1.SineWaveform:
+		profile.Waveform = chaosdaemonpb.TcProfile_SINE
+	case v1alpha1.SquareWaveform:
+		profile.Waveform = chaosdaemonpb.TcProfile_SQUARE
+	case v1alpha1.RandomWalkWaveform:
+		profile.Waveform = chaosdaemonpb.TcProfile_RANDOM_WALK
+	default:
+		return nil, fmt.Errorf("unknown waveform type %s", in.Waveform.Type)
+	}
+
+	var err error
+	if profile.Min, err = parse(in.Waveform.Min); err != nil {
+		return nil, err
+	}
+	if profile.Max, err = parse(in.Waveform.Max); err != nil {
+		return nil, err
+	}
+
+	if len(in.Waveform.Period) > 0 {
+		period, err := time.ParseDuration(in.Waveform.Period)
+		if err != nil {
+			return nil, err
+		}
+		profile.Period = int64(period)
+	}
+
+	interval := DefaultProfileInterval
+	if len(in.Waveform.Interval) > 0 {
+		if interval, err = time.ParseDuration(in.Waveform.Interval); err != nil {
+			return nil, err
+		}
+	}
+	profile.Interval = int64(interval)
+
+	return profile, nil
+}
+
+// DefaultProfileInterval is the default duration between two updates of the value of a waveform
+const DefaultProfileInterval = time.Second
+
+// InitialValue returns the value of the profile when it starts, which is the value of the
+// first step or the min value of the waveform
+func InitialValue(profile *chaosdaemonpb.TcProfile) float64 {
+	if profile.Waveform == chaosdaemonpb.TcProfile_STEPS {
+		if len(profile.Steps) == 0 {
+			return 0
+		}
+		return profile.Steps[0].Value
+	}
+
+	return profile.Min
+}
+
+func parseLatency(value string) (float64, error) {
+	latency, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+
+	return float64(latency.Microseconds()), nil
+}
+
+func parseLoss(value string) (float64, error) {
+	return strconv.ParseFloat(value, 32)
+}
+
+func parseRate(value string) (float64, error) {
+	rate, err := v1alpha1.ConvertUnitToBytes(value)
+
+	return float64(rate), err
+}
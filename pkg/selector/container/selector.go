@@ -15,6 +15,7 @@ package container
 
 import (
 	"context"
+	"strings"
 
 	"go.uber.org/fx"
 	v1 "k8s.io/api/core/v1"
@@ -47,14 +48,20 @@ func (impl *SelectImpl) Select(ctx context.Context, cs *v1alpha1.ContainerSelect
 		return nil, err
 	}
 
+	return containersOfPods(pods, cs.ContainerNames), nil
+}
+
+// containersOfPods returns the containers with the names from the pods.
+// The first container of the pod is used if the names are empty.
+func containersOfPods(pods []v1.Pod, containerNames []string) []*Container {
 	containerNameMap := make(map[string]struct{})
-	for _, name := range cs.ContainerNames {
+	for _, name := range containerNames {
 		containerNameMap[name] = struct{}{}
 	}
 
 	var result []*Container
 	for _, pod := range pods {
-		if len(cs.ContainerNames) == 0 {
+		if len(containerNames) == 0 {
 			result = append(result, &Container{
 				Pod:           pod,
 				ContainerName: pod.Spec.Containers[0].Name,
@@ -72,7 +79,39 @@ func (impl *SelectImpl) Select(ctx context.Context, cs *v1alpha1.ContainerSelect
 		}
 	}
 
-	return result, nil
+	return result
+}
+
+// Reselect re-evaluates the selector with the ids of the selected containers. It returns the ids of the
+// selected containers which are still alive, and the new containers which should be injected.
+func (impl *SelectImpl) Reselect(ctx context.Context, cs *v1alpha1.ContainerSelector, selected []string) ([]string, []*Container, error) {
+	// the id of a container is "namespace/pod/container"
+	var selectedPods []string
+	containersOfPod := make(map[string][]string)
+	for _, id := range selected {
+		index := strings.LastIndex(id, "/")
+		if index < 0 {
+			continue
+		}
+
+		podId := id[:index]
+		if _, ok := containersOfPod[podId]; !ok {
+			selectedPods = append(selectedPods, podId)
+		}
+		containersOfPod[podId] = append(containersOfPod[podId], id)
+	}
+
+	alive, added, err := pod.ReselectPods(ctx, impl.c, impl.r, &cs.PodSelector, selectedPods, impl.ClusterScoped, impl.TargetNamespace, impl.EnableFilterNamespace)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var aliveIds []string
+	for _, p := range alive {
+		aliveIds = append(aliveIds, containersOfPod[p.Namespace+"/"+p.Name]...)
+	}
+
+	return aliveIds, containersOfPods(added, cs.ContainerNames), nil
 }
 
 type Params struct {
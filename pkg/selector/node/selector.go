@@ -0,0 +1,156 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	"github.com/chaos-mesh/chaos-mesh/pkg/selector/pod"
+)
+
+type SelectImpl struct {
+	c client.Client
+}
+
+type Node struct {
+	v1.Node
+}
+
+func (node *Node) Id() string {
+	return node.Name
+}
+
+// ErrNoNodeSelected is returned when no node matches the selector
+var ErrNoNodeSelected = errors.New("no node is selected")
+
+// Select returns the nodes with the names or matching the node selectors of the selector, filtered by the mode.
+// The other selectors are ignored.
+func (impl *SelectImpl) Select(ctx context.Context, ns *v1alpha1.NodeSelector) ([]*Node, error) {
+	if ns == nil {
+		return []*Node{}, nil
+	}
+
+	nodes, err := SelectNodes(ctx, impl.c, ns.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(nodes) == 0 {
+		return nil, ErrNoNodeSelected
+	}
+
+	nodes, err = filterNodesByMode(nodes, ns.Mode, ns.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*Node
+	for _, node := range nodes {
+		result = append(result, &Node{
+			node,
+		})
+	}
+
+	return result, nil
+}
+
+// SelectNodes returns the nodes with the names and the nodes matching the node selectors of the selector
+func SelectNodes(ctx context.Context, c client.Client, selector v1alpha1.PodSelectorSpec) ([]v1.Node, error) {
+	var nodes []v1.Node
+	selected := make(map[string]struct{})
+
+	for _, name := range selector.Nodes {
+		var node v1.Node
+		if err := c.Get(ctx, types.NamespacedName{Name: name}, &node); err != nil {
+			return nil, err
+		}
+		if _, ok := selected[node.Name]; !ok {
+			selected[node.Name] = struct{}{}
+			nodes = append(nodes, node)
+		}
+	}
+
+	if len(selector.NodeSelectors) > 0 {
+		var nodeList v1.NodeList
+		if err := c.List(ctx, &nodeList, &client.ListOptions{
+			LabelSelector: labels.SelectorFromSet(selector.NodeSelectors),
+		}); err != nil {
+			return nil, err
+		}
+
+		for _, node := range nodeList.Items {
+			if _, ok := selected[node.Name]; !ok {
+				selected[node.Name] = struct{}{}
+				nodes = append(nodes, node)
+			}
+		}
+	}
+
+	return nodes, nil
+}
+
+// filterNodesByMode filters the nodes by the mode, in the same way as the pods
+func filterNodesByMode(nodes []v1.Node, mode v1alpha1.PodMode, value string) ([]v1.Node, error) {
+	var num int
+	switch mode {
+	case v1alpha1.OnePodMode:
+		num = 1
+	case v1alpha1.AllPodMode:
+		return nodes, nil
+	case v1alpha1.FixedPodMode:
+		fixed, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, err
+		}
+		if fixed <= 0 {
+			return nil, errors.New("cannot select any node as value below or equal 0")
+		}
+		num = fixed
+	case v1alpha1.FixedPercentPodMode, v1alpha1.RandomMaxPercentPodMode:
+		percentage, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, err
+		}
+		if percentage <= 0 || percentage > 100 {
+			return nil, fmt.Errorf("percentage value of %d is invalid, Must be (0,100]", percentage)
+		}
+		if mode == v1alpha1.RandomMaxPercentPodMode {
+			percentage = int(pod.RandomFixedIndexes(0, uint(percentage)+1, 1)[0])
+		}
+		num = int(math.Floor(float64(len(nodes)) * float64(percentage) / 100))
+	default:
+		return nil, fmt.Errorf("mode %s not supported", mode)
+	}
+
+	var filtered []v1.Node
+	for _, index := range pod.RandomFixedIndexes(0, uint(len(nodes)), uint(num)) {
+		filtered = append(filtered, nodes[index])
+	}
+
+	return filtered, nil
+}
+
+func New(c client.Client) *SelectImpl {
+	return &SelectImpl{c}
+}
@@ -0,0 +1,93 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package node
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+)
+
+func newNode(name string, labels map[string]string) *v1.Node {
+	return &v1.Node{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Node",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+	}
+}
+
+func TestSelectNodes(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	objects := []runtime.Object{
+		newNode("node0", map[string]string{"zone": "a"}),
+		newNode("node1", map[string]string{"zone": "a"}),
+		newNode("node2", map[string]string{"zone": "b"}),
+	}
+	c := fake.NewFakeClient(objects...)
+	impl := New(c)
+
+	nodes, err := impl.Select(context.TODO(), &v1alpha1.NodeSelector{
+		Selector: v1alpha1.PodSelectorSpec{
+			Nodes:         []string{"node0", "node2"},
+			NodeSelectors: map[string]string{"zone": "a"},
+		},
+		Mode: v1alpha1.AllPodMode,
+	})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	var names []string
+	for _, node := range nodes {
+		names = append(names, node.Id())
+	}
+	g.Expect(names).To(ConsistOf("node0", "node1", "node2"))
+
+	nodes, err = impl.Select(context.TODO(), &v1alpha1.NodeSelector{
+		Selector: v1alpha1.PodSelectorSpec{
+			NodeSelectors: map[string]string{"zone": "a"},
+		},
+		Mode:  v1alpha1.FixedPodMode,
+		Value: "1",
+	})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(nodes).To(HaveLen(1))
+	g.Expect(nodes[0].Id()).To(BeElementOf("node0", "node1"))
+
+	_, err = impl.Select(context.TODO(), &v1alpha1.NodeSelector{
+		Selector: v1alpha1.PodSelectorSpec{
+			NodeSelectors: map[string]string{"zone": "c"},
+		},
+		Mode: v1alpha1.AllPodMode,
+	})
+	g.Expect(err).To(Equal(ErrNoNodeSelected))
+
+	_, err = impl.Select(context.TODO(), &v1alpha1.NodeSelector{
+		Selector: v1alpha1.PodSelectorSpec{
+			Nodes: []string{"node3"},
+		},
+		Mode: v1alpha1.AllPodMode,
+	})
+	g.Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,122 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pod
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+)
+
+// filterPodsByGroup groups the pods, then applies the mode within every chosen group
+func filterPodsByGroup(ctx context.Context, c client.Client, pods []v1.Pod, spec *v1alpha1.PodSelector) ([]v1.Pod, error) {
+	groups, err := groupPods(ctx, c, pods, spec.GroupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(groups) == 0 {
+		return nil, ErrNoPodSelected
+	}
+
+	if spec.GroupBy.Groups == v1alpha1.OneGroupMode {
+		groups = [][]v1.Pod{groups[getRandomNumber(len(groups))]}
+	}
+
+	var filteredPods []v1.Pod
+	for _, group := range groups {
+		filtered, err := filterPodsByMode(group, spec.Mode, spec.Value)
+		if err != nil {
+			return nil, err
+		}
+		filteredPods = append(filteredPods, filtered...)
+	}
+
+	return filteredPods, nil
+}
+
+// groupPods groups the pods by the key of groupBy. The groups are in the order of the first pod in every group.
+func groupPods(ctx context.Context, c client.Client, pods []v1.Pod, groupBy *v1alpha1.GroupBy) ([][]v1.Pod, error) {
+	var keys []string
+	groups := make(map[string][]v1.Pod)
+
+	// nodeLabels caches the labels of the nodes, as many pods are running on the same node
+	nodeLabels := make(map[string]map[string]string)
+	for _, pod := range pods {
+		var key string
+		switch groupBy.Type {
+		case v1alpha1.GroupByNode:
+			// the pods not scheduled yet are not selected
+			if len(pod.Spec.NodeName) == 0 {
+				continue
+			}
+			key = pod.Spec.NodeName
+		case v1alpha1.GroupByOwner:
+			key = ownerKey(pod)
+		case v1alpha1.GroupByTopology:
+			if len(pod.Spec.NodeName) == 0 {
+				continue
+			}
+
+			labels, ok := nodeLabels[pod.Spec.NodeName]
+			if !ok {
+				var node v1.Node
+				if err := c.Get(ctx, types.NamespacedName{Name: pod.Spec.NodeName}, &node); err != nil {
+					if !apierrors.IsNotFound(err) {
+						return nil, err
+					}
+				}
+				labels = node.Labels
+				nodeLabels[pod.Spec.NodeName] = labels
+			}
+
+			value, ok := labels[groupBy.TopologyKey]
+			if !ok {
+				continue
+			}
+			key = value
+		default:
+			return nil, fmt.Errorf("group by %s not supported", groupBy.Type)
+		}
+
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], pod)
+	}
+
+	result := make([][]v1.Pod, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, groups[key])
+	}
+
+	return result, nil
+}
+
+// ownerKey returns the identity of the controller of the pod, or the pod itself if it doesn't have one
+func ownerKey(pod v1.Pod) string {
+	owner := metav1.GetControllerOf(&pod)
+	if owner == nil {
+		return fmt.Sprintf("Pod/%s/%s", pod.Namespace, pod.Name)
+	}
+
+	return fmt.Sprintf("%s/%s/%s", owner.Kind, pod.Namespace, owner.Name)
+}
@@ -0,0 +1,103 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pod
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	. "github.com/chaos-mesh/chaos-mesh/pkg/testutils"
+)
+
+func newOwnedPod(name string, nodeName string, owner string) v1.Pod {
+	pod := NewPod(PodArg{Name: name, Nodename: nodeName})
+	if len(owner) > 0 {
+		controller := true
+		pod.OwnerReferences = []metav1.OwnerReference{
+			{Kind: "ReplicaSet", Name: owner, Controller: &controller},
+		}
+	}
+	return pod
+}
+
+func TestGroupPods(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	zoneKey := "topology.kubernetes.io/zone"
+	nodeA := NewNode("node-a", map[string]string{zoneKey: "a"})
+	nodeB := NewNode("node-b", map[string]string{zoneKey: "b"})
+	nodeC := NewNode("node-c", nil)
+	c := fake.NewFakeClient(&nodeA, &nodeB, &nodeC)
+
+	pods := []v1.Pod{
+		newOwnedPod("p0", "node-a", "rs-0"),
+		newOwnedPod("p1", "node-b", "rs-0"),
+		newOwnedPod("p2", "node-a", "rs-1"),
+		newOwnedPod("p3", "node-b", "rs-1"),
+		newOwnedPod("p4", "node-c", ""),
+	}
+
+	names := func(groups [][]v1.Pod) [][]string {
+		var result [][]string
+		for _, group := range groups {
+			var names []string
+			for _, pod := range group {
+				names = append(names, pod.Name)
+			}
+			result = append(result, names)
+		}
+		return result
+	}
+
+	groups, err := groupPods(context.TODO(), c, pods, &v1alpha1.GroupBy{Type: v1alpha1.GroupByNode})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(names(groups)).To(Equal([][]string{{"p0", "p2"}, {"p1", "p3"}, {"p4"}}))
+
+	groups, err = groupPods(context.TODO(), c, pods, &v1alpha1.GroupBy{Type: v1alpha1.GroupByOwner})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(names(groups)).To(Equal([][]string{{"p0", "p1"}, {"p2", "p3"}, {"p4"}}))
+
+	// the pod on the node without the topology label is not selected
+	groups, err = groupPods(context.TODO(), c, pods, &v1alpha1.GroupBy{Type: v1alpha1.GroupByTopology, TopologyKey: zoneKey})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(names(groups)).To(Equal([][]string{{"p0", "p2"}, {"p1", "p3"}}))
+
+	// one pod of every owner
+	filtered, err := filterPodsByGroup(context.TODO(), c, pods, &v1alpha1.PodSelector{
+		Mode:    v1alpha1.OnePodMode,
+		GroupBy: &v1alpha1.GroupBy{Type: v1alpha1.GroupByOwner},
+	})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(filtered).To(HaveLen(3))
+	owners := make(map[string]struct{})
+	for _, pod := range filtered {
+		owners[ownerKey(pod)] = struct{}{}
+	}
+	g.Expect(owners).To(HaveLen(3))
+
+	// all pods in one random zone
+	filtered, err = filterPodsByGroup(context.TODO(), c, pods, &v1alpha1.PodSelector{
+		Mode:    v1alpha1.AllPodMode,
+		GroupBy: &v1alpha1.GroupBy{Type: v1alpha1.GroupByTopology, TopologyKey: zoneKey, Groups: v1alpha1.OneGroupMode},
+	})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(filtered).To(HaveLen(2))
+	g.Expect(filtered[0].Spec.NodeName).To(Equal(filtered[1].Spec.NodeName))
+}
@@ -0,0 +1,158 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pod
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+)
+
+// filterByReferences filters a list of pods by the services and workloads of the selector.
+// A pod is kept if it's behind one of the services or owned by one of the workloads.
+func filterByReferences(ctx context.Context, c client.Client, pods []v1.Pod, selector v1alpha1.PodSelectorSpec) ([]v1.Pod, error) {
+	servicePods, err := resolveServicePods(ctx, c, selector.ServiceSelectors)
+	if err != nil {
+		return nil, err
+	}
+
+	owners := &ownerResolver{
+		client:      c,
+		replicaSets: make(map[types.NamespacedName]*metav1.OwnerReference),
+	}
+
+	var filteredList []v1.Pod
+	for _, pod := range pods {
+		if _, ok := servicePods[types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}]; ok {
+			filteredList = append(filteredList, pod)
+			continue
+		}
+
+		if len(selector.WorkloadSelectors) == 0 {
+			continue
+		}
+		chain, err := owners.chain(ctx, pod)
+		if err != nil {
+			return nil, err
+		}
+		if matchWorkloads(pod.Namespace, chain, selector.WorkloadSelectors) {
+			filteredList = append(filteredList, pod)
+		}
+	}
+
+	return filteredList, nil
+}
+
+// resolveServicePods returns the pods in the endpoints of the services, including the not ready ones
+func resolveServicePods(ctx context.Context, c client.Client, services map[string][]string) (map[types.NamespacedName]struct{}, error) {
+	pods := make(map[types.NamespacedName]struct{})
+
+	for ns, names := range services {
+		for _, name := range names {
+			if _, err := GetService(ctx, c, ns, "", name); err != nil {
+				return nil, err
+			}
+
+			var endpoints v1.Endpoints
+			if err := c.Get(ctx, types.NamespacedName{Namespace: ns, Name: name}, &endpoints); err != nil {
+				if apierrors.IsNotFound(err) {
+					log.Info("endpoints of service is not found", "namespace", ns, "service", name)
+					continue
+				}
+				return nil, err
+			}
+
+			for _, subset := range endpoints.Subsets {
+				for _, addresses := range [][]v1.EndpointAddress{subset.Addresses, subset.NotReadyAddresses} {
+					for _, address := range addresses {
+						if address.TargetRef == nil || address.TargetRef.Kind != "Pod" {
+							continue
+						}
+						namespace := address.TargetRef.Namespace
+						if len(namespace) == 0 {
+							namespace = ns
+						}
+						pods[types.NamespacedName{Namespace: namespace, Name: address.TargetRef.Name}] = struct{}{}
+					}
+				}
+			}
+		}
+	}
+
+	return pods, nil
+}
+
+// matchWorkloads returns whether one of the owners in the chain is one of the workloads
+func matchWorkloads(namespace string, chain []*metav1.OwnerReference, workloads []v1alpha1.WorkloadSelector) bool {
+	for _, workload := range workloads {
+		if len(workload.Namespace) > 0 && workload.Namespace != namespace {
+			continue
+		}
+		for _, owner := range chain {
+			if owner.Kind == string(workload.Kind) && owner.Name == workload.Name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// ownerResolver resolves the chain of the controllers of the pods, e.g. Pod -> ReplicaSet -> Deployment
+type ownerResolver struct {
+	client client.Client
+
+	// replicaSets caches the controllers of the ReplicaSets, as many pods share the same ReplicaSet
+	replicaSets map[types.NamespacedName]*metav1.OwnerReference
+}
+
+// chain returns the controllers of the pod from the nearest one. The ReplicaSets are looked up to
+// find the Deployments or Rollouts owning them.
+func (r *ownerResolver) chain(ctx context.Context, pod v1.Pod) ([]*metav1.OwnerReference, error) {
+	owner := metav1.GetControllerOf(&pod)
+	if owner == nil {
+		return nil, nil
+	}
+
+	chain := []*metav1.OwnerReference{owner}
+	if owner.Kind != "ReplicaSet" {
+		return chain, nil
+	}
+
+	key := types.NamespacedName{Namespace: pod.Namespace, Name: owner.Name}
+	rsOwner, ok := r.replicaSets[key]
+	if !ok {
+		var rs appsv1.ReplicaSet
+		if err := r.client.Get(ctx, key, &rs); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, err
+			}
+		} else {
+			rsOwner = metav1.GetControllerOf(&rs)
+		}
+		r.replicaSets[key] = rsOwner
+	}
+	if rsOwner != nil {
+		chain = append(chain, rsOwner)
+	}
+
+	return chain, nil
+}
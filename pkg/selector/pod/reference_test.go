@@ -0,0 +1,125 @@
+// Copyright 2021 Chaos Mesh Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pod
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
+	. "github.com/chaos-mesh/chaos-mesh/pkg/testutils"
+)
+
+func newControlledPod(name string, kind string, owner string) v1.Pod {
+	pod := NewPod(PodArg{Name: name})
+	controller := true
+	pod.OwnerReferences = []metav1.OwnerReference{
+		{Kind: kind, Name: owner, Controller: &controller},
+	}
+	return pod
+}
+
+func newReplicaSet(name string, kind string, owner string) *appsv1.ReplicaSet {
+	controller := true
+	return &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      name,
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: kind, Name: owner, Controller: &controller},
+			},
+		},
+	}
+}
+
+func TestFilterByReferences(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	pods := []v1.Pod{
+		newControlledPod("orders-0", "ReplicaSet", "orders-5d8f"),
+		newControlledPod("payments-0", "ReplicaSet", "payments-7c9b"),
+		newControlledPod("db-0", "StatefulSet", "db"),
+		newControlledPod("canary-0", "ReplicaSet", "canary-6f4d"),
+		NewPod(PodArg{Name: "standalone"}),
+	}
+
+	service := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "payments"},
+	}
+	endpoints := &v1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "payments"},
+		Subsets: []v1.EndpointSubset{
+			{
+				Addresses: []v1.EndpointAddress{
+					{IP: "10.0.0.1", TargetRef: &v1.ObjectReference{Kind: "Pod", Name: "payments-0"}},
+				},
+				NotReadyAddresses: []v1.EndpointAddress{
+					{IP: "10.0.0.2", TargetRef: &v1.ObjectReference{Kind: "Pod", Name: "standalone"}},
+				},
+			},
+		},
+	}
+	c := fake.NewFakeClient(
+		service,
+		endpoints,
+		newReplicaSet("orders-5d8f", "Deployment", "orders"),
+		newReplicaSet("payments-7c9b", "Deployment", "payments"),
+		newReplicaSet("canary-6f4d", "Rollout", "canary"),
+	)
+
+	names := func(pods []v1.Pod) []string {
+		var names []string
+		for _, pod := range pods {
+			names = append(names, pod.Name)
+		}
+		return names
+	}
+
+	filtered, err := filterByReferences(context.TODO(), c, pods, v1alpha1.PodSelectorSpec{
+		ServiceSelectors: map[string][]string{metav1.NamespaceDefault: {"payments"}},
+	})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(names(filtered)).To(Equal([]string{"payments-0", "standalone"}))
+
+	filtered, err = filterByReferences(context.TODO(), c, pods, v1alpha1.PodSelectorSpec{
+		WorkloadSelectors: []v1alpha1.WorkloadSelector{
+			{Kind: v1alpha1.DeploymentWorkload, Name: "orders"},
+			{Kind: v1alpha1.StatefulSetWorkload, Namespace: metav1.NamespaceDefault, Name: "db"},
+			{Kind: v1alpha1.RolloutWorkload, Name: "canary"},
+		},
+	})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(names(filtered)).To(Equal([]string{"orders-0", "db-0", "canary-0"}))
+
+	// the workload in another namespace doesn't match
+	filtered, err = filterByReferences(context.TODO(), c, pods, v1alpha1.PodSelectorSpec{
+		WorkloadSelectors: []v1alpha1.WorkloadSelector{
+			{Kind: v1alpha1.StatefulSetWorkload, Namespace: "other", Name: "db"},
+		},
+	})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(filtered).To(BeEmpty())
+
+	// the service doesn't exist
+	_, err = filterByReferences(context.TODO(), c, pods, v1alpha1.PodSelectorSpec{
+		ServiceSelectors: map[string][]string{metav1.NamespaceDefault: {"oops"}},
+	})
+	g.Expect(err).Should(HaveOccurred())
+}
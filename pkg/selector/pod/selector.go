@@ -88,6 +88,33 @@ func (impl *SelectImpl) Select(ctx context.Context, ps *v1alpha1.PodSelector) ([
 	return result, nil
 }
 
+// Reselect re-evaluates the selector with the ids of the selected pods. It returns the ids of the
+// selected pods which are still alive, and the new pods which should be injected.
+func (impl *SelectImpl) Reselect(ctx context.Context, ps *v1alpha1.PodSelector, selected []string) ([]string, []*Pod, error) {
+	if ps == nil {
+		return []string{}, []*Pod{}, nil
+	}
+
+	alive, added, err := ReselectPods(ctx, impl.c, impl.r, ps, selected, impl.ClusterScoped, impl.TargetNamespace, impl.EnableFilterNamespace)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var aliveIds []string
+	for _, pod := range alive {
+		aliveIds = append(aliveIds, (&Pod{pod}).Id())
+	}
+
+	var result []*Pod
+	for _, pod := range added {
+		result = append(result, &Pod{
+			pod,
+		})
+	}
+
+	return aliveIds, result, nil
+}
+
 type Params struct {
 	fx.In
 
@@ -107,6 +134,9 @@ func New(params Params) *SelectImpl {
 	}
 }
 
+// ErrNoPodSelected is returned when no pod matches the selector
+var ErrNoPodSelected = errors.New("no pod is selected")
+
 // SelectAndFilterPods returns the list of pods that filtered by selector and PodMode
 func SelectAndFilterPods(ctx context.Context, c client.Client, r client.Reader, spec *v1alpha1.PodSelector, clusterScoped bool, targetNamespace string, enableFilterNamespace bool) ([]v1.Pod, error) {
 	if pods := mock.On("MockSelectAndFilterPods"); pods != nil {
@@ -126,8 +156,11 @@ func SelectAndFilterPods(ctx context.Context, c client.Client, r client.Reader,
 	}
 
 	if len(pods) == 0 {
-		err = errors.New("no pod is selected")
-		return nil, err
+		return nil, ErrNoPodSelected
+	}
+
+	if spec.GroupBy != nil {
+		return filterPodsByGroup(ctx, c, pods, spec)
 	}
 
 	filteredPod, err := filterPodsByMode(pods, mode, value)
@@ -138,12 +171,194 @@ func SelectAndFilterPods(ctx context.Context, c client.Client, r client.Reader,
 	return filteredPod, nil
 }
 
+// SelectCandidates returns the pods which could be selected by the selector, and the max count of the pods which
+// could be selected among them. Some modes choose the pods randomly, so it's used to evaluate the worst case of an
+// experiment before it runs.
+func SelectCandidates(ctx context.Context, c client.Client, r client.Reader, spec *v1alpha1.PodSelector, clusterScoped bool, targetNamespace string, enableFilterNamespace bool) ([]v1.Pod, int, error) {
+	pods, err := SelectPods(ctx, c, r, spec.Selector, clusterScoped, targetNamespace, enableFilterNamespace)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if spec.GroupBy == nil {
+		max, err := maxCountByMode(len(pods), spec)
+		return pods, max, err
+	}
+
+	groups, err := groupPods(ctx, c, pods, spec.GroupBy)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var candidates []v1.Pod
+	max := 0
+	for _, group := range groups {
+		count, err := maxCountByMode(len(group), spec)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		candidates = append(candidates, group...)
+		if spec.GroupBy.Groups != v1alpha1.OneGroupMode {
+			max += count
+		} else if count > max {
+			// only one of the groups is chosen
+			max = count
+		}
+	}
+
+	return candidates, max, nil
+}
+
+// maxCountByMode returns the max count of the pods which could be selected from `total` pods by the mode of the
+// selector. The ramp mode injects the share of its last stage at most.
+func maxCountByMode(total int, spec *v1alpha1.PodSelector) (int, error) {
+	switch spec.Mode {
+	case v1alpha1.OnePodMode:
+		if total == 0 {
+			return 0, nil
+		}
+		return 1, nil
+	case v1alpha1.AllPodMode:
+		return total, nil
+	case v1alpha1.FixedPodMode:
+		num, err := strconv.Atoi(spec.Value)
+		if err != nil {
+			return 0, err
+		}
+		if num > total {
+			return total, nil
+		}
+
+		return num, nil
+	case v1alpha1.FixedPercentPodMode, v1alpha1.RandomMaxPercentPodMode:
+		percentage, err := strconv.Atoi(spec.Value)
+		if err != nil {
+			return 0, err
+		}
+
+		return int(math.Floor(float64(total) * float64(percentage) / 100)), nil
+	case v1alpha1.RampPodMode:
+		policy := spec.GetRampPolicy()
+		if policy == nil {
+			return total, nil
+		}
+		percentage := policy.Percent(int32(len(policy.Steps) - 1))
+
+		return int(math.Ceil(float64(total) * float64(percentage) / 100)), nil
+	default:
+		return 0, fmt.Errorf("mode %s not supported", spec.Mode)
+	}
+}
+
+// ReselectPods re-evaluates the selector during an experiment. `selected` is the list of the
+// ids (namespace/name) of the pods which have been selected before.
+// It returns the selected pods which still match the selector, and the new running pods which
+// should be selected to keep the count required by the PodMode.
+func ReselectPods(ctx context.Context, c client.Client, r client.Reader, spec *v1alpha1.PodSelector, selected []string, clusterScoped bool, targetNamespace string, enableFilterNamespace bool) ([]v1.Pod, []v1.Pod, error) {
+	pods, err := SelectPods(ctx, c, r, spec.Selector, clusterScoped, targetNamespace, enableFilterNamespace)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	selectedSet := make(map[string]struct{})
+	for _, id := range selected {
+		selectedSet[id] = struct{}{}
+	}
+
+	var alive, candidates []v1.Pod
+	for _, pod := range pods {
+		// the terminating pods are regarded as vanished
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+
+		id := types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}.String()
+		if _, ok := selectedSet[id]; ok {
+			alive = append(alive, pod)
+		} else if pod.Status.Phase == v1.PodRunning {
+			candidates = append(candidates, pod)
+		}
+	}
+
+	count, err := expectedCountByMode(len(alive)+len(candidates), len(selected), spec.Mode, spec.Value)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(alive) >= count || len(candidates) == 0 {
+		return alive, nil, nil
+	}
+
+	return alive, getFixedSubListFromPodList(candidates, count-len(alive)), nil
+}
+
+// expectedCountByMode returns the count of pods which should be selected from `total` pods.
+// The `previous` count is kept for the random mode, so that the count won't change randomly
+// every time it's evaluated.
+func expectedCountByMode(total int, previous int, mode v1alpha1.PodMode, value string) (int, error) {
+	switch mode {
+	case v1alpha1.OnePodMode:
+		return 1, nil
+	case v1alpha1.AllPodMode:
+		return total, nil
+	case v1alpha1.FixedPodMode:
+		num, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, err
+		}
+
+		return num, nil
+	case v1alpha1.FixedPercentPodMode:
+		percentage, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, err
+		}
+
+		return int(math.Floor(float64(total) * float64(percentage) / 100)), nil
+	case v1alpha1.RandomMaxPercentPodMode:
+		return previous, nil
+	case v1alpha1.RampPodMode:
+		return total, nil
+	default:
+		return 0, fmt.Errorf("mode %s not supported", mode)
+	}
+}
+
 //revive:disable:flag-parameter
 
 // SelectPods returns the list of pods that are available for pod chaos action.
-// It returns all pods that match the configured label, annotation and namespace selectors.
+// It returns all pods that match the configured label, annotation and namespace selectors, and
+// belong to the configured services or workloads if any.
 // If pods are specifically specified by `selector.Pods`, it just returns the selector.Pods.
+// The pods matching `selector.Exclude` and the protected pods are never returned.
 func SelectPods(ctx context.Context, c client.Client, r client.Reader, selector v1alpha1.PodSelectorSpec, clusterScoped bool, targetNamespace string, enableFilterNamespace bool) ([]v1.Pod, error) {
+	pods, err := selectPods(ctx, c, r, selector, clusterScoped, targetNamespace, enableFilterNamespace)
+	if err != nil {
+		return nil, err
+	}
+	pods = filterProtectedPods(pods)
+
+	if selector.Exclude.IsEmpty() {
+		return pods, nil
+	}
+
+	excluded, err := selectPods(ctx, c, r, selector.Exclude.ToPodSelectorSpec(), clusterScoped, targetNamespace, enableFilterNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return filterExcludedPods(pods, excluded), nil
+}
+
+// ListPods returns the list of pods matching the selectors, including the protected pods and the pods matching
+// `selector.Exclude`. It's used to observe the pods, e.g. by the abort conditions, rather than to inject faults.
+func ListPods(ctx context.Context, c client.Client, r client.Reader, selector v1alpha1.PodSelectorSpec, clusterScoped bool, targetNamespace string, enableFilterNamespace bool) ([]v1.Pod, error) {
+	return selectPods(ctx, c, r, selector, clusterScoped, targetNamespace, enableFilterNamespace)
+}
+
+// selectPods returns the list of pods matching the selectors, without the exclusion
+func selectPods(ctx context.Context, c client.Client, r client.Reader, selector v1alpha1.PodSelectorSpec, clusterScoped bool, targetNamespace string, enableFilterNamespace bool) ([]v1.Pod, error) {
 	// TODO: refactor: make different selectors to replace if-else logics
 	var pods []v1.Pod
 
@@ -236,6 +451,14 @@ func SelectPods(ctx context.Context, c client.Client, r client.Reader, selector
 		pods = append(pods, podList.Items...)
 	}
 
+	if selector.HasReferences() {
+		var err error
+		pods, err = filterByReferences(ctx, c, pods, selector)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	var (
 		nodes           []v1.Node
 		nodeList        v1.NodeList
@@ -473,11 +696,51 @@ func filterPodsByMode(pods []v1.Pod, mode v1alpha1.PodMode, value string) ([]v1.
 		num := int(math.Floor(float64(len(pods)) * float64(percentage) / 100))
 
 		return getFixedSubListFromPodList(pods, num), nil
+	case v1alpha1.RampPodMode:
+		// all pods are selected in a random order, and they will be injected in this order stage by stage
+		return getFixedSubListFromPodList(pods, len(pods)), nil
 	default:
 		return nil, fmt.Errorf("mode %s not supported", mode)
 	}
 }
 
+// IsProtectedPod returns whether the pod is protected from all experiments by the annotation
+func IsProtectedPod(pod *v1.Pod) bool {
+	return pod.Annotations[v1alpha1.ProtectedAnnotationKey] == "true"
+}
+
+// filterProtectedPods filters out the protected pods
+func filterProtectedPods(pods []v1.Pod) []v1.Pod {
+	var filtered []v1.Pod
+	for i := range pods {
+		if IsProtectedPod(&pods[i]) {
+			log.Info("skip the protected pod", "namespace", pods[i].Namespace, "name", pods[i].Name)
+			continue
+		}
+		filtered = append(filtered, pods[i])
+	}
+
+	return filtered
+}
+
+// filterExcludedPods filters out the excluded pods
+func filterExcludedPods(pods []v1.Pod, excluded []v1.Pod) []v1.Pod {
+	excludedSet := make(map[types.NamespacedName]struct{})
+	for _, pod := range excluded {
+		excludedSet[types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}] = struct{}{}
+	}
+
+	var filtered []v1.Pod
+	for _, pod := range pods {
+		if _, ok := excludedSet[types.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}]; ok {
+			continue
+		}
+		filtered = append(filtered, pod)
+	}
+
+	return filtered
+}
+
 // filterByAnnotations filters a list of pods by a given annotation selector.
 func filterByAnnotations(pods []v1.Pod, annotations labels.Selector) []v1.Pod {
 	// empty filter returns original list
@@ -15,6 +15,7 @@ package pod
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/chaos-mesh/chaos-mesh/api/v1alpha1"
@@ -147,6 +148,33 @@ func TestSelectPods(t *testing.T) {
 			},
 			expectedPods: []v1.Pod{pods[0], pods[1], pods[2], pods[3], pods[4], pods[5], pods[6]},
 		},
+		{
+			name: "exclude specified pods",
+			selector: v1alpha1.PodSelectorSpec{
+				LabelSelectors: map[string]string{"l1": "l1"},
+				Exclude: &v1alpha1.PodExcludeSelectorSpec{
+					Pods: map[string][]string{metav1.NamespaceDefault: {"p0"}},
+				},
+			},
+			expectedPods: []v1.Pod{pods[1], pods[2], pods[3], pods[4]},
+		},
+		{
+			name: "exclude pods by node",
+			selector: v1alpha1.PodSelectorSpec{
+				Exclude: &v1alpha1.PodExcludeSelectorSpec{
+					NodeSelectors: map[string]string{"zone": "az1"},
+				},
+			},
+			expectedPods: []v1.Pod{pods[5], pods[6]},
+		},
+		{
+			name: "empty exclude selector",
+			selector: v1alpha1.PodSelectorSpec{
+				LabelSelectors: map[string]string{"l2": "l2"},
+				Exclude:        &v1alpha1.PodExcludeSelectorSpec{},
+			},
+			expectedPods: []v1.Pod{pods[5], pods[6]},
+		},
 	}
 
 	var (
@@ -161,6 +189,172 @@ func TestSelectPods(t *testing.T) {
 	}
 }
 
+func TestSelectProtectedPods(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	objects, pods := GenerateNPods("p", 3, PodArg{})
+	protected := NewPod(PodArg{Name: "leader", Ans: map[string]string{v1alpha1.ProtectedAnnotationKey: "true"}})
+	objects = append(objects, &protected)
+	c := fake.NewFakeClient(objects...)
+
+	selectors := []v1alpha1.PodSelectorSpec{
+		{Namespaces: []string{metav1.NamespaceDefault}},
+		{Pods: map[string][]string{metav1.NamespaceDefault: {"p0", "p1", "p2", "leader"}}},
+	}
+	for _, selector := range selectors {
+		filteredPods, err := SelectPods(context.Background(), c, nil, selector, true, "", false)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(filteredPods).To(ConsistOf(pods))
+	}
+
+	_, err := SelectAndFilterPods(context.Background(), c, nil, &v1alpha1.PodSelector{
+		Selector: v1alpha1.PodSelectorSpec{Pods: map[string][]string{metav1.NamespaceDefault: {"leader"}}},
+		Mode:     v1alpha1.AllPodMode,
+	}, true, "", false)
+	g.Expect(err).To(Equal(ErrNoPodSelected))
+}
+
+func TestReselectPods(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	objects, _ := GenerateNPods("p", 3, PodArg{Labels: map[string]string{"l1": "l1"}})
+	pendingObjects, _ := GenerateNPods("pending", 2, PodArg{Labels: map[string]string{"l1": "l1"}, Status: v1.PodPending})
+	objects = append(objects, pendingObjects...)
+
+	c := fake.NewFakeClient(objects...)
+	var r client.Reader
+
+	type TestCase struct {
+		name          string
+		mode          v1alpha1.PodMode
+		value         string
+		selected      []string
+		expectedAlive int
+		expectedAdded int
+	}
+
+	tcs := []TestCase{
+		{
+			name:          "keep the selected pods",
+			mode:          v1alpha1.OnePodMode,
+			selected:      []string{"default/p0"},
+			expectedAlive: 1,
+			expectedAdded: 0,
+		},
+		{
+			name:          "replace the vanished pod",
+			mode:          v1alpha1.OnePodMode,
+			selected:      []string{"default/p9"},
+			expectedAlive: 0,
+			expectedAdded: 1,
+		},
+		{
+			name:          "select the new running pods in all mode",
+			mode:          v1alpha1.AllPodMode,
+			selected:      []string{"default/p0", "default/pending0"},
+			expectedAlive: 2,
+			expectedAdded: 2,
+		},
+		{
+			name:          "fill the fixed count",
+			mode:          v1alpha1.FixedPodMode,
+			value:         "2",
+			selected:      []string{"default/p1"},
+			expectedAlive: 1,
+			expectedAdded: 1,
+		},
+		{
+			name:          "keep the count of random mode",
+			mode:          v1alpha1.RandomMaxPercentPodMode,
+			value:         "100",
+			selected:      []string{"default/p0", "default/p9"},
+			expectedAlive: 1,
+			expectedAdded: 1,
+		},
+	}
+
+	for _, tc := range tcs {
+		spec := &v1alpha1.PodSelector{
+			Selector: v1alpha1.PodSelectorSpec{
+				LabelSelectors: map[string]string{"l1": "l1"},
+			},
+			Mode:  tc.mode,
+			Value: tc.value,
+		}
+		alive, added, err := ReselectPods(context.Background(), c, r, spec, tc.selected, true, "", false)
+		g.Expect(err).ShouldNot(HaveOccurred(), tc.name)
+		g.Expect(len(alive)).To(Equal(tc.expectedAlive), tc.name)
+		g.Expect(len(added)).To(Equal(tc.expectedAdded), tc.name)
+		for _, pod := range added {
+			g.Expect(pod.Status.Phase).To(Equal(v1.PodRunning), tc.name)
+		}
+	}
+}
+
+func TestMaxCountByMode(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	type TestCase struct {
+		name     string
+		spec     v1alpha1.PodSelector
+		expected int
+	}
+
+	tcs := []TestCase{
+		{name: "one", spec: v1alpha1.PodSelector{Mode: v1alpha1.OnePodMode}, expected: 1},
+		{name: "all", spec: v1alpha1.PodSelector{Mode: v1alpha1.AllPodMode}, expected: 10},
+		{name: "fixed", spec: v1alpha1.PodSelector{Mode: v1alpha1.FixedPodMode, Value: "20"}, expected: 10},
+		{name: "fixed percent", spec: v1alpha1.PodSelector{Mode: v1alpha1.FixedPercentPodMode, Value: "25"}, expected: 2},
+		{name: "random max percent", spec: v1alpha1.PodSelector{Mode: v1alpha1.RandomMaxPercentPodMode, Value: "50"}, expected: 5},
+		{
+			name: "ramp",
+			spec: v1alpha1.PodSelector{
+				Mode:       v1alpha1.RampPodMode,
+				RampPolicy: &v1alpha1.RampPolicy{Steps: []int32{10, 25}, Interval: "1m"},
+			},
+			expected: 3,
+		},
+	}
+
+	for _, tc := range tcs {
+		count, err := maxCountByMode(10, &tc.spec)
+		g.Expect(err).ShouldNot(HaveOccurred(), tc.name)
+		g.Expect(count).To(Equal(tc.expected), tc.name)
+	}
+}
+
+func TestExpectedCountByMode(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	count, err := expectedCountByMode(10, 3, v1alpha1.FixedPercentPodMode, "25")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(count).To(Equal(2))
+
+	count, err = expectedCountByMode(10, 3, v1alpha1.RandomMaxPercentPodMode, "50")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(count).To(Equal(3))
+
+	count, err = expectedCountByMode(10, 3, v1alpha1.RampPodMode, "")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(count).To(Equal(10))
+
+	_, err = expectedCountByMode(10, 3, v1alpha1.FixedPodMode, "a")
+	g.Expect(err).Should(HaveOccurred())
+}
+
+func TestFilterPodsByRampMode(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	var pods []v1.Pod
+	for i := 0; i < 10; i++ {
+		pods = append(pods, v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: fmt.Sprintf("pod-%d", i)}})
+	}
+
+	filtered, err := filterPodsByMode(pods, v1alpha1.RampPodMode, "")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(filtered).To(ConsistOf(pods))
+}
+
 func TestCheckPodMeetSelector(t *testing.T) {
 	g := NewGomegaWithT(t)
 
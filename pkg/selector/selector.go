@@ -23,6 +23,7 @@ import (
 	"github.com/chaos-mesh/chaos-mesh/pkg/selector/aws"
 	"github.com/chaos-mesh/chaos-mesh/pkg/selector/container"
 	"github.com/chaos-mesh/chaos-mesh/pkg/selector/gcp"
+	"github.com/chaos-mesh/chaos-mesh/pkg/selector/node"
 	"github.com/chaos-mesh/chaos-mesh/pkg/selector/pod"
 )
 
@@ -60,6 +61,39 @@ func (s *Selector) Select(ctx context.Context, spec interface{}) ([]Target, erro
 	return nil, errors.Errorf("specification type not found: %s", reflect.TypeOf(spec))
 }
 
+// Reselect re-evaluates the spec during an experiment. It returns the ids of the selected targets
+// which still match the spec, and the new targets which should be added.
+func (s *Selector) Reselect(ctx context.Context, spec interface{}, selected []string) ([]string, []Target, error) {
+	if spec == nil {
+		return []string{}, []Target{}, nil
+	}
+
+	impl, ok := s.selectorMap[reflect.TypeOf(spec)]
+	if !ok {
+		return nil, nil, errors.Errorf("specification type not found: %s", reflect.TypeOf(spec))
+	}
+
+	method := reflect.ValueOf(impl).MethodByName("Reselect")
+	if !method.IsValid() {
+		return nil, nil, errors.Errorf("reselect is not supported for specification type: %s", reflect.TypeOf(spec))
+	}
+
+	vals := method.Call([]reflect.Value{reflect.ValueOf(ctx), reflect.ValueOf(spec), reflect.ValueOf(selected)})
+	err := vals[2].Interface()
+	if err != nil {
+		return nil, nil, err.(error)
+	}
+
+	alive := vals[0].Interface().([]string)
+	var added []Target
+	ret := vals[1]
+	for i := 0; i < ret.Len(); i++ {
+		added = append(added, ret.Index(i).Interface().(Target))
+	}
+
+	return alive, added, nil
+}
+
 type SelectorParams struct {
 	fx.In
 
@@ -67,6 +101,7 @@ type SelectorParams struct {
 	ContainerSelector *container.SelectImpl
 	AwsSelector       *aws.SelectImpl
 	GcpSelector       *gcp.SelectImpl
+	NodeSelector      *node.SelectImpl
 }
 
 func New(p SelectorParams) *Selector {
@@ -93,4 +128,5 @@ var Module = fx.Provide(
 	container.New,
 	aws.New,
 	gcp.New,
+	node.New,
 )
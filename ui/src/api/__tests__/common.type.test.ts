@@ -5,7 +5,6 @@ const { Config, RBACConfigParams } = createCheckers(CommonTI)
 
 const dummyConfig = {
   security_mode: true,
-  dns_server_create: false,
   version: 'xxx',
 }
 
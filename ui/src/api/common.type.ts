@@ -1,6 +1,5 @@
 export interface Config {
   security_mode: boolean
-  dns_server_create: boolean
   version: string
 }
 
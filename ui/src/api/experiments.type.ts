@@ -18,7 +18,18 @@ export interface Experiment {
   status: 'injecting' | 'running' | 'finished' | 'paused'
 }
 
+export interface ExperimentRecord {
+  id: string
+  selectorKey: string
+  phase: string
+  lastError?: string
+  injectedAt?: string
+  recoveredAt?: string
+  failedAttempts?: number
+}
+
 export interface ExperimentSingle extends Experiment {
   failed_message: string
+  records?: ExperimentRecord[]
   kube_object: any
 }
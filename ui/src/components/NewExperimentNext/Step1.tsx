@@ -50,11 +50,7 @@ const Step1 = () => {
   const classes = useStyles()
 
   const state = useStoreSelector((state) => state)
-  const { dnsServerCreate } = state.globalStatus
-  let targetDataEntries = Object.entries(targetData) as [Kind, Target][]
-  if (!dnsServerCreate) {
-    targetDataEntries = targetDataEntries.filter((d) => d[0] !== 'DNSChaos')
-  }
+  const targetDataEntries = Object.entries(targetData) as [Kind, Target][]
   const {
     kindAction: [_kind, _action],
     step1,
@@ -23,7 +23,6 @@ const initialState: {
   confirmOpen: boolean // control global confirm dialog
   namespace: string
   securityMode: boolean
-  dnsServerCreate: boolean
   version: string
   tokens: TokenFormValues[]
   tokenName: string
@@ -40,7 +39,6 @@ const initialState: {
   confirmOpen: false,
   namespace: 'All',
   securityMode: true,
-  dnsServerCreate: false,
   version: '',
   tokens: [],
   tokenName: '',
@@ -73,7 +71,6 @@ const globalStatusSlice = createSlice({
     },
     setConfig(state, action: PayloadAction<Config>) {
       state.securityMode = action.payload.security_mode
-      state.dnsServerCreate = action.payload.dns_server_create
       state.version = action.payload.version
     },
     setTokens(state, action: PayloadAction<TokenFormValues[]>) {